@@ -4,6 +4,7 @@ import (
 	"liquiswiss/pkg/utils"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -25,10 +26,32 @@ type Config struct {
 	SMTPTLS               string
 	FixerIOURl            string
 	FixerIOKey            string
+	FXProvider            string // "ecb", "snb", "fixer", "manual" or "fallback"
+	FXProviderURL         string
+	FXManualRatesFile     string
+	FXMaxRateAge          time.Duration
 	ResetPasswordDelay    time.Duration
 	ResetPasswordValidity time.Duration
 	InvitationResendDelay time.Duration
 	InvitationValidity    time.Duration
+	ForecastDebounce      time.Duration
+	// EventBroker shares events between instances: "memory" (single instance) or "mysql"
+	EventBroker             string
+	EventOutboxPollInterval time.Duration
+	SSOProviders            []SSOProvider
+	// WebhookAllowedTargets are private CIDR ranges or addresses webhooks may reach anyway
+	WebhookAllowedTargets []string
+}
+
+// SSOProvider is an OpenID Connect identity provider users can sign in with
+type SSOProvider struct {
+	ID           string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// TrustEmail treats the email as verified when the provider sends no email_verified claim (e.g. Microsoft Entra)
+	TrustEmail bool
 }
 
 func GetConfig() Config {
@@ -55,11 +78,70 @@ func GetConfig() Config {
 		FixerIOURl: getEnv("FIXER_IO_URL", ""),
 		FixerIOKey: getEnv("FIXER_IO_KEY", ""),
 
+		FXProvider:        getEnv("FX_PROVIDER", ""),
+		FXProviderURL:     getEnv("FX_PROVIDER_URL", ""),
+		FXManualRatesFile: getEnv("FX_MANUAL_RATES_FILE", ""),
+		FXMaxRateAge:      getEnvDurationDays("FX_MAX_RATE_AGE_DAYS", utils.FXMaxRateAge),
+
 		ResetPasswordDelay:    getEnvDurationMinutes("RESET_PASSWORD_DELAY_MINUTES", utils.ResetPasswordDelay),
 		ResetPasswordValidity: getEnvDurationMinutes("RESET_PASSWORD_VALIDITY_MINUTES", utils.ResetPasswordValidity),
 		InvitationResendDelay: getEnvDurationMinutes("INVITATION_RESEND_DELAY_MINUTES", utils.InvitationResendDelay),
 		InvitationValidity:    getEnvDurationMinutes("INVITATION_VALIDITY_MINUTES", utils.InvitationValidity),
+
+		ForecastDebounce: getEnvDurationMilliseconds("FORECAST_DEBOUNCE_MS", utils.ForecastDebounce),
+
+		EventBroker:             getEnv("EVENT_BROKER", "memory"),
+		EventOutboxPollInterval: getEnvDurationMilliseconds("EVENT_OUTBOX_POLL_MS", utils.EventOutboxPollInterval),
+
+		SSOProviders: getSSOProviders(),
+
+		WebhookAllowedTargets: getEnvList("WEBHOOK_ALLOWED_TARGETS"),
+	}
+}
+
+// getSSOProviders reads the providers listed in SSO_PROVIDERS (e.g. "google,entra"), each one
+// configured by SSO_<ID>_ISSUER, SSO_<ID>_CLIENT_ID, SSO_<ID>_CLIENT_SECRET, SSO_<ID>_NAME
+// and SSO_<ID>_TRUST_EMAIL. Providers without issuer or client id are skipped.
+func getSSOProviders() []SSOProvider {
+	providers := []SSOProvider{}
+	for _, id := range strings.Split(getEnv("SSO_PROVIDERS", ""), ",") {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+		prefix := "SSO_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+		provider := SSOProvider{
+			ID:           id,
+			Name:         getEnv(prefix+"NAME", id),
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			TrustEmail:   getEnv(prefix+"TRUST_EMAIL", "") == "true",
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			continue
+		}
+		providers = append(providers, provider)
 	}
+	return providers
+}
+
+func getEnvDurationMilliseconds(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return time.Duration(n) * time.Millisecond
+		}
+	}
+	return fallback
+}
+
+func getEnvDurationDays(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour
+		}
+	}
+	return fallback
 }
 
 func getEnvDurationMinutes(key string, fallback time.Duration) time.Duration {
@@ -71,6 +153,17 @@ func getEnvDurationMinutes(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+// getEnvList splits a comma separated variable, empty entries are skipped
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
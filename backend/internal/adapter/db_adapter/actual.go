@@ -0,0 +1,438 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+func (d *DatabaseAdapter) ListBankStatementImports(userID int64, bankAccountID int64) ([]models.BankStatementImport, error) {
+	imports := []models.BankStatementImport{}
+
+	query, err := sqlQueries.ReadFile("queries/list_bank_statement_imports.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), bankAccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		statementImport, err := scanBankStatementImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, *statementImport)
+	}
+
+	return imports, nil
+}
+
+func (d *DatabaseAdapter) GetBankStatementImport(userID int64, bankAccountID int64, importID int64) (*models.BankStatementImport, error) {
+	query, err := sqlQueries.ReadFile("queries/get_bank_statement_import.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBankStatementImport(d.db.QueryRow(string(query), importID, bankAccountID, userID))
+}
+
+// CreateBankStatementImport stores the import along with its bookings in one transaction.
+// It returns the ID of the import and how many bookings were skipped as already imported.
+func (d *DatabaseAdapter) CreateBankStatementImport(payload models.CreateBankStatementImport, actuals []models.CreateActual, userID int64, bankAccountID int64) (importID int64, skipped int64, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	importQuery, err := sqlQueries.ReadFile("queries/create_bank_statement_import.sql")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	res, err := tx.Exec(
+		string(importQuery),
+		payload.FileName, payload.Format, payload.OpeningBalance, payload.ClosingBalance, payload.ClosingDate,
+		bankAccountID, userID,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The insert selects from the bank account, so nothing is written for foreign accounts
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return 0, 0, err
+	}
+
+	importID, err = res.LastInsertId()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	actualQuery, err := sqlQueries.ReadFile("queries/create_actual.sql")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	stmt, err := tx.Prepare(string(actualQuery))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer stmt.Close()
+
+	for _, actual := range actuals {
+		actual.BankStatementImport = &importID
+		res, err = stmt.Exec(createActualArgs(actual, userID)...)
+		if err != nil {
+			return 0, 0, err
+		}
+		affected, err = res.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		if affected == 0 {
+			skipped++
+		}
+	}
+
+	return importID, skipped, nil
+}
+
+func (d *DatabaseAdapter) DeleteBankStatementImport(userID int64, bankAccountID int64, importID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_bank_statement_import.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(importID, bankAccountID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) ListActualsByImport(userID int64, importID int64) ([]models.Actual, error) {
+	actuals := []models.Actual{}
+
+	query, err := sqlQueries.ReadFile("queries/list_actuals_by_import.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), importID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		actual, err := scanActual(rows)
+		if err != nil {
+			return nil, err
+		}
+		actuals = append(actuals, *actual)
+	}
+
+	return actuals, nil
+}
+
+// ListActuals returns the actuals booked between from and to (inclusive, YYYY-MM-DD)
+func (d *DatabaseAdapter) ListActuals(userID int64, from string, to string) ([]models.Actual, error) {
+	actuals := []models.Actual{}
+
+	query, err := sqlQueries.ReadFile("queries/list_actuals.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		actual, err := scanActual(rows)
+		if err != nil {
+			return nil, err
+		}
+		actuals = append(actuals, *actual)
+	}
+
+	return actuals, nil
+}
+
+func (d *DatabaseAdapter) GetActual(userID int64, actualID int64) (*models.Actual, error) {
+	query, err := sqlQueries.ReadFile("queries/get_actual.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanActual(d.db.QueryRow(string(query), actualID, userID))
+}
+
+// CreateActuals stores the actuals in one transaction and returns the IDs of the created ones.
+// Bookings with a reference that was already imported for the same bank account are skipped.
+func (d *DatabaseAdapter) CreateActuals(actuals []models.CreateActual, userID int64) (ids []int64, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query, err := sqlQueries.ReadFile("queries/create_actual.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare(string(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	ids = make([]int64, 0, len(actuals))
+	for _, actual := range actuals {
+		res, err := stmt.Exec(createActualArgs(actual, userID)...)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			continue
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (d *DatabaseAdapter) UpdateActual(payload models.CreateActual, userID int64, actualID int64) error {
+	query, err := sqlQueries.ReadFile("queries/update_actual.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		payload.BookingDate, payload.Amount, payload.Name, payload.Reference, payload.Status,
+		payload.PlannedDate, payload.PlannedAmount, payload.Transaction, payload.Currency,
+		actualID, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) DeleteActual(userID int64, actualID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_actual.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(actualID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListMatchedTransactionOccurrences returns all planned occurrences that are already settled by an actual
+func (d *DatabaseAdapter) ListMatchedTransactionOccurrences(userID int64) (map[models.TransactionOccurrence]bool, error) {
+	occurrences := make(map[models.TransactionOccurrence]bool)
+
+	query, err := sqlQueries.ReadFile("queries/list_matched_transaction_occurrences.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionID int64
+		var plannedDate time.Time
+
+		err := rows.Scan(&transactionID, &plannedDate)
+		if err != nil {
+			return nil, err
+		}
+
+		occurrences[models.TransactionOccurrence{
+			TransactionID: transactionID,
+			Date:          plannedDate.Format(utils.InternalDateFormat),
+		}] = true
+	}
+
+	return occurrences, nil
+}
+
+func createActualArgs(actual models.CreateActual, userID int64) []any {
+	return []any{
+		actual.BookingDate, actual.Amount, actual.Name, actual.Reference, actual.Status, actual.PlannedDate, actual.PlannedAmount,
+		actual.Transaction, actual.BankAccount, actual.BankStatementImport, actual.Currency, userID,
+	}
+}
+
+func scanBankStatementImport(row rowScanner) (*models.BankStatementImport, error) {
+	var statementImport models.BankStatementImport
+	// Required for proper date convertion afterwards
+	var closingDate sql.NullTime
+
+	err := row.Scan(
+		&statementImport.ID,
+		&statementImport.FileName,
+		&statementImport.Format,
+		&statementImport.OpeningBalance,
+		&statementImport.ClosingBalance,
+		&closingDate,
+		&statementImport.CreatedAt,
+		&statementImport.BankAccountID,
+		&statementImport.Matched,
+		&statementImport.Deviations,
+		&statementImport.Unmatched,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if closingDate.Valid {
+		convertedDate := types.AsDate(closingDate.Time)
+		statementImport.ClosingDate = &convertedDate
+	}
+
+	return &statementImport, nil
+}
+
+func scanActual(row rowScanner) (*models.Actual, error) {
+	var actual models.Actual
+	// These are required for proper date convertion afterwards
+	var bookingDate time.Time
+	var plannedDate sql.NullTime
+	var transactionID sql.NullInt64
+	var transactionName sql.NullString
+	var categoryID sql.NullInt64
+	var categoryName sql.NullString
+
+	err := row.Scan(
+		&actual.ID,
+		&bookingDate,
+		&actual.Amount,
+		&actual.Name,
+		&actual.Reference,
+		&actual.Status,
+		&plannedDate,
+		&actual.PlannedAmount,
+		&transactionID,
+		&transactionName,
+		&categoryID,
+		&categoryName,
+		&actual.BankAccountID,
+		&actual.BankStatementImportID,
+		&actual.Currency.ID,
+		&actual.Currency.Code,
+		&actual.Currency.Description,
+		&actual.Currency.LocaleCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	actual.BookingDate = types.AsDate(bookingDate)
+	if plannedDate.Valid {
+		convertedDate := types.AsDate(plannedDate.Time)
+		actual.PlannedDate = &convertedDate
+	}
+	if transactionID.Valid {
+		actual.Transaction = &models.ActualTransaction{
+			ID:   transactionID.Int64,
+			Name: transactionName.String,
+		}
+		if categoryID.Valid {
+			actual.Transaction.Category = &models.Category{
+				ID:   categoryID.Int64,
+				Name: categoryName.String,
+			}
+		}
+	}
+
+	return &actual, nil
+}
@@ -0,0 +1,141 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+	"time"
+)
+
+func (d *DatabaseAdapter) CreateAPIToken(payload models.CreateAPIToken, userID int64, organisationID int64, tokenHash string, tokenPrefix string, expiresAt time.Time) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/create_api_token.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), payload.Name, tokenHash, tokenPrefix, payload.Scope, expiresAt, userID, organisationID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) ListAPITokens(userID int64) ([]models.APIToken, error) {
+	tokens := []models.APIToken{}
+
+	query, err := sqlQueries.ReadFile("queries/list_api_tokens.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (d *DatabaseAdapter) GetAPIToken(userID int64, tokenID int64) (*models.APIToken, error) {
+	query, err := sqlQueries.ReadFile("queries/get_api_token.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAPIToken(d.db.QueryRow(string(query), tokenID, userID))
+}
+
+// DeleteAPIToken returns sql.ErrNoRows if the user has no such token
+func (d *DatabaseAdapter) DeleteAPIToken(userID int64, tokenID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_api_token.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), tokenID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetAPITokenAuth looks up an unexpired token by its hash
+func (d *DatabaseAdapter) GetAPITokenAuth(tokenHash string) (*models.APITokenAuth, error) {
+	var token models.APITokenAuth
+
+	query, err := sqlQueries.ReadFile("queries/get_api_token_auth.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.OrganisationID,
+		&token.Scope,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// TouchAPIToken records the last use, writes within interval of the previous one are skipped
+func (d *DatabaseAdapter) TouchAPIToken(tokenID int64, ipAddress string, interval time.Duration) error {
+	query, err := sqlQueries.ReadFile("queries/touch_api_token.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), ipAddress, tokenID, int64(interval.Seconds()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanAPIToken(row rowScanner) (*models.APIToken, error) {
+	var token models.APIToken
+
+	err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&token.Prefix,
+		&token.Scope,
+		&token.OrganisationID,
+		&token.OrganisationName,
+		&token.LastUsedAt,
+		&token.LastUsedIP,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
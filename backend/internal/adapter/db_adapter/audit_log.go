@@ -0,0 +1,82 @@
+package db_adapter
+
+import (
+	"encoding/json"
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) ListAuditLogs(userID int64, filter models.AuditLogFilter, page int64, limit int64) ([]models.AuditLog, int64, error) {
+	auditLogs := []models.AuditLog{}
+	var totalCount int64
+
+	query, err := sqlQueries.ReadFile("queries/list_audit_logs.sql")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := d.db.Query(
+		string(query), userID,
+		filter.Entity, filter.Entity, filter.UserID, filter.UserID,
+		filter.From, filter.From, filter.To, filter.To,
+		limit, (page-1)*limit,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var auditLog models.AuditLog
+		var changesJSON []byte
+
+		err := rows.Scan(
+			&auditLog.ID, &auditLog.UserID, &auditLog.User, &auditLog.Source, &auditLog.OAuthClientID,
+			&auditLog.Entity, &auditLog.EntityID, &auditLog.ParentID, &auditLog.Action, &changesJSON,
+			&auditLog.CreatedAt, &totalCount,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		auditLog.Changes = []models.AuditFieldChange{}
+		if err := json.Unmarshal(changesJSON, &auditLog.Changes); err != nil {
+			return nil, 0, err
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, totalCount, nil
+}
+
+func (d *DatabaseAdapter) CreateAuditLog(payload models.CreateAuditLog) (int64, error) {
+	changes := payload.Changes
+	if changes == nil {
+		changes = []models.AuditFieldChange{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return 0, err
+	}
+
+	query, err := sqlQueries.ReadFile("queries/create_audit_log.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(
+		string(query),
+		payload.Source, payload.OAuthClientID, payload.Entity, payload.EntityID, payload.ParentID, payload.Action,
+		changesJSON, payload.UserID, payload.OrganisationID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
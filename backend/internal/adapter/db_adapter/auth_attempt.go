@@ -0,0 +1,83 @@
+package db_adapter
+
+import (
+	"time"
+)
+
+// RecordAuthFailure counts a failed attempt and returns the failures within the window and
+// whether the identifier is locked out already
+func (d *DatabaseAdapter) RecordAuthFailure(scope string, identifier string, window time.Duration) (int64, bool, error) {
+	recordQuery, err := sqlQueries.ReadFile("queries/record_auth_failure.sql")
+	if err != nil {
+		return 0, false, err
+	}
+	getQuery, err := sqlQueries.ReadFile("queries/get_auth_attempt.sql")
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = d.db.Exec(string(recordQuery), scope, identifier, int64(window.Seconds()))
+	if err != nil {
+		return 0, false, err
+	}
+
+	var failures int64
+	var locked bool
+	err = d.db.QueryRow(string(getQuery), scope, identifier).Scan(&failures, &locked)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return failures, locked, nil
+}
+
+// BlockAuthAttempts rejects further attempts for the duration, lockout marks the block as lockout
+func (d *DatabaseAdapter) BlockAuthAttempts(scope string, identifier string, duration time.Duration, lockout bool) error {
+	query, err := sqlQueries.ReadFile("queries/block_auth_attempts.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), int64(duration.Seconds()), lockout, scope, identifier)
+
+	return err
+}
+
+// GetAuthBlock returns how long the longer block of both identifiers lasts, zero means not blocked
+func (d *DatabaseAdapter) GetAuthBlock(scope string, identifier string, otherIdentifier string) (time.Duration, error) {
+	query, err := sqlQueries.ReadFile("queries/get_auth_block.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var seconds int64
+	err = d.db.QueryRow(string(query), scope, identifier, otherIdentifier).Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (d *DatabaseAdapter) ResetAuthAttempts(scope string, identifier string) error {
+	query, err := sqlQueries.ReadFile("queries/reset_auth_attempts.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), scope, identifier)
+
+	return err
+}
+
+// UnlockAuthAttempts removes the failures and blocks of all scopes for both identifiers
+func (d *DatabaseAdapter) UnlockAuthAttempts(identifier string, otherIdentifier string) error {
+	query, err := sqlQueries.ReadFile("queries/unlock_auth_attempts.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), identifier, otherIdentifier)
+
+	return err
+}
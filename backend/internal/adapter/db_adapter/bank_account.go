@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"html/template"
 	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
 	"strings"
+	"time"
 )
 
 func (d *DatabaseAdapter) ListBankAccounts(userID int64, page int64, limit int64, sortBy string, sortOrder string, search string) ([]models.BankAccount, int64, error) {
@@ -182,3 +184,168 @@ func (d *DatabaseAdapter) DeleteBankAccount(userID int64, bankAccountID int64) e
 
 	return nil
 }
+
+func (d *DatabaseAdapter) ListBankAccountBalances(userID int64, bankAccountID int64) ([]models.BankAccountBalance, error) {
+	balances := []models.BankAccountBalance{}
+
+	query, err := sqlQueries.ReadFile("queries/list_bank_account_balances.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), bankAccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		balance, err := scanBankAccountBalance(rows)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, *balance)
+	}
+
+	return balances, nil
+}
+
+func (d *DatabaseAdapter) GetBankAccountBalance(userID int64, bankAccountID int64, balanceID int64) (*models.BankAccountBalance, error) {
+	query, err := sqlQueries.ReadFile("queries/get_bank_account_balance.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBankAccountBalance(d.db.QueryRow(string(query), balanceID, bankAccountID, userID))
+}
+
+// UpsertBankAccountBalance stores the balance for the given date, replacing an existing snapshot of the same day
+func (d *DatabaseAdapter) UpsertBankAccountBalance(payload models.CreateBankAccountBalance, userID int64, bankAccountID int64) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/upsert_bank_account_balance.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(payload.Date, payload.Amount, bankAccountID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// The insert selects from the bank account, so nothing is written for foreign accounts
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	// Get the ID of the inserted or updated balance
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) DeleteBankAccountBalance(userID int64, bankAccountID int64, balanceID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_bank_account_balance.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(balanceID, bankAccountID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SyncBankAccountAmount sets the amount of the bank account to its latest balance snapshot
+func (d *DatabaseAdapter) SyncBankAccountAmount(userID int64, bankAccountID int64) error {
+	query, err := sqlQueries.ReadFile("queries/sync_bank_account_amount.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(bankAccountID, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListBankAccountsAtDate returns all bank accounts with the amount they had on the given date
+func (d *DatabaseAdapter) ListBankAccountsAtDate(userID int64, date string) ([]models.BankAccount, error) {
+	bankAccounts := make([]models.BankAccount, 0)
+
+	query, err := sqlQueries.ReadFile("queries/list_bank_accounts_at_date.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), date, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bankAccount models.BankAccount
+
+		err := rows.Scan(
+			&bankAccount.ID, &bankAccount.Name, &bankAccount.Amount,
+			&bankAccount.Currency.ID, &bankAccount.Currency.Code, &bankAccount.Currency.Description, &bankAccount.Currency.LocaleCode,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		bankAccounts = append(bankAccounts, bankAccount)
+	}
+
+	return bankAccounts, nil
+}
+
+func scanBankAccountBalance(row rowScanner) (*models.BankAccountBalance, error) {
+	var balance models.BankAccountBalance
+	// Required for proper date convertion afterwards
+	var balanceDate time.Time
+
+	err := row.Scan(&balance.ID, &balanceDate, &balance.Amount, &balance.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	balance.Date = types.AsDate(balanceDate)
+
+	return &balance, nil
+}
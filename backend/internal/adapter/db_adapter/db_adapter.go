@@ -32,6 +32,46 @@ type IDatabaseAdapter interface {
 	ValidateResetPassword(email, code string, validity time.Duration) (int64, error)
 	DeleteResetPassword(email string) error
 
+	GetUserTwoFactor(userID int64) (*models.UserTwoFactor, error)
+	UpdateUserTwoFactor(userID int64, secret *string, enabled bool) error
+	UpdateUserTwoFactorLastStep(userID int64, step int64) (bool, error)
+	ReplaceUserRecoveryCodes(userID int64, codeHashes []string) error
+	UseUserRecoveryCode(userID int64, codeHash string) (bool, error)
+	CountUserRecoveryCodes(userID int64) (int64, error)
+	CheckUserTwoFactorRequired(userID int64) (bool, error)
+	CheckCurrentUserTwoFactorCompliance(userID int64) (bool, error)
+
+	CreateAPIToken(payload models.CreateAPIToken, userID int64, organisationID int64, tokenHash string, tokenPrefix string, expiresAt time.Time) (int64, error)
+	ListAPITokens(userID int64) ([]models.APIToken, error)
+	GetAPIToken(userID int64, tokenID int64) (*models.APIToken, error)
+	DeleteAPIToken(userID int64, tokenID int64) error
+	GetAPITokenAuth(tokenHash string) (*models.APITokenAuth, error)
+	TouchAPIToken(tokenID int64, ipAddress string, interval time.Duration) error
+
+	GetUserIDBySSOIdentity(provider string, subject string) (int64, error)
+	CreateUserIdentity(userID int64, provider string, subject string, email string) error
+	TouchUserIdentity(provider string, subject string, email string) error
+	GetOrganisationBySSODomain(domain string) (*models.SSOOrganisation, error)
+
+	ListWebhooks(userID int64) ([]models.Webhook, error)
+	GetWebhook(userID int64, webhookID int64) (*models.Webhook, error)
+	CreateWebhook(payload models.CreateWebhook, userID int64, secret string) (int64, error)
+	UpdateWebhook(payload models.UpdateWebhook, userID int64, webhookID int64) error
+	UpdateWebhookSecret(userID int64, webhookID int64, secret string) error
+	DeleteWebhook(userID int64, webhookID int64) error
+	EnqueueWebhookDeliveries(organisationID int64, entity string, action string, payload []byte) (int64, error)
+	ClaimWebhookDeliveries(claimToken string, limit int64, lease time.Duration) ([]models.WebhookDeliveryJob, error)
+	CompleteWebhookDelivery(deliveryID int64, result models.WebhookDeliveryResult) error
+	ListWebhookDeliveries(userID int64, webhookID int64, page int64, limit int64) ([]models.WebhookDelivery, int64, error)
+	GetWebhookDelivery(userID int64, webhookID int64, deliveryID int64) (*models.WebhookDelivery, error)
+	ReplayWebhookDelivery(userID int64, webhookID int64, deliveryID int64) (int64, error)
+	DeleteWebhookDeliveriesBefore(before time.Time) (int64, error)
+
+	CreateEventOutboxEntry(payload []byte) (int64, error)
+	ListEventOutboxEntries(afterID int64, limit int64) ([]models.EventOutboxEntry, error)
+	GetLatestEventOutboxID() (int64, error)
+	DeleteEventOutboxEntriesBefore(before time.Time) (int64, error)
+
 	ListTransactions(userID int64, page int64, limit int64, sortBy string, sortOrder string, search string, hideDisabled bool, hideExpired bool) ([]models.Transaction, int64, error)
 	GetTransaction(userID int64, transactionID int64) (*models.Transaction, error)
 	CreateTransaction(payload models.CreateTransaction, userID int64) (int64, error)
@@ -41,6 +81,7 @@ type IDatabaseAdapter interface {
 	ListOrganisations(userID int64, page int64, limit int64) ([]models.Organisation, int64, error)
 	GetOrganisation(userID int64, organisationID int64) (*models.Organisation, error)
 	GetCurrentUserRole(userID int64) (string, error)
+	GetCurrentUserPayrollAccess(userID int64) (*models.PayrollAccess, error)
 	CreateOrganisation(name string) (int64, error)
 	UpdateOrganisation(payload models.UpdateOrganisation, userID int64, organisationID int64) error
 	AssignUserToOrganisation(userID int64, organisationID int64, role string, isDefault bool) error
@@ -79,6 +120,13 @@ type IDatabaseAdapter interface {
 	UpdateSalaryCostLabel(payload models.CreateSalaryCostLabel, userID int64, salaryCostLabelID int64) error
 	DeleteSalaryCostLabel(userID int64, salaryCostLabelID int64) error
 
+	ListPayrollTemplates(userID int64) ([]models.PayrollTemplate, error)
+	GetPayrollTemplate(userID int64, templateID int64) (*models.PayrollTemplate, error)
+	ListPayrollTemplateItems(templateID int64) ([]models.PayrollTemplateItem, error)
+	CreatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64) (int64, error)
+	UpdatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64, templateID int64) error
+	DeletePayrollTemplate(userID int64, templateID int64) error
+
 	ListForecasts(userID int64, limit int64) ([]models.Forecast, error)
 	ListForecastDetails(userID int64, limit int64) ([]models.ForecastDatabaseDetails, error)
 	UpsertForecast(payload models.CreateForecast, userID int64) (int64, error)
@@ -88,12 +136,55 @@ type IDatabaseAdapter interface {
 	CreateForecastExclusion(payload models.CreateForecastExclusion, userID int64) (int64, error)
 	DeleteForecastExclusion(payload models.CreateForecastExclusion, userID int64) (int64, error)
 	ClearForecasts(userID int64) (int64, error)
+	ArchiveForecasts(userID int64) error
+	ListForecastHistory(userID int64, from string, to string) ([]models.ForecastHistory, error)
+	ListForecastSnapshots(userID int64) ([]models.ForecastSnapshot, error)
+	GetForecastSnapshot(userID int64, snapshotID int64) (*models.ForecastSnapshot, error)
+	CreateForecastSnapshot(payload models.CreateForecastSnapshot, forecasts []models.Forecast, details []models.ForecastDatabaseDetails, openingBalance int64, userID int64) (int64, error)
+	DeleteForecastSnapshot(userID int64, snapshotID int64) error
+
+	ListImports(userID int64) ([]models.Import, error)
+	GetImport(userID int64, importID int64) (*models.Import, error)
+	CreateImport(payload models.CreateImport, userID int64) (int64, error)
+	CommitTransactionImport(userID int64, importID int64, payloads []models.CreateTransaction) ([]int64, error)
+	CommitEmployeeImport(userID int64, importID int64, employees []models.ImportEmployee) ([]models.ImportEmployeeResult, error)
+	DeleteImport(userID int64, importID int64) error
+
+	ListAuditLogs(userID int64, filter models.AuditLogFilter, page int64, limit int64) ([]models.AuditLog, int64, error)
+	CreateAuditLog(payload models.CreateAuditLog) (int64, error)
+
+	ListScenarios(userID int64) ([]models.Scenario, error)
+	GetScenario(userID int64, scenarioID int64) (*models.Scenario, error)
+	CreateScenario(payload models.CreateScenario, userID int64) (int64, error)
+	UpdateScenario(payload models.UpdateScenario, userID int64, scenarioID int64) error
+	DeleteScenario(userID int64, scenarioID int64) error
+	ListScenarioItems(userID int64, scenarioID int64) ([]models.ScenarioItem, error)
+	GetScenarioItem(userID int64, scenarioID int64, scenarioItemID int64) (*models.ScenarioItem, error)
+	CreateScenarioItem(payload models.CreateScenarioItem, userID int64, scenarioID int64) (int64, error)
+	DeleteScenarioItem(userID int64, scenarioID int64, scenarioItemID int64) error
 
 	ListBankAccounts(userID int64, page int64, limit int64, sortBy string, sortOrder string, search string) ([]models.BankAccount, int64, error)
 	GetBankAccount(userID int64, bankAccountID int64) (*models.BankAccount, error)
 	CreateBankAccount(payload models.CreateBankAccount, userID int64) (int64, error)
 	UpdateBankAccount(payload models.UpdateBankAccount, userID int64, bankAccountID int64) error
 	DeleteBankAccount(userID int64, bankAccountID int64) error
+	ListBankAccountBalances(userID int64, bankAccountID int64) ([]models.BankAccountBalance, error)
+	GetBankAccountBalance(userID int64, bankAccountID int64, balanceID int64) (*models.BankAccountBalance, error)
+	UpsertBankAccountBalance(payload models.CreateBankAccountBalance, userID int64, bankAccountID int64) (int64, error)
+	DeleteBankAccountBalance(userID int64, bankAccountID int64, balanceID int64) error
+	SyncBankAccountAmount(userID int64, bankAccountID int64) error
+	ListBankAccountsAtDate(userID int64, date string) ([]models.BankAccount, error)
+	ListBankStatementImports(userID int64, bankAccountID int64) ([]models.BankStatementImport, error)
+	GetBankStatementImport(userID int64, bankAccountID int64, importID int64) (*models.BankStatementImport, error)
+	CreateBankStatementImport(payload models.CreateBankStatementImport, actuals []models.CreateActual, userID int64, bankAccountID int64) (int64, int64, error)
+	DeleteBankStatementImport(userID int64, bankAccountID int64, importID int64) error
+	ListActualsByImport(userID int64, importID int64) ([]models.Actual, error)
+	ListActuals(userID int64, from string, to string) ([]models.Actual, error)
+	GetActual(userID int64, actualID int64) (*models.Actual, error)
+	CreateActuals(actuals []models.CreateActual, userID int64) ([]int64, error)
+	UpdateActual(payload models.CreateActual, userID int64, actualID int64) error
+	DeleteActual(userID int64, actualID int64) error
+	ListMatchedTransactionOccurrences(userID int64) (map[models.TransactionOccurrence]bool, error)
 
 	ListVats(userID int64) ([]models.Vat, error)
 	GetVat(userID int64, vatID int64) (*models.Vat, error)
@@ -128,9 +219,19 @@ type IDatabaseAdapter interface {
 	UpdateCurrency(payload models.UpdateCurrency, currencyID int64) error
 	CountCurrencies() (int64, error)
 
-	StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName string) error
+	StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName string, ipAddress string) error
 	CheckRefreshToken(userID int64, tokenID string) (bool, error)
 	DeleteRefreshToken(userID int64, tokenID string) error
+	TouchRefreshToken(userID int64, tokenID string, ipAddress string) error
+	ListSessions(userID int64) ([]models.Session, error)
+	DeleteSession(userID int64, sessionID int64, currentTokenID string) error
+	DeleteOtherSessions(userID int64, currentTokenID string) (int64, error)
+
+	RecordAuthFailure(scope string, identifier string, window time.Duration) (int64, bool, error)
+	BlockAuthAttempts(scope string, identifier string, duration time.Duration, lockout bool) error
+	GetAuthBlock(scope string, identifier string, otherIdentifier string) (time.Duration, error)
+	ResetAuthAttempts(scope string, identifier string) error
+	UnlockAuthAttempts(identifier string, otherIdentifier string) error
 
 	CreateOAuthClient(clientID, clientName string, redirectURIs []string) error
 	GetOAuthClient(clientID string) (*models.OAuthClient, error)
@@ -148,6 +249,16 @@ type IDatabaseAdapter interface {
 	CountUniqueCurrenciesInFiatRates() (int64, error)
 	GetFiatRate(base, target string) (*models.FiatRate, error)
 	UpsertFiatRate(payload models.CreateFiatRate) error
+	UpsertFiatRateHistory(payload models.CreateFiatRate, date time.Time) error
+	CreateFiatRateFetch(payload models.CreateFiatRateFetch) (int64, error)
+	ListFiatRateFetches(limit int64) ([]models.FiatRateFetch, error)
+	GetLastSucceededFiatRateFetch() (*models.FiatRateFetch, error)
+	ListFiatRateHistory(base string, from time.Time) ([]models.FiatRateHistory, error)
+	GetFiatRateAt(base, target string, date time.Time) (*models.FiatRateHistory, error)
+	ListOrganisationFiatRates(userID int64) ([]models.OrganisationFiatRate, error)
+	GetOrganisationFiatRate(userID int64, base, target string) (*models.OrganisationFiatRate, error)
+	UpsertOrganisationFiatRate(userID int64, base, target string, rate float64) error
+	DeleteOrganisationFiatRate(userID int64, base, target string) error
 
 	CreateInvitation(organisationID int64, email string, role string, token string, invitedBy int64, expiresAt time.Time) (int64, error)
 	ListInvitations(organisationID int64) ([]models.Invitation, error)
@@ -164,15 +275,31 @@ type IDatabaseAdapter interface {
 	ListMembers(organisationID int64) ([]models.OrganisationMember, error)
 	GetMember(organisationID int64, userID int64) (*models.OrganisationMember, error)
 	UpdateMemberRole(organisationID int64, userID int64, role string) error
+	UpdateMemberPayrollClearance(organisationID int64, userID int64, payrollClearance bool) error
 	DeleteMember(organisationID int64, userID int64) error
 	CountOwners(organisationID int64) (int64, error)
 	GetMemberPermission(userID int64, organisationID int64) (*models.MemberPermission, error)
 	UpsertMemberPermission(userID int64, organisationID int64, canView bool, canEdit bool, canDelete bool) error
 	DeleteMemberPermissions(userID int64, organisationID int64) error
+	ListMemberPermissions(userID int64, organisationID int64) ([]models.MemberPermission, error)
+	ListCurrentMemberPermissions(userID int64) ([]models.MemberPermission, error)
+	UpsertMemberEntityPermission(userID int64, organisationID int64, entityType string, canView bool, canEdit bool, canDelete bool) error
+	DeleteMemberEntityPermission(userID int64, organisationID int64, entityType string) error
+
+	ScopeToOrganisation(organisationID int64) (IDatabaseAdapter, func(), error)
+}
+
+// queryer is implemented by the connection pool and by a single connection scoped to an organisation
+type queryer interface {
+	Begin() (*sql.Tx, error)
+	Exec(query string, args ...any) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
 }
 
 type DatabaseAdapter struct {
-	db *sql.DB
+	db queryer
 }
 
 func NewDatabaseAdapter(db *sql.DB) IDatabaseAdapter {
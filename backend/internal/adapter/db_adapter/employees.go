@@ -62,6 +62,7 @@ func (d *DatabaseAdapter) ListEmployees(userID int64, page int64, limit int64, s
 		err := rows.Scan(
 			&employee.ID,
 			&employee.Name,
+			&employee.BirthDate,
 			&employee.HoursPerMonth,
 			&employee.SalaryAmount,
 			&employee.Cycle,
@@ -112,6 +113,7 @@ func (d *DatabaseAdapter) GetEmployee(userID int64, employeeID int64) (*models.E
 	err = d.db.QueryRow(string(query), employeeID, userID).Scan(
 		&employee.ID,
 		&employee.Name,
+		&employee.BirthDate,
 		&employee.HoursPerMonth,
 		&employee.SalaryAmount,
 		&employee.Cycle,
@@ -155,8 +157,13 @@ func (d *DatabaseAdapter) CreateEmployee(payload models.CreateEmployee, userID i
 	}
 	defer stmt.Close()
 
+	birthDate, err := parseOptionalDate(payload.BirthDate)
+	if err != nil {
+		return 0, err
+	}
+
 	res, err := stmt.Exec(
-		payload.Name, userID,
+		payload.Name, birthDate, userID,
 	)
 	if err != nil {
 		return 0, err
@@ -182,6 +189,14 @@ func (d *DatabaseAdapter) UpdateEmployee(payload models.UpdateEmployee, userID i
 		queryBuild = append(queryBuild, "name = ?")
 		args = append(args, *payload.Name)
 	}
+	if payload.BirthDate != nil {
+		birthDate, err := parseOptionalDate(payload.BirthDate)
+		if err != nil {
+			return err
+		}
+		queryBuild = append(queryBuild, "birth_date = ?")
+		args = append(args, birthDate)
+	}
 
 	// Add WHERE clause
 	query += strings.Join(queryBuild, ", ")
@@ -0,0 +1,79 @@
+package db_adapter
+
+import (
+	"liquiswiss/pkg/models"
+	"time"
+)
+
+func (d *DatabaseAdapter) CreateEventOutboxEntry(payload []byte) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/create_event_outbox_entry.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), payload)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// ListEventOutboxEntries returns up to limit entries after the given id in the order of their ids
+func (d *DatabaseAdapter) ListEventOutboxEntries(afterID int64, limit int64) ([]models.EventOutboxEntry, error) {
+	entries := []models.EventOutboxEntry{}
+
+	query, err := sqlQueries.ReadFile("queries/list_event_outbox_entries.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.EventOutboxEntry
+
+		err := rows.Scan(&entry.ID, &entry.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (d *DatabaseAdapter) GetLatestEventOutboxID() (int64, error) {
+	var id int64
+
+	query, err := sqlQueries.ReadFile("queries/get_latest_event_outbox_id.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	err = d.db.QueryRow(string(query)).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) DeleteEventOutboxEntriesBefore(before time.Time) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/delete_event_outbox_entries_before.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -1,6 +1,12 @@
 package db_adapter
 
-import "liquiswiss/pkg/models"
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+	"time"
+)
 
 func (d *DatabaseAdapter) ListFiatRates(base string) ([]models.FiatRate, error) {
 	fiatRates := []models.FiatRate{}
@@ -18,11 +24,13 @@ func (d *DatabaseAdapter) ListFiatRates(base string) ([]models.FiatRate, error)
 
 	for rows.Next() {
 		var fiatRate models.FiatRate
+		var rateDate time.Time
 
-		err := rows.Scan(&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.UpdatedAt)
+		err := rows.Scan(&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.Source, &rateDate, &fiatRate.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
+		fiatRate.RateDate = types.AsDate(rateDate)
 
 		fiatRates = append(fiatRates, fiatRate)
 	}
@@ -32,6 +40,7 @@ func (d *DatabaseAdapter) ListFiatRates(base string) ([]models.FiatRate, error)
 
 func (d *DatabaseAdapter) GetFiatRate(base, target string) (*models.FiatRate, error) {
 	var fiatRate models.FiatRate
+	var rateDate time.Time
 
 	query, err := sqlQueries.ReadFile("queries/get_fiat_rate.sql")
 	if err != nil {
@@ -39,11 +48,12 @@ func (d *DatabaseAdapter) GetFiatRate(base, target string) (*models.FiatRate, er
 	}
 
 	err = d.db.QueryRow(string(query), base, target).Scan(
-		&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.UpdatedAt,
+		&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.Source, &rateDate, &fiatRate.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	fiatRate.RateDate = types.AsDate(rateDate)
 
 	return &fiatRate, nil
 }
@@ -60,8 +70,12 @@ func (d *DatabaseAdapter) UpsertFiatRate(payload models.CreateFiatRate) error {
 	}
 	defer stmt.Close()
 
+	rateDate := utils.GetTodayAsUTC()
+	if payload.Date != nil {
+		rateDate = *payload.Date
+	}
 	_, err = stmt.Exec(
-		payload.Base, payload.Target, payload.Rate,
+		payload.Base, payload.Target, payload.Rate, payload.Source, rateDate.Format(utils.InternalDateFormat),
 	)
 	if err != nil {
 		return err
@@ -84,3 +98,225 @@ func (d *DatabaseAdapter) CountUniqueCurrenciesInFiatRates() (int64, error) {
 
 	return totalCount, nil
 }
+
+func (d *DatabaseAdapter) UpsertFiatRateHistory(payload models.CreateFiatRate, date time.Time) error {
+	query, err := sqlQueries.ReadFile("queries/upsert_fiat_rate_history.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), payload.Base, payload.Target, payload.Rate, payload.Source, date.Format(utils.InternalDateFormat))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) CreateFiatRateFetch(payload models.CreateFiatRateFetch) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/create_fiat_rate_fetch.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var rateDate *string
+	if payload.RateDate != nil {
+		formatted := payload.RateDate.Format(utils.InternalDateFormat)
+		rateDate = &formatted
+	}
+	res, err := d.db.Exec(string(query), payload.Source, payload.Base, rateDate, payload.RateCount, payload.Status, payload.Error)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// ListFiatRateFetches returns the latest fetches first
+func (d *DatabaseAdapter) ListFiatRateFetches(limit int64) ([]models.FiatRateFetch, error) {
+	fetches := []models.FiatRateFetch{}
+
+	query, err := sqlQueries.ReadFile("queries/list_fiat_rate_fetches.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		fetch, err := scanFiatRateFetch(rows)
+		if err != nil {
+			return nil, err
+		}
+		fetches = append(fetches, *fetch)
+	}
+
+	return fetches, nil
+}
+
+// GetLastSucceededFiatRateFetch returns sql.ErrNoRows if no fetch succeeded yet
+func (d *DatabaseAdapter) GetLastSucceededFiatRateFetch() (*models.FiatRateFetch, error) {
+	query, err := sqlQueries.ReadFile("queries/get_last_succeeded_fiat_rate_fetch.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFiatRateFetch(d.db.QueryRow(string(query)))
+}
+
+func scanFiatRateFetch(row rowScanner) (*models.FiatRateFetch, error) {
+	var fetch models.FiatRateFetch
+	var rateDate sql.NullTime
+
+	err := row.Scan(
+		&fetch.ID, &fetch.Source, &fetch.Base, &rateDate, &fetch.RateCount, &fetch.Status, &fetch.Error, &fetch.FetchedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rateDate.Valid {
+		date := types.AsDate(rateDate.Time)
+		fetch.RateDate = &date
+	}
+
+	return &fetch, nil
+}
+
+// ListFiatRateHistory returns the rates of the base valid from the date on, sorted by target and date
+func (d *DatabaseAdapter) ListFiatRateHistory(base string, from time.Time) ([]models.FiatRateHistory, error) {
+	history := []models.FiatRateHistory{}
+
+	query, err := sqlQueries.ReadFile("queries/list_fiat_rate_history.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate := from.Format(utils.InternalDateFormat)
+	rows, err := d.db.Query(string(query), base, fromDate, fromDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.FiatRateHistory
+		var date time.Time
+
+		err := rows.Scan(&entry.Base, &entry.Target, &entry.Rate, &date)
+		if err != nil {
+			return nil, err
+		}
+		entry.Date = types.AsDate(date)
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
+// GetFiatRateAt returns the latest rate known on the date
+func (d *DatabaseAdapter) GetFiatRateAt(base, target string, date time.Time) (*models.FiatRateHistory, error) {
+	var entry models.FiatRateHistory
+	var validDate time.Time
+
+	query, err := sqlQueries.ReadFile("queries/get_fiat_rate_at.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), base, target, date.Format(utils.InternalDateFormat)).Scan(
+		&entry.Base, &entry.Target, &entry.Rate, &entry.Source, &validDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.Date = types.AsDate(validDate)
+
+	return &entry, nil
+}
+
+func (d *DatabaseAdapter) ListOrganisationFiatRates(userID int64) ([]models.OrganisationFiatRate, error) {
+	fiatRates := []models.OrganisationFiatRate{}
+
+	query, err := sqlQueries.ReadFile("queries/list_organisation_fiat_rates.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fiatRate models.OrganisationFiatRate
+
+		err := rows.Scan(&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		fiatRates = append(fiatRates, fiatRate)
+	}
+
+	return fiatRates, nil
+}
+
+func (d *DatabaseAdapter) GetOrganisationFiatRate(userID int64, base, target string) (*models.OrganisationFiatRate, error) {
+	var fiatRate models.OrganisationFiatRate
+
+	query, err := sqlQueries.ReadFile("queries/get_organisation_fiat_rate.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), userID, base, target).Scan(
+		&fiatRate.ID, &fiatRate.Base, &fiatRate.Target, &fiatRate.Rate, &fiatRate.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fiatRate, nil
+}
+
+func (d *DatabaseAdapter) UpsertOrganisationFiatRate(userID int64, base, target string, rate float64) error {
+	query, err := sqlQueries.ReadFile("queries/upsert_organisation_fiat_rate.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), base, target, rate, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) DeleteOrganisationFiatRate(userID int64, base, target string) error {
+	query, err := sqlQueries.ReadFile("queries/delete_organisation_fiat_rate.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), userID, base, target)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -17,7 +17,7 @@ func (d *DatabaseAdapter) ListForecasts(userID int64, limit int64) ([]models.For
 	}
 
 	today := utils.GetTodayAsUTC().Format(utils.InternalDateFormat)
-	rows, err := d.db.Query(string(query), today, today, limit, userID)
+	rows, err := d.db.Query(string(query), today, today, limit, userID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +27,7 @@ func (d *DatabaseAdapter) ListForecasts(userID int64, limit int64) ([]models.For
 		var forecast models.Forecast
 
 		err := rows.Scan(
-			&forecast.Data.Month, &forecast.Data.Revenue, &forecast.Data.Expense, &forecast.Data.Cashflow,
+			&forecast.Data.Month, &forecast.Data.Period, &forecast.Data.EndMonth, &forecast.Data.Revenue, &forecast.Data.Expense, &forecast.Data.Cashflow,
 			&forecast.UpdatedAt,
 		)
 		if err != nil {
@@ -49,7 +49,7 @@ func (d *DatabaseAdapter) ListForecastDetails(userID int64, limit int64) ([]mode
 	}
 
 	today := utils.GetTodayAsUTC().Format(utils.InternalDateFormat)
-	rows, err := d.db.Query(string(query), today, today, limit, userID)
+	rows, err := d.db.Query(string(query), today, today, limit, userID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +89,7 @@ func (d *DatabaseAdapter) UpsertForecast(payload models.CreateForecast, userID i
 	defer stmt.Close()
 
 	res, err := stmt.Exec(
-		payload.Month, payload.Revenue, payload.Expense, payload.Cashflow, userID,
+		payload.Month, payload.Period, payload.EndMonth, payload.Revenue, payload.Expense, payload.Cashflow, userID,
 	)
 	if err != nil {
 		return 0, err
@@ -142,6 +142,67 @@ func (d *DatabaseAdapter) UpsertForecastDetail(payload models.CreateForecastDeta
 	return id, nil
 }
 
+// ArchiveForecasts copies the calculated forecasts of the months after the current one into the history. Months that
+// already started keep the plan they were last archived with.
+func (d *DatabaseAdapter) ArchiveForecasts(userID int64) error {
+	query, err := sqlQueries.ReadFile("queries/archive_forecasts.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	currentMonth := utils.GetTodayAsUTC().Format("2006-01")
+	_, err = stmt.Exec(userID, currentMonth)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListForecastHistory returns the recorded plans between the months from and to (inclusive, YYYY-MM)
+func (d *DatabaseAdapter) ListForecastHistory(userID int64, from string, to string) ([]models.ForecastHistory, error) {
+	history := make([]models.ForecastHistory, 0)
+
+	query, err := sqlQueries.ReadFile("queries/list_forecast_history.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.ForecastHistory
+		var revenueJSON, expenseJSON []byte
+
+		err := rows.Scan(&entry.Month, &entry.Revenue, &entry.Expense, &entry.Cashflow, &revenueJSON, &expenseJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Details.Month = entry.Month
+		if err := json.Unmarshal(revenueJSON, &entry.Details.Revenue); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(expenseJSON, &entry.Details.Expense); err != nil {
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
 func (d *DatabaseAdapter) ListForecastExclusions(userID, relatedID int64, relatedTable string) (map[string]bool, error) {
 	sqlFile := ""
 	switch relatedTable {
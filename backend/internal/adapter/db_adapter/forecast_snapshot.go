@@ -0,0 +1,209 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) ListForecastSnapshots(userID int64) ([]models.ForecastSnapshot, error) {
+	snapshots := []models.ForecastSnapshot{}
+
+	query, err := sqlQueries.ReadFile("queries/list_forecast_snapshots.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var snapshot models.ForecastSnapshot
+
+		err := rows.Scan(
+			&snapshot.ID, &snapshot.Label, &snapshot.Description, &snapshot.OpeningBalance,
+			&snapshot.CreatedAt, &snapshot.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Forecasts = []models.Forecast{}
+		snapshot.Details = []models.ForecastDatabaseDetails{}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// GetForecastSnapshot returns the snapshot along with all of its months and details
+func (d *DatabaseAdapter) GetForecastSnapshot(userID int64, snapshotID int64) (*models.ForecastSnapshot, error) {
+	var snapshot models.ForecastSnapshot
+
+	query, err := sqlQueries.ReadFile("queries/get_forecast_snapshot.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), snapshotID, userID).Scan(
+		&snapshot.ID, &snapshot.Label, &snapshot.Description, &snapshot.OpeningBalance,
+		&snapshot.CreatedAt, &snapshot.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	monthsQuery, err := sqlQueries.ReadFile("queries/list_forecast_snapshot_months.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(monthsQuery), snapshotID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshot.Forecasts = []models.Forecast{}
+	snapshot.Details = []models.ForecastDatabaseDetails{}
+	for rows.Next() {
+		var data models.ForecastData
+		var revenueJSON, expenseJSON []byte
+
+		err := rows.Scan(
+			&data.Month, &data.Period, &data.EndMonth, &data.Revenue, &data.Expense, &data.Cashflow,
+			&data.OpeningBalance, &data.ClosingBalance, &revenueJSON, &expenseJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		details := models.ForecastDatabaseDetails{Month: data.Month}
+		if err := json.Unmarshal(revenueJSON, &details.Revenue); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(expenseJSON, &details.Expense); err != nil {
+			return nil, err
+		}
+
+		createdAt := snapshot.CreatedAt
+		snapshot.Forecasts = append(snapshot.Forecasts, models.Forecast{UpdatedAt: &createdAt, Data: data})
+		snapshot.Details = append(snapshot.Details, details)
+	}
+
+	return &snapshot, nil
+}
+
+// CreateForecastSnapshot stores the snapshot along with all of its months in one transaction
+func (d *DatabaseAdapter) CreateForecastSnapshot(payload models.CreateForecastSnapshot, forecasts []models.Forecast, details []models.ForecastDatabaseDetails, openingBalance int64, userID int64) (snapshotID int64, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	snapshotQuery, err := sqlQueries.ReadFile("queries/create_forecast_snapshot.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.Exec(string(snapshotQuery), payload.Label, payload.Description, openingBalance, userID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	snapshotID, err = res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	monthQuery, err := sqlQueries.ReadFile("queries/create_forecast_snapshot_month.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare(string(monthQuery))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	detailsByMonth := make(map[string]models.ForecastDatabaseDetails, len(details))
+	for _, detail := range details {
+		detailsByMonth[detail.Month] = detail
+	}
+
+	for _, forecast := range forecasts {
+		detail := detailsByMonth[forecast.Data.Month]
+		revenue := detail.Revenue
+		if revenue == nil {
+			revenue = []models.ForecastDetailRevenueExpense{}
+		}
+		expense := detail.Expense
+		if expense == nil {
+			expense = []models.ForecastDetailRevenueExpense{}
+		}
+
+		var revenueJSON, expenseJSON []byte
+		revenueJSON, err = json.Marshal(revenue)
+		if err != nil {
+			return 0, err
+		}
+		expenseJSON, err = json.Marshal(expense)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = stmt.Exec(
+			forecast.Data.Month, forecast.Data.Period, forecast.Data.EndMonth, forecast.Data.Revenue, forecast.Data.Expense, forecast.Data.Cashflow,
+			forecast.Data.OpeningBalance, forecast.Data.ClosingBalance, revenueJSON, expenseJSON,
+			snapshotID,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return snapshotID, nil
+}
+
+func (d *DatabaseAdapter) DeleteForecastSnapshot(userID int64, snapshotID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_forecast_snapshot.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(snapshotID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
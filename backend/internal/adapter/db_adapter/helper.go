@@ -1,6 +1,8 @@
 package db_adapter
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/types"
@@ -133,7 +135,7 @@ func (d *DatabaseAdapter) CalculateCostExecutionDate(
 	return &as
 }
 
-func (d *DatabaseAdapter) CalculateCostAmount(userID int64, cost models.SalaryCost, salary models.Salary, visited map[int64]struct{}) (uint64, error) {
+func (d *DatabaseAdapter) CalculateCostAmount(userID int64, cost models.SalaryCost, salary models.Salary, date time.Time, visited map[int64]struct{}) (uint64, error) {
 	if visited == nil {
 		visited = make(map[int64]struct{})
 	}
@@ -165,16 +167,30 @@ func (d *DatabaseAdapter) CalculateCostAmount(userID int64, cost models.SalaryCo
 				if baseCost.SalaryID != cost.SalaryID {
 					return 0, fmt.Errorf("base salary cost does not belong to the same salary")
 				}
-				amount, err := d.CalculateCostAmount(userID, *baseCost, salary, visited)
+				amount, err := d.CalculateCostAmount(userID, *baseCost, salary, date, visited)
 				if err != nil {
 					return 0, err
 				}
 				baseAmount += amount * models.SalaryCostDistributionMultiplier(baseCost.DistributionType)
 			}
+			baseAmount = cost.LimitBase(baseAmount, utils.CyclesPerYear(salary.Cycle))
 		} else {
-			baseAmount = salary.Amount
+			// Extra payments of the period raise the base, the limits apply to the whole year
+			periodStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+			periodEnd := addCycle(periodStart, cost.Cycle, 1).AddDate(0, 0, -1)
+			if periodEnd.Before(periodStart) {
+				periodEnd = periodStart
+			}
+			yearStart := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			yearlyExtraAmount := salary.ExtraAmountBetween(yearStart, yearStart.AddDate(1, 0, -1))
+			baseAmount = salary.Amount + salary.ExtraAmountBetween(periodStart, periodEnd)
+			if yearlyExtraAmount > 0 {
+				baseAmount = cost.LimitBaseOfYear(baseAmount, salary.Amount*utils.CyclesPerYear(salary.Cycle)+yearlyExtraAmount)
+			} else {
+				baseAmount = cost.LimitBase(baseAmount, utils.CyclesPerYear(salary.Cycle))
+			}
 		}
-		return (baseAmount * cost.Amount) / 100_000, nil
+		return (baseAmount * cost.Percentage(date)) / 100_000, nil
 	default:
 		return 0, nil
 	}
@@ -225,3 +241,61 @@ func lastDayOfMonth(t time.Time) int {
 	year, month := t.Year(), t.Month()
 	return time.Date(year, month+1, 0, 0, 0, 0, 0, t.Location()).Day()
 }
+
+// parseOptionalDate turns an optional date of a payload into a nullable column
+func parseOptionalDate(value *string) (sql.NullTime, error) {
+	if value == nil || *value == "" {
+		return sql.NullTime{Valid: false}, nil
+	}
+	parsed, err := time.Parse(utils.InternalDateFormat, *value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: parsed, Valid: true}, nil
+}
+
+// marshalAgeRates stores no age rates as NULL
+func marshalAgeRates(ageRates []models.SalaryCostAgeRate) (sql.NullString, error) {
+	if len(ageRates) == 0 {
+		return sql.NullString{Valid: false}, nil
+	}
+	encoded, err := json.Marshal(ageRates)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// marshalBonuses stores no bonuses as NULL
+func marshalBonuses(bonuses []models.SalaryBonus) (sql.NullString, error) {
+	if len(bonuses) == 0 {
+		return sql.NullString{Valid: false}, nil
+	}
+	encoded, err := json.Marshal(bonuses)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func unmarshalBonuses(value sql.NullString) ([]models.SalaryBonus, error) {
+	bonuses := make([]models.SalaryBonus, 0)
+	if !value.Valid || value.String == "" {
+		return bonuses, nil
+	}
+	if err := json.Unmarshal([]byte(value.String), &bonuses); err != nil {
+		return nil, err
+	}
+	return bonuses, nil
+}
+
+func unmarshalAgeRates(value sql.NullString) ([]models.SalaryCostAgeRate, error) {
+	ageRates := make([]models.SalaryCostAgeRate, 0)
+	if !value.Valid || value.String == "" {
+		return ageRates, nil
+	}
+	if err := json.Unmarshal([]byte(value.String), &ageRates); err != nil {
+		return nil, err
+	}
+	return ageRates, nil
+}
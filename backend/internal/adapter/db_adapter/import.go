@@ -0,0 +1,288 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) ListImports(userID int64) ([]models.Import, error) {
+	imports := []models.Import{}
+
+	query, err := sqlQueries.ReadFile("queries/list_imports.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fileImport models.Import
+
+		err := rows.Scan(
+			&fileImport.ID, &fileImport.Entity, &fileImport.FileName, &fileImport.Status,
+			&fileImport.RowCount, &fileImport.ErrorCount, &fileImport.CreatedAt, &fileImport.CommittedAt,
+			&fileImport.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, fileImport)
+	}
+
+	return imports, nil
+}
+
+// GetImport returns the import along with its parsed rows
+func (d *DatabaseAdapter) GetImport(userID int64, importID int64) (*models.Import, error) {
+	var fileImport models.Import
+	var rowsJSON []byte
+
+	query, err := sqlQueries.ReadFile("queries/get_import.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), importID, userID).Scan(
+		&fileImport.ID, &fileImport.Entity, &fileImport.FileName, &fileImport.Status,
+		&fileImport.RowCount, &fileImport.ErrorCount, &fileImport.CreatedAt, &fileImport.CommittedAt,
+		&fileImport.CreatedBy, &rowsJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fileImport.Entity {
+	case models.ImportEntityTransactions:
+		fileImport.Transactions = []models.ImportTransactionRow{}
+		err = json.Unmarshal(rowsJSON, &fileImport.Transactions)
+	case models.ImportEntityEmployees:
+		fileImport.Employees = []models.ImportEmployeeRow{}
+		err = json.Unmarshal(rowsJSON, &fileImport.Employees)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileImport, nil
+}
+
+func (d *DatabaseAdapter) CreateImport(payload models.CreateImport, userID int64) (int64, error) {
+	var rows any = payload.Transactions
+	if payload.Entity == models.ImportEntityEmployees {
+		rows = payload.Employees
+	}
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	query, err := sqlQueries.ReadFile("queries/create_import.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		payload.Entity, payload.FileName, rowsJSON, payload.RowCount, payload.ErrorCount, userID, userID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CommitTransactionImport creates all transactions and marks the import as committed in one transaction
+func (d *DatabaseAdapter) CommitTransactionImport(userID int64, importID int64, payloads []models.CreateTransaction) (transactionIDs []int64, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = commitImportTx(tx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := sqlQueries.ReadFile("queries/create_transaction.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare(string(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	transactionIDs = make([]int64, 0, len(payloads))
+	for _, payload := range payloads {
+		var res sql.Result
+		res, err = stmt.Exec(
+			payload.Name, payload.Link, payload.Amount, payload.Cycle, payload.Type, payload.StartDate, payload.EndDate,
+			payload.Category, payload.Currency, payload.Employee, userID, payload.Vat, payload.VatIncluded,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var transactionID int64
+		transactionID, err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+
+	return transactionIDs, nil
+}
+
+// CommitEmployeeImport creates the missing employees with all salaries and marks the import as committed in one transaction
+func (d *DatabaseAdapter) CommitEmployeeImport(userID int64, importID int64, employees []models.ImportEmployee) (results []models.ImportEmployeeResult, err error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = commitImportTx(tx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := sqlQueries.ReadFile("queries/create_employee.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare(string(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	results = make([]models.ImportEmployeeResult, 0, len(employees))
+	for _, employee := range employees {
+		result := models.ImportEmployeeResult{SalaryIDs: []int64{}, AdjustedSalaryIDs: []int64{}}
+		if employee.EmployeeID != nil {
+			result.EmployeeID = *employee.EmployeeID
+		} else {
+			var res sql.Result
+			res, err = stmt.Exec(employee.Name, nil, userID)
+			if err != nil {
+				return nil, err
+			}
+			result.EmployeeID, err = res.LastInsertId()
+			if err != nil {
+				return nil, err
+			}
+			result.Created = true
+		}
+
+		for _, salary := range employee.Salaries {
+			var salaryID, previousSalaryID, nextSalaryID int64
+			salaryID, previousSalaryID, nextSalaryID, err = d.createSalaryTx(tx, salary, userID, result.EmployeeID)
+			if err != nil {
+				return nil, err
+			}
+			result.SalaryIDs = append(result.SalaryIDs, salaryID)
+			for _, adjustedSalaryID := range []int64{previousSalaryID, nextSalaryID} {
+				if adjustedSalaryID != 0 {
+					result.AdjustedSalaryIDs = append(result.AdjustedSalaryIDs, adjustedSalaryID)
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// commitImportTx marks a previewed import as committed, an import can only be committed once
+func commitImportTx(tx *sql.Tx, userID int64, importID int64) error {
+	query, err := sqlQueries.ReadFile("queries/commit_import.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(string(query), importID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) DeleteImport(userID int64, importID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_import.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(importID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package db_adapter
 
 import (
+	"database/sql"
 	"liquiswiss/pkg/models"
 )
 
@@ -27,6 +28,8 @@ func (d *DatabaseAdapter) ListMembers(organisationID int64) ([]models.Organisati
 			&member.Email,
 			&member.Role,
 			&member.IsDefault,
+			&member.PayrollClearance,
+			&member.LockedUntil,
 		)
 		if err != nil {
 			return nil, err
@@ -52,6 +55,8 @@ func (d *DatabaseAdapter) GetMember(organisationID int64, userID int64) (*models
 		&member.Email,
 		&member.Role,
 		&member.IsDefault,
+		&member.PayrollClearance,
+		&member.LockedUntil,
 	)
 	if err != nil {
 		return nil, err
@@ -80,6 +85,26 @@ func (d *DatabaseAdapter) UpdateMemberRole(organisationID int64, userID int64, r
 	return nil
 }
 
+func (d *DatabaseAdapter) UpdateMemberPayrollClearance(organisationID int64, userID int64, payrollClearance bool) error {
+	query, err := sqlQueries.ReadFile("queries/update_member_payroll_clearance.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(payrollClearance, organisationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (d *DatabaseAdapter) DeleteMember(organisationID int64, userID int64) error {
 	query, err := sqlQueries.ReadFile("queries/delete_member.sql")
 	if err != nil {
@@ -181,3 +206,97 @@ func (d *DatabaseAdapter) DeleteMemberPermissions(userID int64, organisationID i
 
 	return nil
 }
+
+func (d *DatabaseAdapter) ListMemberPermissions(userID int64, organisationID int64) ([]models.MemberPermission, error) {
+	query, err := sqlQueries.ReadFile("queries/list_member_permissions.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return d.queryMemberPermissions(string(query), userID, organisationID)
+}
+
+// ListCurrentMemberPermissions lists the global and entity permissions of the user in their current organisation
+func (d *DatabaseAdapter) ListCurrentMemberPermissions(userID int64) ([]models.MemberPermission, error) {
+	query, err := sqlQueries.ReadFile("queries/list_current_member_permissions.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return d.queryMemberPermissions(string(query), userID, userID)
+}
+
+func (d *DatabaseAdapter) queryMemberPermissions(query string, args ...any) ([]models.MemberPermission, error) {
+	permissions := []models.MemberPermission{}
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var permission models.MemberPermission
+
+		err := rows.Scan(
+			&permission.ID,
+			&permission.UserID,
+			&permission.OrganisationID,
+			&permission.EntityType,
+			&permission.CanView,
+			&permission.CanEdit,
+			&permission.CanDelete,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (d *DatabaseAdapter) UpsertMemberEntityPermission(userID int64, organisationID int64, entityType string, canView bool, canEdit bool, canDelete bool) error {
+	query, err := sqlQueries.ReadFile("queries/upsert_member_entity_permission.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(userID, organisationID, entityType, canView, canEdit, canDelete)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) DeleteMemberEntityPermission(userID int64, organisationID int64, entityType string) error {
+	query, err := sqlQueries.ReadFile("queries/delete_member_entity_permission.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), userID, organisationID, entityType)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -33,6 +33,14 @@ func (d *DatabaseAdapter) ListOrganisations(userID int64, page int64, limit int6
 			&organisation.MemberCount,
 			&organisation.Role,
 			&organisation.IsDefault,
+			&organisation.ForecastYears,
+			&organisation.ForecastMonthlyYears,
+			&organisation.ForecastBucket,
+			&organisation.PayrollConfidential,
+			&organisation.PayrollClearance,
+			&organisation.RequireTwoFactor,
+			&organisation.SSODomain,
+			&organisation.SSODefaultRole,
 			&totalCount,
 		)
 		if err != nil {
@@ -62,6 +70,14 @@ func (d *DatabaseAdapter) GetOrganisation(userID int64, organisationID int64) (*
 		&organisation.Currency.LocaleCode,
 		&organisation.MemberCount,
 		&organisation.Role,
+		&organisation.ForecastYears,
+		&organisation.ForecastMonthlyYears,
+		&organisation.ForecastBucket,
+		&organisation.PayrollConfidential,
+		&organisation.PayrollClearance,
+		&organisation.RequireTwoFactor,
+		&organisation.SSODomain,
+		&organisation.SSODefaultRole,
 	)
 	if err != nil {
 		return nil, err
@@ -85,6 +101,26 @@ func (d *DatabaseAdapter) GetCurrentUserRole(userID int64) (string, error) {
 	return role, nil
 }
 
+func (d *DatabaseAdapter) GetCurrentUserPayrollAccess(userID int64) (*models.PayrollAccess, error) {
+	var access models.PayrollAccess
+
+	query, err := sqlQueries.ReadFile("queries/get_current_user_payroll_access.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), userID, userID).Scan(
+		&access.Role,
+		&access.Confidential,
+		&access.Clearance,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
+
 func (d *DatabaseAdapter) CreateOrganisation(name string) (int64, error) {
 	query, err := sqlQueries.ReadFile("queries/create_organisation.sql")
 	if err != nil {
@@ -126,6 +162,34 @@ func (d *DatabaseAdapter) UpdateOrganisation(payload models.UpdateOrganisation,
 		queryBuild = append(queryBuild, "main_currency_id = ?")
 		args = append(args, *payload.CurrencyID)
 	}
+	if payload.ForecastYears != nil {
+		queryBuild = append(queryBuild, "forecast_years = ?")
+		args = append(args, *payload.ForecastYears)
+	}
+	if payload.ForecastMonthlyYears != nil {
+		queryBuild = append(queryBuild, "forecast_monthly_years = ?")
+		args = append(args, *payload.ForecastMonthlyYears)
+	}
+	if payload.ForecastBucket != nil {
+		queryBuild = append(queryBuild, "forecast_bucket = ?")
+		args = append(args, *payload.ForecastBucket)
+	}
+	if payload.PayrollConfidential != nil {
+		queryBuild = append(queryBuild, "payroll_confidential = ?")
+		args = append(args, *payload.PayrollConfidential)
+	}
+	if payload.RequireTwoFactor != nil {
+		queryBuild = append(queryBuild, "require_two_factor = ?")
+		args = append(args, *payload.RequireTwoFactor)
+	}
+	if payload.SSODomain != nil {
+		queryBuild = append(queryBuild, "sso_domain = NULLIF(?, '')")
+		args = append(args, strings.ToLower(*payload.SSODomain))
+	}
+	if payload.SSODefaultRole != nil {
+		queryBuild = append(queryBuild, "sso_default_role = ?")
+		args = append(args, *payload.SSODefaultRole)
+	}
 
 	// Add WHERE clause
 	query += strings.Join(queryBuild, ", ")
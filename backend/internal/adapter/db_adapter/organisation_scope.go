@@ -0,0 +1,69 @@
+package db_adapter
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// organisationConn runs the queries of a scoped adapter on the connection that carries the scope
+type organisationConn struct {
+	conn *sql.Conn
+}
+
+func (c organisationConn) Begin() (*sql.Tx, error) {
+	return c.conn.BeginTx(context.Background(), nil)
+}
+
+func (c organisationConn) Exec(query string, args ...any) (sql.Result, error) {
+	return c.conn.ExecContext(context.Background(), query, args...)
+}
+
+func (c organisationConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.conn.PrepareContext(context.Background(), query)
+}
+
+func (c organisationConn) Query(query string, args ...any) (*sql.Rows, error) {
+	return c.conn.QueryContext(context.Background(), query, args...)
+}
+
+func (c organisationConn) QueryRow(query string, args ...any) *sql.Row {
+	return c.conn.QueryRowContext(context.Background(), query, args...)
+}
+
+// ScopeToOrganisation returns an adapter whose queries act on the given organisation instead of the
+// current organisation of the user, get_current_user_organisation_id reads the scope from the session.
+// The adapter holds a connection of the pool until release is called.
+func (d *DatabaseAdapter) ScopeToOrganisation(organisationID int64) (IDatabaseAdapter, func(), error) {
+	pool, ok := d.db.(*sql.DB)
+	if !ok {
+		return nil, nil, errors.New("adapter is already scoped to an organisation")
+	}
+
+	query, err := sqlQueries.ReadFile("queries/set_scoped_organisation.sql")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := context.Background()
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = conn.ExecContext(ctx, string(query), organisationID)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	release := func() {
+		_, err := conn.ExecContext(ctx, string(query), nil)
+		if err != nil {
+			// A connection that keeps the scope must never be handed out again by the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+	return &DatabaseAdapter{db: organisationConn{conn: conn}}, release, nil
+}
@@ -0,0 +1,282 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) ListPayrollTemplates(userID int64) ([]models.PayrollTemplate, error) {
+	templates := make([]models.PayrollTemplate, 0)
+
+	query, err := sqlQueries.ReadFile("queries/list_payroll_templates.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var template models.PayrollTemplate
+		err := rows.Scan(
+			&template.ID,
+			&template.Name,
+			&template.Year,
+			&template.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		items, err := d.ListPayrollTemplateItems(templates[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		templates[i].Items = items
+	}
+
+	return templates, nil
+}
+
+func (d *DatabaseAdapter) GetPayrollTemplate(userID int64, templateID int64) (*models.PayrollTemplate, error) {
+	var template models.PayrollTemplate
+
+	query, err := sqlQueries.ReadFile("queries/get_payroll_template.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), templateID, userID).Scan(
+		&template.ID,
+		&template.Name,
+		&template.Year,
+		&template.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Items, err = d.ListPayrollTemplateItems(template.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (d *DatabaseAdapter) ListPayrollTemplateItems(templateID int64) ([]models.PayrollTemplateItem, error) {
+	items := make([]models.PayrollTemplateItem, 0)
+
+	query, err := sqlQueries.ReadFile("queries/list_payroll_template_items.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.PayrollTemplateItem
+		var ageRates sql.NullString
+
+		err := rows.Scan(
+			&item.ID,
+			&item.StatutoryKey,
+			&item.Label,
+			&item.Cycle,
+			&item.AmountType,
+			&item.Amount,
+			&item.DistributionType,
+			&item.RelativeOffset,
+			&item.BaseThreshold,
+			&item.BaseCeiling,
+			&item.BaseDeduction,
+			&item.BaseMinimum,
+			&ageRates,
+			&item.SortOrder,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.AgeRates, err = unmarshalAgeRates(ageRates)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (d *DatabaseAdapter) CreatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64) (templateID int64, err error) {
+	query, err := sqlQueries.ReadFile("queries/create_payroll_template.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	res, err := tx.Exec(string(query), payload.Name, payload.Year, userID)
+	if err != nil {
+		return 0, err
+	}
+	templateID, err = res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if templateID == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	err = insertPayrollTemplateItemsTx(tx, payload.Items, userID, templateID)
+	return templateID, err
+}
+
+// UpdatePayrollTemplate replaces the name, year and all items of the template
+func (d *DatabaseAdapter) UpdatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64, templateID int64) (err error) {
+	updateQuery, err := sqlQueries.ReadFile("queries/update_payroll_template.sql")
+	if err != nil {
+		return err
+	}
+	deleteQuery, err := sqlQueries.ReadFile("queries/delete_payroll_template_items.sql")
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.Exec(string(updateQuery), payload.Name, payload.Year, templateID, userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(string(deleteQuery), templateID, userID); err != nil {
+		return err
+	}
+
+	err = insertPayrollTemplateItemsTx(tx, payload.Items, userID, templateID)
+	return err
+}
+
+func (d *DatabaseAdapter) DeletePayrollTemplate(userID int64, templateID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_payroll_template.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(templateID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// insertPayrollTemplateItemsTx keeps the order of the payload as the sort order
+func insertPayrollTemplateItemsTx(tx *sql.Tx, items []models.CreatePayrollTemplateItem, userID int64, templateID int64) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	query, err := sqlQueries.ReadFile("queries/insert_payroll_template_item.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, item := range items {
+		ageRates, err := marshalAgeRates(item.AgeRates)
+		if err != nil {
+			return err
+		}
+
+		res, err := stmt.Exec(
+			item.StatutoryKey,
+			item.Label,
+			item.Cycle,
+			item.AmountType,
+			item.Amount,
+			item.DistributionType,
+			item.RelativeOffset,
+			item.BaseThreshold,
+			item.BaseCeiling,
+			item.BaseDeduction,
+			item.BaseMinimum,
+			ageRates,
+			i,
+			templateID,
+			userID,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+INSERT INTO forecast_history (month, revenue, expense, cashflow, revenue_details, expense_details, organisation_id)
+SELECT
+    f.month,
+    f.revenue,
+    f.expense,
+    f.cashflow,
+    COALESCE(fd.revenue, JSON_ARRAY()),
+    COALESCE(fd.expense, JSON_ARRAY()),
+    f.organisation_id
+FROM
+    forecasts f
+    LEFT JOIN forecast_details fd ON fd.forecast_id = f.id
+WHERE
+    f.organisation_id = get_current_user_organisation_id(?)
+    -- Only months that did not start yet: from their first day on the forecast skips the past
+    -- occurrences, so the last calculation before the month began is its complete plan
+    AND f.month > ?
+    -- Aggregated quarters and years cannot be compared with the actuals of a month
+    AND f.period = 'month'
+ON DUPLICATE KEY UPDATE
+    revenue = VALUES(revenue),
+    expense = VALUES(expense),
+    cashflow = VALUES(cashflow),
+    revenue_details = VALUES(revenue_details),
+    expense_details = VALUES(expense_details)
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE auth_attempts
+SET blocked_until = NOW() + INTERVAL ? SECOND,
+    locked_at = IF(?, NOW(), locked_at)
+WHERE scope = ? AND identifier = ?
\ No newline at end of file
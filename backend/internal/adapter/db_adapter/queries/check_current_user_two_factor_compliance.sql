@@ -0,0 +1,4 @@
+SELECT NOT o.require_two_factor OR u.totp_enabled
+FROM users u
+INNER JOIN organisations o ON o.id = get_current_user_organisation_id(u.id)
+WHERE u.id = ?
\ No newline at end of file
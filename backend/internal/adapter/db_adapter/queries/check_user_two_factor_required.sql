@@ -0,0 +1,6 @@
+SELECT EXISTS(
+    SELECT 1
+    FROM users_2_organisations u2o
+    INNER JOIN organisations o ON o.id = u2o.organisation_id
+    WHERE u2o.user_id = ? AND o.require_two_factor
+)
\ No newline at end of file
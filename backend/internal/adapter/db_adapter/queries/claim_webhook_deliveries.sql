@@ -0,0 +1,10 @@
+UPDATE webhook_deliveries
+SET claim_token = ?, locked_until = NOW() + INTERVAL ? SECOND
+WHERE
+    status = 'pending'
+    AND next_attempt_at <= NOW()
+    AND (locked_until IS NULL OR locked_until < NOW())
+ORDER BY
+    next_attempt_at,
+    id
+LIMIT ?
\ No newline at end of file
@@ -0,0 +1,8 @@
+UPDATE imports
+SET
+    status = 'committed',
+    committed_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+    AND organisation_id = get_current_user_organisation_id(?)
+    AND status = 'preview'
\ No newline at end of file
@@ -0,0 +1,12 @@
+UPDATE webhook_deliveries
+SET
+    status = ?,
+    attempts = attempts + 1,
+    last_attempt_at = NOW(),
+    response_status = ?,
+    last_error = ?,
+    next_attempt_at = NOW() + INTERVAL ? SECOND,
+    locked_until = NULL,
+    claim_token = NULL
+WHERE
+    id = ?
\ No newline at end of file
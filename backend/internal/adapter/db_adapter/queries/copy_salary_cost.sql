@@ -5,6 +5,11 @@ INSERT INTO salary_costs (
     distribution_type,
     relative_offset,
     target_date,
+    base_threshold,
+    base_ceiling,
+    base_deduction,
+    base_minimum,
+    age_rates,
     label_id,
     salary_id
 )
@@ -15,6 +20,11 @@ SELECT
     hc.distribution_type,
     hc.relative_offset,
     hc.target_date,
+    hc.base_threshold,
+    hc.base_ceiling,
+    hc.base_deduction,
+    hc.base_minimum,
+    hc.age_rates,
     hc.label_id,
     ?
 FROM salary_costs as hc
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM user_recovery_codes
+WHERE user_id = ? AND used_at IS NULL
\ No newline at end of file
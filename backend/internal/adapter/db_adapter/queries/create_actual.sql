@@ -0,0 +1,7 @@
+INSERT INTO actuals (
+    booking_date, amount, name, reference, status, planned_date, planned_amount,
+    transaction_id, bank_account_id, bank_statement_import_id, currency_id, organisation_id
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, get_current_user_organisation_id(?))
+-- Bookings already imported from an overlapping statement are skipped
+ON DUPLICATE KEY UPDATE id = id
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO api_tokens (name, token_hash, token_prefix, scope, expires_at, user_id, organisation_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
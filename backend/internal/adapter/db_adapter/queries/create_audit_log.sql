@@ -0,0 +1,2 @@
+INSERT INTO audit_logs (source, oauth_client_id, entity, entity_id, parent_id, action, changes, user_id, organisation_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
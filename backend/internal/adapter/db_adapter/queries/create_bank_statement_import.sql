@@ -0,0 +1,6 @@
+INSERT INTO bank_statement_imports (file_name, format, opening_balance, closing_balance, closing_date, bank_account_id, organisation_id)
+SELECT ?, ?, ?, ?, ?, ba.id, ba.organisation_id
+FROM bank_accounts ba
+WHERE
+    ba.id = ?
+    AND ba.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
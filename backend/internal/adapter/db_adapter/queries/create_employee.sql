@@ -1,2 +1,2 @@
-INSERT INTO employees (name, organisation_id)
-VALUES (?, get_current_user_organisation_id(?))
\ No newline at end of file
+INSERT INTO employees (name, birth_date, organisation_id)
+VALUES (?, ?, get_current_user_organisation_id(?))
\ No newline at end of file
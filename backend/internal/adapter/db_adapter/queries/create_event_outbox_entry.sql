@@ -0,0 +1,2 @@
+INSERT INTO event_outbox (payload)
+VALUES (?)
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO fiat_rate_fetches (source, base, rate_date, rate_count, status, error)
+VALUES (?, ?, ?, ?, ?, ?)
\ No newline at end of file
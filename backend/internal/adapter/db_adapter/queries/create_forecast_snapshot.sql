@@ -0,0 +1,2 @@
+INSERT INTO forecast_snapshots (label, description, opening_balance, created_by, organisation_id)
+VALUES (?, ?, ?, ?, get_current_user_organisation_id(?))
\ No newline at end of file
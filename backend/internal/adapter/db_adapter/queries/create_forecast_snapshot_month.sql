@@ -0,0 +1,2 @@
+INSERT INTO forecast_snapshot_months (month, period, end_month, revenue, expense, cashflow, opening_balance, closing_balance, revenue_details, expense_details, snapshot_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
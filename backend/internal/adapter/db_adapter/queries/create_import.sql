@@ -0,0 +1,2 @@
+INSERT INTO imports (entity, file_name, `rows`, row_count, error_count, created_by, organisation_id)
+VALUES (?, ?, ?, ?, ?, ?, get_current_user_organisation_id(?))
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO payroll_templates (name, year, organisation_id)
+VALUES (?, ?, get_current_user_organisation_id(?))
\ No newline at end of file
@@ -4,13 +4,16 @@ INSERT INTO salaries
      hours_per_month,
      amount,
      cycle,
+     extra_salaries,
+     extra_salary_payout,
+     bonuses,
      currency_id,
      vacation_days_per_year,
      from_date,
      to_date,
      is_termination
     )
-SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?
+SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 FROM employees e
 WHERE e.id = ?
   AND e.organisation_id = get_current_user_organisation_id(?)
@@ -6,10 +6,15 @@ INSERT INTO salary_costs
      distribution_type,
      relative_offset,
      target_date,
+     base_threshold,
+     base_ceiling,
+     base_deduction,
+     base_minimum,
+     age_rates,
      label_id,
      salary_id
     )
-SELECT ?, ?, ?, ?, ?, ?, ?, ?
+SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 FROM salaries AS h
 JOIN employees AS e ON e.id = h.employee_id
 WHERE h.id = ?
@@ -0,0 +1,2 @@
+INSERT INTO scenarios (name, description, organisation_id)
+VALUES (?, ?, get_current_user_organisation_id(?))
\ No newline at end of file
@@ -0,0 +1,9 @@
+INSERT INTO scenario_items (
+    operation, related_table, related_id, name, amount, cycle, type, start_date, end_date,
+    category_id, currency_id, scenario_id
+)
+SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, s.id
+FROM scenarios s
+WHERE
+    s.id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
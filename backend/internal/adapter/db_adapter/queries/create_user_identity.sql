@@ -0,0 +1,2 @@
+INSERT INTO user_identities (provider, subject, email, last_login_at, user_id)
+VALUES (?, ?, ?, NOW(), ?)
\ No newline at end of file
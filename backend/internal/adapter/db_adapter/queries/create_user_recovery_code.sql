@@ -0,0 +1 @@
+INSERT INTO user_recovery_codes (user_id, code_hash) VALUES (?, ?)
\ No newline at end of file
@@ -0,0 +1,6 @@
+DELETE b FROM bank_account_balances b
+    INNER JOIN bank_accounts ba ON b.bank_account_id = ba.id
+WHERE
+    b.id = ?
+    AND b.bank_account_id = ?
+    AND ba.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
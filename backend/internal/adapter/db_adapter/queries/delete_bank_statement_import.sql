@@ -0,0 +1,5 @@
+DELETE FROM bank_statement_imports
+WHERE
+    id = ?
+    AND bank_account_id = ?
+    AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM event_outbox
+WHERE
+    created_at < ?
\ No newline at end of file
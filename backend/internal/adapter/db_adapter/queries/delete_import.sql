@@ -0,0 +1,4 @@
+DELETE FROM imports
+WHERE
+    id = ?
+    AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
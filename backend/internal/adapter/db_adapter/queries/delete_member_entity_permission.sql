@@ -0,0 +1 @@
+DELETE FROM member_permissions WHERE user_id = ? AND organisation_id = ? AND entity_type = ?
\ No newline at end of file
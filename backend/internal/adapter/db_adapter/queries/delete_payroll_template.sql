@@ -0,0 +1,3 @@
+DELETE FROM payroll_templates
+WHERE id = ?
+  AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,5 @@
+DELETE pti
+FROM payroll_template_items pti
+JOIN payroll_templates pt ON pt.id = pti.template_id
+WHERE pt.id = ?
+  AND pt.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,6 @@
+DELETE i FROM scenario_items i
+    INNER JOIN scenarios s ON i.scenario_id = s.id
+WHERE
+    i.id = ?
+    AND i.scenario_id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM refresh_tokens
+WHERE id = ? AND user_id = ? AND token_id <> ?
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM user_recovery_codes WHERE user_id = ?
\ No newline at end of file
@@ -0,0 +1,4 @@
+DELETE FROM webhook_deliveries
+WHERE
+    status <> 'pending'
+    AND created_at < ?
\ No newline at end of file
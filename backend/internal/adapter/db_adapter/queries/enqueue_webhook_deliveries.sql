@@ -0,0 +1,8 @@
+INSERT INTO webhook_deliveries (entity, action, payload, next_attempt_at, webhook_id)
+SELECT ?, ?, ?, NOW(), w.id
+FROM
+    webhooks w
+WHERE
+    w.organisation_id = ?
+    AND w.enabled
+    AND (JSON_LENGTH(w.entities) = 0 OR JSON_CONTAINS(w.entities, JSON_QUOTE(?)))
\ No newline at end of file
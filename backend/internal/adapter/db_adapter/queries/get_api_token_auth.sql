@@ -0,0 +1,7 @@
+SELECT
+    id,
+    user_id,
+    organisation_id,
+    scope
+FROM api_tokens
+WHERE token_hash = ? AND expires_at > NOW()
\ No newline at end of file
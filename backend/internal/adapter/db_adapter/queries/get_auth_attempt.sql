@@ -0,0 +1,3 @@
+SELECT failures, locked_at IS NOT NULL
+FROM auth_attempts
+WHERE scope = ? AND identifier = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT COALESCE(MAX(TIMESTAMPDIFF(SECOND, NOW(), blocked_until)), 0)
+FROM auth_attempts
+WHERE scope = ? AND identifier IN (?, ?) AND blocked_until > NOW()
\ No newline at end of file
@@ -0,0 +1,18 @@
+SELECT
+    i.id,
+    i.file_name,
+    i.format,
+    i.opening_balance,
+    i.closing_balance,
+    i.closing_date,
+    i.created_at,
+    i.bank_account_id,
+    (SELECT COUNT(*) FROM actuals a WHERE a.bank_statement_import_id = i.id AND a.status = 'matched') AS matched,
+    (SELECT COUNT(*) FROM actuals a WHERE a.bank_statement_import_id = i.id AND a.status = 'deviation') AS deviations,
+    (SELECT COUNT(*) FROM actuals a WHERE a.bank_statement_import_id = i.id AND a.status = 'unmatched') AS unmatched
+FROM
+    bank_statement_imports i
+WHERE
+    i.id = ?
+    AND i.bank_account_id = ?
+    AND i.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
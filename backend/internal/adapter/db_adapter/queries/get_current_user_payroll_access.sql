@@ -0,0 +1,8 @@
+SELECT
+    u2o.role,
+    o.payroll_confidential,
+    u2o.payroll_clearance
+FROM users_2_organisations u2o
+INNER JOIN organisations o ON o.id = u2o.organisation_id
+WHERE u2o.user_id = ?
+  AND u2o.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -1,6 +1,7 @@
 SELECT
     e.id,
     e.name,
+    e.birth_date,
     rs.hours_per_month,
     rs.amount + rs.employer_costs AS salary,
     rs.cycle,
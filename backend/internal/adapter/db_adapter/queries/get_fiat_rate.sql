@@ -1,3 +1,3 @@
-SELECT id, base, target, rate, updated_at
+SELECT id, base, target, rate, source, rate_date, updated_at
 FROM fiat_rates
 WHERE base = ? AND target = ?;
\ No newline at end of file
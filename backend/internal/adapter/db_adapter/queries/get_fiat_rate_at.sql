@@ -0,0 +1,5 @@
+SELECT base, target, rate, source, date
+FROM fiat_rate_history
+WHERE base = ? AND target = ? AND date <= ?
+ORDER BY date DESC
+LIMIT 1
\ No newline at end of file
@@ -0,0 +1,13 @@
+SELECT
+    s.id,
+    s.label,
+    s.description,
+    s.opening_balance,
+    s.created_at,
+    u.name
+FROM
+    forecast_snapshots s
+    LEFT JOIN users u ON u.id = s.created_by
+WHERE
+    s.id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,17 @@
+SELECT
+    i.id,
+    i.entity,
+    i.file_name,
+    i.status,
+    i.row_count,
+    i.error_count,
+    i.created_at,
+    i.committed_at,
+    u.name,
+    i.`rows`
+FROM
+    imports i
+    LEFT JOIN users u ON u.id = i.created_by
+WHERE
+    i.id = ?
+    AND i.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
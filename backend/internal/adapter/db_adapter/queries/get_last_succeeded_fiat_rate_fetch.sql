@@ -0,0 +1,5 @@
+SELECT id, source, base, rate_date, rate_count, status, error, fetched_at
+FROM fiat_rate_fetches
+WHERE status = 'succeeded'
+ORDER BY fetched_at DESC, id DESC
+LIMIT 1
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT
+    COALESCE(MAX(e.id), 0)
+FROM
+    event_outbox e
\ No newline at end of file
@@ -6,7 +6,15 @@ SELECT
     c.description,
     c.locale_code,
     member_counts.member_count AS member_count,
-    u2o.role
+    u2o.role,
+    o.forecast_years,
+    o.forecast_monthly_years,
+    o.forecast_bucket,
+    o.payroll_confidential,
+    u2o.payroll_clearance,
+    o.require_two_factor,
+    o.sso_domain,
+    o.sso_default_role
 FROM users_2_organisations AS u2o
 INNER JOIN organisations o ON o.id = u2o.organisation_id
 LEFT JOIN currencies c ON c.id = get_current_user_organisation_currency_id(o.id)
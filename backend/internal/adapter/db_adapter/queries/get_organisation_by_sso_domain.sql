@@ -0,0 +1,3 @@
+SELECT id, sso_default_role
+FROM organisations
+WHERE sso_domain = ?
\ No newline at end of file
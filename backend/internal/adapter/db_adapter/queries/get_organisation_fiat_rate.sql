@@ -0,0 +1,3 @@
+SELECT id, base, target, rate, updated_at
+FROM organisation_fiat_rates
+WHERE organisation_id = get_current_user_organisation_id(?) AND base = ? AND target = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT o.forecast_years
+FROM organisations o
+WHERE o.id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,8 @@
+SELECT
+    pt.id,
+    pt.name,
+    pt.year,
+    pt.created_at
+FROM payroll_templates pt
+WHERE pt.id = ?
+  AND pt.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
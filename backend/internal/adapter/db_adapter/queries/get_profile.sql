@@ -2,7 +2,7 @@ SELECT
     u.id,
     u.name,
     u.email,
-    u.current_organisation_id,
+    COALESCE(get_current_user_organisation_id(u.id), u.current_organisation_id) AS current_organisation_id,
     c.id AS currency_id,
     c.code AS currency_code,
     c.description AS currency_description,
@@ -4,6 +4,9 @@ SELECT
     s.hours_per_month,
     s.amount,
     s.cycle,
+    s.extra_salaries,
+    s.extra_salary_payout,
+    s.bonuses,
     c.id AS currency_id,
     c.locale_code,
     c.description,
@@ -13,11 +16,44 @@ SELECT
     s.to_date,
     s.is_termination,
     s.is_disabled,
-    CURDATE() AS db_date
+    CURDATE() AS db_date,
+    COALESCE(
+        (
+            SELECT MIN(p.from_date)
+            FROM salaries p
+            WHERE p.employee_id = s.employee_id
+              AND p.is_disabled = 0
+              AND p.is_termination = 0
+              AND p.from_date <= s.from_date
+              AND p.from_date > COALESCE(
+                  (
+                      SELECT MAX(t.from_date)
+                      FROM salaries t
+                      WHERE t.employee_id = s.employee_id
+                        AND t.is_disabled = 0
+                        AND t.is_termination = 1
+                        AND t.from_date <= s.from_date
+                  ),
+                  '1000-01-01'
+              )
+        ),
+        s.from_date
+    ) AS employment_from_date,
+    COALESCE(DATE_SUB(n.from_date, INTERVAL 1 DAY), s.to_date) AS period_to_date,
+    n.id IS NULL OR n.is_termination = 1 AS ends_employment
 FROM salaries s
 JOIN employees e ON e.id = s.employee_id
 JOIN currencies c ON s.currency_id = c.id
 LEFT JOIN salary_costs sc ON sc.salary_id = s.id
+LEFT JOIN salaries n ON n.id = (
+    SELECT nx.id
+    FROM salaries nx
+    WHERE nx.employee_id = s.employee_id
+      AND nx.is_disabled = 0
+      AND nx.from_date > s.from_date
+    ORDER BY nx.from_date
+    LIMIT 1
+)
 WHERE s.id = ?
   AND e.organisation_id = get_current_user_organisation_id(?)
 LIMIT 1;
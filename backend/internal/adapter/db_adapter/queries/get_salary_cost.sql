@@ -8,11 +8,17 @@ SELECT
     sc.distribution_type,
     sc.relative_offset,
     sc.target_date,
+    sc.base_threshold,
+    sc.base_ceiling,
+    sc.base_deduction,
+    sc.base_minimum,
+    sc.age_rates,
     sc.salary_id,
     s.cycle     AS salary_cycle,
     s.amount,
     s.from_date AS salary_from_date,
     s.to_date AS salary_to_date,
+    e.birth_date AS employee_birth_date,
     CURDATE() AS db_date
 FROM salary_costs sc
 JOIN salaries s ON s.id = sc.salary_id
@@ -0,0 +1,10 @@
+SELECT
+    s.id,
+    s.name,
+    s.description,
+    s.created_at
+FROM
+    scenarios s
+WHERE
+    s.id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
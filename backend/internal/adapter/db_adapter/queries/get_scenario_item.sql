@@ -0,0 +1,27 @@
+SELECT
+    i.id,
+    i.operation,
+    i.related_table,
+    i.related_id,
+    i.name,
+    i.amount,
+    i.cycle,
+    i.type,
+    i.start_date,
+    i.end_date,
+    c.id,
+    c.name,
+    cur.id,
+    cur.code,
+    cur.description,
+    cur.locale_code,
+    i.scenario_id
+FROM
+    scenario_items i
+    INNER JOIN scenarios s ON i.scenario_id = s.id
+    LEFT JOIN categories c ON i.category_id = c.id
+    LEFT JOIN currencies cur ON i.currency_id = cur.id
+WHERE
+    i.id = ?
+    AND i.scenario_id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
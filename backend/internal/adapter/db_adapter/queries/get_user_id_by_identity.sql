@@ -0,0 +1,3 @@
+SELECT user_id
+FROM user_identities
+WHERE provider = ? AND subject = ?
\ No newline at end of file
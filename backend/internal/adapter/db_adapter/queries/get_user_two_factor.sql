@@ -0,0 +1,3 @@
+SELECT totp_secret, totp_enabled, totp_last_step
+FROM users
+WHERE id = ?
\ No newline at end of file
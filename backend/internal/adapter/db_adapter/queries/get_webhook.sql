@@ -0,0 +1,12 @@
+SELECT
+    w.id,
+    w.name,
+    w.url,
+    w.entities,
+    w.enabled,
+    w.created_at
+FROM
+    webhooks w
+WHERE
+    w.id = ?
+    AND w.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
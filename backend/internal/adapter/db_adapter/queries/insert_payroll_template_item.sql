@@ -0,0 +1,21 @@
+INSERT INTO payroll_template_items
+    (
+     statutory_key,
+     label,
+     cycle,
+     amount_type,
+     amount,
+     distribution_type,
+     relative_offset,
+     base_threshold,
+     base_ceiling,
+     base_deduction,
+     base_minimum,
+     age_rates,
+     sort_order,
+     template_id
+    )
+SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, pt.id
+FROM payroll_templates pt
+WHERE pt.id = ?
+  AND pt.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
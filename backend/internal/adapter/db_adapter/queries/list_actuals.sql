@@ -0,0 +1,29 @@
+SELECT
+    a.id,
+    a.booking_date,
+    a.amount,
+    a.name,
+    a.reference,
+    a.status,
+    a.planned_date,
+    a.planned_amount,
+    t.id,
+    t.name,
+    cat.id,
+    cat.name,
+    a.bank_account_id,
+    a.bank_statement_import_id,
+    cur.id,
+    cur.code,
+    cur.description,
+    cur.locale_code
+FROM
+    actuals a
+    INNER JOIN currencies cur ON a.currency_id = cur.id
+    LEFT JOIN transactions t ON a.transaction_id = t.id
+    LEFT JOIN categories cat ON t.category_id = cat.id
+WHERE
+    a.organisation_id = get_current_user_organisation_id(?)
+    AND a.booking_date BETWEEN ? AND ?
+ORDER BY
+    a.booking_date DESC, a.id DESC
\ No newline at end of file
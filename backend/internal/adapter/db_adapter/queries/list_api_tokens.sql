@@ -0,0 +1,15 @@
+SELECT
+    t.id,
+    t.name,
+    t.token_prefix,
+    t.scope,
+    t.organisation_id,
+    o.name AS organisation_name,
+    t.last_used_at,
+    t.last_used_ip,
+    t.created_at,
+    t.expires_at
+FROM api_tokens AS t
+INNER JOIN organisations o ON o.id = t.organisation_id
+WHERE t.user_id = ?
+ORDER BY t.created_at DESC, t.id DESC
\ No newline at end of file
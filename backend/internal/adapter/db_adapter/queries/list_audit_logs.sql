@@ -0,0 +1,27 @@
+SELECT
+    a.id,
+    a.user_id,
+    u.name,
+    a.source,
+    a.oauth_client_id,
+    a.entity,
+    a.entity_id,
+    a.parent_id,
+    a.action,
+    a.changes,
+    a.created_at,
+    COUNT(*) OVER () AS total_count
+FROM
+    audit_logs a
+    LEFT JOIN users u ON u.id = a.user_id
+WHERE
+    a.organisation_id = get_current_user_organisation_id(?)
+    AND (? IS NULL OR a.entity = ?)
+    AND (? IS NULL OR a.user_id = ?)
+    AND (? IS NULL OR a.created_at >= ?)
+    AND (? IS NULL OR a.created_at < DATE_ADD(?, INTERVAL 1 DAY))
+ORDER BY
+    a.created_at DESC,
+    a.id DESC
+LIMIT ?
+OFFSET ?
\ No newline at end of file
@@ -0,0 +1,13 @@
+SELECT
+    b.id,
+    b.balance_date,
+    b.amount,
+    b.bank_account_id
+FROM
+    bank_account_balances b
+    INNER JOIN bank_accounts ba ON b.bank_account_id = ba.id
+WHERE
+    b.bank_account_id = ?
+    AND ba.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    b.balance_date DESC
\ No newline at end of file
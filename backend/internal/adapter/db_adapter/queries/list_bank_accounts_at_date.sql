@@ -0,0 +1,25 @@
+SELECT
+    ba.id,
+    ba.name,
+    -- Latest known balance on or before the given date, accounts without history keep their amount
+    COALESCE(
+        (
+            SELECT b.amount
+            FROM bank_account_balances b
+            WHERE b.bank_account_id = ba.id AND b.balance_date <= ?
+            ORDER BY b.balance_date DESC
+            LIMIT 1
+        ),
+        ba.amount
+    ) AS amount,
+    cur.id,
+    cur.code,
+    cur.description,
+    cur.locale_code
+FROM
+    bank_accounts AS ba
+INNER JOIN currencies cur ON ba.currency_id = cur.id
+WHERE
+    ba.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    ba.name
\ No newline at end of file
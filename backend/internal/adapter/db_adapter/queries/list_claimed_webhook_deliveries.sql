@@ -0,0 +1,17 @@
+SELECT
+    d.id,
+    d.entity,
+    d.action,
+    d.payload,
+    d.attempts,
+    w.url,
+    w.secret,
+    w.enabled
+FROM
+    webhook_deliveries d
+    INNER JOIN webhooks w ON w.id = d.webhook_id
+WHERE
+    d.claim_token = ?
+ORDER BY
+    d.next_attempt_at,
+    d.id
\ No newline at end of file
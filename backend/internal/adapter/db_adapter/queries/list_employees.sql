@@ -1,6 +1,7 @@
 SELECT
     e.id,
     e.name,
+    e.birth_date,
     rs.hours_per_month,
     rs.amount + rs.employer_costs AS salary,
     rs.cycle,
@@ -24,7 +25,7 @@ WHERE e.organisation_id = get_current_user_organisation_id(?)
     {{if .hasSearch}}AND LOWER(e.name) LIKE LOWER(?){{end}}
     {{if .hideTerminated}}AND COALESCE(rs.is_termination, false) = false{{end}}
 GROUP BY
-    e.id, e.name, rs.hours_per_month, rs.amount, rs.employer_costs,
+    e.id, e.name, e.birth_date, rs.hours_per_month, rs.amount, rs.employer_costs,
     rs.cycle, c.id, c.locale_code, c.description, c.code,
     rs.vacation_days_per_year, rs.from_date, rs.to_date,
     rs.is_in_future, rs.is_termination, rs.id
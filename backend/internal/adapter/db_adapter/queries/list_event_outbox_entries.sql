@@ -0,0 +1,10 @@
+SELECT
+    e.id,
+    e.payload
+FROM
+    event_outbox e
+WHERE
+    e.id > ?
+ORDER BY
+    e.id
+LIMIT ?
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT id, source, base, rate_date, rate_count, status, error, fetched_at
+FROM fiat_rate_fetches
+ORDER BY fetched_at DESC, id DESC
+LIMIT ?
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- The entries from the date on plus the last one before, which is still valid on the date
+SELECT h.base, h.target, h.rate, h.date
+FROM fiat_rate_history h
+WHERE h.base = ?
+  AND h.date >= COALESCE(
+    (
+        SELECT MAX(p.date)
+        FROM fiat_rate_history p
+        WHERE p.base = h.base AND p.target = h.target AND p.date <= ?
+    ),
+    ?
+  )
+ORDER BY h.target, h.date
\ No newline at end of file
@@ -0,0 +1,14 @@
+SELECT
+    h.month,
+    h.revenue,
+    h.expense,
+    h.cashflow,
+    h.revenue_details,
+    h.expense_details
+FROM
+    forecast_history h
+WHERE
+    h.organisation_id = get_current_user_organisation_id(?)
+    AND h.month BETWEEN ? AND ?
+ORDER BY
+    h.month
\ No newline at end of file
@@ -0,0 +1,19 @@
+SELECT
+    m.month,
+    m.period,
+    m.end_month,
+    m.revenue,
+    m.expense,
+    m.cashflow,
+    m.opening_balance,
+    m.closing_balance,
+    m.revenue_details,
+    m.expense_details
+FROM
+    forecast_snapshot_months m
+    INNER JOIN forecast_snapshots s ON s.id = m.snapshot_id
+WHERE
+    m.snapshot_id = ?
+    AND s.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    m.month
\ No newline at end of file
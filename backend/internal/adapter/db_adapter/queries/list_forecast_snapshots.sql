@@ -0,0 +1,15 @@
+SELECT
+    s.id,
+    s.label,
+    s.description,
+    s.opening_balance,
+    s.created_at,
+    u.name
+FROM
+    forecast_snapshots s
+    LEFT JOIN users u ON u.id = s.created_by
+WHERE
+    s.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    s.created_at DESC,
+    s.id DESC
\ No newline at end of file
@@ -10,6 +10,8 @@ WITH RECURSIVE date_series AS (
 )
 SELECT
     DATE_FORMAT(ds.date, '%Y-%m') AS month,
+    COALESCE(f.period, 'month') AS period,
+    COALESCE(f.end_month, DATE_FORMAT(ds.date, '%Y-%m')) AS end_month,
     COALESCE(f.revenue, 0) AS revenue,
     COALESCE(f.expense, 0) AS expense,
     COALESCE(f.cashflow, 0) AS cashflow,
@@ -17,4 +19,12 @@ SELECT
 FROM date_series ds
 LEFT JOIN forecasts f ON DATE_FORMAT(ds.date, '%Y-%m') = f.month
     AND f.organisation_id = get_current_user_organisation_id(?)
+-- Months within an aggregated quarter or year are represented by the row of its first month
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM forecasts b
+    WHERE b.organisation_id = get_current_user_organisation_id(?)
+        AND b.month < DATE_FORMAT(ds.date, '%Y-%m')
+        AND b.end_month >= DATE_FORMAT(ds.date, '%Y-%m')
+)
 ORDER BY ds.date
\ No newline at end of file
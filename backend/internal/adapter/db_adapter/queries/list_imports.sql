@@ -0,0 +1,18 @@
+SELECT
+    i.id,
+    i.entity,
+    i.file_name,
+    i.status,
+    i.row_count,
+    i.error_count,
+    i.created_at,
+    i.committed_at,
+    u.name
+FROM
+    imports i
+    LEFT JOIN users u ON u.id = i.created_by
+WHERE
+    i.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    i.created_at DESC,
+    i.id DESC
\ No newline at end of file
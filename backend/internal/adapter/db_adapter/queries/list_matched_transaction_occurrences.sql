@@ -0,0 +1,9 @@
+SELECT
+    a.transaction_id,
+    a.planned_date
+FROM
+    actuals a
+WHERE
+    a.organisation_id = get_current_user_organisation_id(?)
+    AND a.transaction_id IS NOT NULL
+    AND a.planned_date IS NOT NULL
\ No newline at end of file
@@ -3,7 +3,17 @@ SELECT
     u.name,
     u.email,
     u2o.role,
-    u2o.is_default
+    u2o.is_default,
+    u2o.payroll_clearance,
+    (
+        SELECT MAX(a.blocked_until)
+        FROM auth_attempts a
+        WHERE a.locked_at IS NOT NULL AND a.blocked_until > NOW()
+          AND (
+              (a.scope = 'login' AND a.identifier = CONCAT('email:', LOWER(u.email)))
+              OR (a.scope = 'two-factor' AND a.identifier = CONCAT('user:', u.id))
+          )
+    ) AS locked_until
 FROM users_2_organisations u2o
 INNER JOIN users u ON u.id = u2o.user_id
 WHERE u2o.organisation_id = ?
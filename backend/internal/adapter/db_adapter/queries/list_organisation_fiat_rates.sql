@@ -0,0 +1,4 @@
+SELECT id, base, target, rate, updated_at
+FROM organisation_fiat_rates
+WHERE organisation_id = get_current_user_organisation_id(?)
+ORDER BY base, target
\ No newline at end of file
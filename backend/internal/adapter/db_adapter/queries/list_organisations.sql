@@ -8,6 +8,14 @@ SELECT
     member_counts.member_count AS member_count,
     u2o.role,
     u2o.is_default,
+    o.forecast_years,
+    o.forecast_monthly_years,
+    o.forecast_bucket,
+    o.payroll_confidential,
+    u2o.payroll_clearance,
+    o.require_two_factor,
+    o.sso_domain,
+    o.sso_default_role,
     COUNT(*) OVER () AS total_count
 FROM users_2_organisations AS u2o
 INNER JOIN organisations o ON o.id = u2o.organisation_id
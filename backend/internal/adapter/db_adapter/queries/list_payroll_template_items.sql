@@ -0,0 +1,18 @@
+SELECT
+    pti.id,
+    pti.statutory_key,
+    pti.label,
+    pti.cycle,
+    pti.amount_type,
+    pti.amount,
+    pti.distribution_type,
+    pti.relative_offset,
+    pti.base_threshold,
+    pti.base_ceiling,
+    pti.base_deduction,
+    pti.base_minimum,
+    pti.age_rates,
+    pti.sort_order
+FROM payroll_template_items pti
+WHERE pti.template_id = ?
+ORDER BY pti.sort_order, pti.id
\ No newline at end of file
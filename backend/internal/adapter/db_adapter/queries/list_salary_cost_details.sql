@@ -2,6 +2,7 @@ SELECT
     hcd.id,
     hcd.month,
     hcd.amount,
+    hcd.extra_payment_amount,
     hcd.divider,
     hcd.is_extra_month,
     hcd.cost_id
@@ -0,0 +1,11 @@
+SELECT
+    s.id,
+    s.name,
+    s.description,
+    s.created_at
+FROM
+    scenarios s
+WHERE
+    s.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    s.name
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT id, token_id, device_name, ip_address, created_at, last_used_at, expires_at
+FROM refresh_tokens
+WHERE user_id = ? AND expires_at > NOW()
+ORDER BY COALESCE(last_used_at, created_at) DESC
\ No newline at end of file
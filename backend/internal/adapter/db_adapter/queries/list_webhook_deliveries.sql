@@ -0,0 +1,26 @@
+SELECT
+    d.id,
+    d.webhook_id,
+    d.entity,
+    d.action,
+    d.payload,
+    d.status,
+    d.attempts,
+    CASE WHEN d.status = 'pending' THEN d.next_attempt_at END,
+    d.last_attempt_at,
+    d.response_status,
+    d.last_error,
+    d.replay_of,
+    d.created_at,
+    COUNT(*) OVER () AS total_count
+FROM
+    webhook_deliveries d
+    INNER JOIN webhooks w ON w.id = d.webhook_id
+WHERE
+    d.webhook_id = ?
+    AND w.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    d.created_at DESC,
+    d.id DESC
+LIMIT ?
+OFFSET ?
\ No newline at end of file
@@ -0,0 +1,14 @@
+SELECT
+    w.id,
+    w.name,
+    w.url,
+    w.entities,
+    w.enabled,
+    w.created_at
+FROM
+    webhooks w
+WHERE
+    w.organisation_id = get_current_user_organisation_id(?)
+ORDER BY
+    w.name,
+    w.id
\ No newline at end of file
@@ -0,0 +1,7 @@
+INSERT INTO auth_attempts (scope, identifier, failures, last_failure_at)
+VALUES (?, ?, 1, NOW())
+ON DUPLICATE KEY UPDATE
+    -- Failures older than the window are forgotten, an expired lockout starts over as well
+    failures = IF(last_failure_at < NOW() - INTERVAL ? SECOND OR locked_at IS NOT NULL AND blocked_until <= NOW(), 1, failures + 1),
+    locked_at = IF(failures = 1, NULL, locked_at),
+    last_failure_at = NOW()
\ No newline at end of file
@@ -0,0 +1,9 @@
+INSERT INTO webhook_deliveries (entity, action, payload, next_attempt_at, webhook_id, replay_of)
+SELECT d.entity, d.action, d.payload, NOW(), d.webhook_id, d.id
+FROM
+    webhook_deliveries d
+    INNER JOIN webhooks w ON w.id = d.webhook_id
+WHERE
+    d.id = ?
+    AND d.webhook_id = ?
+    AND w.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
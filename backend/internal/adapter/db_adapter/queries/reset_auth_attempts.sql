@@ -0,0 +1,2 @@
+DELETE FROM auth_attempts
+WHERE scope = ? AND identifier = ?
\ No newline at end of file
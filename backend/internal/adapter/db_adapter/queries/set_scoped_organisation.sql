@@ -0,0 +1 @@
+SET @scoped_organisation_id = ?
\ No newline at end of file
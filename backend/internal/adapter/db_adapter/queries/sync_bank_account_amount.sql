@@ -0,0 +1,14 @@
+UPDATE bank_accounts ba
+SET ba.amount = COALESCE(
+    (
+        SELECT b.amount
+        FROM bank_account_balances b
+        WHERE b.bank_account_id = ba.id
+        ORDER BY b.balance_date DESC
+        LIMIT 1
+    ),
+    ba.amount
+)
+WHERE
+    ba.id = ?
+    AND ba.organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE api_tokens
+SET last_used_at = NOW(), last_used_ip = ?
+WHERE id = ? AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL ? SECOND)
\ No newline at end of file
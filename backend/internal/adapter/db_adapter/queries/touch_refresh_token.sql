@@ -0,0 +1,3 @@
+UPDATE refresh_tokens
+SET last_used_at = CURRENT_TIMESTAMP, ip_address = ?
+WHERE token_id = ? AND user_id = ?
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE user_identities
+SET email = ?, last_login_at = NOW()
+WHERE provider = ? AND subject = ?
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM auth_attempts
+WHERE identifier IN (?, ?)
\ No newline at end of file
@@ -0,0 +1,14 @@
+UPDATE actuals
+SET
+    booking_date = ?,
+    amount = ?,
+    name = ?,
+    reference = ?,
+    status = ?,
+    planned_date = ?,
+    planned_amount = ?,
+    transaction_id = ?,
+    currency_id = ?
+WHERE
+    id = ?
+    AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE users_2_organisations SET payroll_clearance = ? WHERE organisation_id = ? AND user_id = ?
\ No newline at end of file
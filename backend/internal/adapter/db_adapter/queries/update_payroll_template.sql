@@ -0,0 +1,6 @@
+UPDATE payroll_templates
+SET
+    name = ?,
+    year = ?
+WHERE id = ?
+  AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
@@ -6,6 +6,11 @@ SET
     distribution_type = ?,
     relative_offset = ?,
     target_date = ?,
+    base_threshold = ?,
+    base_ceiling = ?,
+    base_deduction = ?,
+    base_minimum = ?,
+    age_rates = ?,
     label_id = ?
 WHERE hc.id = ?
     AND EXISTS (
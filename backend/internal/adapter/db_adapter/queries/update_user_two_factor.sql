@@ -0,0 +1 @@
+UPDATE users SET totp_secret = ?, totp_enabled = ?, totp_last_step = 0 WHERE id = ?
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE users SET totp_last_step = ? WHERE id = ? AND totp_last_step < ?
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE webhooks
+SET secret = ?
+WHERE
+    id = ?
+    AND organisation_id = get_current_user_organisation_id(?)
\ No newline at end of file
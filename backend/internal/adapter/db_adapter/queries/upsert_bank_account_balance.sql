@@ -0,0 +1,9 @@
+INSERT INTO bank_account_balances (balance_date, amount, bank_account_id)
+SELECT ?, ?, ba.id
+FROM bank_accounts ba
+WHERE
+    ba.id = ?
+    AND ba.organisation_id = get_current_user_organisation_id(?)
+ON DUPLICATE KEY UPDATE
+    amount = VALUES(amount),
+    id = LAST_INSERT_ID(id)
\ No newline at end of file
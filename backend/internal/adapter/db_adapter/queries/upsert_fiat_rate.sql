@@ -1,4 +1,6 @@
-INSERT INTO fiat_rates (base, target, rate)
-VALUES (?, ?, ?)
+INSERT INTO fiat_rates (base, target, rate, source, rate_date)
+VALUES (?, ?, ?, ?, ?)
 ON DUPLICATE KEY UPDATE
-    rate = VALUES(rate)
\ No newline at end of file
+    rate = VALUES(rate),
+    source = VALUES(source),
+    rate_date = VALUES(rate_date)
\ No newline at end of file
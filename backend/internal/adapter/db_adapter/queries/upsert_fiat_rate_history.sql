@@ -0,0 +1,5 @@
+INSERT INTO fiat_rate_history (base, target, rate, source, date)
+VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    rate = VALUES(rate),
+    source = VALUES(source)
\ No newline at end of file
@@ -1,6 +1,8 @@
-INSERT INTO forecasts (month, revenue, expense, cashflow, organisation_id)
-VALUES (?, ?, ?, ?, (SELECT current_organisation_id FROM users u WHERE u.id = ?))
+INSERT INTO forecasts (month, period, end_month, revenue, expense, cashflow, organisation_id)
+VALUES (?, ?, ?, ?, ?, ?, get_current_user_organisation_id(?))
 ON DUPLICATE KEY UPDATE
+    period = VALUES(period),
+    end_month = VALUES(end_month),
     revenue = VALUES(revenue),
     expense = VALUES(expense),
     cashflow = VALUES(cashflow);
\ No newline at end of file
@@ -1,5 +1,5 @@
 INSERT INTO forecast_details (month, revenue, expense, forecast_id, organisation_id)
-VALUES (?, ?, ?, ?, (SELECT current_organisation_id FROM users u WHERE u.id = ?))
+VALUES (?, ?, ?, ?, get_current_user_organisation_id(?))
 ON DUPLICATE KEY UPDATE
     revenue = VALUES(revenue),
     expense = VALUES(expense);
\ No newline at end of file
@@ -0,0 +1,4 @@
+INSERT INTO organisation_fiat_rates (base, target, rate, organisation_id)
+VALUES (?, ?, ?, get_current_user_organisation_id(?))
+ON DUPLICATE KEY UPDATE
+    rate = VALUES(rate)
\ No newline at end of file
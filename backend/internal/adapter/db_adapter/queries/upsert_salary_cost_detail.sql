@@ -1,8 +1,9 @@
-INSERT INTO salary_cost_details (month, amount, divider, is_extra_month, cost_id)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO salary_cost_details (month, amount, extra_payment_amount, divider, is_extra_month, cost_id)
+VALUES (?, ?, ?, ?, ?, ?)
 ON DUPLICATE KEY UPDATE
     month = VALUES(month),
     amount = VALUES(amount),
+    extra_payment_amount = VALUES(extra_payment_amount),
     divider = VALUES(divider),
     is_extra_month = VALUES(is_extra_month),
     cost_id = VALUES(cost_id);
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE user_recovery_codes SET used_at = NOW() WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
\ No newline at end of file
@@ -1,9 +1,13 @@
 package db_adapter
 
-import "time"
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+	"time"
+)
 
-// StoreRefreshTokenID stores the refresh token's token ID, user ID, device name and expiration time in the database
-func (d *DatabaseAdapter) StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName string) error {
+// StoreRefreshTokenID stores the refresh token's token ID, user ID, device name, IP address and expiration time in the database
+func (d *DatabaseAdapter) StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName string, ipAddress string) error {
 	query, err := sqlQueries.ReadFile("queries/create_refresh_token.sql")
 	if err != nil {
 		return err
@@ -15,7 +19,12 @@ func (d *DatabaseAdapter) StoreRefreshTokenID(userID int64, tokenId string, expi
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(userID, tokenId, expirationTime, deviceName)
+	var ip *string
+	if ipAddress != "" {
+		ip = &ipAddress
+	}
+
+	_, err = stmt.Exec(userID, tokenId, expirationTime, deviceName, ip)
 	if err != nil {
 		return err
 	}
@@ -48,3 +57,90 @@ func (d *DatabaseAdapter) DeleteRefreshToken(userID int64, tokenID string) error
 
 	return err
 }
+
+// TouchRefreshToken records that the refresh token renewed an access token, and from where
+func (d *DatabaseAdapter) TouchRefreshToken(userID int64, tokenID string, ipAddress string) error {
+	query, err := sqlQueries.ReadFile("queries/touch_refresh_token.sql")
+	if err != nil {
+		return err
+	}
+
+	var ip *string
+	if ipAddress != "" {
+		ip = &ipAddress
+	}
+
+	_, err = d.db.Exec(string(query), ip, tokenID, userID)
+
+	return err
+}
+
+func (d *DatabaseAdapter) ListSessions(userID int64) ([]models.Session, error) {
+	query, err := sqlQueries.ReadFile("queries/list_sessions.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var session models.Session
+		if err := rows.Scan(
+			&session.ID,
+			&session.TokenID,
+			&session.DeviceName,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// DeleteSession removes a session of the user other than the current one,
+// sql.ErrNoRows means there was no such session
+func (d *DatabaseAdapter) DeleteSession(userID int64, sessionID int64, currentTokenID string) error {
+	query, err := sqlQueries.ReadFile("queries/delete_session.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), sessionID, userID, currentTokenID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteOtherSessions removes all sessions of the user except the current one
+func (d *DatabaseAdapter) DeleteOtherSessions(userID int64, currentTokenID string) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/delete_other_sessions.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), userID, currentTokenID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -55,6 +55,8 @@ func (d *DatabaseAdapter) ListSalaries(userID int64, employeeID int64, page int6
 func (d *DatabaseAdapter) GetSalary(userID int64, salaryID int64) (*models.Salary, error) {
 	var salary models.Salary
 	var toDate sql.NullTime
+	var bonuses sql.NullString
+	var periodToDate sql.NullTime
 
 	salary.Currency = models.Currency{}
 
@@ -69,6 +71,9 @@ func (d *DatabaseAdapter) GetSalary(userID int64, salaryID int64) (*models.Salar
 		&salary.HoursPerMonth,
 		&salary.Amount,
 		&salary.Cycle,
+		&salary.ExtraSalaries,
+		&salary.ExtraSalaryPayout,
+		&bonuses,
 		&salary.Currency.ID,
 		&salary.Currency.LocaleCode,
 		&salary.Currency.Description,
@@ -79,6 +84,9 @@ func (d *DatabaseAdapter) GetSalary(userID int64, salaryID int64) (*models.Salar
 		&salary.IsTermination,
 		&salary.IsDisabled,
 		&salary.DBDate,
+		&salary.EmploymentFromDate,
+		&periodToDate,
+		&salary.EndsEmployment,
 	)
 	if err != nil {
 		return nil, err
@@ -88,11 +96,20 @@ func (d *DatabaseAdapter) GetSalary(userID int64, salaryID int64) (*models.Salar
 		convertedDate := types.AsDate(toDate.Time)
 		salary.ToDate = &convertedDate
 	}
+	if periodToDate.Valid {
+		convertedDate := types.AsDate(periodToDate.Time)
+		salary.PeriodToDate = &convertedDate
+	}
+
+	salary.Bonuses, err = unmarshalBonuses(bonuses)
+	if err != nil {
+		return nil, err
+	}
 
 	return &salary, nil
 }
 
-func (d *DatabaseAdapter) CreateSalary(payload models.CreateSalary, userID int64, employeeID int64) (int64, int64, int64, error) {
+func (d *DatabaseAdapter) CreateSalary(payload models.CreateSalary, userID int64, employeeID int64) (salaryID int64, previousSalaryID int64, nextSalaryID int64, err error) {
 	tx, err := d.db.Begin()
 	if err != nil {
 		return 0, 0, 0, err
@@ -109,6 +126,12 @@ func (d *DatabaseAdapter) CreateSalary(payload models.CreateSalary, userID int64
 		}
 	}()
 
+	salaryID, previousSalaryID, nextSalaryID, err = d.createSalaryTx(tx, payload, userID, employeeID)
+	return salaryID, previousSalaryID, nextSalaryID, err
+}
+
+// createSalaryTx inserts the salary and adjusts the neighbouring salaries within the given transaction
+func (d *DatabaseAdapter) createSalaryTx(tx *sql.Tx, payload models.CreateSalary, userID int64, employeeID int64) (int64, int64, int64, error) {
 	query, err := sqlQueries.ReadFile("queries/create_salary.sql")
 	if err != nil {
 		return 0, 0, 0, err
@@ -131,6 +154,15 @@ func (d *DatabaseAdapter) CreateSalary(payload models.CreateSalary, userID int64
 		payload.Amount = 0
 		payload.VacationDaysPerYear = 0
 		payload.ToDate = nil
+		payload.ExtraSalaries = 0
+		payload.Bonuses = nil
+	}
+	if payload.ExtraSalaryPayout == "" {
+		payload.ExtraSalaryPayout = models.SalaryExtraPayoutDecember
+	}
+	bonuses, err := marshalBonuses(payload.Bonuses)
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
 	var toDate sql.NullTime
@@ -149,6 +181,9 @@ func (d *DatabaseAdapter) CreateSalary(payload models.CreateSalary, userID int64
 		payload.HoursPerMonth,
 		payload.Amount,
 		payload.Cycle,
+		payload.ExtraSalaries,
+		payload.ExtraSalaryPayout,
+		bonuses,
 		payload.CurrencyID,
 		payload.VacationDaysPerYear,
 		fromDate,
@@ -308,6 +343,22 @@ func (d *DatabaseAdapter) UpdateSalary(payload models.UpdateSalary, employeeID i
 		queryBuild = append(queryBuild, "is_disabled = ?")
 		args = append(args, *payload.IsDisabled)
 	}
+	if payload.ExtraSalaries != nil {
+		queryBuild = append(queryBuild, "extra_salaries = ?")
+		args = append(args, *payload.ExtraSalaries)
+	}
+	if payload.ExtraSalaryPayout != nil {
+		queryBuild = append(queryBuild, "extra_salary_payout = ?")
+		args = append(args, *payload.ExtraSalaryPayout)
+	}
+	if payload.Bonuses != nil {
+		bonuses, err := marshalBonuses(*payload.Bonuses)
+		if err != nil {
+			return 0, 0, err
+		}
+		queryBuild = append(queryBuild, "bonuses = ?")
+		args = append(args, bonuses)
+	}
 	// Always consider ToDate in case it is set back to null
 	queryBuild = append(queryBuild, "to_date = ?")
 	if payload.ToDate != nil {
@@ -52,6 +52,7 @@ func (d *DatabaseAdapter) GetSalaryCost(userID int64, salaryCostID int64) (*mode
 	var salaryCost models.SalaryCost
 	var labelID sql.NullInt64
 	var labelName sql.NullString
+	var ageRates sql.NullString
 
 	query, err := sqlQueries.ReadFile("queries/get_salary_cost.sql")
 	if err != nil {
@@ -68,17 +69,28 @@ func (d *DatabaseAdapter) GetSalaryCost(userID int64, salaryCostID int64) (*mode
 		&salaryCost.DistributionType,
 		&salaryCost.RelativeOffset,
 		&salaryCost.TargetDate,
+		&salaryCost.BaseThreshold,
+		&salaryCost.BaseCeiling,
+		&salaryCost.BaseDeduction,
+		&salaryCost.BaseMinimum,
+		&ageRates,
 		&salaryCost.SalaryID,
 		&salaryCost.SalaryCycle,
 		&salaryCost.SalaryAmount,
 		&salaryCost.SalaryFromDate,
 		&salaryCost.SalaryToDate,
+		&salaryCost.EmployeeBirthDate,
 		&salaryCost.DBDate,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	salaryCost.AgeRates, err = unmarshalAgeRates(ageRates)
+	if err != nil {
+		return nil, err
+	}
+
 	if labelID.Valid && labelName.Valid {
 		salaryCost.Label = &models.SalaryCostLabel{
 			ID:   labelID.Int64,
@@ -204,6 +216,11 @@ func (d *DatabaseAdapter) CreateSalaryCost(payload models.CreateSalaryCost, user
 		targetDate = sql.NullTime{Valid: false}
 	}
 
+	ageRates, err := marshalAgeRates(payload.AgeRates)
+	if err != nil {
+		return 0, err
+	}
+
 	res, err := stmt.Exec(
 		payload.Cycle,
 		payload.AmountType,
@@ -211,6 +228,11 @@ func (d *DatabaseAdapter) CreateSalaryCost(payload models.CreateSalaryCost, user
 		payload.DistributionType,
 		payload.RelativeOffset,
 		targetDate,
+		payload.BaseThreshold,
+		payload.BaseCeiling,
+		payload.BaseDeduction,
+		payload.BaseMinimum,
+		ageRates,
 		payload.LabelID,
 		salaryID,
 		salaryID,
@@ -256,6 +278,11 @@ func (d *DatabaseAdapter) UpdateSalaryCost(payload models.CreateSalaryCost, user
 		targetDate = sql.NullTime{Valid: false}
 	}
 
+	ageRates, err := marshalAgeRates(payload.AgeRates)
+	if err != nil {
+		return err
+	}
+
 	_, err = stmt.Exec(
 		payload.Cycle,
 		payload.AmountType,
@@ -263,6 +290,11 @@ func (d *DatabaseAdapter) UpdateSalaryCost(payload models.CreateSalaryCost, user
 		payload.DistributionType,
 		payload.RelativeOffset,
 		targetDate,
+		payload.BaseThreshold,
+		payload.BaseCeiling,
+		payload.BaseDeduction,
+		payload.BaseMinimum,
+		ageRates,
 		payload.LabelID,
 		salaryCostID,
 		userID,
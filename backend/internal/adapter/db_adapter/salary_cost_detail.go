@@ -27,6 +27,7 @@ func (d *DatabaseAdapter) ListSalaryCostDetails(salaryCostID int64) ([]models.Sa
 			&salaryCostDetail.ID,
 			&salaryCostDetail.Month,
 			&salaryCostDetail.Amount,
+			&salaryCostDetail.ExtraPaymentAmount,
 			&salaryCostDetail.Divider,
 			&salaryCostDetail.IsExtraMonth,
 			&salaryCostDetail.CostID,
@@ -74,8 +75,12 @@ func (d *DatabaseAdapter) CalculateSalaryCostDetails(userID int64, salaryCostID
 	nextCostExecution := time.Time(*currCostExecutionPtr)
 	nextCostExecution = addCycle(nextCostExecution, cost.Cycle, -cost.RelativeOffset)
 
+	forecastYears, err := d.getForecastYears(userID)
+	if err != nil {
+		return err
+	}
 	today := utils.GetTodayAsUTC()
-	maxEndDate := today.AddDate(utils.MaxForecastYears, 0, 0)
+	maxEndDate := today.AddDate(forecastYears, 0, 0)
 	// We include the whole final month, otherwise the results might be confusing
 	lastDayOfMaxEndDate := time.Date(maxEndDate.Year(), maxEndDate.Month()+1, 0, 23, 59, 59, 999999999, maxEndDate.Location())
 
@@ -106,20 +111,31 @@ func (d *DatabaseAdapter) CalculateSalaryCostDetails(userID int64, salaryCostID
 			validMonths = append(validMonths, month)
 		}
 
-		amountPerMonth, err := d.CalculateCostAmount(userID, *cost, *salary, map[int64]struct{}{})
-		if err != nil {
-			return err
+		// 2. Each month has its own base as the extra payments of the salary differ between them
+		var totalAmount, regularAmount uint64
+		regularSalary := salary.WithoutExtraPayments()
+		for _, month := range validMonths {
+			amount, err := d.CalculateCostAmount(userID, *cost, *salary, month, map[int64]struct{}{})
+			if err != nil {
+				return err
+			}
+			totalAmount += amount
+			amount, err = d.CalculateCostAmount(userID, *cost, regularSalary, month, map[int64]struct{}{})
+			if err != nil {
+				return err
+			}
+			regularAmount += amount
 		}
-		totalAmount := amountPerMonth * uint64(len(validMonths))
 		if totalAmount > 0 {
 			monthStr := nextCostExecution.Format("2006-01")
 			isExtraMonth := i == 1 && cost.Cycle != utils.CycleOnce
 			payload := models.CreateSalaryCostDetail{
-				Month:        monthStr,
-				Amount:       totalAmount,
-				Divider:      uint(len(validMonths)),
-				IsExtraMonth: isExtraMonth,
-				CostID:       cost.ID,
+				Month:              monthStr,
+				Amount:             totalAmount,
+				ExtraPaymentAmount: totalAmount - min(regularAmount, totalAmount),
+				Divider:            uint(len(validMonths)),
+				IsExtraMonth:       isExtraMonth,
+				CostID:             cost.ID,
 			}
 			_, err := d.UpsertSalaryCostDetails(payload)
 			if err != nil {
@@ -153,6 +169,7 @@ func (d *DatabaseAdapter) UpsertSalaryCostDetails(payload models.CreateSalaryCos
 	res, err := stmt.Exec(
 		payload.Month,
 		payload.Amount,
+		payload.ExtraPaymentAmount,
 		payload.Divider,
 		payload.IsExtraMonth,
 		payload.CostID,
@@ -203,3 +220,19 @@ func (d *DatabaseAdapter) RefreshSalaryCostDetails(userID int64, salaryID int64)
 	}
 	return nil
 }
+
+// getForecastYears returns the forecast horizon of the user's current organisation
+func (d *DatabaseAdapter) getForecastYears(userID int64) (int, error) {
+	query, err := sqlQueries.ReadFile("queries/get_organisation_forecast_years.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var forecastYears int
+	err = d.db.QueryRow(string(query), userID).Scan(&forecastYears)
+	if err != nil {
+		return 0, err
+	}
+
+	return forecastYears, nil
+}
@@ -0,0 +1,329 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"strings"
+)
+
+func (d *DatabaseAdapter) ListScenarios(userID int64) ([]models.Scenario, error) {
+	scenarios := []models.Scenario{}
+
+	query, err := sqlQueries.ReadFile("queries/list_scenarios.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var scenario models.Scenario
+
+		err := rows.Scan(&scenario.ID, &scenario.Name, &scenario.Description, &scenario.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		scenario.Items = []models.ScenarioItem{}
+		scenarios = append(scenarios, scenario)
+	}
+
+	return scenarios, nil
+}
+
+func (d *DatabaseAdapter) GetScenario(userID int64, scenarioID int64) (*models.Scenario, error) {
+	var scenario models.Scenario
+
+	query, err := sqlQueries.ReadFile("queries/get_scenario.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), scenarioID, userID).Scan(
+		&scenario.ID, &scenario.Name, &scenario.Description, &scenario.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := d.ListScenarioItems(userID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+	scenario.Items = items
+
+	return &scenario, nil
+}
+
+func (d *DatabaseAdapter) CreateScenario(payload models.CreateScenario, userID int64) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/create_scenario.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(payload.Name, payload.Description, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the ID of the newly inserted scenario
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) UpdateScenario(payload models.UpdateScenario, userID int64, scenarioID int64) error {
+	// Base query
+	query := "UPDATE scenarios SET "
+	queryBuild := []string{}
+	args := []any{}
+
+	// Dynamically add fields that are not nil
+	if payload.Name != nil {
+		queryBuild = append(queryBuild, "name = ?")
+		args = append(args, *payload.Name)
+	}
+	if payload.Description != nil {
+		queryBuild = append(queryBuild, "description = ?")
+		args = append(args, *payload.Description)
+	}
+
+	if len(queryBuild) == 0 {
+		return nil
+	}
+
+	// Add WHERE clause
+	query += strings.Join(queryBuild, ", ")
+	query += " WHERE id = ? AND organisation_id = get_current_user_organisation_id(?)"
+	args = append(args, scenarioID, userID)
+
+	stmt, err := d.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) DeleteScenario(userID int64, scenarioID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_scenario.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(scenarioID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) ListScenarioItems(userID int64, scenarioID int64) ([]models.ScenarioItem, error) {
+	items := []models.ScenarioItem{}
+
+	query, err := sqlQueries.ReadFile("queries/list_scenario_items.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), scenarioID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanScenarioItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
+
+func (d *DatabaseAdapter) GetScenarioItem(userID int64, scenarioID int64, scenarioItemID int64) (*models.ScenarioItem, error) {
+	query, err := sqlQueries.ReadFile("queries/get_scenario_item.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanScenarioItem(d.db.QueryRow(string(query), scenarioItemID, scenarioID, userID))
+}
+
+func (d *DatabaseAdapter) CreateScenarioItem(payload models.CreateScenarioItem, userID int64, scenarioID int64) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/create_scenario_item.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(
+		payload.Operation, payload.RelatedTable, payload.RelatedID, payload.Name, payload.Amount, payload.Cycle,
+		payload.Type, payload.StartDate, payload.EndDate, payload.Category, payload.Currency,
+		scenarioID, userID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// The insert selects from the scenario, so nothing is written for foreign scenarios
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	// Get the ID of the newly inserted scenario item
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) DeleteScenarioItem(userID int64, scenarioID int64, scenarioItemID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_scenario_item.sql")
+	if err != nil {
+		return err
+	}
+
+	stmt, err := d.db.Prepare(string(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(scenarioItemID, scenarioID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScenarioItem(row rowScanner) (*models.ScenarioItem, error) {
+	var item models.ScenarioItem
+	// These are required for proper date convertion afterwards
+	var startDate sql.NullTime
+	var endDate sql.NullTime
+	var categoryID sql.NullInt64
+	var categoryName sql.NullString
+	var currencyID sql.NullInt64
+	var currencyCode sql.NullString
+	var currencyDescription sql.NullString
+	var currencyLocaleCode sql.NullString
+
+	err := row.Scan(
+		&item.ID,
+		&item.Operation,
+		&item.RelatedTable,
+		&item.RelatedID,
+		&item.Name,
+		&item.Amount,
+		&item.Cycle,
+		&item.Type,
+		&startDate,
+		&endDate,
+		&categoryID,
+		&categoryName,
+		&currencyID,
+		&currencyCode,
+		&currencyDescription,
+		&currencyLocaleCode,
+		&item.ScenarioID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startDate.Valid {
+		convertedDate := types.AsDate(startDate.Time)
+		item.StartDate = &convertedDate
+	}
+	if endDate.Valid {
+		convertedDate := types.AsDate(endDate.Time)
+		item.EndDate = &convertedDate
+	}
+
+	if categoryID.Valid {
+		item.Category = &models.Category{
+			ID:   categoryID.Int64,
+			Name: categoryName.String,
+		}
+	}
+
+	if currencyID.Valid {
+		item.Currency = &models.Currency{
+			ID:          &currencyID.Int64,
+			Code:        nullStringPtr(currencyCode),
+			Description: nullStringPtr(currencyDescription),
+			LocaleCode:  nullStringPtr(currencyLocaleCode),
+		}
+	}
+
+	return &item, nil
+}
+
+func nullStringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
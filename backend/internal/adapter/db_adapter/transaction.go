@@ -55,6 +55,8 @@ func (d *DatabaseAdapter) ListTransactions(userID int64, page int64, limit int64
 	}
 	defer rows.Close()
 
+	// The rows are read completely first, an adapter scoped to an organisation runs all queries on one connection
+	transactionIDs := make([]int64, 0)
 	for rows.Next() {
 		var transactionID int64
 
@@ -66,6 +68,14 @@ func (d *DatabaseAdapter) ListTransactions(userID int64, page int64, limit int64
 			return nil, 0, err
 		}
 
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	for _, transactionID := range transactionIDs {
 		transaction, err := d.GetTransaction(userID, transactionID)
 		if err != nil {
 			return nil, 0, err
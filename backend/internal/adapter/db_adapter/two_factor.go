@@ -0,0 +1,173 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"errors"
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) GetUserTwoFactor(userID int64) (*models.UserTwoFactor, error) {
+	var twoFactor models.UserTwoFactor
+
+	query, err := sqlQueries.ReadFile("queries/get_user_two_factor.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), userID).Scan(
+		&twoFactor.Secret,
+		&twoFactor.Enabled,
+		&twoFactor.LastStep,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+// UpdateUserTwoFactor stores the secret and resets the last used time step
+func (d *DatabaseAdapter) UpdateUserTwoFactor(userID int64, secret *string, enabled bool) error {
+	query, err := sqlQueries.ReadFile("queries/update_user_two_factor.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), secret, enabled, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserTwoFactorLastStep only moves the step forward, false means the step was used already
+func (d *DatabaseAdapter) UpdateUserTwoFactorLastStep(userID int64, step int64) (bool, error) {
+	query, err := sqlQueries.ReadFile("queries/update_user_two_factor_last_step.sql")
+	if err != nil {
+		return false, err
+	}
+
+	res, err := d.db.Exec(string(query), step, userID, step)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// ReplaceUserRecoveryCodes drops all codes of the user, used or not, and stores the new hashes
+func (d *DatabaseAdapter) ReplaceUserRecoveryCodes(userID int64, codeHashes []string) (err error) {
+	deleteQuery, err := sqlQueries.ReadFile("queries/delete_user_recovery_codes.sql")
+	if err != nil {
+		return err
+	}
+	createQuery, err := sqlQueries.ReadFile("queries/create_user_recovery_code.sql")
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.Exec(string(deleteQuery), userID)
+	if err != nil {
+		return err
+	}
+	for _, codeHash := range codeHashes {
+		_, err = tx.Exec(string(createQuery), userID, codeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseUserRecoveryCode marks an unused code as used, false means there was no such code
+func (d *DatabaseAdapter) UseUserRecoveryCode(userID int64, codeHash string) (bool, error) {
+	query, err := sqlQueries.ReadFile("queries/use_user_recovery_code.sql")
+	if err != nil {
+		return false, err
+	}
+
+	res, err := d.db.Exec(string(query), userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (d *DatabaseAdapter) CountUserRecoveryCodes(userID int64) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/count_user_recovery_codes.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = d.db.QueryRow(string(query), userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CheckUserTwoFactorRequired tells whether any organisation of the user requires two-factor authentication
+func (d *DatabaseAdapter) CheckUserTwoFactorRequired(userID int64) (bool, error) {
+	query, err := sqlQueries.ReadFile("queries/check_user_two_factor_required.sql")
+	if err != nil {
+		return false, err
+	}
+
+	var required bool
+	err = d.db.QueryRow(string(query), userID).Scan(&required)
+	if err != nil {
+		return false, err
+	}
+
+	return required, nil
+}
+
+// CheckCurrentUserTwoFactorCompliance is false when the current organisation requires
+// two-factor authentication and the user did not enable it. Users without a current
+// organisation are compliant, no organisation requires anything from them.
+func (d *DatabaseAdapter) CheckCurrentUserTwoFactorCompliance(userID int64) (bool, error) {
+	query, err := sqlQueries.ReadFile("queries/check_current_user_two_factor_compliance.sql")
+	if err != nil {
+		return false, err
+	}
+
+	var compliant bool
+	err = d.db.QueryRow(string(query), userID).Scan(&compliant)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return compliant, nil
+}
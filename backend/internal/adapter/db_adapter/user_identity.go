@@ -0,0 +1,69 @@
+package db_adapter
+
+import (
+	"liquiswiss/pkg/models"
+)
+
+func (d *DatabaseAdapter) GetUserIDBySSOIdentity(provider string, subject string) (int64, error) {
+	var userID int64
+
+	query, err := sqlQueries.ReadFile("queries/get_user_id_by_identity.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	err = d.db.QueryRow(string(query), provider, subject).Scan(&userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (d *DatabaseAdapter) CreateUserIdentity(userID int64, provider string, subject string, email string) error {
+	query, err := sqlQueries.ReadFile("queries/create_user_identity.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), provider, subject, email, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TouchUserIdentity remembers the last login and the current email of the provider
+func (d *DatabaseAdapter) TouchUserIdentity(provider string, subject string, email string) error {
+	query, err := sqlQueries.ReadFile("queries/touch_user_identity.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(string(query), email, provider, subject)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) GetOrganisationBySSODomain(domain string) (*models.SSOOrganisation, error) {
+	var organisation models.SSOOrganisation
+
+	query, err := sqlQueries.ReadFile("queries/get_organisation_by_sso_domain.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), domain).Scan(
+		&organisation.ID,
+		&organisation.DefaultRole,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organisation, nil
+}
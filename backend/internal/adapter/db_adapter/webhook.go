@@ -0,0 +1,359 @@
+package db_adapter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"liquiswiss/pkg/models"
+	"strings"
+	"time"
+)
+
+func (d *DatabaseAdapter) ListWebhooks(userID int64) ([]models.Webhook, error) {
+	webhooks := []models.Webhook{}
+
+	query, err := sqlQueries.ReadFile("queries/list_webhooks.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.Query(string(query), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func (d *DatabaseAdapter) GetWebhook(userID int64, webhookID int64) (*models.Webhook, error) {
+	query, err := sqlQueries.ReadFile("queries/get_webhook.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWebhook(d.db.QueryRow(string(query), webhookID, userID))
+}
+
+func (d *DatabaseAdapter) CreateWebhook(payload models.CreateWebhook, userID int64, secret string) (int64, error) {
+	entitiesJSON, err := marshalWebhookEntities(payload.Entities)
+	if err != nil {
+		return 0, err
+	}
+
+	query, err := sqlQueries.ReadFile("queries/create_webhook.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), payload.Name, payload.URL, secret, entitiesJSON, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DatabaseAdapter) UpdateWebhook(payload models.UpdateWebhook, userID int64, webhookID int64) error {
+	// Base query
+	query := "UPDATE webhooks SET "
+	queryBuild := []string{}
+	args := []any{}
+
+	// Dynamically add fields that are not nil
+	if payload.Name != nil {
+		queryBuild = append(queryBuild, "name = ?")
+		args = append(args, *payload.Name)
+	}
+	if payload.URL != nil {
+		queryBuild = append(queryBuild, "url = ?")
+		args = append(args, *payload.URL)
+	}
+	if payload.Entities != nil {
+		entitiesJSON, err := marshalWebhookEntities(*payload.Entities)
+		if err != nil {
+			return err
+		}
+		queryBuild = append(queryBuild, "entities = ?")
+		args = append(args, entitiesJSON)
+	}
+	if payload.Enabled != nil {
+		queryBuild = append(queryBuild, "enabled = ?")
+		args = append(args, *payload.Enabled)
+	}
+
+	if len(queryBuild) == 0 {
+		return nil
+	}
+
+	// Add WHERE clause
+	query += strings.Join(queryBuild, ", ")
+	query += " WHERE id = ? AND organisation_id = get_current_user_organisation_id(?)"
+	args = append(args, webhookID, userID)
+
+	_, err := d.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateWebhookSecret returns sql.ErrNoRows if the organisation has no such webhook
+func (d *DatabaseAdapter) UpdateWebhookSecret(userID int64, webhookID int64, secret string) error {
+	query, err := sqlQueries.ReadFile("queries/update_webhook_secret.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), secret, webhookID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteWebhook returns sql.ErrNoRows if the organisation has no such webhook
+func (d *DatabaseAdapter) DeleteWebhook(userID int64, webhookID int64) error {
+	query, err := sqlQueries.ReadFile("queries/delete_webhook.sql")
+	if err != nil {
+		return err
+	}
+
+	res, err := d.db.Exec(string(query), webhookID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues the event for every enabled webhook of the organisation that
+// subscribed to the entity and returns the number of queued deliveries
+func (d *DatabaseAdapter) EnqueueWebhookDeliveries(organisationID int64, entity string, action string, payload []byte) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/enqueue_webhook_deliveries.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), entity, action, payload, organisationID, entity)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// ClaimWebhookDeliveries locks up to limit due deliveries for the lease so that no other
+// instance attempts them at the same time. Deliveries of a crashed instance are due again
+// once the lease expired.
+func (d *DatabaseAdapter) ClaimWebhookDeliveries(claimToken string, limit int64, lease time.Duration) ([]models.WebhookDeliveryJob, error) {
+	jobs := []models.WebhookDeliveryJob{}
+
+	claimQuery, err := sqlQueries.ReadFile("queries/claim_webhook_deliveries.sql")
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.db.Exec(string(claimQuery), claimToken, int64(lease.Seconds()), limit)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if claimed == 0 {
+		return jobs, nil
+	}
+
+	query, err := sqlQueries.ReadFile("queries/list_claimed_webhook_deliveries.sql")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := d.db.Query(string(query), claimToken)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var job models.WebhookDeliveryJob
+
+		err := rows.Scan(&job.ID, &job.Entity, &job.Action, &job.Payload, &job.Attempts, &job.URL, &job.Secret, &job.Enabled)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// CompleteWebhookDelivery stores the outcome of an attempt and releases the claim
+func (d *DatabaseAdapter) CompleteWebhookDelivery(deliveryID int64, result models.WebhookDeliveryResult) error {
+	query, err := sqlQueries.ReadFile("queries/complete_webhook_delivery.sql")
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(
+		string(query),
+		result.Status, result.ResponseStatus, result.Error, int64(result.RetryIn.Seconds()), deliveryID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DatabaseAdapter) ListWebhookDeliveries(userID int64, webhookID int64, page int64, limit int64) ([]models.WebhookDelivery, int64, error) {
+	deliveries := []models.WebhookDelivery{}
+	var totalCount int64
+
+	query, err := sqlQueries.ReadFile("queries/list_webhook_deliveries.sql")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := d.db.Query(string(query), webhookID, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+
+		err := rows.Scan(
+			&delivery.ID, &delivery.WebhookID, &delivery.Entity, &delivery.Action, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastAttemptAt,
+			&delivery.ResponseStatus, &delivery.LastError, &delivery.ReplayOf, &delivery.CreatedAt,
+			&totalCount,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, totalCount, rows.Err()
+}
+
+func (d *DatabaseAdapter) GetWebhookDelivery(userID int64, webhookID int64, deliveryID int64) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	query, err := sqlQueries.ReadFile("queries/get_webhook_delivery.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.QueryRow(string(query), deliveryID, webhookID, userID).Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.Entity, &delivery.Action, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastAttemptAt,
+		&delivery.ResponseStatus, &delivery.LastError, &delivery.ReplayOf, &delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// ReplayWebhookDelivery queues a copy of the delivery and returns its id, sql.ErrNoRows if the
+// organisation has no such delivery
+func (d *DatabaseAdapter) ReplayWebhookDelivery(userID int64, webhookID int64, deliveryID int64) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/replay_webhook_delivery.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), deliveryID, webhookID, userID)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	return res.LastInsertId()
+}
+
+// DeleteWebhookDeliveriesBefore removes finished deliveries created before the given time
+func (d *DatabaseAdapter) DeleteWebhookDeliveriesBefore(before time.Time) (int64, error) {
+	query, err := sqlQueries.ReadFile("queries/delete_webhook_deliveries_before.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.db.Exec(string(query), before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var entitiesJSON []byte
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.Name,
+		&webhook.URL,
+		&entitiesJSON,
+		&webhook.Enabled,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Entities = []string{}
+	if err := json.Unmarshal(entitiesJSON, &webhook.Entities); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func marshalWebhookEntities(entities []string) ([]byte, error) {
+	if entities == nil {
+		entities = []string{}
+	}
+	return json.Marshal(entities)
+}
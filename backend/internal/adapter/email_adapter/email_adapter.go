@@ -3,12 +3,14 @@ package email_adapter
 
 import (
 	"liquiswiss/config"
+	"time"
 )
 
 type IEmailAdapter interface {
 	SendRegistrationMail(email, code string) error
 	SendPasswordResetMail(email, code string) error
 	SendInvitationMail(email, token, organisationName, invitedByName string) error
+	SendAccountLockedMail(email string, lockedFor time.Duration) error
 }
 
 func NewEmailAdapter(cfg config.Config) IEmailAdapter {
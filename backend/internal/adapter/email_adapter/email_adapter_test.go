@@ -29,6 +29,7 @@ func TestSendSkipsWhenSMTPHostEmpty(t *testing.T) {
 	require.NoError(t, a.SendRegistrationMail("user@example.com", "code123"))
 	require.NoError(t, a.SendPasswordResetMail("user@example.com", "code456"))
 	require.NoError(t, a.SendInvitationMail("user@example.com", "tok", "Acme", "Bob"))
+	require.NoError(t, a.SendAccountLockedMail("user@example.com", 30*time.Minute))
 }
 
 func TestRenderRegistrationTemplate(t *testing.T) {
@@ -174,3 +174,19 @@ func (s *smtpAdapter) SendInvitationMail(email, token, organisationName, invited
 	}
 	return s.sendHTML(email, "base.tmpl", content)
 }
+
+func (s *smtpAdapter) SendAccountLockedMail(email string, lockedFor time.Duration) error {
+	content := models.EmailContent{
+		Subject:   "Ihr Konto wurde vorübergehend gesperrt",
+		PreHeader: "Zu viele fehlgeschlagene Anmeldeversuche ...",
+		Hello:     "Guten Tag! 👋",
+		Content: fmt.Sprintf(
+			"Für Ihr Konto gab es zu viele fehlgeschlagene Anmeldeversuche, deshalb ist die Anmeldung für %s gesperrt. Waren das nicht Sie, sollten Sie Ihr Passwort zurücksetzen. Ein Administrator Ihrer Organisation kann die Sperre vorzeitig aufheben.",
+			formatValidityWindow(lockedFor),
+		),
+		ButtonText: "Passwort zurücksetzen",
+		ButtonUrl:  fmt.Sprintf("%s/auth/forgot-password", s.cfg.WebHost),
+		Greetings:  "Wir wünschen Ihnen weiterhin viel Erfolg<br/>Ihr liquiswiss.ch Team 🚀",
+	}
+	return s.sendHTML(email, "base.tmpl", content)
+}
@@ -0,0 +1,74 @@
+package fx_adapter
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// ECBDailyURL is the daily reference rate feed of the European Central Bank
+const ECBDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBProvider reads the euro reference rates, published on TARGET working days around 16:00 CET
+type ECBProvider struct {
+	url    string
+	client *http.Client
+}
+
+func NewECBProvider(url string, client *http.Client) IRateProvider {
+	if url == "" {
+		url = ECBDailyURL
+	}
+	return &ECBProvider{url: url, client: client}
+}
+
+// ecbEnvelope matches the nested Cube elements of the feed, namespaces are ignored
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *ECBProvider) Source() string {
+	return SourceECB
+}
+
+func (p *ECBProvider) Fetch(ctx context.Context) (*Snapshot, error) {
+	body, err := get(ctx, p.client, p.url)
+	if err != nil {
+		return nil, err
+	}
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if len(envelope.Cube.Days) == 0 {
+		return nil, fmt.Errorf("%w: no rates", ErrInvalidSnapshot)
+	}
+
+	// The daily feed has one day, the history feeds start with the latest
+	day := envelope.Cube.Days[0]
+	date, err := time.Parse(utils.InternalDateFormat, day.Time)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	snapshot := &Snapshot{
+		Source: SourceECB,
+		Base:   "EUR",
+		Date:   date,
+		Rates:  make(map[string]float64, len(day.Rates)),
+	}
+	for _, rate := range day.Rates {
+		snapshot.Rates[rate.Currency] = rate.Rate
+	}
+	return snapshot, snapshot.validate()
+}
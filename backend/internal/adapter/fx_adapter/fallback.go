@@ -0,0 +1,70 @@
+package fx_adapter
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+// fallbackBase is the base of the snapshot, the bundled file holds every pair
+const fallbackBase = "CHF"
+
+//go:embed fallback_rates.json
+var fallbackRatesJSON []byte
+
+type fallbackRate struct {
+	Base   string  `json:"base"`
+	Target string  `json:"target"`
+	Rate   float64 `json:"rate"`
+}
+
+type fallbackRatesFile struct {
+	SnapshotDate string         `json:"snapshot_date"`
+	Source       string         `json:"source"`
+	Rates        []fallbackRate `json:"rates"`
+}
+
+// FallbackProvider serves the bundled rates, for setups without a provider
+type FallbackProvider struct{}
+
+func NewFallbackProvider() IRateProvider {
+	return &FallbackProvider{}
+}
+
+func (p *FallbackProvider) Source() string {
+	return SourceFallback
+}
+
+func (p *FallbackProvider) Fetch(ctx context.Context) (*Snapshot, error) {
+	data, err := loadFallbackRates()
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(utils.InternalDateFormat, data.SnapshotDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	snapshot := &Snapshot{
+		Source: SourceFallback,
+		Base:   fallbackBase,
+		Date:   date,
+		Rates:  make(map[string]float64),
+	}
+	for _, rate := range data.Rates {
+		if rate.Base == fallbackBase {
+			snapshot.Rates[rate.Target] = rate.Rate
+		}
+	}
+	return snapshot, snapshot.validate()
+}
+
+func loadFallbackRates() (*fallbackRatesFile, error) {
+	var data fallbackRatesFile
+	if err := json.Unmarshal(fallbackRatesJSON, &data); err != nil {
+		return nil, fmt.Errorf("parse fallback_rates.json: %w", err)
+	}
+	return &data, nil
+}
@@ -1,4 +1,4 @@
-package fixer_io_service
+package fx_adapter
 
 import (
 	"testing"
@@ -0,0 +1,66 @@
+package fx_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FixerProvider reads the latest rates of Fixer.io in a single request. The base is the
+// default of the account (EUR on the free plan), the other pairs are cross rates.
+type FixerProvider struct {
+	url    string
+	key    string
+	client *http.Client
+}
+
+// NewFixerProvider accepts the API root or the /latest endpoint as url
+func NewFixerProvider(apiURL string, key string, client *http.Client) IRateProvider {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	if !strings.HasSuffix(apiURL, "/latest") {
+		apiURL += "/latest"
+	}
+	return &FixerProvider{url: apiURL, key: key, client: client}
+}
+
+func (p *FixerProvider) Source() string {
+	return SourceFixer
+}
+
+func (p *FixerProvider) Fetch(ctx context.Context) (*Snapshot, error) {
+	body, err := get(ctx, p.client, p.url+"?access_key="+url.QueryEscape(p.key))
+	if err != nil {
+		return nil, err
+	}
+	var response models.FixerIOResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if !response.Success {
+		if response.Error != nil {
+			return nil, fmt.Errorf("fixer error %d: %s", response.Error.Code, response.Error.Info)
+		}
+		return nil, fmt.Errorf("fixer request failed")
+	}
+	if response.Rates == nil {
+		return nil, fmt.Errorf("%w: no rates", ErrInvalidSnapshot)
+	}
+
+	date, err := time.Parse(utils.InternalDateFormat, response.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	snapshot := &Snapshot{
+		Source: SourceFixer,
+		Base:   response.Base,
+		Date:   date,
+		Rates:  *response.Rates,
+	}
+	return snapshot, snapshot.validate()
+}
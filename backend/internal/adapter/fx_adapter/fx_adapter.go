@@ -0,0 +1,156 @@
+package fx_adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"liquiswiss/config"
+	"liquiswiss/pkg/models"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	SourceECB      = "ecb"
+	SourceSNB      = "snb"
+	SourceFixer    = "fixer"
+	SourceManual   = "manual"
+	SourceFallback = models.FiatRateSourceFallback
+
+	// maxResponseSize bounds the bodies read from the providers
+	maxResponseSize = 5 << 20
+)
+
+// ErrInvalidSnapshot is returned for provider responses without usable rates
+var ErrInvalidSnapshot = errors.New("invalid rate snapshot")
+
+// IRateProvider fetches the rates of a single base currency, the rates between all other
+// currencies are derived from it with CrossRates
+type IRateProvider interface {
+	// Source identifies the provider in the stored rates and fetches
+	Source() string
+	Fetch(ctx context.Context) (*Snapshot, error)
+}
+
+// Snapshot holds how many units of each currency one unit of the base buys on a day
+type Snapshot struct {
+	Source string
+	Base   string
+	Date   time.Time
+	Rates  map[string]float64
+}
+
+// NewRateProvider returns the provider selected by FX_PROVIDER. Without one Fixer is used
+// if a key is configured, otherwise the bundled fallback rates.
+func NewRateProvider(cfg config.Config, client *http.Client) (IRateProvider, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	provider := cfg.FXProvider
+	if provider == "" {
+		provider = SourceFallback
+		if cfg.FixerIOKey != "" {
+			provider = SourceFixer
+		}
+	}
+
+	switch provider {
+	case SourceECB:
+		return NewECBProvider(cfg.FXProviderURL, client), nil
+	case SourceSNB:
+		return NewSNBProvider(cfg.FXProviderURL, client), nil
+	case SourceFixer:
+		if cfg.FixerIOKey == "" {
+			return nil, fmt.Errorf("FX_PROVIDER=%s requires FIXER_IO_KEY", provider)
+		}
+		apiURL := cfg.FixerIOURl
+		if cfg.FXProviderURL != "" {
+			apiURL = cfg.FXProviderURL
+		}
+		return NewFixerProvider(apiURL, cfg.FixerIOKey, client), nil
+	case SourceManual:
+		if cfg.FXManualRatesFile == "" {
+			return nil, fmt.Errorf("FX_PROVIDER=%s requires FX_MANUAL_RATES_FILE", provider)
+		}
+		return NewManualProvider(cfg.FXManualRatesFile), nil
+	case SourceFallback:
+		return NewFallbackProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown FX_PROVIDER %q", provider)
+	}
+}
+
+// CrossRates derives the rates between all given currencies from the snapshot. Currencies
+// the snapshot doesn't know are skipped, every known one also gets its rate to itself.
+func CrossRates(snapshot *Snapshot, currencies []string) []models.CreateFiatRate {
+	perBase := make(map[string]float64, len(snapshot.Rates)+1)
+	for currency, rate := range snapshot.Rates {
+		if rate > 0 {
+			perBase[currency] = rate
+		}
+	}
+	perBase[snapshot.Base] = 1.0
+
+	date := snapshot.Date
+	rates := make([]models.CreateFiatRate, 0)
+	for _, base := range currencies {
+		baseRate, ok := perBase[base]
+		if !ok {
+			continue
+		}
+		for _, target := range currencies {
+			targetRate, ok := perBase[target]
+			if !ok {
+				continue
+			}
+			rates = append(rates, models.CreateFiatRate{
+				Base:   base,
+				Target: target,
+				// The columns keep six decimals
+				Rate:   math.Round(targetRate/baseRate*1e6) / 1e6,
+				Source: snapshot.Source,
+				Date:   &date,
+			})
+		}
+	}
+	return rates
+}
+
+// get returns the body of a successful GET request
+func get(ctx context.Context, client *http.Client, requestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		// The URL may carry an access key, it must not end up in the logs or fetches
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return nil, urlErr.Err
+		}
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+}
+
+// validate rejects snapshots a fetch must not store
+func (s *Snapshot) validate() error {
+	if len(s.Base) != 3 {
+		return fmt.Errorf("%w: base %q", ErrInvalidSnapshot, s.Base)
+	}
+	if s.Date.IsZero() {
+		return fmt.Errorf("%w: no date", ErrInvalidSnapshot)
+	}
+	if len(s.Rates) == 0 {
+		return fmt.Errorf("%w: no rates", ErrInvalidSnapshot)
+	}
+	return nil
+}
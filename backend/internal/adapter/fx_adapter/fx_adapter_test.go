@@ -0,0 +1,155 @@
+package fx_adapter
+
+import (
+	"context"
+	"liquiswiss/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ecbFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-05-03">
+			<Cube currency="USD" rate="1.0760"/>
+			<Cube currency="CHF" rate="0.9750"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+const snbExport = `"CubeId";"devkud"
+"PublishingDate";"2024-05-06 14:30"
+
+"Date";"D0";"D1";"Value"
+"2024-05-02";"M0";"EUR1";"0.9790"
+"2024-05-03";"M0";"EUR1";"0.9750"
+"2024-05-03";"M0";"JPY100";"0.5900"
+"2024-05-06";"M0";"JPY100";""`
+
+func serve(t *testing.T, contentType string, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestECBProvider(t *testing.T) {
+	server := serve(t, "application/xml", ecbFeed)
+
+	snapshot, err := NewECBProvider(server.URL, server.Client()).Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, SourceECB, snapshot.Source)
+	require.Equal(t, "EUR", snapshot.Base)
+	require.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), snapshot.Date)
+	require.Equal(t, map[string]float64{"USD": 1.076, "CHF": 0.975}, snapshot.Rates)
+}
+
+func TestSNBProvider(t *testing.T) {
+	server := serve(t, "text/csv", snbExport)
+
+	snapshot, err := NewSNBProvider(server.URL, server.Client()).Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "CHF", snapshot.Base)
+	require.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), snapshot.Date)
+	require.InDelta(t, 1/0.975, snapshot.Rates["EUR"], 1e-9)
+	require.InDelta(t, 100/0.59, snapshot.Rates["JPY"], 1e-9)
+}
+
+func TestFixerProvider(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/latest", r.URL.Path)
+		require.Equal(t, "secret", r.URL.Query().Get("access_key"))
+		_, _ = w.Write([]byte(`{"success":true,"timestamp":1714752000,"base":"EUR","date":"2024-05-03","rates":{"CHF":0.975,"USD":1.076}}`))
+	}))
+	defer server.Close()
+
+	snapshot, err := NewFixerProvider(server.URL, "secret", server.Client()).Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+	require.Equal(t, "EUR", snapshot.Base)
+	require.Equal(t, 0.975, snapshot.Rates["CHF"])
+}
+
+func TestFixerProviderDoesNotLeakKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	_, err := NewFixerProvider(url, "secret", http.DefaultClient).Fetch(context.Background())
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret")
+}
+
+func TestProviderRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewECBProvider(server.URL, server.Client()).Fetch(context.Background())
+	require.EqualError(t, err, "unexpected status 503")
+}
+
+func TestManualProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"CHF","date":"2024-05-03","rates":{"EUR":1.05}}`), 0o600))
+
+	snapshot, err := NewManualProvider(path).Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, SourceManual, snapshot.Source)
+	require.Equal(t, map[string]float64{"EUR": 1.05}, snapshot.Rates)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"CHF","date":"2024-05-03","rates":{}}`), 0o600))
+	_, err = NewManualProvider(path).Fetch(context.Background())
+	require.ErrorIs(t, err, ErrInvalidSnapshot)
+}
+
+func TestCrossRates(t *testing.T) {
+	snapshot := &Snapshot{
+		Source: SourceECB,
+		Base:   "EUR",
+		Date:   time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		Rates:  map[string]float64{"CHF": 0.975, "USD": 1.076},
+	}
+
+	rates := CrossRates(snapshot, []string{"CHF", "USD", "XAU"})
+	// XAU is unknown to the snapshot and skipped
+	require.Len(t, rates, 4)
+
+	byPair := make(map[string]float64)
+	for _, rate := range rates {
+		require.Equal(t, SourceECB, rate.Source)
+		require.Equal(t, snapshot.Date, *rate.Date)
+		byPair[rate.Base+rate.Target] = rate.Rate
+	}
+	require.Equal(t, 1.0, byPair["CHFCHF"])
+	require.Equal(t, 1.103590, byPair["CHFUSD"])
+	require.Equal(t, 0.906134, byPair["USDCHF"])
+}
+
+func TestNewRateProvider(t *testing.T) {
+	_, err := NewRateProvider(config.Config{FXProvider: "fixer"}, nil)
+	require.Error(t, err)
+
+	provider, err := NewRateProvider(config.Config{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceFallback, provider.Source())
+
+	provider, err = NewRateProvider(config.Config{FixerIOKey: "key"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceFixer, provider.Source())
+
+	_, err = NewRateProvider(config.Config{FXProvider: "unknown"}, nil)
+	require.Error(t, err)
+}
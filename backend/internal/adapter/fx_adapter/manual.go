@@ -0,0 +1,56 @@
+package fx_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"liquiswiss/pkg/utils"
+	"os"
+	"time"
+)
+
+// ManualProvider reads the rates an operator uploaded as JSON file, e.g. for fixed
+// accounting rates or setups without internet access:
+//
+//	{"base": "CHF", "date": "2024-05-03", "rates": {"EUR": 1.05, "USD": 1.1}}
+//
+// The file is read on every fetch, so replacing it is picked up by the next run.
+type ManualProvider struct {
+	path string
+}
+
+type manualRatesFile struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func NewManualProvider(path string) IRateProvider {
+	return &ManualProvider{path: path}
+}
+
+func (p *ManualProvider) Source() string {
+	return SourceManual
+}
+
+func (p *ManualProvider) Fetch(ctx context.Context) (*Snapshot, error) {
+	body, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	var data manualRatesFile
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	date, err := time.Parse(utils.InternalDateFormat, data.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	snapshot := &Snapshot{
+		Source: SourceManual,
+		Base:   data.Base,
+		Date:   date,
+		Rates:  data.Rates,
+	}
+	return snapshot, snapshot.validate()
+}
@@ -0,0 +1,120 @@
+package fx_adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SNBDailyURL is the CSV export of the daily foreign exchange rates cube of the SNB data portal
+const SNBDailyURL = "https://data.snb.ch/api/cube/devkud/data/csv/en"
+
+// snbUnitPattern matches the currency dimension, e.g. EUR1 or JPY100 (CHF per 100 JPY)
+var snbUnitPattern = regexp.MustCompile(`^([A-Z]{3})(\d+)$`)
+
+// SNBProvider reads the CHF rates of the Swiss National Bank. The export lists CHF per unit
+// of each currency, a few days back; the latest day of every currency is used.
+type SNBProvider struct {
+	url    string
+	client *http.Client
+}
+
+func NewSNBProvider(url string, client *http.Client) IRateProvider {
+	if url == "" {
+		url = SNBDailyURL
+	}
+	return &SNBProvider{url: url, client: client}
+}
+
+func (p *SNBProvider) Source() string {
+	return SourceSNB
+}
+
+func (p *SNBProvider) Fetch(ctx context.Context) (*Snapshot, error) {
+	body, err := get(ctx, p.client, p.url)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.Comma = ';'
+	// The metadata lines before the table have fewer fields
+	reader.FieldsPerRecord = -1
+
+	snapshot := &Snapshot{Source: SourceSNB, Base: "CHF", Rates: make(map[string]float64)}
+	dates := make(map[string]time.Time)
+	var header []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+		if header == nil {
+			if len(record) > 1 && strings.EqualFold(record[0], "Date") {
+				header = record
+			}
+			continue
+		}
+		if len(record) != len(header) {
+			continue
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		date, err := time.Parse(utils.InternalDateFormat, row["Date"])
+		if err != nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(row["Value"], 64)
+		if err != nil || value <= 0 {
+			// Days without a fixing have an empty value
+			continue
+		}
+		currency, units := snbUnit(header, row)
+		if currency == "" || date.Before(dates[currency]) {
+			continue
+		}
+		// value CHF buy units of the currency
+		dates[currency] = date
+		snapshot.Rates[currency] = units / value
+		if date.After(snapshot.Date) {
+			snapshot.Date = date
+		}
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%w: no table", ErrInvalidSnapshot)
+	}
+	return snapshot, snapshot.validate()
+}
+
+// snbUnit returns the currency and units of the first dimension column holding a currency
+func snbUnit(header []string, row map[string]string) (string, float64) {
+	for _, column := range header {
+		if !strings.HasPrefix(column, "D") {
+			continue
+		}
+		match := snbUnitPattern.FindStringSubmatch(row[column])
+		if match == nil {
+			continue
+		}
+		units, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		return match[1], units
+	}
+	return "", 0
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListActuals returns the actuals booked between the optional "from" and "to" dates (YYYY-MM-DD),
+// by default those of the last twelve months
+func ListActuals(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	today := utils.GetTodayAsUTC()
+	from := c.DefaultQuery("from", time.Date(today.Year(), today.Month()-11, 1, 0, 0, 0, 0, time.UTC).Format(utils.InternalDateFormat))
+	to := c.DefaultQuery("to", today.Format(utils.InternalDateFormat))
+	validator := utils.GetValidator()
+	if validator.Var(from, "datetime=2006-01-02") != nil || validator.Var(to, "datetime=2006-01-02") != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	actuals, err := apiService.ListActuals(c.Request.Context(), userID, from, to)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, actuals)
+}
+
+func GetActual(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	actualID, err := strconv.ParseInt(c.Param("actualID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	actual, err := apiService.GetActual(c.Request.Context(), userID, actualID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, actual)
+}
+
+func CreateActual(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.CreateManualActual
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	actual, err := apiService.CreateActual(c.Request.Context(), payload, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, actual)
+}
+
+func UpdateActual(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	actualID, err := strconv.ParseInt(c.Param("actualID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	var payload models.UpdateActual
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	actual, err := apiService.UpdateActual(c.Request.Context(), payload, userID, actualID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, actual)
+}
+
+func DeleteActual(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	actualID, err := strconv.ParseInt(c.Param("actualID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteActual(c.Request.Context(), userID, actualID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+// ImportActuals expects a multipart upload with a CSV as "file"
+func ImportActuals(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Es fehlt die Datei"})
+		return
+	}
+	if fileHeader.Size > maxBankStatementSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Die Datei ist zu gross"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxBankStatementSize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	result, err := apiService.ImportActuals(c.Request.Context(), userID, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestActuals_CrossOrgIsolation verifies that actuals can only be read and changed within
+// the own organisation and never linked to transactions of another organisation
+func TestActuals_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	categoryA, err := env.APIService.CreateCategory(context.Background(), models.CreateCategory{Name: "Category A"}, &env.UserA.ID)
+	require.NoError(t, err)
+	txA, err := env.APIService.CreateTransaction(context.Background(), models.CreateTransaction{
+		Name:      "Client A",
+		Amount:    100_00,
+		Type:      "single",
+		StartDate: "2025-01-01",
+		Category:  categoryA.ID,
+		Currency:  *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	plannedDate := "2025-01-01"
+	actualA, err := env.APIService.CreateActual(context.Background(), models.CreateManualActual{
+		BookingDate: "2025-01-03",
+		Amount:      90_00,
+		Name:        "Client A AG",
+		Transaction: &txA.ID,
+		PlannedDate: &plannedDate,
+		Currency:    *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+	require.Equal(t, utils.ActualStatusDeviation, actualA.Status)
+	require.EqualValues(t, 100_00, *actualA.PlannedAmount)
+
+	// User B cannot link User A's transaction
+	_, err = env.APIService.CreateActual(context.Background(), models.CreateManualActual{
+		BookingDate: "2025-01-03",
+		Amount:      100_00,
+		Name:        "Client A AG",
+		Transaction: &txA.ID,
+		PlannedDate: &plannedDate,
+		Currency:    *env.Currency.ID,
+	}, env.UserB.ID)
+	require.Error(t, err)
+
+	// User B can neither see nor change User A's actual
+	actualsB, err := env.APIService.ListActuals(context.Background(), env.UserB.ID, "2025-01-01", "2025-01-31")
+	require.NoError(t, err)
+	require.Len(t, actualsB, 0)
+
+	_, err = env.APIService.GetActual(context.Background(), env.UserB.ID, actualA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	amount := int64(100_00)
+	_, err = env.APIService.UpdateActual(context.Background(), models.UpdateActual{Amount: &amount}, env.UserB.ID, actualA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = env.APIService.DeleteActual(context.Background(), env.UserB.ID, actualA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// User A settles the planned amount
+	updatedA, err := env.APIService.UpdateActual(context.Background(), models.UpdateActual{Amount: &amount}, env.UserA.ID, actualA.ID)
+	require.NoError(t, err)
+	require.Equal(t, utils.ActualStatusMatched, updatedA.Status)
+	require.Equal(t, txA.ID, updatedA.Transaction.ID)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListAPITokens(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	tokens, err := apiService.ListAPITokens(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateAPIToken answers with the secret of the token, it can't be retrieved later
+func CreateAPIToken(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.CreateAPIToken
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	token, err := apiService.CreateAPIToken(c.Request.Context(), payload, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organisation nicht gefunden"})
+		case errors.Is(err, api_service.ErrAPITokenScopeExceedsRole):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Das API-Token darf nicht mehr erlauben als Ihre Rolle in der Organisation"})
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, token)
+}
+
+func DeleteAPIToken(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	tokenID, err := strconv.ParseInt(c.Param("tokenID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteAPIToken(c.Request.Context(), userID, tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API-Token nicht gefunden"})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/config"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/api"
+	"liquiswiss/internal/middleware"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+)
+
+func TestAPITokenAccess(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+	middleware.InjectUserService(dbAdapter)
+	apiHandler := api.NewAPI(dbAdapter, apiService, emailService)
+
+	user, organisation, err := CreateUserWithOrganisation(apiService, dbAdapter, "api-token@api-token-test.com", "test", "API Token Org")
+	require.NoError(t, err)
+
+	readOnlyToken, err := apiService.CreateAPIToken(context.Background(), models.CreateAPIToken{
+		Name: "Nightly export", Scope: "read-only", ExpiresInDays: 30,
+	}, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, organisation.ID, readOnlyToken.OrganisationID)
+
+	request := func(method string, path string, token string) int {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString("{}"))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		apiHandler.Router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, request(http.MethodGet, "/api/transactions?page=1&limit=10", readOnlyToken.Token))
+	// Read-only tokens don't write, tokens never reach the account or admin routes
+	require.Equal(t, http.StatusForbidden, request(http.MethodPost, "/api/transactions", readOnlyToken.Token))
+	require.Equal(t, http.StatusForbidden, request(http.MethodGet, "/api/profile", readOnlyToken.Token))
+	require.Equal(t, http.StatusForbidden, request(http.MethodGet, "/api/audit-logs", readOnlyToken.Token))
+
+	tokens, err := apiService.ListAPITokens(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.NotNil(t, tokens[0].LastUsedAt)
+
+	// The token keeps acting on its organisation while the user works in another one in the web app
+	employee, err := apiService.CreateEmployee(context.Background(), models.CreateEmployee{Name: "Token Employee"}, user.ID)
+	require.NoError(t, err)
+	otherOrganisation, err := apiService.CreateOrganisation(context.Background(), models.CreateOrganisation{Name: "Other Org"}, user.ID)
+	require.NoError(t, err)
+	err = apiService.SetUserCurrentOrganisation(context.Background(), models.UpdateUserCurrentOrganisation{OrganisationID: otherOrganisation.ID}, user.ID)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/api/employees?page=1&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer "+readOnlyToken.Token)
+	w := httptest.NewRecorder()
+	apiHandler.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var employees models.ListResponse[models.Employee]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &employees))
+	require.Len(t, employees.Data, 1)
+	require.Equal(t, employee.ID, employees.Data[0].ID)
+	profile, err := apiService.GetProfile(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, otherOrganisation.ID, profile.CurrentOrganisationID)
+
+	err = apiService.DeleteAPIToken(context.Background(), user.ID, readOnlyToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/api/transactions?page=1&limit=10", readOnlyToken.Token))
+	require.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/api/transactions?page=1&limit=10", "lsw_unknown"))
+}
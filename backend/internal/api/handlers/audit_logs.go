@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAuditLogs accepts the optional filters "entity", "userID" as well as "from" and "to" (YYYY-MM-DD, inclusive)
+func ListAuditLogs(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	limit, err := strconv.ParseInt(c.Query("limit"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	page, err := strconv.ParseInt(c.Query("page"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	var filter models.AuditLogFilter
+	if entity := c.Query("entity"); entity != "" {
+		filter.Entity = &entity
+	}
+	if rawUserID := c.Query("userID"); rawUserID != "" {
+		filterUserID, err := strconv.ParseInt(rawUserID, 10, 64)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		filter.UserID = &filterUserID
+	}
+	if from := c.Query("from"); from != "" {
+		if _, err := time.Parse(utils.InternalDateFormat, from); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			return
+		}
+		filter.From = &from
+	}
+	if to := c.Query("to"); to != "" {
+		if _, err := time.Parse(utils.InternalDateFormat, to); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
+			return
+		}
+		filter.To = &to
+	}
+
+	// Action
+	auditLogs, totalCount, err := apiService.ListAuditLogs(c.Request.Context(), userID, filter, page, limit)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, models.ListResponse[models.AuditLog]{
+		Data:       auditLogs,
+		Pagination: models.CalculatePagination(page, limit, totalCount),
+	})
+}
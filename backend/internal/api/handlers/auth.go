@@ -7,7 +7,9 @@ import (
 	"liquiswiss/pkg/auth"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
+	"math"
 	"net/http"
+	"strconv"
 )
 
 func Login(apiService api_service.IAPIService, c *gin.Context) {
@@ -32,10 +34,19 @@ func Login(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	user, accessToken, accessExpirationTime, refreshToken, refreshExpirationTime, err := apiService.Login(c.Request.Context(), payload, deviceName, existingRefreshToken)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		if errors.Is(err, api_service.ErrInvalidCredentials) {
 			c.Status(http.StatusUnauthorized)
 			return
 		}
+		// The password was correct, the session is only issued after the second factor
+		var challenge *api_service.TwoFactorChallenge
+		if errors.As(err, &challenge) {
+			c.JSON(http.StatusAccepted, challenge.Challenge)
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -82,6 +93,9 @@ func ForgotPassword(apiService api_service.IAPIService, c *gin.Context) {
 	code := utils.GenerateUUID()
 	err := apiService.ForgotPassword(c.Request.Context(), payload, code)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -106,6 +120,9 @@ func ResetPassword(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	err := apiService.ResetPassword(c.Request.Context(), payload)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -130,6 +147,9 @@ func CheckResetPasswordCode(apiService api_service.IAPIService, c *gin.Context)
 	// Action
 	err := apiService.CheckResetPasswordCode(c.Request.Context(), payload)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -179,6 +199,9 @@ func CheckRegistrationCode(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	_, err := apiService.CheckRegistrationCode(c.Request.Context(), payload, utils.RegistrationCodeValidity)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		c.Status(http.StatusBadRequest)
 		return
 	}
@@ -204,6 +227,9 @@ func FinishRegistration(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	user, accessToken, accessExpirationTime, refreshToken, refreshExpirationTime, err := apiService.FinishRegistration(c.Request.Context(), payload, deviceName, utils.RegistrationCodeValidity)
 	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -216,3 +242,15 @@ func FinishRegistration(apiService api_service.IAPIService, c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
+
+// handleTooManyAttempts answers with 429 and Retry-After while the attempt limiter blocks the request
+func handleTooManyAttempts(c *gin.Context, err error) bool {
+	var tooManyAttempts *api_service.TooManyAttemptsError
+	if !errors.As(err, &tooManyAttempts) {
+		return false
+	}
+	retryAfter := int(math.Ceil(tooManyAttempts.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": "Zu viele Versuche, bitte versuchen Sie es später erneut", "retryAfter": retryAfter})
+	return true
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -163,9 +164,15 @@ func TestLoginWrongPasswordReturnsUnauthorized(t *testing.T) {
 	// bcrypt hash of "correct-password"
 	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
 	assert.NoError(t, err)
+	mockDBService.EXPECT().
+		GetAuthBlock("login", "email:user@example.com", gomock.Any()).
+		Return(time.Duration(0), nil)
 	mockDBService.EXPECT().
 		GetUserPasswordByEMail("user@example.com").
 		Return(&models.Login{ID: 1, Email: "user@example.com", Password: string(hash)}, nil)
+	mockDBService.EXPECT().
+		RecordAuthFailure("login", "email:user@example.com", gomock.Any()).
+		Return(int64(1), false, nil)
 
 	payloadBytes, err := json.Marshal(map[string]string{
 		"email":    "user@example.com",
@@ -193,9 +200,15 @@ func TestLoginUnknownEmailReturnsUnauthorized(t *testing.T) {
 	mockEmailService := mocks.NewMockIEmailAdapter(ctrl)
 	apiService := api_service.NewAPIService(mockDBService, mockEmailService)
 
+	mockDBService.EXPECT().
+		GetAuthBlock("login", "email:nobody@example.com", gomock.Any()).
+		Return(time.Duration(0), nil)
 	mockDBService.EXPECT().
 		GetUserPasswordByEMail("nobody@example.com").
 		Return(nil, sql.ErrNoRows)
+	mockDBService.EXPECT().
+		RecordAuthFailure("login", "email:nobody@example.com", gomock.Any()).
+		Return(int64(1), false, nil)
 
 	payloadBytes, err := json.Marshal(map[string]string{
 		"email":    "nobody@example.com",
@@ -223,6 +236,9 @@ func TestLoginDatabaseErrorReturnsInternalServerError(t *testing.T) {
 	mockEmailService := mocks.NewMockIEmailAdapter(ctrl)
 	apiService := api_service.NewAPIService(mockDBService, mockEmailService)
 
+	mockDBService.EXPECT().
+		GetAuthBlock("login", "email:user@example.com", gomock.Any()).
+		Return(time.Duration(0), nil)
 	mockDBService.EXPECT().
 		GetUserPasswordByEMail("user@example.com").
 		Return(nil, errors.New("database is on fire"))
@@ -243,3 +259,34 @@ func TestLoginDatabaseErrorReturnsInternalServerError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+// A blocked account answers 429 before the password is even looked at
+func TestLoginBlockedReturnsTooManyRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDBService := mocks.NewMockIDatabaseAdapter(ctrl)
+	mockEmailService := mocks.NewMockIEmailAdapter(ctrl)
+	apiService := api_service.NewAPIService(mockDBService, mockEmailService)
+
+	mockDBService.EXPECT().
+		GetAuthBlock("login", "email:user@example.com", gomock.Any()).
+		Return(90*time.Second, nil)
+
+	payloadBytes, err := json.Marshal(map[string]string{
+		"email":    "user@example.com",
+		"password": "correct-password",
+	})
+	assert.NoError(t, err)
+
+	myAPI := api.NewAPI(mockDBService, apiService, mockEmailService)
+	req, err := http.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewBuffer(payloadBytes))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	myAPI.Router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "90", w.Header().Get("Retry-After"))
+}
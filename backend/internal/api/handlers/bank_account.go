@@ -162,3 +162,108 @@ func DeleteBankAccount(apiService api_service.IAPIService, c *gin.Context) {
 	// Post
 	c.Status(http.StatusNoContent)
 }
+
+func ListBankAccountBalances(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Ungültiger Benutzer"})
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Es fehlt die ID"})
+		return
+	}
+
+	// Action
+	balances, err := apiService.ListBankAccountBalances(c.Request.Context(), userID, bankAccountID)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Kein Bankkonto gefunden mit ID: %d", bankAccountID)})
+			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// Post
+	c.JSON(http.StatusOK, balances)
+}
+
+func CreateBankAccountBalance(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	var payload models.CreateBankAccountBalance
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	balance, err := apiService.CreateBankAccountBalance(c.Request.Context(), payload, userID, bankAccountID)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Kein Bankkonto gefunden mit ID: %d", bankAccountID)})
+			return
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, balance)
+}
+
+func DeleteBankAccountBalance(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	balanceID, err := strconv.ParseInt(c.Param("balanceID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteBankAccountBalance(c.Request.Context(), userID, bankAccountID, balanceID)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.Status(http.StatusNotFound)
+			return
+		default:
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
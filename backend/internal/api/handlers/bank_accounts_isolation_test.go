@@ -158,3 +158,53 @@ func TestDeleteBankAccount_CrossOrgIsolation(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, sql.ErrNoRows)
 }
+
+// TestBankAccountBalances_CrossOrgIsolation verifies that a user cannot read, record
+// or delete balance snapshots of a bank account belonging to another organisation
+func TestBankAccountBalances_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	baA, err := env.APIService.CreateBankAccount(context.Background(), models.CreateBankAccount{
+		Name:     "Account A",
+		Amount:   100000,
+		Currency: *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	// Creating the account starts the history with the initial amount
+	balancesA, err := env.APIService.ListBankAccountBalances(context.Background(), env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Len(t, balancesA, 1)
+	require.Equal(t, int64(100000), balancesA[0].Amount)
+
+	amount := int64(50000)
+	balance, err := env.APIService.CreateBankAccountBalance(context.Background(), models.CreateBankAccountBalance{
+		Date:   "2020-01-31",
+		Amount: &amount,
+	}, env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Equal(t, baA.ID, balance.BankAccountID)
+
+	// User B cannot list, create or delete balances of User A's bank account
+	_, err = env.APIService.ListBankAccountBalances(context.Background(), env.UserB.ID, baA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = env.APIService.CreateBankAccountBalance(context.Background(), models.CreateBankAccountBalance{
+		Date:   "2020-02-29",
+		Amount: &amount,
+	}, env.UserB.ID, baA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = env.APIService.DeleteBankAccountBalance(context.Background(), env.UserB.ID, baA.ID, balance.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// User A's history is unchanged and the older snapshot did not replace the current amount
+	balancesA, err = env.APIService.ListBankAccountBalances(context.Background(), env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Len(t, balancesA, 2)
+
+	fetchedBA, err := env.APIService.GetBankAccount(context.Background(), env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), fetchedBA.Amount)
+}
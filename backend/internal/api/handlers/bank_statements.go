@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/bankstatement"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBankStatementSize limits uploaded statements, even yearly camt.053 files stay well below
+const maxBankStatementSize = 10 << 20
+
+func ListBankStatementImports(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	imports, err := apiService.ListBankStatementImports(c.Request.Context(), userID, bankAccountID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, imports)
+}
+
+func GetBankStatementImport(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	importID, err := strconv.ParseInt(c.Param("importID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	statementImport, err := apiService.GetBankStatementImport(c.Request.Context(), userID, bankAccountID, importID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, statementImport)
+}
+
+// ImportBankStatement expects a multipart upload with the statement as "file" and an optional
+// "format" (camt053, mt940 or csv) which is otherwise detected from the content
+func ImportBankStatement(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	format := c.PostForm("format")
+	switch format {
+	case "", bankstatement.FormatCamt053, bankstatement.FormatMT940, bankstatement.FormatCSV:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Format"})
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Es fehlt die Datei"})
+		return
+	}
+	if fileHeader.Size > maxBankStatementSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Die Datei ist zu gross"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxBankStatementSize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	statementImport, err := apiService.ImportBankStatement(c.Request.Context(), userID, bankAccountID, fileHeader.Filename, format, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, statementImport)
+}
+
+func DeleteBankStatementImport(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	bankAccountID, err := strconv.ParseInt(c.Param("bankAccountID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	importID, err := strconv.ParseInt(c.Param("importID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteBankStatementImport(c.Request.Context(), userID, bankAccountID, importID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
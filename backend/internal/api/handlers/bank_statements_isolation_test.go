@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/models"
+)
+
+// TestBankStatementImports_CrossOrgIsolation verifies that statements can only be imported into,
+// listed from and deleted from bank accounts of the user's own organisation
+func TestBankStatementImports_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	baA, err := env.APIService.CreateBankAccount(context.Background(), models.CreateBankAccount{
+		Name:     "Account A",
+		Amount:   100000,
+		Currency: *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	statement := []byte("date;name;amount;reference;balance\n" +
+		"2020-01-15;Client X AG;250.00;REF-1;1250.00\n" +
+		"2020-01-20;Office Rent;-50.00;REF-2;1200.00\n")
+
+	importA, err := env.APIService.ImportBankStatement(context.Background(), env.UserA.ID, baA.ID, "statement.csv", "", statement)
+	require.NoError(t, err)
+	require.Len(t, importA.Actuals, 2)
+	require.Equal(t, int64(2), importA.Unmatched)
+
+	// Importing the same bookings again skips them
+	importAgain, err := env.APIService.ImportBankStatement(context.Background(), env.UserA.ID, baA.ID, "statement.csv", "", statement)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), importAgain.Skipped)
+	require.Len(t, importAgain.Actuals, 0)
+
+	// User B can neither import into nor read or delete User A's statements
+	_, err = env.APIService.ImportBankStatement(context.Background(), env.UserB.ID, baA.ID, "statement.csv", "", statement)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = env.APIService.ListBankStatementImports(context.Background(), env.UserB.ID, baA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = env.APIService.GetBankStatementImport(context.Background(), env.UserB.ID, baA.ID, importA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = env.APIService.DeleteBankStatementImport(context.Background(), env.UserB.ID, baA.ID, importA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	importsA, err := env.APIService.ListBankStatementImports(context.Background(), env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Len(t, importsA, 2)
+
+	// The closing balance is kept in the history, the newer initial amount stays current
+	balancesA, err := env.APIService.ListBankAccountBalances(context.Background(), env.UserA.ID, baA.ID)
+	require.NoError(t, err)
+	require.Len(t, balancesA, 2)
+	require.Equal(t, int64(120000), balancesA[1].Amount)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 	"liquiswiss/internal/events"
 	"liquiswiss/internal/service/api_service"
@@ -21,6 +22,9 @@ const (
 // StreamEvents streams change notifications for the user's current organisation
 // as Server-Sent Events. Clients reconnect automatically (EventSource), which
 // re-runs the full auth middleware including the refresh token DB check.
+// Reconnects with Last-Event-ID (header or "lastEventId" query) replay the
+// missed events, a "resync" event tells the client to refetch everything
+// when they are no longer available.
 func StreamEvents(hub *events.Hub, apiService api_service.IAPIService, c *gin.Context) {
 	userID := c.GetInt64("userID")
 	if userID == 0 {
@@ -35,6 +39,10 @@ func StreamEvents(hub *events.Hub, apiService api_service.IAPIService, c *gin.Co
 	// Identifies this browser tab; used to compute the per-connection "own"
 	// flag on delivered events. MCP and other clients never send one.
 	clientID := c.Query("client")
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
 
 	// At the connection cap the hub evicts the user's oldest stream
 	sub := hub.Subscribe(userID)
@@ -75,6 +83,61 @@ func StreamEvents(hub *events.Hub, apiService api_service.IAPIService, c *gin.Co
 		return cachedOrgID, true
 	}
 
+	// lastSeq is the latest event handled by this stream, later duplicates
+	// from the channel are skipped after a replay. lastPosition is where the
+	// stream resumes after its buffer overflowed.
+	lastSeq := sub.Seq
+	lastPosition := sub.Position
+	send := func(event events.Event) {
+		if event.Seq <= lastSeq {
+			return
+		}
+		lastSeq = event.Seq
+		if event.Position != 0 {
+			lastPosition = event.Position
+		}
+		orgID, ok := currentOrgID()
+		if !ok || orgID != event.OrganisationID {
+			return
+		}
+		// Own is per connection: only the exact tab (user + client id)
+		// that caused the change sees own=true. MCP mutations carry no
+		// client id, so every tab gets own=false and shows notifications.
+		event.Own = clientID != "" && event.OriginUserID == userID && event.OriginClientID == clientID
+		// Without a position the event keeps the previous id, a reconnect
+		// resumes before it and replays it
+		var id string
+		if event.Position != 0 {
+			id = hub.EventID(event.OrganisationID, event.Position)
+		}
+		c.Render(-1, sse.Event{Id: id, Event: "change", Data: event})
+		c.Writer.Flush()
+	}
+	// replay sends the missed events or asks the client to refetch everything
+	replay := func(missed []events.Event, ok bool) {
+		if ok {
+			for _, event := range missed {
+				send(event)
+			}
+			return
+		}
+		// The client refetches everything, so it resumes from the latest position
+		orgID, _ := currentOrgID()
+		c.Render(-1, sse.Event{Id: hub.EventID(orgID, hub.Position()), Event: "resync", Data: gin.H{}})
+		c.Writer.Flush()
+	}
+	if lastEventID != "" {
+		if orgID, ok := currentOrgID(); ok {
+			missed, resumed := hub.Resume(orgID, lastEventID)
+			if resumed {
+				// The replay may reach past the position, the channel then skips those events
+				lastSeq = 0
+			}
+			replay(missed, resumed)
+			lastSeq = max(lastSeq, sub.Seq)
+		}
+	}
+
 	heartbeat := time.NewTicker(heartbeatInterval)
 	defer heartbeat.Stop()
 	expiryTimer := time.NewTimer(time.Until(deadline))
@@ -94,16 +157,13 @@ func StreamEvents(hub *events.Hub, apiService api_service.IAPIService, c *gin.Co
 			_, _ = io.WriteString(c.Writer, ": ping\n\n")
 			c.Writer.Flush()
 		case event := <-sub.Events:
+			send(event)
+		case <-sub.Lagged:
 			orgID, ok := currentOrgID()
-			if !ok || orgID != event.OrganisationID {
+			if !ok {
 				continue
 			}
-			// Own is per connection: only the exact tab (user + client id)
-			// that caused the change sees own=true. MCP mutations carry no
-			// client id, so every tab gets own=false and shows notifications.
-			event.Own = clientID != "" && event.OriginUserID == userID && event.OriginClientID == clientID
-			c.SSEvent("change", event)
-			c.Writer.Flush()
+			replay(hub.Resume(orgID, hub.EventID(orgID, lastPosition)))
 		}
 	}
 }
@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -292,3 +293,98 @@ func TestEventsStreamClosedOnOrganisationSwitch(t *testing.T) {
 		t.Fatal("stream not closed after organisation switch")
 	}
 }
+
+// openResumedStream reconnects like EventSource does, with the id of the last received event
+func (env *eventsTestEnv) openResumedStream(t *testing.T, user *models.User, lastEventID string) *bufio.Reader {
+	t.Helper()
+	accessToken, expiration, _, err := auth.GenerateAccessToken(*user)
+	require.NoError(t, err)
+	cookie := auth.GenerateCookie(utils.AccessTokenName, accessToken, expiration)
+
+	req, err := http.NewRequest(http.MethodGet, env.Server.URL+"/api/events", nil)
+	require.NoError(t, err)
+	req.AddCookie(&cookie)
+	req.Header.Set("Last-Event-ID", lastEventID)
+
+	resp, err := env.Server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return bufio.NewReader(resp.Body)
+}
+
+type streamEvent struct {
+	ID   string
+	Name string
+	Data string
+}
+
+// readEvent reads the fields of the next event, the zero value on timeout/stream end
+func readEvent(reader *bufio.Reader, timeout time.Duration) streamEvent {
+	result := make(chan streamEvent, 1)
+	go func() {
+		var event streamEvent
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				result <- streamEvent{}
+				return
+			}
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "id:"):
+				event.ID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+			case strings.HasPrefix(line, "event:"):
+				event.Name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				event.Data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			case line == "" && event.Name != "":
+				result <- event
+				return
+			}
+		}
+	}()
+	select {
+	case event := <-result:
+		return event
+	case <-time.After(timeout):
+		return streamEvent{}
+	}
+}
+
+func TestEventsStreamReplaysMissedEventsOnReconnect(t *testing.T) {
+	env := setupEventsTestEnvironment(t)
+	resp, reader := env.openStream(t, env.UserA)
+
+	_, err := env.APIService.CreateBankAccount(context.Background(), models.CreateBankAccount{
+		Name:     "Vor dem Abbruch",
+		Amount:   100,
+		Currency: env.CurrencyID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+	received := readEvent(reader, 3*time.Second)
+	require.Equal(t, "change", received.Name)
+	require.NotEmpty(t, received.ID)
+	resp.Body.Close()
+
+	// Created while the client was disconnected
+	missed, err := env.APIService.CreateBankAccount(context.Background(), models.CreateBankAccount{
+		Name:     "Während dem Abbruch",
+		Amount:   200,
+		Currency: env.CurrencyID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	replayed := readEvent(env.openResumedStream(t, env.UserA, received.ID), 3*time.Second)
+	require.Equal(t, "change", replayed.Name)
+	require.Contains(t, replayed.Data, `"entity":"bank_account"`)
+	require.Contains(t, replayed.Data, fmt.Sprintf(`"id":%d`, missed.ID))
+}
+
+func TestEventsStreamRequestsResyncForUnknownLastEventID(t *testing.T) {
+	env := setupEventsTestEnvironment(t)
+
+	event := readEvent(env.openResumedStream(t, env.UserA, "restarted-instance-1-42"), 3*time.Second)
+	require.Equal(t, "resync", event.Name)
+	require.NotEmpty(t, event.ID)
+}
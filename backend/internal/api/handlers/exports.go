@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/export"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultExportMonths is the forecast range exported when no "months" are given
+const defaultExportMonths = 12
+
+// ExportForecast exports the forecast in the query parameter "format" (csv, xlsx or pdf) for the optional number of "months"
+func ExportForecast(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	format := c.Query("format")
+	if !export.IsSupportedFormat(format) {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	months := int64(defaultExportMonths)
+	if c.Query("months") != "" {
+		parsed, err := strconv.ParseInt(c.Query("months"), 10, 64)
+		if err != nil || parsed < 1 || float64(parsed) > utils.GetTotalMonthsForMaxForecastYears() {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		months = parsed
+	}
+
+	// Action
+	report, err := apiService.ExportForecast(c.Request.Context(), userID, months)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	writeExport(c, "prognose", format, report)
+}
+
+// ExportTransactions exports all transactions in the query parameter "format" (csv, xlsx or pdf)
+func ExportTransactions(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	format := c.Query("format")
+	if !export.IsSupportedFormat(format) {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	report, err := apiService.ExportTransactions(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	writeExport(c, "transaktionen", format, report)
+}
+
+// ExportEmployees exports the employees with salaries and costs in the query parameter "format" (csv, xlsx or pdf)
+func ExportEmployees(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	format := c.Query("format")
+	if !export.IsSupportedFormat(format) {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	report, err := apiService.ExportEmployees(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	writeExport(c, "mitarbeitende", format, report)
+}
+
+// writeExport renders the report completely before anything is sent, so errors still result in a 500
+func writeExport(c *gin.Context, name string, format string, report *export.Report) {
+	var buffer bytes.Buffer
+	if err := export.Write(&buffer, format, report); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	fileName := export.FileName(name, format, report.CreatedAt)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, export.ContentType(format), buffer.Bytes())
+}
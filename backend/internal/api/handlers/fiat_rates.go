@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
 	"net/http"
+	"time"
 )
 
 func ListFiatRates(apiService api_service.IAPIService, c *gin.Context) {
@@ -38,6 +43,26 @@ func GetFiatRate(apiService api_service.IAPIService, c *gin.Context) {
 		return
 	}
 
+	// The rate valid on a past day comes from the history
+	if dateParam := c.Query("date"); dateParam != "" {
+		date, err := time.Parse(utils.InternalDateFormat, dateParam)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		fiatRate, err := apiService.GetFiatRateAt(c.Request.Context(), base, target, date)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, fiatRate)
+		return
+	}
+
 	// Action
 	fiatRate, err := apiService.GetFiatRate(c.Request.Context(), base, target)
 	if err != nil {
@@ -48,3 +73,105 @@ func GetFiatRate(apiService api_service.IAPIService, c *gin.Context) {
 	// Post
 	c.JSON(http.StatusOK, fiatRate)
 }
+
+func ListOrganisationFiatRates(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	fiatRates, err := apiService.ListOrganisationFiatRates(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, fiatRates)
+}
+
+func UpsertOrganisationFiatRate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	target := c.Param("target")
+
+	var payload models.UpsertOrganisationFiatRate
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	fiatRate, err := apiService.UpsertOrganisationFiatRate(c.Request.Context(), payload, userID, target)
+	if err != nil {
+		if errors.Is(err, api_service.ErrInvalidFiatRateTarget) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, fiatRate)
+}
+
+func DeleteOrganisationFiatRate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	target := c.Param("target")
+
+	// Action
+	err := apiService.DeleteOrganisationFiatRate(c.Request.Context(), userID, target)
+	if err != nil {
+		if errors.Is(err, api_service.ErrInvalidFiatRateTarget) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+// GetFiatRateHealth shows whether the rates are current and the latest fetches
+func GetFiatRateHealth(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	health, err := apiService.GetFiatRateHealth(c.Request.Context())
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, health)
+}
@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/config"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestFiatRateHistoryAndOrganisationRates(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+
+	january := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for date, rate := range map[time.Time]float64{january: 0.95, march: 0.97} {
+		require.NoError(t, apiService.UpsertFiatRate(context.Background(), models.CreateFiatRate{
+			Base: "CHF", Target: "EUR", Rate: rate, Date: &date,
+		}))
+	}
+
+	// The latest rate on or before the date is valid
+	fiatRate, err := apiService.GetFiatRateAt(context.Background(), "CHF", "EUR", time.Date(2024, time.February, 20, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 0.95, fiatRate.Rate)
+	require.Equal(t, "2024-01-15", fiatRate.Date.ToString())
+
+	_, err = apiService.GetFiatRateAt(context.Background(), "CHF", "EUR", time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Planning rates belong to the organisation
+	user, _, err := CreateUserWithOrganisation(apiService, dbAdapter, "rates@rates-test.com", "test", "Rates Org")
+	require.NoError(t, err)
+	otherUser, _, err := CreateUserWithOrganisation(apiService, dbAdapter, "other@rates-test.com", "test", "Other Org")
+	require.NoError(t, err)
+
+	override, err := apiService.UpsertOrganisationFiatRate(context.Background(), models.UpsertOrganisationFiatRate{Rate: 0.9}, user.ID, "EUR")
+	require.NoError(t, err)
+	require.Equal(t, "EUR", override.Target)
+	require.Equal(t, 0.9, override.Rate)
+
+	overrides, err := apiService.ListOrganisationFiatRates(context.Background(), otherUser.ID)
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+	err = apiService.DeleteOrganisationFiatRate(context.Background(), otherUser.ID, "EUR")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = apiService.DeleteOrganisationFiatRate(context.Background(), user.ID, "EUR")
+	require.NoError(t, err)
+	overrides, err = apiService.ListOrganisationFiatRates(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+}
+
+func TestFiatRateFetchHealth(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+
+	health, err := apiService.GetFiatRateHealth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.FiatRateHealthUnknown, health.Status)
+
+	today := utils.GetTodayAsUTC()
+	base := "EUR"
+	require.NoError(t, apiService.UpsertFiatRate(context.Background(), models.CreateFiatRate{
+		Base: "CHF", Target: "USD", Rate: 1.1, Source: "ecb", Date: &today,
+	}))
+	require.NoError(t, apiService.RecordFiatRateFetch(context.Background(), models.CreateFiatRateFetch{
+		Source: "ecb", Base: &base, RateDate: &today, RateCount: 1, Status: models.FiatRateFetchStatusSucceeded,
+	}))
+
+	fiatRate, err := apiService.GetFiatRate(context.Background(), "CHF", "USD")
+	require.NoError(t, err)
+	require.Equal(t, "ecb", fiatRate.Source)
+	require.Equal(t, today.Format(utils.InternalDateFormat), fiatRate.RateDate.ToString())
+
+	health, err = apiService.GetFiatRateHealth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.FiatRateHealthOK, health.Status)
+
+	fetchError := "unexpected status 503"
+	require.NoError(t, apiService.RecordFiatRateFetch(context.Background(), models.CreateFiatRateFetch{
+		Source: "ecb", Status: models.FiatRateFetchStatusFailed, Error: &fetchError,
+	}))
+
+	health, err = apiService.GetFiatRateHealth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.FiatRateHealthFailing, health.Status)
+	require.EqualValues(t, 1, health.FailedSince)
+	require.Len(t, health.RecentFetches, 2)
+	require.Equal(t, fetchError, *health.RecentFetches[0].Error)
+	require.Equal(t, models.FiatRateFetchStatusSucceeded, health.LastSuccess.Status)
+}
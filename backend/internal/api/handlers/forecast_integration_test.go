@@ -343,3 +343,52 @@ func TestCalculateForecast_VATSettlement_MonthEndDates(t *testing.T) {
 // 	require.NoError(t, err)
 // 	require.Empty(t, results, "Past billing/transaction dates should not create future VAT settlements")
 // }
+
+// TestArchiveForecasts_OnlyRecordsMonthsBeforeTheyStart verifies that a month is archived with its
+// complete plan: started months are neither added nor replaced by a later recalculation
+func TestArchiveForecasts_OnlyRecordsMonthsBeforeTheyStart(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	clock := &stubClock{fixed: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)}
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = clock
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+
+	_, err := CreateCurrency(apiService, "CHF", "Swiss Franc", "de-CH")
+	require.NoError(t, err)
+	user, _, err := CreateUserWithOrganisation(apiService, dbAdapter, "archive@example.com", "test", "Archive Org")
+	require.NoError(t, err)
+
+	upsert := func(month string, revenue int64) {
+		_, err := dbAdapter.UpsertForecast(models.CreateForecast{
+			Month: month, Period: "month", EndMonth: month, Revenue: revenue, Expense: -1, Cashflow: revenue - 1,
+		}, user.ID)
+		require.NoError(t, err)
+	}
+	upsert("2025-03", 100_00)
+	upsert("2025-04", 200_00)
+	require.NoError(t, dbAdapter.ArchiveForecasts(user.ID))
+
+	history, err := dbAdapter.ListForecastHistory(user.ID, "2025-03", "2025-04")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "2025-04", history[0].Month)
+	require.EqualValues(t, 200_00, history[0].Revenue)
+
+	// Once April started its recalculated forecast lacks the past days and must not replace the plan
+	clock.fixed = time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC)
+	upsert("2025-04", 50_00)
+	require.NoError(t, dbAdapter.ArchiveForecasts(user.ID))
+
+	history, err = dbAdapter.ListForecastHistory(user.ID, "2025-04", "2025-04")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.EqualValues(t, 200_00, history[0].Revenue)
+}
@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestForecastSnapshot_CrossOrgIsolation verifies that a user can neither list, fetch,
+// diff nor delete a forecast snapshot belonging to another organisation
+func TestForecastSnapshot_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	simulatedTime := "2025-01-01"
+	err := SetDatabaseTime(env.Conn, simulatedTime)
+	require.NoError(t, err)
+	parsedTime, err := time.Parse(utils.InternalDateFormat, simulatedTime)
+	require.NoError(t, err)
+	utils.DefaultClock.SetFixedTime(&parsedTime)
+	defer utils.DefaultClock.SetFixedTime(nil)
+
+	categoryA, err := env.APIService.CreateCategory(context.Background(), models.CreateCategory{Name: "Category A"}, &env.UserA.ID)
+	require.NoError(t, err)
+	_, err = env.APIService.CreateTransaction(context.Background(), models.CreateTransaction{
+		Name:      "Transaction A",
+		Amount:    1000_00,
+		Type:      "single",
+		StartDate: "2025-01-15",
+		Category:  categoryA.ID,
+		Currency:  *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	snapshotA, err := env.APIService.CreateForecastSnapshot(context.Background(), models.CreateForecastSnapshot{Label: "Snapshot A"}, env.UserA.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000_00), snapshotA.Forecasts[0].Data.Revenue)
+
+	snapshotB, err := env.APIService.CreateForecastSnapshot(context.Background(), models.CreateForecastSnapshot{Label: "Snapshot B"}, env.UserB.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), snapshotB.Forecasts[0].Data.Revenue)
+
+	snapshotsB, err := env.APIService.ListForecastSnapshots(context.Background(), env.UserB.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshotsB, 1)
+	require.Equal(t, snapshotB.ID, snapshotsB[0].ID)
+
+	_, err = env.APIService.GetForecastSnapshot(context.Background(), env.UserB.ID, snapshotA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = env.APIService.DiffForecastSnapshots(context.Background(), env.UserB.ID, snapshotA.ID, snapshotB.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = env.APIService.DeleteForecastSnapshot(context.Background(), env.UserB.ID, snapshotA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Snapshot A is unchanged
+	fetched, err := env.APIService.GetForecastSnapshot(context.Background(), env.UserA.ID, snapshotA.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Snapshot A", fetched.Label)
+	require.Len(t, fetched.Forecasts, len(snapshotA.Forecasts))
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"liquiswiss/internal/service/api_service"
 	"liquiswiss/pkg/models"
@@ -77,6 +78,46 @@ func CalculateForecasts(apiService api_service.IAPIService, c *gin.Context) {
 	c.JSON(http.StatusOK, foreCasts)
 }
 
+// GetForecastStatus tells whether a background recalculation of the forecast is pending, running or failed
+func GetForecastStatus(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	status, err := apiService.GetForecastStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, status)
+}
+
+// ListForecastWarnings lists the items the forecast converts with a missing, stale or fallback rate
+func ListForecastWarnings(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	warnings, err := apiService.ListForecastWarnings(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, warnings)
+}
+
 func ListForecastExclusions(apiService api_service.IAPIService, c *gin.Context) {
 	// Pre
 	userID := c.GetInt64("userID")
@@ -204,3 +245,28 @@ func DeleteForecastExclusion(apiService api_service.IAPIService, c *gin.Context)
 	// Post
 	c.Status(http.StatusNoContent)
 }
+
+// GetForecastVariance compares plan and actuals for the optional months "from" and "to" (YYYY-MM)
+func GetForecastVariance(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	report, err := apiService.GetVarianceReport(c.Request.Context(), userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		switch {
+		case errors.Is(err, api_service.ErrInvalidVarianceRange):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, report)
+}
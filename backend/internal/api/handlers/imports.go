@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize limits uploaded import files, a few thousand rows stay far below
+const maxImportSize = 10 << 20
+
+func ListImports(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	imports, err := apiService.ListImports(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, imports)
+}
+
+func GetImport(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	importID, err := strconv.ParseInt(c.Param("importID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	fileImport, err := apiService.GetImport(c.Request.Context(), userID, importID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, fileImport)
+}
+
+// PreviewTransactionImport expects a multipart upload with a CSV or XLSX file as "file"
+func PreviewTransactionImport(apiService api_service.IAPIService, c *gin.Context) {
+	previewImport(c, apiService.PreviewTransactionImport)
+}
+
+// PreviewEmployeeImport expects a multipart upload with a CSV or XLSX file as "file"
+func PreviewEmployeeImport(apiService api_service.IAPIService, c *gin.Context) {
+	previewImport(c, apiService.PreviewEmployeeImport)
+}
+
+func previewImport(c *gin.Context, preview func(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error)) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Es fehlt die Datei"})
+		return
+	}
+	if fileHeader.Size > maxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Die Datei ist zu gross"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxImportSize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	fileImport, err := preview(c.Request.Context(), userID, fileHeader.Filename, data)
+	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, fileImport)
+}
+
+func CommitImport(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	importID, err := strconv.ParseInt(c.Param("importID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	fileImport, err := apiService.CommitImport(c.Request.Context(), userID, importID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		case errors.Is(err, api_service.ErrImportCommitted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, api_service.ErrImportHasErrors):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, api_service.ErrPayrollConfidential):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, fileImport)
+}
+
+func DeleteImport(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	importID, err := strconv.ParseInt(c.Param("importID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteImport(c.Request.Context(), userID, importID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.Status(http.StatusNotFound)
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
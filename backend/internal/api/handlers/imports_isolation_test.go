@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestImport_CrossOrgIsolation verifies that names are only matched within the own organisation
+// and that a user can neither fetch, commit nor delete an import of another organisation
+func TestImport_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	simulatedTime := "2025-01-01"
+	err := SetDatabaseTime(env.Conn, simulatedTime)
+	require.NoError(t, err)
+	parsedTime, err := time.Parse(utils.InternalDateFormat, simulatedTime)
+	require.NoError(t, err)
+	utils.DefaultClock.SetFixedTime(&parsedTime)
+	defer utils.DefaultClock.SetFixedTime(nil)
+
+	_, err = env.APIService.CreateCategory(context.Background(), models.CreateCategory{Name: "Category A"}, &env.UserA.ID)
+	require.NoError(t, err)
+
+	file := []byte("Name;Kategorie;Art;Zyklus;Betrag;Währung;Start;Ende\n" +
+		"Rent;category a;Wiederkehrend;Monatlich;-1'500.00;" + *env.Currency.Code + ";01.02.2025;31.12.2025\n" +
+		"Client;Category A;Einmalig;;2500.50;;15.03.2025;\n")
+
+	importA, err := env.APIService.PreviewTransactionImport(context.Background(), env.UserA.ID, "a.csv", file)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), importA.RowCount)
+	require.Equal(t, int64(0), importA.ErrorCount)
+
+	// Category A is unknown to organisation B
+	importB, err := env.APIService.PreviewTransactionImport(context.Background(), env.UserB.ID, "b.csv", file)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), importB.ErrorCount)
+	_, err = env.APIService.CommitImport(context.Background(), env.UserB.ID, importB.ID)
+	require.ErrorIs(t, err, api_service.ErrImportHasErrors)
+
+	importsB, err := env.APIService.ListImports(context.Background(), env.UserB.ID)
+	require.NoError(t, err)
+	require.Len(t, importsB, 1)
+	require.Equal(t, importB.ID, importsB[0].ID)
+
+	_, err = env.APIService.GetImport(context.Background(), env.UserB.ID, importA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = env.APIService.CommitImport(context.Background(), env.UserB.ID, importA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	err = env.APIService.DeleteImport(context.Background(), env.UserB.ID, importA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	committed, err := env.APIService.CommitImport(context.Background(), env.UserA.ID, importA.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.ImportStatusCommitted, committed.Status)
+	_, err = env.APIService.CommitImport(context.Background(), env.UserA.ID, importA.ID)
+	require.ErrorIs(t, err, api_service.ErrImportCommitted)
+
+	transactionsA, _, err := env.APIService.ListTransactions(context.Background(), env.UserA.ID, 1, 100, "name", "ASC", "", false, false)
+	require.NoError(t, err)
+	require.Len(t, transactionsA, 2)
+	transactionsB, _, err := env.APIService.ListTransactions(context.Background(), env.UserB.ID, 1, 100, "name", "ASC", "", false, false)
+	require.NoError(t, err)
+	require.Len(t, transactionsB, 0)
+}
+
+// TestImport_EmployeesWithSalaries verifies that salaries are grouped by employee and that
+// existing employees of the organisation are extended instead of duplicated
+func TestImport_EmployeesWithSalaries(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	simulatedTime := "2025-01-01"
+	err := SetDatabaseTime(env.Conn, simulatedTime)
+	require.NoError(t, err)
+	parsedTime, err := time.Parse(utils.InternalDateFormat, simulatedTime)
+	require.NoError(t, err)
+	utils.DefaultClock.SetFixedTime(&parsedTime)
+	defer utils.DefaultClock.SetFixedTime(nil)
+
+	existing, err := CreateEmployee(env.APIService, env.UserA.ID, "Anna Muster")
+	require.NoError(t, err)
+
+	file := []byte("Mitarbeiter;Von;Bis;Zyklus;Stunden pro Monat;Ferientage;Lohn;Währung\n" +
+		"Anna Muster;01.01.2025;;Monatlich;160;25;7'000.00;\n" +
+		"Ben Beispiel;01.01.2025;;Monatlich;120;25;5'000.00;\n" +
+		"Ben Beispiel;01.07.2025;;Monatlich;160;25;6'500.00;\n" +
+		"Clara Neu;;;;;;;\n")
+
+	preview, err := env.APIService.PreviewEmployeeImport(context.Background(), env.UserA.ID, "employees.csv", file)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), preview.ErrorCount)
+	require.Equal(t, &existing.ID, preview.Employees[0].EmployeeID)
+	require.Nil(t, preview.Employees[3].Salary)
+
+	_, err = env.APIService.CommitImport(context.Background(), env.UserA.ID, preview.ID)
+	require.NoError(t, err)
+
+	employeesA, _, err := env.APIService.ListEmployees(context.Background(), env.UserA.ID, 1, 100, "name", "ASC", "", false)
+	require.NoError(t, err)
+	require.Len(t, employeesA, 3)
+	employeesB, _, err := env.APIService.ListEmployees(context.Background(), env.UserB.ID, 1, 100, "name", "ASC", "", false)
+	require.NoError(t, err)
+	require.Len(t, employeesB, 0)
+
+	for _, employee := range employeesA {
+		if employee.Name != "Ben Beispiel" {
+			continue
+		}
+		salaries, _, err := env.APIService.ListSalaries(context.Background(), env.UserA.ID, employee.ID, 1, 100)
+		require.NoError(t, err)
+		require.Len(t, salaries, 2)
+	}
+
+	// The same from date cannot be imported twice
+	again, err := env.APIService.PreviewEmployeeImport(context.Background(), env.UserA.ID, "employees.csv", file)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), again.ErrorCount)
+}
@@ -283,6 +283,7 @@ func TestMCPForecastReflectsTransactions(t *testing.T) {
 	}
 	require.True(t, foundRevenue, "monthly revenue transaction must appear in the forecast")
 	require.NotNil(t, forecast.Structured["details"])
+	require.Equal(t, models.ForecastStatusIdle, forecast.Structured["status"].(map[string]any)["status"])
 }
 
 func TestMCPSalaryTimelineSemantics(t *testing.T) {
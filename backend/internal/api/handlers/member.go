@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -126,3 +128,81 @@ func RemoveOrganisationMember(apiService api_service.IAPIService, c *gin.Context
 	// Post
 	c.Status(http.StatusNoContent)
 }
+
+func DeleteOrganisationMemberPermission(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	organisationID, err := strconv.ParseInt(c.Param("organisationID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	memberUserID, err := strconv.ParseInt(c.Param("memberUserID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeleteOrganisationMemberPermission(c.Request.Context(), userID, organisationID, memberUserID, c.Param("entityType"))
+	if err != nil {
+		if err.Error() == "permission denied" {
+			c.Status(http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+func UnlockOrganisationMember(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	organisationID, err := strconv.ParseInt(c.Param("organisationID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	memberUserID, err := strconv.ParseInt(c.Param("memberUserID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.UnlockOrganisationMember(c.Request.Context(), userID, organisationID, memberUserID)
+	if err != nil {
+		if err.Error() == "permission denied" {
+			c.Status(http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
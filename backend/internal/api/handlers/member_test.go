@@ -350,3 +350,89 @@ func TestListMembers_IncludesPermissions(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateMember_EntityPermissionsOverrideGlobal(t *testing.T) {
+	conn, apiService, dbAdapter, user, org := setupMemberDependencies(t)
+	defer conn.Close()
+
+	// Create an editor
+	memberID, err := dbAdapter.CreateUser("entityperms@test.com", "password")
+	require.NoError(t, err)
+	err = dbAdapter.AssignUserToOrganisation(memberID, org.ID, "editor", false)
+	require.NoError(t, err)
+	err = dbAdapter.SetUserCurrentOrganisation(memberID, org.ID)
+	require.NoError(t, err)
+
+	// Can edit transactions but only view employees and salaries
+	canEdit := false
+	err = apiService.UpdateOrganisationMember(context.Background(), models.UpdateMember{
+		EntityPermissions: []models.UpdateMemberEntityPermission{
+			{EntityType: models.PermissionEntityEmployee, CanEdit: &canEdit},
+			{EntityType: models.PermissionEntitySalary, CanEdit: &canEdit},
+		},
+	}, user.ID, org.ID, memberID)
+	require.NoError(t, err)
+
+	role, err := dbAdapter.GetCurrentUserRole(memberID)
+	require.NoError(t, err)
+	permissions, err := dbAdapter.ListCurrentMemberPermissions(memberID)
+	require.NoError(t, err)
+
+	transactionPermission := models.ResolvePermission(role, permissions, models.PermissionEntityTransaction)
+	require.True(t, transactionPermission.Allows(models.PermissionActionEdit))
+	employeePermission := models.ResolvePermission(role, permissions, models.PermissionEntityEmployee)
+	require.True(t, employeePermission.Allows(models.PermissionActionView))
+	require.False(t, employeePermission.Allows(models.PermissionActionEdit))
+	require.False(t, employeePermission.Allows(models.PermissionActionDelete))
+
+	members, err := apiService.ListOrganisationMembers(context.Background(), user.ID, org.ID)
+	require.NoError(t, err)
+	for _, member := range members {
+		if member.UserID == memberID {
+			require.Len(t, member.EntityPermissions, 2)
+			break
+		}
+	}
+
+	// Removing the override falls back to the role default
+	err = apiService.DeleteOrganisationMemberPermission(context.Background(), user.ID, org.ID, memberID, models.PermissionEntityEmployee)
+	require.NoError(t, err)
+	permissions, err = dbAdapter.ListCurrentMemberPermissions(memberID)
+	require.NoError(t, err)
+	require.True(t, models.ResolvePermission(role, permissions, models.PermissionEntityEmployee).Allows(models.PermissionActionEdit))
+}
+
+func TestUpdateMember_AdminCanManagePermissionsButNotRoles(t *testing.T) {
+	conn, apiService, dbAdapter, _, org := setupMemberDependencies(t)
+	defer conn.Close()
+
+	adminID, err := dbAdapter.CreateUser("permadmin@test.com", "password")
+	require.NoError(t, err)
+	err = dbAdapter.AssignUserToOrganisation(adminID, org.ID, "admin", false)
+	require.NoError(t, err)
+
+	memberID, err := dbAdapter.CreateUser("permmember@test.com", "password")
+	require.NoError(t, err)
+	err = dbAdapter.AssignUserToOrganisation(memberID, org.ID, "read-only", false)
+	require.NoError(t, err)
+
+	canEdit := true
+	err = apiService.UpdateOrganisationMember(context.Background(), models.UpdateMember{
+		EntityPermissions: []models.UpdateMemberEntityPermission{
+			{EntityType: models.PermissionEntityTransaction, CanEdit: &canEdit},
+		},
+	}, adminID, org.ID, memberID)
+	require.NoError(t, err)
+
+	permissions, err := dbAdapter.ListMemberPermissions(memberID, org.ID)
+	require.NoError(t, err)
+	require.True(t, models.ResolvePermission("read-only", permissions, models.PermissionEntityTransaction).Allows(models.PermissionActionEdit))
+	require.False(t, models.ResolvePermission("read-only", permissions, models.PermissionEntityEmployee).Allows(models.PermissionActionEdit))
+
+	newRole := "editor"
+	err = apiService.UpdateOrganisationMember(context.Background(), models.UpdateMember{
+		Role: &newRole,
+	}, adminID, org.ID, memberID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+}
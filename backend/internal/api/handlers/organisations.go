@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"liquiswiss/internal/service/api_service"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
@@ -133,7 +134,18 @@ func UpdateOrganisation(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	organisation, err := apiService.UpdateOrganisation(c.Request.Context(), payload, userID, organisationID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, api_service.ErrForecastMonthlyYearsExceedHorizon):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, api_service.ErrTwoFactorNotEnabled):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Aktivieren Sie zuerst Ihre eigene Zwei-Faktor-Authentifizierung"})
+		case errors.Is(err, api_service.ErrSSODomainNotAllowed):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Es kann nur die Domain Ihrer eigenen geschäftlichen E-Mail-Adresse verwendet werden"})
+		case errors.Is(err, api_service.ErrSSODomainTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": "Diese Domain wird bereits von einer anderen Organisation verwendet"})
+		default:
+			c.Status(http.StatusInternalServerError)
+		}
 		return
 	}
 
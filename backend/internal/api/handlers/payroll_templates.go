@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListStatutoryPayrollItems(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	year := uint64(utils.GetTodayAsUTC().Year())
+	if c.Query("year") != "" {
+		parsedYear, err := strconv.ParseUint(c.Query("year"), 10, 16)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Jahr"})
+			return
+		}
+		year = parsedYear
+	}
+
+	// Action
+	items, err := apiService.ListStatutoryPayrollItems(c.Request.Context(), uint16(year))
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, items)
+}
+
+func ListPayrollTemplates(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	templates, err := apiService.ListPayrollTemplates(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, templates)
+}
+
+func GetPayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	templateID, err := strconv.ParseInt(c.Param("payrollTemplateID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	template, err := apiService.GetPayrollTemplate(c.Request.Context(), userID, templateID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, template)
+}
+
+func CreatePayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.CreatePayrollTemplate
+	if err := c.BindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Daten", "details": err.Error()})
+		return
+	}
+
+	// Action
+	template, err := apiService.CreatePayrollTemplate(c.Request.Context(), payload, userID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, template)
+}
+
+func CreateStatutoryPayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.CreateStatutoryPayrollTemplate
+	if err := c.BindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Daten", "details": err.Error()})
+		return
+	}
+
+	// Action
+	template, err := apiService.CreateStatutoryPayrollTemplate(c.Request.Context(), payload, userID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, template)
+}
+
+func CreatePayrollTemplateVersion(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	templateID, err := strconv.ParseInt(c.Param("payrollTemplateID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	var payload models.CreatePayrollTemplateVersion
+	if err := c.BindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Daten", "details": err.Error()})
+		return
+	}
+
+	// Action
+	template, err := apiService.CreatePayrollTemplateVersion(c.Request.Context(), payload, userID, templateID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, template)
+}
+
+func UpdatePayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	templateID, err := strconv.ParseInt(c.Param("payrollTemplateID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	var payload models.CreatePayrollTemplate
+	if err := c.BindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Daten", "details": err.Error()})
+		return
+	}
+
+	// Action
+	template, err := apiService.UpdatePayrollTemplate(c.Request.Context(), payload, userID, templateID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, template)
+}
+
+func DeletePayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	templateID, err := strconv.ParseInt(c.Param("payrollTemplateID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.DeletePayrollTemplate(c.Request.Context(), userID, templateID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+func ApplyPayrollTemplate(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Ungültiger Benutzer"})
+		return
+	}
+	salaryID, err := strconv.ParseInt(c.Param("salaryID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Es fehlt die Lohn ID"})
+		return
+	}
+	var payload models.ApplyPayrollTemplate
+	if err := c.BindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Daten", "details": err.Error()})
+		return
+	}
+
+	// Action
+	salaryCosts, err := apiService.ApplyPayrollTemplate(c.Request.Context(), payload, userID, salaryID)
+	if err != nil {
+		handlePayrollTemplateError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusCreated, salaryCosts)
+}
+
+func handlePayrollTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, api_service.ErrPayrollConfidential):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+	case errors.Is(err, api_service.ErrPayrollStatutoryYearUnknown):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Für dieses Jahr sind keine gesetzlichen Ansätze hinterlegt"})
+	case errors.Is(err, api_service.ErrPayrollTemplateExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Für dieses Jahr gibt es bereits eine Vorlage mit diesem Namen"})
+	case errors.Is(err, api_service.ErrPayrollTemplateEmpty):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Die Vorlage enthält keine Lohnkosten"})
+	default:
+		c.Status(http.StatusInternalServerError)
+	}
+}
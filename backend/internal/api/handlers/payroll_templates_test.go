@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"context"
+	"liquiswiss/config"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStatutoryPayrollTemplate(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+
+	err := SetDatabaseTime(conn, "2025-01-15")
+	require.NoError(t, err)
+	databaseTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	utils.DefaultClock.SetFixedTime(&databaseTime)
+	defer utils.DefaultClock.SetFixedTime(nil)
+
+	// Preparations
+	currency, err := CreateCurrency(apiService, "CHF", "Swiss Franc", "de-CH")
+	require.NoError(t, err)
+
+	user, _, err := CreateUserWithOrganisation(
+		apiService, dbAdapter, "john@doe.com", "test", "Test Organisation",
+	)
+	require.NoError(t, err)
+
+	employee, err := apiService.CreateEmployee(context.Background(), models.CreateEmployee{
+		Name:      "Tom Riddle",
+		BirthDate: utils.StringAsPointer("1985-03-10"),
+	}, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "1985-03-10", employee.BirthDate.ToString())
+
+	salary, err := apiService.CreateSalary(context.Background(), models.CreateSalary{
+		HoursPerMonth:       160,
+		Amount:              10000_00,
+		Cycle:               utils.CycleMonthly,
+		CurrencyID:          *currency.ID,
+		VacationDaysPerYear: 25,
+		FromDate:            "2025-01-01",
+	}, user.ID, employee.ID)
+	require.NoError(t, err)
+
+	template, err := apiService.CreateStatutoryPayrollTemplate(context.Background(), models.CreateStatutoryPayrollTemplate{
+		Name: "Standard",
+		Year: 2025,
+	}, user.ID)
+	require.NoError(t, err)
+	require.Len(t, template.Items, 7)
+
+	// Tests
+	salaryCosts, err := apiService.ApplyPayrollTemplate(context.Background(), models.ApplyPayrollTemplate{
+		TemplateID: template.ID,
+	}, user.ID, salary.ID)
+	require.NoError(t, err)
+	require.Len(t, salaryCosts, 7)
+
+	amounts := make(map[string]uint64, len(salaryCosts))
+	for _, salaryCost := range salaryCosts {
+		require.NotNil(t, salaryCost.Label)
+		amounts[salaryCost.Label.Name] = salaryCost.CalculatedAmount
+	}
+	assert.Equal(t, uint64(530_00), amounts["AHV/IV/EO"])
+	// Below the ceiling of 148'200
+	assert.Equal(t, uint64(110_00), amounts["ALV"])
+	// (90'720 - 26'460) / 12 at 5% for a 40 year old
+	assert.Equal(t, uint64(267_75), amounts["BVG"])
+	// The organisation has not set its insurance rates yet
+	assert.Equal(t, uint64(0), amounts["UVG BU"])
+
+	// Applying again with replace doesn't duplicate the costs or the labels
+	salaryCosts, err = apiService.ApplyPayrollTemplate(context.Background(), models.ApplyPayrollTemplate{
+		TemplateID: template.ID,
+		Replace:    true,
+	}, user.ID, salary.ID)
+	require.NoError(t, err)
+	require.Len(t, salaryCosts, 7)
+	labels, _, err := apiService.ListSalaryCostLabels(context.Background(), user.ID, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, labels, 7)
+
+	version, err := apiService.CreatePayrollTemplateVersion(context.Background(), models.CreatePayrollTemplateVersion{
+		Year: 2026,
+	}, user.ID, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Standard", version.Name)
+	assert.Equal(t, uint16(2026), version.Year)
+	assert.Len(t, version.Items, 7)
+
+	_, err = apiService.CreatePayrollTemplateVersion(context.Background(), models.CreatePayrollTemplateVersion{
+		Year: 2026,
+	}, user.ID, template.ID)
+	assert.ErrorIs(t, err, api_service.ErrPayrollTemplateExists)
+}
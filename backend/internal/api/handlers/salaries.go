@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"liquiswiss/internal/service/api_service"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
@@ -37,6 +38,10 @@ func ListSalaries(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	salaries, totalCount, err := apiService.ListSalaries(c.Request.Context(), userID, employeeID, page, limit)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -68,6 +73,9 @@ func GetSalary(apiService api_service.IAPIService, c *gin.Context) {
 		case sql.ErrNoRows:
 			c.Status(http.StatusNotFound)
 			return
+		case api_service.ErrPayrollConfidential:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
 		default:
 			c.Status(http.StatusInternalServerError)
 			return
@@ -104,10 +112,16 @@ func CreateSalary(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	salary, err := apiService.CreateSalary(c.Request.Context(), payload, userID, employeeID)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
 			c.Status(http.StatusNotFound)
 			return
+		case errors.Is(err, api_service.ErrPayrollConfidential):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		case errors.Is(err, api_service.ErrInvalidSalaryExtraPayments):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Zusatzzahlungen", "details": err.Error()})
+			return
 		default:
 			c.Status(http.StatusInternalServerError)
 			return
@@ -144,6 +158,14 @@ func UpdateSalary(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	salary, err := apiService.UpdateSalary(c.Request.Context(), payload, userID, salaryID)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
+		if errors.Is(err, api_service.ErrInvalidSalaryExtraPayments) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Zusatzzahlungen", "details": err.Error()})
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -168,6 +190,10 @@ func DeleteSalary(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	err = apiService.DeleteSalary(c.Request.Context(), userID, salaryID)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
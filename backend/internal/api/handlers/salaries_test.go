@@ -8,6 +8,7 @@ import (
 	"liquiswiss/internal/adapter/email_adapter"
 	"liquiswiss/internal/service/api_service"
 	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
 	"liquiswiss/pkg/utils"
 	"testing"
 	"time"
@@ -387,3 +388,69 @@ func TestListEmployeesIncludesEmployerCosts(t *testing.T) {
 	assert.NotNil(t, employees[0].SalaryAmount)
 	assert.Equal(t, salary.Amount+employerCostAmount, *employees[0].SalaryAmount)
 }
+
+func TestThirteenthMonthSalaryRaisesTheCostBase(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+
+	err := SetDatabaseTime(conn, "2025-01-15")
+	assert.NoError(t, err)
+	databaseTime := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	utils.DefaultClock.SetFixedTime(&databaseTime)
+	defer utils.DefaultClock.SetFixedTime(nil)
+
+	currency, err := CreateCurrency(apiService, "CHF", "Swiss Franc", "de-CH")
+	assert.NoError(t, err)
+
+	user, _, err := CreateUserWithOrganisation(
+		apiService, dbAdapter, "john@doe.com", "test", "Test Organisation",
+	)
+	assert.NoError(t, err)
+
+	employee, err := CreateEmployee(apiService, user.ID, "Tom Riddle")
+	assert.NoError(t, err)
+
+	salary, err := apiService.CreateSalary(context.Background(), models.CreateSalary{
+		HoursPerMonth:       160,
+		Amount:              6000_00,
+		Cycle:               utils.CycleMonthly,
+		ExtraSalaries:       1,
+		ExtraSalaryPayout:   models.SalaryExtraPayoutDecember,
+		Bonuses:             []models.SalaryBonus{{Name: "Bonus", Date: types.AsDate(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)), Amount: 2000_00}},
+		CurrencyID:          *currency.ID,
+		VacationDaysPerYear: 25,
+		FromDate:            "2025-04-01",
+	}, user.ID, employee.ID)
+	assert.NoError(t, err)
+	// Entry in April pays 9 of 12 months
+	assert.Len(t, salary.ExtraPayments, 2)
+	assert.Equal(t, uint64(2000_00), salary.ExtraPayments[0].Amount)
+	assert.Equal(t, uint64(4500_00), salary.ExtraPayments[1].Amount)
+
+	salaryCost, err := apiService.CreateSalaryCost(context.Background(), models.CreateSalaryCost{
+		Cycle:            utils.CycleMonthly,
+		AmountType:       "percentage",
+		Amount:           5_300,
+		DistributionType: models.SalaryCostDistributionBoth,
+		RelativeOffset:   1,
+	}, user.ID, salary.ID)
+	assert.NoError(t, err)
+	// The regular amount isn't affected by the extra payments
+	assert.Equal(t, uint64(318_00), salaryCost.CalculatedAmount)
+
+	details := make(map[string]models.SalaryCostDetail)
+	for _, detail := range salaryCost.CalculatedCostDetails {
+		details[detail.Month] = detail
+	}
+	assert.Equal(t, uint64(318_00), details["2025-06"].Amount)
+	assert.Equal(t, uint64(0), details["2025-06"].ExtraPaymentAmount)
+	// The bonus of June is paid in July
+	assert.Equal(t, uint64(424_00), details["2025-07"].Amount)
+	// December is paid in January with the 13th month salary
+	assert.Equal(t, uint64(556_50), details["2026-01"].Amount)
+	assert.Equal(t, uint64(238_50), details["2026-01"].ExtraPaymentAmount)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"liquiswiss/internal/service/api_service"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
@@ -37,6 +38,10 @@ func ListSalaryCosts(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	salaryCosts, totalCount, err := apiService.ListSalaryCosts(c.Request.Context(), userID, salaryID, page, limit, true)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -68,6 +73,9 @@ func GetSalaryCost(apiService api_service.IAPIService, c *gin.Context) {
 		case sql.ErrNoRows:
 			c.Status(http.StatusNotFound)
 			return
+		case api_service.ErrPayrollConfidential:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
 		default:
 			c.Status(http.StatusInternalServerError)
 			return
@@ -108,6 +116,9 @@ func CreateSalaryCost(apiService api_service.IAPIService, c *gin.Context) {
 		case sql.ErrNoRows:
 			c.Status(http.StatusNotFound)
 			return
+		case api_service.ErrPayrollConfidential:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
 		default:
 			c.Status(http.StatusInternalServerError)
 			return
@@ -145,6 +156,10 @@ func UpdateSalaryCost(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	salaryCost, err := apiService.UpdateSalaryCost(c.Request.Context(), payload, userID, salaryCostID)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -169,6 +184,10 @@ func DeleteSalaryCost(apiService api_service.IAPIService, c *gin.Context) {
 	// Action
 	err = apiService.DeleteSalaryCost(c.Request.Context(), userID, salaryCostID)
 	if err != nil {
+		if errors.Is(err, api_service.ErrPayrollConfidential) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -212,6 +231,9 @@ func CopySalaryCosts(apiService api_service.IAPIService, c *gin.Context) {
 		case sql.ErrNoRows:
 			c.Status(http.StatusNotFound)
 			return
+		case api_service.ErrPayrollConfidential:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lohndaten sind vertraulich"})
+			return
 		default:
 			c.Status(http.StatusInternalServerError)
 			return
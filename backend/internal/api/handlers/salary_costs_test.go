@@ -213,7 +213,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Monthly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * utils.GetTotalMonthsForMaxForecastYears()),
+			ExpectedNextCost:              uint64(250_00 * utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)),
 			ExpectedEmployeeDeductions:    250_00,
 			ExpectedNextExecutionDate:     "2085-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -323,7 +323,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Quarterly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/3)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/3)),
 			ExpectedEmployeeDeductions:    250_00 / 3,
 			ExpectedNextExecutionDate:     "2205-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -433,7 +433,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Biannually giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/6)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/6)),
 			ExpectedEmployeeDeductions:    250_00 / 6,
 			ExpectedNextExecutionDate:     "2385-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -489,7 +489,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset fixed before salary",
 			DatabaseTime:                  "2025-06-29",
 			ExpectedCalculatedAmount:      500_00,
-			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-06-01",
 			ExpectedPreviousExecutionDate: "",
@@ -507,7 +507,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset fixed after salary",
 			DatabaseTime:                  "2025-07-01",
 			ExpectedCalculatedAmount:      500_00,
-			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-07-01",
 			ExpectedPreviousExecutionDate: "",
@@ -525,7 +525,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset percentage",
 			DatabaseTime:                  "2025-07-01",
 			ExpectedCalculatedAmount:      7500_00,
-			ExpectedNextCost:              uint64(7500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(7500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    7500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-07-01",
 			ExpectedPreviousExecutionDate: "",
@@ -543,7 +543,7 @@ func TestMonthlySalaryAtTheEndOfMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    250_00 / 12,
 			ExpectedNextExecutionDate:     "2745-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2229,7 +2229,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Monthly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * utils.GetTotalMonthsForMaxForecastYears()),
+			ExpectedNextCost:              uint64(250_00 * utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)),
 			ExpectedEmployeeDeductions:    250_00,
 			ExpectedNextExecutionDate:     "2085-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2339,7 +2339,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Quarterly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/3)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/3)),
 			ExpectedEmployeeDeductions:    250_00 / 3,
 			ExpectedNextExecutionDate:     "2205-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2449,7 +2449,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Biannually giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/6)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/6)),
 			ExpectedEmployeeDeductions:    250_00 / 6,
 			ExpectedNextExecutionDate:     "2385-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2505,7 +2505,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset fixed before salary",
 			DatabaseTime:                  "2025-06-25",
 			ExpectedCalculatedAmount:      500_00,
-			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-06-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2523,7 +2523,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset fixed after salary",
 			DatabaseTime:                  "2025-07-01",
 			ExpectedCalculatedAmount:      500_00,
-			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-07-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2541,7 +2541,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly offset percentage",
 			DatabaseTime:                  "2025-07-01",
 			ExpectedCalculatedAmount:      7500_00,
-			ExpectedNextCost:              uint64(7500_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(7500_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    7500_00 / 12,
 			ExpectedNextExecutionDate:     "2032-07-01",
 			ExpectedPreviousExecutionDate: "",
@@ -2559,7 +2559,7 @@ func TestMonthlySalaryInBetweenMonthWithoutToDate(t *testing.T) {
 			Description:                   "Yearly giga offset fixed",
 			DatabaseTime:                  "2025-01-01",
 			ExpectedCalculatedAmount:      250_00,
-			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForMaxForecastYears()/12)),
+			ExpectedNextCost:              uint64(250_00 * math.Ceil(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears)/12)),
 			ExpectedEmployeeDeductions:    250_00 / 12,
 			ExpectedNextExecutionDate:     "2745-01-01",
 			ExpectedPreviousExecutionDate: "",
@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestScenario_CrossOrgIsolation verifies that a user can neither list, fetch,
+// update nor delete a scenario belonging to another organisation
+func TestScenario_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	scenarioA, err := env.APIService.CreateScenario(context.Background(), models.CreateScenario{Name: "Scenario A"}, env.UserA.ID)
+	require.NoError(t, err)
+
+	scenariosB, err := env.APIService.ListScenarios(context.Background(), env.UserB.ID)
+	require.NoError(t, err)
+	require.Empty(t, scenariosB)
+
+	_, err = env.APIService.GetScenario(context.Background(), env.UserB.ID, scenarioA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	maliciousName := "Hacked"
+	_, err = env.APIService.UpdateScenario(context.Background(), models.UpdateScenario{Name: &maliciousName}, env.UserB.ID, scenarioA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = env.APIService.DeleteScenario(context.Background(), env.UserB.ID, scenarioA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = env.APIService.CalculateScenarioForecast(context.Background(), env.UserB.ID, scenarioA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Scenario A is unchanged
+	fetched, err := env.APIService.GetScenario(context.Background(), env.UserA.ID, scenarioA.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Scenario A", fetched.Name)
+}
+
+// TestScenarioItem_CrossOrgIsolation verifies that scenario items can neither be added
+// to foreign scenarios nor reference transactions or categories of another organisation
+func TestScenarioItem_CrossOrgIsolation(t *testing.T) {
+	env := SetupCrossOrgTestEnvironment(t)
+	defer env.Conn.Close()
+
+	categoryA, err := env.APIService.CreateCategory(context.Background(), models.CreateCategory{Name: "Category A"}, &env.UserA.ID)
+	require.NoError(t, err)
+	txA, err := env.APIService.CreateTransaction(context.Background(), models.CreateTransaction{
+		Name:      "Transaction A",
+		Amount:    100_00,
+		Type:      "single",
+		StartDate: "2025-01-01",
+		Category:  categoryA.ID,
+		Currency:  *env.Currency.ID,
+	}, env.UserA.ID)
+	require.NoError(t, err)
+
+	scenarioA, err := env.APIService.CreateScenario(context.Background(), models.CreateScenario{Name: "Scenario A"}, env.UserA.ID)
+	require.NoError(t, err)
+	scenarioB, err := env.APIService.CreateScenario(context.Background(), models.CreateScenario{Name: "Scenario B"}, env.UserB.ID)
+	require.NoError(t, err)
+
+	removeTxA := models.CreateScenarioItem{
+		Operation:    "remove",
+		RelatedTable: utils.TransactionsTableName,
+		RelatedID:    &txA.ID,
+	}
+
+	// User A can remove their own transaction within their scenario
+	itemA, err := env.APIService.CreateScenarioItem(context.Background(), removeTxA, env.UserA.ID, scenarioA.ID)
+	require.NoError(t, err)
+
+	// User B cannot add items to User A's scenario
+	_, err = env.APIService.CreateScenarioItem(context.Background(), removeTxA, env.UserB.ID, scenarioA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// User B cannot reference User A's transaction within their own scenario
+	_, err = env.APIService.CreateScenarioItem(context.Background(), removeTxA, env.UserB.ID, scenarioB.ID)
+	require.Error(t, err)
+
+	// User B cannot use User A's category for an addition
+	name := "Foreign Category"
+	amount := int64(50_00)
+	single := "single"
+	startDate := "2025-03-01"
+	_, err = env.APIService.CreateScenarioItem(context.Background(), models.CreateScenarioItem{
+		Operation:    "add",
+		RelatedTable: utils.TransactionsTableName,
+		Name:         &name,
+		Amount:       &amount,
+		Type:         &single,
+		StartDate:    &startDate,
+		Category:     &categoryA.ID,
+		Currency:     env.Currency.ID,
+	}, env.UserB.ID, scenarioB.ID)
+	require.Error(t, err)
+
+	// User B cannot delete User A's scenario item
+	err = env.APIService.DeleteScenarioItem(context.Background(), env.UserB.ID, scenarioA.ID, itemA.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	fetched, err := env.APIService.GetScenario(context.Background(), env.UserA.ID, scenarioA.ID)
+	require.NoError(t, err)
+	require.Len(t, fetched.Items, 1)
+}
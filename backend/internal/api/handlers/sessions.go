@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"liquiswiss/internal/service/api_service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListSessions(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	sessions, err := apiService.ListSessions(c.Request.Context(), userID, c.GetString("sessionID"))
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession logs out another device, the current session is only ended by logging out
+func RevokeSession(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	sessionID, err := strconv.ParseInt(c.Param("sessionID"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err = apiService.RevokeSession(c.Request.Context(), userID, sessionID, c.GetString("sessionID"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Sitzung nicht gefunden"})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+func RevokeOtherSessions(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	err := apiService.RevokeOtherSessions(c.Request.Context(), userID, c.GetString("sessionID"))
+	if err != nil {
+		if errors.Is(err, api_service.ErrCurrentSessionUnknown) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Bitte melden Sie sich neu an, um andere Sitzungen zu beenden"})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
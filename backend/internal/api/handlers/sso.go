@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"liquiswiss/config"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/internal/sso"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"net/url"
+)
+
+func ListSSOProviders(registry *sso.Registry, c *gin.Context) {
+	c.JSON(http.StatusOK, registry.List())
+}
+
+// StartSSO sends the browser to the provider, the state cookie binds the callback to this browser
+func StartSSO(registry *sso.Registry, c *gin.Context) {
+	// Pre
+	provider, err := registry.Get(c.Param("provider"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	state, err := sso.NewLoginState(provider.ID(), c.Query("redirect"))
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Action
+	authURL, err := provider.AuthCodeURL(c.Request.Context(), state)
+	if err != nil {
+		logger.Logger.Error(err)
+		redirectSSOError(c, "provider")
+		return
+	}
+	stateToken, expiresAt, err := state.Encode()
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	stateCookie := auth.GenerateCookie(sso.StateCookieName, stateToken, expiresAt)
+	http.SetCookie(c.Writer, &stateCookie)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// SSOCallback finishes the login of the provider and redirects back to the web app
+func SSOCallback(apiService api_service.IAPIService, registry *sso.Registry, c *gin.Context) {
+	// Pre
+	provider, err := registry.Get(c.Param("provider"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	stateToken, err := c.Cookie(sso.StateCookieName)
+	if err != nil {
+		redirectSSOError(c, "state")
+		return
+	}
+	// The state is single use
+	deleteStateCookie := auth.GenerateDeleteCookie(sso.StateCookieName)
+	http.SetCookie(c.Writer, &deleteStateCookie)
+	state, err := sso.DecodeLoginState(stateToken, provider.ID(), c.Query("state"))
+	if err != nil {
+		redirectSSOError(c, "state")
+		return
+	}
+	// The user cancelled or the provider refused the login
+	if c.Query("error") != "" || c.Query("code") == "" {
+		redirectSSOError(c, "cancelled")
+		return
+	}
+	deviceName := c.Request.UserAgent()
+	existingRefreshToken, err := c.Cookie(utils.RefreshTokenName)
+	if err != nil && !errors.Is(err, http.ErrNoCookie) {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Action
+	identity, err := provider.Exchange(c.Request.Context(), c.Query("code"), *state)
+	if err != nil {
+		logger.Logger.Error(err)
+		redirectSSOError(c, "provider")
+		return
+	}
+	_, accessToken, accessExpirationTime, refreshToken, refreshExpirationTime, err := apiService.LoginSSO(c.Request.Context(), *identity, deviceName, existingRefreshToken)
+	if err != nil {
+		// The web app continues with the second step like after a password login
+		var challenge *api_service.TwoFactorChallenge
+		if errors.As(err, &challenge) {
+			// The token stays out of the URL, history and logs. LoginTwoFactor reads the cookie.
+			challengeCookie := twoFactorChallengeCookie(challenge.Challenge.ChallengeToken, challenge.Challenge.ExpiresAt)
+			http.SetCookie(c.Writer, &challengeCookie)
+			query := url.Values{}
+			query.Set("twoFactor", "required")
+			query.Set("redirect", state.Redirect)
+			c.Redirect(http.StatusFound, config.GetConfig().WebHost+"/auth?"+query.Encode())
+			return
+		}
+		if errors.Is(err, api_service.ErrSSOEmailNotVerified) {
+			redirectSSOError(c, "email-not-verified")
+			return
+		}
+		redirectSSOError(c, "internal")
+		return
+	}
+
+	// Post
+	accessTokenCookie := auth.GenerateCookie(utils.AccessTokenName, *accessToken, *accessExpirationTime)
+	http.SetCookie(c.Writer, &accessTokenCookie)
+	refreshTokenCookie := auth.GenerateCookie(utils.RefreshTokenName, *refreshToken, *refreshExpirationTime)
+	http.SetCookie(c.Writer, &refreshTokenCookie)
+
+	c.Redirect(http.StatusFound, config.GetConfig().WebHost+state.Redirect)
+}
+
+// redirectSSOError shows the login page with an error code, the callback is a browser navigation
+func redirectSSOError(c *gin.Context, code string) {
+	c.Redirect(http.StatusFound, config.GetConfig().WebHost+"/auth?ssoError="+url.QueryEscape(code))
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginTwoFactor completes a login that was answered with a two-factor challenge
+func LoginTwoFactor(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	var payload models.LoginTwoFactor
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	// SSO logins pass the challenge in a cookie
+	if payload.ChallengeToken == "" {
+		payload.ChallengeToken, _ = c.Cookie(utils.TwoFactorChallengeName)
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	deviceName := c.Request.UserAgent()
+	existingRefreshToken, err := c.Cookie(utils.RefreshTokenName)
+	if err != nil && !errors.Is(err, http.ErrNoCookie) {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Action
+	user, accessToken, accessExpirationTime, refreshToken, refreshExpirationTime, err := apiService.LoginTwoFactor(c.Request.Context(), payload, deviceName, existingRefreshToken)
+	if err != nil {
+		if handleTooManyAttempts(c, err) {
+			return
+		}
+		if errors.Is(err, api_service.ErrInvalidTwoFactorCode) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Ungültiger Code"})
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	accessTokenCookie := auth.GenerateCookie(utils.AccessTokenName, *accessToken, *accessExpirationTime)
+	http.SetCookie(c.Writer, &accessTokenCookie)
+	refreshTokenCookie := auth.GenerateCookie(utils.RefreshTokenName, *refreshToken, *refreshExpirationTime)
+	http.SetCookie(c.Writer, &refreshTokenCookie)
+	if _, err := c.Cookie(utils.TwoFactorChallengeName); err == nil {
+		deleteChallengeCookie := twoFactorChallengeCookie("", time.Unix(0, 0))
+		deleteChallengeCookie.MaxAge = -1
+		http.SetCookie(c.Writer, &deleteChallengeCookie)
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// twoFactorChallengeCookie is only sent to the second step of the login and never on cross-site requests
+func twoFactorChallengeCookie(challengeToken string, expiration time.Time) http.Cookie {
+	cookie := auth.GenerateCookie(utils.TwoFactorChallengeName, challengeToken, expiration)
+	cookie.Path = "/api/auth/login/two-factor"
+	cookie.SameSite = http.SameSiteStrictMode
+	return cookie
+}
+
+func GetTwoFactorStatus(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	status, err := apiService.GetTwoFactorStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, status)
+}
+
+func SetupTwoFactor(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	// Action
+	setup, err := apiService.SetupTwoFactor(c.Request.Context(), userID)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, setup)
+}
+
+func EnableTwoFactor(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.TwoFactorCode
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	recoveryCodes, err := apiService.EnableTwoFactor(c.Request.Context(), payload, userID)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, recoveryCodes)
+}
+
+func DisableTwoFactor(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.TwoFactorCode
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	err := apiService.DisableTwoFactor(c.Request.Context(), payload, userID)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	// Post
+	c.Status(http.StatusNoContent)
+}
+
+func RegenerateTwoFactorRecoveryCodes(apiService api_service.IAPIService, c *gin.Context) {
+	// Pre
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	var payload models.TwoFactorCode
+	if err := c.BindJSON(&payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Action
+	recoveryCodes, err := apiService.RegenerateTwoFactorRecoveryCodes(c.Request.Context(), payload, userID)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	// Post
+	c.JSON(http.StatusOK, recoveryCodes)
+}
+
+func handleTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, api_service.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Code"})
+	case errors.Is(err, api_service.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "Die Zwei-Faktor-Authentifizierung ist bereits aktiviert"})
+	case errors.Is(err, api_service.ErrTwoFactorNotEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "Die Zwei-Faktor-Authentifizierung ist nicht aktiviert"})
+	case errors.Is(err, api_service.ErrTwoFactorSetupMissing):
+		c.JSON(http.StatusConflict, gin.H{"error": "Die Einrichtung wurde noch nicht gestartet"})
+	default:
+		c.Status(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/api/handlers"
+	"liquiswiss/internal/mocks"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestLoginTwoFactor_ReadsChallengeCookie verifies that the second step of an SSO login takes the
+// challenge from the cookie of the callback and removes it afterwards
+func TestLoginTwoFactor_ReadsChallengeCookie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiService := mocks.NewMockIAPIService(ctrl)
+	accessToken, refreshToken := "access", "refresh"
+	expiration := time.Now().Add(time.Hour)
+	apiService.EXPECT().
+		LoginTwoFactor(gomock.Any(), models.LoginTwoFactor{ChallengeToken: "sso-challenge", Code: "123456"}, gomock.Any(), "").
+		DoAndReturn(func(context.Context, models.LoginTwoFactor, string, string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+			return &models.User{ID: 1}, &accessToken, &expiration, &refreshToken, &expiration, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login/two-factor", bytes.NewBufferString(`{"code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: utils.TwoFactorChallengeName, Value: "sso-challenge"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handlers.LoginTwoFactor(apiService, c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	removed := false
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == utils.TwoFactorChallengeName {
+			removed = cookie.MaxAge < 0
+		}
+	}
+	require.True(t, removed, "challenge cookie must be removed after the login")
+}
@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/config"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/api"
+	"liquiswiss/internal/middleware"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// TestTwoFactorRequirement verifies that members without two-factor authentication only reach the
+// profile and the enrolment while their organisation requires it
+func TestTwoFactorRequirement(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+	middleware.InjectUserService(dbAdapter)
+	apiHandler := api.NewAPI(dbAdapter, apiService, emailService)
+
+	user, organisation, err := CreateUserWithOrganisation(apiService, dbAdapter, "two-factor@two-factor-test.com", "test", "Two Factor Org")
+	require.NoError(t, err)
+	requireTwoFactor := true
+	err = dbAdapter.UpdateOrganisation(models.UpdateOrganisation{RequireTwoFactor: &requireTwoFactor}, user.ID, organisation.ID)
+	require.NoError(t, err)
+
+	accessToken, expiration, _, err := auth.GenerateAccessToken(*user)
+	require.NoError(t, err)
+	cookie := auth.GenerateCookie(utils.AccessTokenName, accessToken, expiration)
+	request := func(method string, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(&cookie)
+		w := httptest.NewRecorder()
+		apiHandler.Router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, request(http.MethodGet, "/api/profile").Code)
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "/api/profile/two-factor/setup").Code)
+
+	// Routes without a permission check are blocked as well
+	for _, path := range []string{"/api/transactions?page=1&limit=10", fmt.Sprintf("/api/organisations/%d/members", organisation.ID)} {
+		w := request(http.MethodGet, path)
+		require.Equal(t, http.StatusForbidden, w.Code, path)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, true, response["twoFactorRequired"], path)
+	}
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/config"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+)
+
+func TestWebhookDeliveryLog(t *testing.T) {
+	conn := SetupTestEnvironment(t)
+	defer conn.Close()
+
+	dbAdapter := db_adapter.NewDatabaseAdapter(conn)
+	emailService := email_adapter.NewEmailAdapter(config.Config{})
+	apiService := api_service.NewAPIService(dbAdapter, emailService)
+	apiService.EnableWebhooks()
+
+	user, organisation, err := CreateUserWithOrganisation(apiService, dbAdapter, "webhook@webhook-test.com", "test", "Webhook Org")
+	require.NoError(t, err)
+
+	webhook, err := apiService.CreateWebhook(context.Background(), models.CreateWebhook{
+		Name: "ERP", URL: "https://erp.example.com/hooks", Entities: []string{"scenario"},
+	}, user.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.Secret)
+
+	// Only subscribed entities are queued
+	scenario, err := apiService.CreateScenario(context.Background(), models.CreateScenario{Name: "Best case"}, user.ID)
+	require.NoError(t, err)
+	_, err = apiService.CreateWebhook(context.Background(), models.CreateWebhook{
+		Name: "Second", URL: "https://second.example.com/hooks",
+	}, user.ID)
+	require.NoError(t, err)
+
+	deliveries, totalCount, err := apiService.ListWebhookDeliveries(context.Background(), user.ID, webhook.ID, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalCount)
+	require.Equal(t, models.WebhookDeliveryStatusPending, deliveries[0].Status)
+	require.NotNil(t, deliveries[0].NextAttemptAt)
+
+	var event models.WebhookEvent
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &event))
+	require.Equal(t, "scenario", event.Entity)
+	require.Equal(t, scenario.ID, event.ID)
+	require.Equal(t, organisation.ID, event.OrganisationID)
+
+	replay, err := apiService.ReplayWebhookDelivery(context.Background(), user.ID, webhook.ID, deliveries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveries[0].ID, *replay.ReplayOf)
+	require.JSONEq(t, string(deliveries[0].Payload), string(replay.Payload))
+
+	// Deliveries of other organisations are not found
+	otherUser, _, err := CreateUserWithOrganisation(apiService, dbAdapter, "other@webhook-test.com", "test", "Other Org")
+	require.NoError(t, err)
+	_, err = apiService.ReplayWebhookDelivery(context.Background(), otherUser.ID, webhook.ID, deliveries[0].ID)
+	require.Error(t, err)
+
+	err = apiService.DeleteWebhook(context.Background(), user.ID, webhook.ID)
+	require.NoError(t, err)
+	_, _, err = apiService.ListWebhookDeliveries(context.Background(), user.ID, webhook.ID, 1, 10)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	"liquiswiss/config"
 	"liquiswiss/internal/adapter/db_adapter"
 	"liquiswiss/internal/adapter/email_adapter"
 	"liquiswiss/internal/api/handlers"
@@ -10,6 +11,8 @@ import (
 	"liquiswiss/internal/middleware"
 	"liquiswiss/internal/oauth"
 	"liquiswiss/internal/service/api_service"
+	"liquiswiss/internal/sso"
+	"liquiswiss/pkg/models"
 )
 
 type API struct {
@@ -39,8 +42,17 @@ func NewAPI(
 	return api
 }
 
+// service returns the service for the request, requests with an API token act on the organisation of the token
+func (api *API) service(c *gin.Context) api_service.IAPIService {
+	if scoped, ok := middleware.ScopedDatabase(c); ok {
+		return api.APIService.WithDatabase(scoped)
+	}
+	return api.APIService
+}
+
 func (api *API) setupRouter() {
 	oauthHandler := oauth.NewHandler(api.DBService)
+	ssoRegistry := sso.NewRegistry(config.GetConfig(), nil)
 
 	// OAuth discovery endpoints must live at the root path per RFC 8414
 	api.Router.GET("/.well-known/oauth-protected-resource", oauthHandler.ProtectedResourceMetadata)
@@ -68,348 +80,599 @@ func (api *API) setupRouter() {
 		})
 
 		public := group.Group("/auth")
+		public.Use(middleware.ClientIPMiddleware)
 		{
 			public.POST("/login", func(ctx *gin.Context) {
-				handlers.Login(api.APIService, ctx)
+				handlers.Login(api.service(ctx), ctx)
+			})
+			public.POST("/login/two-factor", func(ctx *gin.Context) {
+				handlers.LoginTwoFactor(api.service(ctx), ctx)
 			})
 			public.GET("/logout", func(ctx *gin.Context) {
-				handlers.Logout(api.APIService, ctx)
+				handlers.Logout(api.service(ctx), ctx)
 			})
 			public.POST("/forgot-password", func(ctx *gin.Context) {
-				handlers.ForgotPassword(api.APIService, ctx)
+				handlers.ForgotPassword(api.service(ctx), ctx)
 			})
 			public.POST("/reset-password", func(ctx *gin.Context) {
-				handlers.ResetPassword(api.APIService, ctx)
+				handlers.ResetPassword(api.service(ctx), ctx)
 			})
 			public.POST("/reset-password-check-code", func(ctx *gin.Context) {
-				handlers.CheckResetPasswordCode(api.APIService, ctx)
+				handlers.CheckResetPasswordCode(api.service(ctx), ctx)
 			})
 
 			// Registration
 			public.POST("/registration/create", func(ctx *gin.Context) {
-				handlers.CreateRegistration(api.APIService, ctx)
+				handlers.CreateRegistration(api.service(ctx), ctx)
 			})
 			public.POST("/registration/check-code", func(ctx *gin.Context) {
-				handlers.CheckRegistrationCode(api.APIService, ctx)
+				handlers.CheckRegistrationCode(api.service(ctx), ctx)
 			})
 			public.POST("/registration/finish", func(ctx *gin.Context) {
-				handlers.FinishRegistration(api.APIService, ctx)
+				handlers.FinishRegistration(api.service(ctx), ctx)
 			})
 
 			// Invitations (public)
 			public.GET("/invitation/check", func(ctx *gin.Context) {
-				handlers.CheckInvitation(api.APIService, ctx)
+				handlers.CheckInvitation(api.service(ctx), ctx)
 			})
 			public.POST("/invitation/accept", func(ctx *gin.Context) {
-				handlers.AcceptInvitation(api.APIService, ctx)
+				handlers.AcceptInvitation(api.service(ctx), ctx)
+			})
+
+			// Single sign-on (OpenID Connect)
+			public.GET("/sso/providers", func(ctx *gin.Context) {
+				handlers.ListSSOProviders(ssoRegistry, ctx)
+			})
+			public.GET("/sso/:provider/start", func(ctx *gin.Context) {
+				handlers.StartSSO(ssoRegistry, ctx)
+			})
+			public.GET("/sso/:provider/callback", func(ctx *gin.Context) {
+				handlers.SSOCallback(api.service(ctx), ssoRegistry, ctx)
 			})
 		}
 
 		protected := group.Group("/")
 		protected.Use(middleware.AuthMiddleware)
-		// editorRoutes: mutations on organisation-scoped business data (editor+)
-		editorRoutes := protected.Group("/")
-		editorRoutes.Use(middleware.RequireMinRole(middleware.RoleEditor))
+		protected.Use(middleware.RequireTwoFactorCompliance)
+		// Organisation-scoped business data is guarded per route by the member's permission of the entity type
 		// adminRoutes: organisation + member/invitation management (admin+)
 		adminRoutes := protected.Group("/")
 		adminRoutes.Use(middleware.RequireMinRole(middleware.RoleAdmin))
+		// sessionRoutes: the account, its sessions and tokens are not reachable with a personal API token
+		sessionRoutes := protected.Group("/")
+		sessionRoutes.Use(middleware.RejectAPITokens)
 		{
 			// OAuth consent + connection management (cookie session)
-			protected.POST("/oauth/approve", oauthHandler.Approve)
-			protected.GET("/oauth/connections", oauthHandler.ListConnections)
-			protected.DELETE("/oauth/connections/:clientId", oauthHandler.RevokeConnection)
+			sessionRoutes.POST("/oauth/approve", oauthHandler.Approve)
+			sessionRoutes.GET("/oauth/connections", oauthHandler.ListConnections)
+			sessionRoutes.DELETE("/oauth/connections/:clientId", oauthHandler.RevokeConnection)
 
 			// Profile & Auth
-			protected.GET("/profile", func(ctx *gin.Context) {
-				handlers.GetProfile(api.APIService, ctx)
+			sessionRoutes.GET("/profile", func(ctx *gin.Context) {
+				handlers.GetProfile(api.service(ctx), ctx)
+			})
+			sessionRoutes.PATCH("/profile", func(ctx *gin.Context) {
+				handlers.UpdateProfile(api.service(ctx), ctx)
+			})
+			sessionRoutes.POST("/profile/password", func(ctx *gin.Context) {
+				handlers.UpdatePassword(api.service(ctx), ctx)
+			})
+			sessionRoutes.PATCH("/profile/organisation", func(ctx *gin.Context) {
+				handlers.SetUserCurrentOrganisation(api.service(ctx), ctx)
+			})
+			sessionRoutes.GET("/profile/organisation", func(ctx *gin.Context) {
+				handlers.GetUserCurrentOrganisation(api.service(ctx), ctx)
 			})
-			protected.PATCH("/profile", func(ctx *gin.Context) {
-				handlers.UpdateProfile(api.APIService, ctx)
+			sessionRoutes.GET("/profile/two-factor", func(ctx *gin.Context) {
+				handlers.GetTwoFactorStatus(api.service(ctx), ctx)
 			})
-			protected.POST("/profile/password", func(ctx *gin.Context) {
-				handlers.UpdatePassword(api.APIService, ctx)
+			sessionRoutes.POST("/profile/two-factor/setup", func(ctx *gin.Context) {
+				handlers.SetupTwoFactor(api.service(ctx), ctx)
 			})
-			protected.PATCH("/profile/organisation", func(ctx *gin.Context) {
-				handlers.SetUserCurrentOrganisation(api.APIService, ctx)
+			sessionRoutes.POST("/profile/two-factor/enable", func(ctx *gin.Context) {
+				handlers.EnableTwoFactor(api.service(ctx), ctx)
 			})
-			protected.GET("/profile/organisation", func(ctx *gin.Context) {
-				handlers.GetUserCurrentOrganisation(api.APIService, ctx)
+			sessionRoutes.POST("/profile/two-factor/disable", func(ctx *gin.Context) {
+				handlers.DisableTwoFactor(api.service(ctx), ctx)
 			})
-			protected.GET("/access-token", func(ctx *gin.Context) {
+			sessionRoutes.POST("/profile/two-factor/recovery-codes", func(ctx *gin.Context) {
+				handlers.RegenerateTwoFactorRecoveryCodes(api.service(ctx), ctx)
+			})
+			sessionRoutes.GET("/profile/sessions", func(ctx *gin.Context) {
+				handlers.ListSessions(api.service(ctx), ctx)
+			})
+			sessionRoutes.DELETE("/profile/sessions", func(ctx *gin.Context) {
+				handlers.RevokeOtherSessions(api.service(ctx), ctx)
+			})
+			sessionRoutes.DELETE("/profile/sessions/:sessionID", func(ctx *gin.Context) {
+				handlers.RevokeSession(api.service(ctx), ctx)
+			})
+			sessionRoutes.GET("/profile/api-tokens", func(ctx *gin.Context) {
+				handlers.ListAPITokens(api.service(ctx), ctx)
+			})
+			sessionRoutes.POST("/profile/api-tokens", func(ctx *gin.Context) {
+				handlers.CreateAPIToken(api.service(ctx), ctx)
+			})
+			sessionRoutes.DELETE("/profile/api-tokens/:tokenID", func(ctx *gin.Context) {
+				handlers.DeleteAPIToken(api.service(ctx), ctx)
+			})
+			sessionRoutes.GET("/access-token", func(ctx *gin.Context) {
 				handlers.GetAccessToken(ctx)
 			})
 
 			// Real-time change notifications (SSE)
-			protected.GET("/events", func(ctx *gin.Context) {
-				handlers.StreamEvents(api.EventHub, api.APIService, ctx)
+			sessionRoutes.GET("/events", func(ctx *gin.Context) {
+				handlers.StreamEvents(api.EventHub, api.service(ctx), ctx)
 			})
 
 			// Organisations
-			protected.GET("/organisations", func(ctx *gin.Context) {
-				handlers.ListOrganisations(api.APIService, ctx)
+			sessionRoutes.GET("/organisations", func(ctx *gin.Context) {
+				handlers.ListOrganisations(api.service(ctx), ctx)
 			})
-			protected.GET("/organisations/:organisationID", func(ctx *gin.Context) {
-				handlers.GetOrganisation(api.APIService, ctx)
+			sessionRoutes.GET("/organisations/:organisationID", func(ctx *gin.Context) {
+				handlers.GetOrganisation(api.service(ctx), ctx)
 			})
-			protected.POST("/organisations", func(ctx *gin.Context) {
-				handlers.CreateOrganisation(api.APIService, ctx)
+			sessionRoutes.POST("/organisations", func(ctx *gin.Context) {
+				handlers.CreateOrganisation(api.service(ctx), ctx)
 			})
 			adminRoutes.PATCH("/organisations/:organisationID", func(ctx *gin.Context) {
-				handlers.UpdateOrganisation(api.APIService, ctx)
+				handlers.UpdateOrganisation(api.service(ctx), ctx)
 			})
 			// TODO: Find a way to delete organisations by offering reassigning or transferring data
 
 			// Organisation Members
-			protected.GET("/organisations/:organisationID/members", func(ctx *gin.Context) {
-				handlers.ListOrganisationMembers(api.APIService, ctx)
+			sessionRoutes.GET("/organisations/:organisationID/members", func(ctx *gin.Context) {
+				handlers.ListOrganisationMembers(api.service(ctx), ctx)
 			})
 			adminRoutes.PATCH("/organisations/:organisationID/members/:memberUserID", func(ctx *gin.Context) {
-				handlers.UpdateOrganisationMember(api.APIService, ctx)
+				handlers.UpdateOrganisationMember(api.service(ctx), ctx)
 			})
 			adminRoutes.DELETE("/organisations/:organisationID/members/:memberUserID", func(ctx *gin.Context) {
-				handlers.RemoveOrganisationMember(api.APIService, ctx)
+				handlers.RemoveOrganisationMember(api.service(ctx), ctx)
+			})
+			adminRoutes.DELETE("/organisations/:organisationID/members/:memberUserID/permissions/:entityType", func(ctx *gin.Context) {
+				handlers.DeleteOrganisationMemberPermission(api.service(ctx), ctx)
+			})
+			adminRoutes.POST("/organisations/:organisationID/members/:memberUserID/unlock", func(ctx *gin.Context) {
+				handlers.UnlockOrganisationMember(api.service(ctx), ctx)
 			})
 
 			// Pending invitations for the current user (across any organisation)
-			protected.GET("/me/invitations", func(ctx *gin.Context) {
-				handlers.ListMyPendingInvitations(api.APIService, ctx)
+			sessionRoutes.GET("/me/invitations", func(ctx *gin.Context) {
+				handlers.ListMyPendingInvitations(api.service(ctx), ctx)
 			})
-			protected.DELETE("/me/invitations/:invitationID", func(ctx *gin.Context) {
-				handlers.DeclineMyInvitation(api.APIService, ctx)
+			sessionRoutes.DELETE("/me/invitations/:invitationID", func(ctx *gin.Context) {
+				handlers.DeclineMyInvitation(api.service(ctx), ctx)
 			})
 
 			// Organisation Invitations (admin+ only)
 			adminRoutes.GET("/organisations/:organisationID/invitations", func(ctx *gin.Context) {
-				handlers.ListOrganisationInvitations(api.APIService, ctx)
+				handlers.ListOrganisationInvitations(api.service(ctx), ctx)
 			})
 			adminRoutes.POST("/organisations/:organisationID/invitations", func(ctx *gin.Context) {
-				handlers.CreateOrganisationInvitation(api.APIService, ctx)
+				handlers.CreateOrganisationInvitation(api.service(ctx), ctx)
 			})
 			adminRoutes.DELETE("/organisations/:organisationID/invitations/:invitationID", func(ctx *gin.Context) {
-				handlers.DeleteOrganisationInvitation(api.APIService, ctx)
+				handlers.DeleteOrganisationInvitation(api.service(ctx), ctx)
 			})
 			adminRoutes.POST("/organisations/:organisationID/invitations/:invitationID/resend", func(ctx *gin.Context) {
-				handlers.ResendOrganisationInvitation(api.APIService, ctx)
+				handlers.ResendOrganisationInvitation(api.service(ctx), ctx)
+			})
+
+			// Audit Log
+			adminRoutes.GET("/audit-logs", func(ctx *gin.Context) {
+				handlers.ListAuditLogs(api.service(ctx), ctx)
+			})
+
+			// Planning rates of the organisation
+			adminRoutes.PUT("/fiat-rate-overrides/:target", func(ctx *gin.Context) {
+				handlers.UpsertOrganisationFiatRate(api.service(ctx), ctx)
+			})
+			adminRoutes.DELETE("/fiat-rate-overrides/:target", func(ctx *gin.Context) {
+				handlers.DeleteOrganisationFiatRate(api.service(ctx), ctx)
+			})
+
+			// Health of the rate fetches
+			adminRoutes.GET("/fiat-rate-health", func(ctx *gin.Context) {
+				handlers.GetFiatRateHealth(api.service(ctx), ctx)
+			})
+
+			// Webhooks
+			adminRoutes.GET("/webhooks", func(ctx *gin.Context) {
+				handlers.ListWebhooks(api.service(ctx), ctx)
+			})
+			adminRoutes.POST("/webhooks", func(ctx *gin.Context) {
+				handlers.CreateWebhook(api.service(ctx), ctx)
+			})
+			adminRoutes.PATCH("/webhooks/:webhookID", func(ctx *gin.Context) {
+				handlers.UpdateWebhook(api.service(ctx), ctx)
+			})
+			adminRoutes.DELETE("/webhooks/:webhookID", func(ctx *gin.Context) {
+				handlers.DeleteWebhook(api.service(ctx), ctx)
+			})
+			adminRoutes.POST("/webhooks/:webhookID/secret", func(ctx *gin.Context) {
+				handlers.RotateWebhookSecret(api.service(ctx), ctx)
+			})
+			adminRoutes.GET("/webhooks/:webhookID/deliveries", func(ctx *gin.Context) {
+				handlers.ListWebhookDeliveries(api.service(ctx), ctx)
+			})
+			adminRoutes.POST("/webhooks/:webhookID/deliveries/:deliveryID/replay", func(ctx *gin.Context) {
+				handlers.ReplayWebhookDelivery(api.service(ctx), ctx)
 			})
 
 			// Transactions
-			protected.GET("/transactions", func(ctx *gin.Context) {
-				handlers.ListTransactions(api.APIService, ctx)
+			protected.GET("/transactions", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListTransactions(api.service(ctx), ctx)
+			})
+			protected.GET("/transactions/export", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ExportTransactions(api.service(ctx), ctx)
 			})
-			protected.GET("/transactions/:transactionID", func(ctx *gin.Context) {
-				handlers.GetTransaction(api.APIService, ctx)
+			protected.GET("/transactions/:transactionID", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetTransaction(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/transactions", func(ctx *gin.Context) {
-				handlers.CreateTransaction(api.APIService, ctx)
+			protected.POST("/transactions", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateTransaction(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/transactions/:transactionID", func(ctx *gin.Context) {
-				handlers.UpdateTransaction(api.APIService, ctx)
+			protected.PATCH("/transactions/:transactionID", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateTransaction(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/transactions/:transactionID", func(ctx *gin.Context) {
-				handlers.DeleteTransaction(api.APIService, ctx)
+			protected.DELETE("/transactions/:transactionID", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteTransaction(api.service(ctx), ctx)
 			})
 
 			// Employees
-			protected.GET("/employees", func(ctx *gin.Context) {
-				handlers.ListEmployees(api.APIService, ctx)
+			protected.GET("/employees", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListEmployees(api.service(ctx), ctx)
 			})
-			protected.GET("/employees/:employeeID", func(ctx *gin.Context) {
-				handlers.GetEmployee(api.APIService, ctx)
+			protected.GET("/employees/export", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ExportEmployees(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/employees", func(ctx *gin.Context) {
-				handlers.CreateEmployee(api.APIService, ctx)
+			protected.GET("/employees/:employeeID", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetEmployee(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/employees/:employeeID", func(ctx *gin.Context) {
-				handlers.UpdateEmployee(api.APIService, ctx)
+			protected.POST("/employees", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateEmployee(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/employees/:employeeID", func(ctx *gin.Context) {
-				handlers.DeleteEmployee(api.APIService, ctx)
+			protected.PATCH("/employees/:employeeID", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateEmployee(api.service(ctx), ctx)
 			})
-			protected.GET("/employees/pagination", func(ctx *gin.Context) {
-				handlers.GetEmployeesPagination(api.APIService, ctx)
+			protected.DELETE("/employees/:employeeID", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteEmployee(api.service(ctx), ctx)
+			})
+			protected.GET("/employees/pagination", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetEmployeesPagination(api.service(ctx), ctx)
 			})
 
 			// Employee Salaries
-			protected.GET("/employees/:employeeID/salary", func(ctx *gin.Context) {
-				handlers.ListSalaries(api.APIService, ctx)
+			protected.GET("/employees/:employeeID/salary", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListSalaries(api.service(ctx), ctx)
 			})
-			protected.GET("/employees/salary/:salaryID", func(ctx *gin.Context) {
-				handlers.GetSalary(api.APIService, ctx)
+			protected.GET("/employees/salary/:salaryID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetSalary(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/employees/:employeeID/salary", func(ctx *gin.Context) {
-				handlers.CreateSalary(api.APIService, ctx)
+			protected.POST("/employees/:employeeID/salary", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateSalary(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/employees/salary/:salaryID", func(ctx *gin.Context) {
-				handlers.UpdateSalary(api.APIService, ctx)
+			protected.PATCH("/employees/salary/:salaryID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateSalary(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/employees/salary/:salaryID", func(ctx *gin.Context) {
-				handlers.DeleteSalary(api.APIService, ctx)
+			protected.DELETE("/employees/salary/:salaryID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteSalary(api.service(ctx), ctx)
 			})
 
 			// Employee Salary Costs
-			protected.GET("/employees/salary/:salaryID/costs", func(ctx *gin.Context) {
-				handlers.ListSalaryCosts(api.APIService, ctx)
+			protected.GET("/employees/salary/:salaryID/costs", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListSalaryCosts(api.service(ctx), ctx)
+			})
+			protected.GET("/employees/salary/costs/:salaryCostID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetSalaryCost(api.service(ctx), ctx)
 			})
-			protected.GET("/employees/salary/costs/:salaryCostID", func(ctx *gin.Context) {
-				handlers.GetSalaryCost(api.APIService, ctx)
+			protected.POST("/employees/salary/:salaryID/costs", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateSalaryCost(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/employees/salary/:salaryID/costs", func(ctx *gin.Context) {
-				handlers.CreateSalaryCost(api.APIService, ctx)
+			protected.POST("/employees/salary/:salaryID/costs/copy", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CopySalaryCosts(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/employees/salary/:salaryID/costs/copy", func(ctx *gin.Context) {
-				handlers.CopySalaryCosts(api.APIService, ctx)
+			protected.POST("/employees/salary/:salaryID/costs/template", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.ApplyPayrollTemplate(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/employees/salary/costs/:salaryCostID", func(ctx *gin.Context) {
-				handlers.UpdateSalaryCost(api.APIService, ctx)
+			protected.PATCH("/employees/salary/costs/:salaryCostID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateSalaryCost(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/employees/salary/costs/:salaryCostID", func(ctx *gin.Context) {
-				handlers.DeleteSalaryCost(api.APIService, ctx)
+			protected.DELETE("/employees/salary/costs/:salaryCostID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteSalaryCost(api.service(ctx), ctx)
 			})
 
 			// Employee Salary Cost Labels
-			protected.GET("/employees/salary/costs/labels", func(ctx *gin.Context) {
-				handlers.ListSalaryCostLabels(api.APIService, ctx)
+			protected.GET("/employees/salary/costs/labels", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListSalaryCostLabels(api.service(ctx), ctx)
 			})
-			protected.GET("/employees/salary/costs/labels/:salaryCostLabelID", func(ctx *gin.Context) {
-				handlers.GetSalaryCostLabel(api.APIService, ctx)
+			protected.GET("/employees/salary/costs/labels/:salaryCostLabelID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetSalaryCostLabel(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/employees/salary/costs/labels", func(ctx *gin.Context) {
-				handlers.CreateSalaryCostLabel(api.APIService, ctx)
+			protected.POST("/employees/salary/costs/labels", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateSalaryCostLabel(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/employees/salary/costs/labels/:salaryCostLabelID", func(ctx *gin.Context) {
-				handlers.UpdateSalaryCostLabel(api.APIService, ctx)
+			protected.PATCH("/employees/salary/costs/labels/:salaryCostLabelID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateSalaryCostLabel(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/employees/salary/costs/labels/:salaryCostLabelID", func(ctx *gin.Context) {
-				handlers.DeleteSalaryCostLabel(api.APIService, ctx)
+			protected.DELETE("/employees/salary/costs/labels/:salaryCostLabelID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteSalaryCostLabel(api.service(ctx), ctx)
+			})
+
+			// Payroll Templates
+			protected.GET("/employees/salary/costs/templates", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListPayrollTemplates(api.service(ctx), ctx)
+			})
+			protected.GET("/employees/salary/costs/templates/statutory", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListStatutoryPayrollItems(api.service(ctx), ctx)
+			})
+			protected.GET("/employees/salary/costs/templates/:payrollTemplateID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetPayrollTemplate(api.service(ctx), ctx)
+			})
+			protected.POST("/employees/salary/costs/templates", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreatePayrollTemplate(api.service(ctx), ctx)
+			})
+			protected.POST("/employees/salary/costs/templates/statutory", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateStatutoryPayrollTemplate(api.service(ctx), ctx)
+			})
+			protected.POST("/employees/salary/costs/templates/:payrollTemplateID/versions", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreatePayrollTemplateVersion(api.service(ctx), ctx)
+			})
+			protected.PATCH("/employees/salary/costs/templates/:payrollTemplateID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdatePayrollTemplate(api.service(ctx), ctx)
+			})
+			protected.DELETE("/employees/salary/costs/templates/:payrollTemplateID", middleware.RequirePermission(models.PermissionEntitySalary, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeletePayrollTemplate(api.service(ctx), ctx)
 			})
 
 			// Forecasts
-			protected.GET("/forecasts", func(ctx *gin.Context) {
-				handlers.ListForecasts(api.APIService, ctx)
+			protected.GET("/forecasts", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListForecasts(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/details", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListForecastDetails(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/calculate", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.CalculateForecasts(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/status", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetForecastStatus(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/warnings", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListForecastWarnings(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/variance", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetForecastVariance(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/export", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ExportForecast(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/exclude", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListForecastExclusions(api.service(ctx), ctx)
+			})
+			protected.POST("/forecasts/exclude", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateForecastExclusion(api.service(ctx), ctx)
+			})
+			protected.PUT("/forecasts/exclude", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateForecastExclusions(api.service(ctx), ctx)
+			})
+			protected.DELETE("/forecasts/exclude", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteForecastExclusion(api.service(ctx), ctx)
+			})
+
+			// Forecast Snapshots
+			protected.GET("/forecasts/snapshots", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListForecastSnapshots(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/snapshots/diff", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.DiffForecastSnapshots(api.service(ctx), ctx)
+			})
+			protected.GET("/forecasts/snapshots/:snapshotID", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetForecastSnapshot(api.service(ctx), ctx)
+			})
+			protected.POST("/forecasts/snapshots", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateForecastSnapshot(api.service(ctx), ctx)
+			})
+			protected.DELETE("/forecasts/snapshots/:snapshotID", middleware.RequirePermission(models.PermissionEntityForecast, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteForecastSnapshot(api.service(ctx), ctx)
+			})
+
+			// Forecast Scenarios
+			protected.GET("/scenarios", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListScenarios(api.service(ctx), ctx)
+			})
+			protected.GET("/scenarios/:scenarioID", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetScenario(api.service(ctx), ctx)
 			})
-			protected.GET("/forecasts/details", func(ctx *gin.Context) {
-				handlers.ListForecastDetails(api.APIService, ctx)
+			protected.GET("/scenarios/:scenarioID/forecast", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.CalculateScenarioForecast(api.service(ctx), ctx)
 			})
-			protected.GET("/forecasts/calculate", func(ctx *gin.Context) {
-				handlers.CalculateForecasts(api.APIService, ctx)
+			protected.POST("/scenarios", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateScenario(api.service(ctx), ctx)
 			})
-			protected.GET("/forecasts/exclude", func(ctx *gin.Context) {
-				handlers.ListForecastExclusions(api.APIService, ctx)
+			protected.PATCH("/scenarios/:scenarioID", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateScenario(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/forecasts/exclude", func(ctx *gin.Context) {
-				handlers.CreateForecastExclusion(api.APIService, ctx)
+			protected.DELETE("/scenarios/:scenarioID", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteScenario(api.service(ctx), ctx)
 			})
-			editorRoutes.PUT("/forecasts/exclude", func(ctx *gin.Context) {
-				handlers.UpdateForecastExclusions(api.APIService, ctx)
+			protected.POST("/scenarios/:scenarioID/items", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateScenarioItem(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/forecasts/exclude", func(ctx *gin.Context) {
-				handlers.DeleteForecastExclusion(api.APIService, ctx)
+			protected.DELETE("/scenarios/:scenarioID/items/:scenarioItemID", middleware.RequirePermission(models.PermissionEntityScenario, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteScenarioItem(api.service(ctx), ctx)
+			})
+
+			// Actuals
+			protected.GET("/actuals", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListActuals(api.service(ctx), ctx)
+			})
+			protected.GET("/actuals/:actualID", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetActual(api.service(ctx), ctx)
+			})
+			protected.POST("/actuals", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateActual(api.service(ctx), ctx)
+			})
+			protected.POST("/actuals/import", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.ImportActuals(api.service(ctx), ctx)
+			})
+			protected.PATCH("/actuals/:actualID", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateActual(api.service(ctx), ctx)
+			})
+			protected.DELETE("/actuals/:actualID", middleware.RequirePermission(models.PermissionEntityActual, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteActual(api.service(ctx), ctx)
 			})
 
 			// Bank Accounts
-			protected.GET("/bank-accounts", func(ctx *gin.Context) {
-				handlers.ListBankAccounts(api.APIService, ctx)
+			protected.GET("/bank-accounts", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListBankAccounts(api.service(ctx), ctx)
+			})
+			protected.GET("/bank-accounts/:bankAccountID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetBankAccount(api.service(ctx), ctx)
+			})
+			protected.POST("/bank-accounts", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateBankAccount(api.service(ctx), ctx)
+			})
+			protected.PATCH("/bank-accounts/:bankAccountID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateBankAccount(api.service(ctx), ctx)
+			})
+			protected.DELETE("/bank-accounts/:bankAccountID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteBankAccount(api.service(ctx), ctx)
 			})
-			protected.GET("/bank-accounts/:bankAccountID", func(ctx *gin.Context) {
-				handlers.GetBankAccount(api.APIService, ctx)
+			protected.GET("/bank-accounts/:bankAccountID/balances", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListBankAccountBalances(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/bank-accounts", func(ctx *gin.Context) {
-				handlers.CreateBankAccount(api.APIService, ctx)
+			protected.POST("/bank-accounts/:bankAccountID/balances", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateBankAccountBalance(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/bank-accounts/:bankAccountID", func(ctx *gin.Context) {
-				handlers.UpdateBankAccount(api.APIService, ctx)
+			protected.DELETE("/bank-accounts/:bankAccountID/balances/:balanceID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteBankAccountBalance(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/bank-accounts/:bankAccountID", func(ctx *gin.Context) {
-				handlers.DeleteBankAccount(api.APIService, ctx)
+			protected.GET("/bank-accounts/:bankAccountID/statements", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListBankStatementImports(api.service(ctx), ctx)
+			})
+			protected.GET("/bank-accounts/:bankAccountID/statements/:importID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetBankStatementImport(api.service(ctx), ctx)
+			})
+			protected.POST("/bank-accounts/:bankAccountID/statements", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.ImportBankStatement(api.service(ctx), ctx)
+			})
+			protected.DELETE("/bank-accounts/:bankAccountID/statements/:importID", middleware.RequirePermission(models.PermissionEntityBankAccount, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteBankStatementImport(api.service(ctx), ctx)
+			})
+
+			// Imports
+			protected.GET("/imports", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionView), middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListImports(api.service(ctx), ctx)
+			})
+			protected.GET("/imports/:importID", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionView), middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetImport(api.service(ctx), ctx)
+			})
+			protected.POST("/imports/transactions", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.PreviewTransactionImport(api.service(ctx), ctx)
+			})
+			protected.POST("/imports/employees", middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.PreviewEmployeeImport(api.service(ctx), ctx)
+			})
+			protected.POST("/imports/:importID/commit", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CommitImport(api.service(ctx), ctx)
+			})
+			protected.DELETE("/imports/:importID", middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), middleware.RequirePermission(models.PermissionEntityEmployee, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.DeleteImport(api.service(ctx), ctx)
 			})
 
 			// Vats
-			protected.GET("/vats", func(ctx *gin.Context) {
-				handlers.ListVats(api.APIService, ctx)
+			protected.GET("/vats", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListVats(api.service(ctx), ctx)
 			})
-			protected.GET("/vats/:vatID", func(ctx *gin.Context) {
-				handlers.GetVat(api.APIService, ctx)
+			protected.GET("/vats/:vatID", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetVat(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/vats", func(ctx *gin.Context) {
-				handlers.CreateVat(api.APIService, ctx)
+			protected.POST("/vats", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateVat(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/vats/:vatID", func(ctx *gin.Context) {
-				handlers.UpdateVat(api.APIService, ctx)
+			protected.PATCH("/vats/:vatID", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateVat(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/vats/:vatID", func(ctx *gin.Context) {
-				handlers.DeleteVat(api.APIService, ctx)
+			protected.DELETE("/vats/:vatID", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteVat(api.service(ctx), ctx)
 			})
 
 			// VAT Settings
-			protected.GET("/vat-settings", func(ctx *gin.Context) {
-				handlers.GetVatSetting(api.APIService, ctx)
+			protected.GET("/vat-settings", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetVatSetting(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/vat-settings", func(ctx *gin.Context) {
-				handlers.CreateVatSetting(api.APIService, ctx)
+			protected.POST("/vat-settings", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateVatSetting(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/vat-settings", func(ctx *gin.Context) {
-				handlers.UpdateVatSetting(api.APIService, ctx)
+			protected.PATCH("/vat-settings", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateVatSetting(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/vat-settings", func(ctx *gin.Context) {
-				handlers.DeleteVatSetting(api.APIService, ctx)
+			protected.DELETE("/vat-settings", middleware.RequirePermission(models.PermissionEntityVat, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteVatSetting(api.service(ctx), ctx)
 			})
 
 			// User Settings (global)
-			protected.GET("/user-settings", func(ctx *gin.Context) {
-				handlers.GetUserSetting(api.APIService, ctx)
+			sessionRoutes.GET("/user-settings", func(ctx *gin.Context) {
+				handlers.GetUserSetting(api.service(ctx), ctx)
 			})
-			protected.PATCH("/user-settings", func(ctx *gin.Context) {
-				handlers.UpdateUserSetting(api.APIService, ctx)
+			sessionRoutes.PATCH("/user-settings", func(ctx *gin.Context) {
+				handlers.UpdateUserSetting(api.service(ctx), ctx)
 			})
 
 			// User Organisation Settings (per-organisation)
-			protected.GET("/user-organisation-settings", func(ctx *gin.Context) {
-				handlers.GetUserOrganisationSetting(api.APIService, ctx)
+			sessionRoutes.GET("/user-organisation-settings", func(ctx *gin.Context) {
+				handlers.GetUserOrganisationSetting(api.service(ctx), ctx)
 			})
-			protected.PATCH("/user-organisation-settings", func(ctx *gin.Context) {
-				handlers.UpdateUserOrganisationSetting(api.APIService, ctx)
+			sessionRoutes.PATCH("/user-organisation-settings", func(ctx *gin.Context) {
+				handlers.UpdateUserOrganisationSetting(api.service(ctx), ctx)
 			})
 
 			// Categories
-			protected.GET("/categories", func(ctx *gin.Context) {
-				handlers.ListCategories(api.APIService, ctx)
+			protected.GET("/categories", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.ListCategories(api.service(ctx), ctx)
 			})
-			protected.GET("/categories/:categoryID", func(ctx *gin.Context) {
-				handlers.GetCategory(api.APIService, ctx)
+			protected.GET("/categories/:categoryID", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionView), func(ctx *gin.Context) {
+				handlers.GetCategory(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/categories", func(ctx *gin.Context) {
-				handlers.CreateCategory(api.APIService, ctx)
+			protected.POST("/categories", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.CreateCategory(api.service(ctx), ctx)
 			})
-			editorRoutes.PATCH("/categories/:categoryID", func(ctx *gin.Context) {
-				handlers.UpdateCategory(api.APIService, ctx)
+			protected.PATCH("/categories/:categoryID", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.UpdateCategory(api.service(ctx), ctx)
 			})
-			editorRoutes.DELETE("/categories/:categoryID", func(ctx *gin.Context) {
-				handlers.DeleteCategory(api.APIService, ctx)
+			protected.DELETE("/categories/:categoryID", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionDelete), func(ctx *gin.Context) {
+				handlers.DeleteCategory(api.service(ctx), ctx)
 			})
-			editorRoutes.POST("/categories/:categoryID/reassign", func(ctx *gin.Context) {
-				handlers.ReassignCategory(api.APIService, ctx)
+			protected.POST("/categories/:categoryID/reassign", middleware.RequirePermission(models.PermissionEntityCategory, models.PermissionActionEdit), middleware.RequirePermission(models.PermissionEntityTransaction, models.PermissionActionEdit), func(ctx *gin.Context) {
+				handlers.ReassignCategory(api.service(ctx), ctx)
 			})
 
 			// Currencies
 			protected.GET("/currencies", func(ctx *gin.Context) {
-				handlers.ListCurrencies(api.APIService, ctx)
+				handlers.ListCurrencies(api.service(ctx), ctx)
 			})
 			protected.GET("/currencies/:currencyID", func(ctx *gin.Context) {
-				handlers.GetCurrency(api.APIService, ctx)
+				handlers.GetCurrency(api.service(ctx), ctx)
 			})
 			protected.POST("/currencies", func(ctx *gin.Context) {
-				handlers.CreateCurrency(api.APIService, ctx)
+				handlers.CreateCurrency(api.service(ctx), ctx)
 			})
 			protected.PATCH("/currencies/:currencyID", func(ctx *gin.Context) {
-				handlers.UpdateCurrency(api.APIService, ctx)
+				handlers.UpdateCurrency(api.service(ctx), ctx)
 			})
 
 			// Fiat Rates
 			protected.GET("/fiat-rates/:base", func(ctx *gin.Context) {
-				handlers.ListFiatRates(api.APIService, ctx)
+				handlers.ListFiatRates(api.service(ctx), ctx)
 			})
 			protected.GET("/fiat-rates/:base/:target", func(ctx *gin.Context) {
-				handlers.GetFiatRate(api.APIService, ctx)
+				handlers.GetFiatRate(api.service(ctx), ctx)
+			})
+			protected.GET("/fiat-rate-overrides", func(ctx *gin.Context) {
+				handlers.ListOrganisationFiatRates(api.service(ctx), ctx)
 			})
 		}
 	}
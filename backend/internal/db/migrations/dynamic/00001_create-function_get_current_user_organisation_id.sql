@@ -7,10 +7,16 @@ BEGIN
     DECLARE v_organisation_id BIGINT UNSIGNED;
     DECLARE v_is_member INT;
 
-    SELECT current_organisation_id
-    INTO v_organisation_id
-    FROM users
-    WHERE id = p_user_id;
+    -- Connections scoped to an organisation (e.g. requests with an API token) act on it
+    -- instead of the organisation the user currently selected in the web app
+    IF @scoped_organisation_id IS NOT NULL THEN
+        SET v_organisation_id = @scoped_organisation_id;
+    ELSE
+        SELECT current_organisation_id
+        INTO v_organisation_id
+        FROM users
+        WHERE id = p_user_id;
+    END IF;
 
     IF v_organisation_id IS NULL THEN
         RETURN NULL;
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS scenarios (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_Scenario_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS scenario_items (
+    id SERIAL PRIMARY KEY,
+    operation ENUM('add', 'remove', 'override') NOT NULL,
+    related_table ENUM('transactions', 'salaries', 'salary_costs') NOT NULL,
+    -- Points to the overlaid base item for removals and overrides (no FK because it is polymorphic)
+    related_id BIGINT UNSIGNED,
+    name VARCHAR(255),
+    amount BIGINT,
+    cycle ENUM('monthly', 'quarterly', 'biannually', 'yearly'),
+    type ENUM('single', 'repeating'),
+    start_date DATE,
+    end_date DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    scenario_id BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED,
+    currency_id BIGINT UNSIGNED,
+
+    CONSTRAINT FK_ScenarioItem_Scenario FOREIGN KEY (scenario_id) REFERENCES scenarios (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_ScenarioItem_Category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT FK_ScenarioItem_Currency FOREIGN KEY (currency_id) REFERENCES currencies (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+
+    -- A base item can only be removed or overridden once per scenario
+    CONSTRAINT UQ_ScenarioItem_Related UNIQUE (scenario_id, related_table, related_id),
+
+    CONSTRAINT CK_ScenarioItem_Related_Required CHECK (operation = 'add' OR related_id IS NOT NULL),
+    CONSTRAINT CK_ScenarioItem_Cycle_Required CHECK (type IS NULL OR type != 'repeating' OR cycle IS NOT NULL)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scenario_items;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scenarios;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bank_account_balances (
+    id SERIAL PRIMARY KEY,
+    balance_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    bank_account_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_BankAccountBalance_BankAccount FOREIGN KEY (bank_account_id) REFERENCES bank_accounts (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT UQ_BankAccountBalance_Date UNIQUE (bank_account_id, balance_date)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Seed the history with the current balance so existing accounts keep their value
+INSERT INTO bank_account_balances (balance_date, amount, bank_account_id)
+SELECT CURDATE(), ba.amount, ba.id
+FROM bank_accounts ba;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bank_account_balances;
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bank_statement_imports (
+    id SERIAL PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format ENUM('camt053', 'mt940', 'csv') NOT NULL,
+    opening_balance BIGINT,
+    closing_balance BIGINT,
+    closing_date DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    bank_account_id BIGINT UNSIGNED NOT NULL,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_BankStatementImport_BankAccount FOREIGN KEY (bank_account_id) REFERENCES bank_accounts (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_BankStatementImport_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Realised bookings, optionally linked to the planned transaction occurrence they settle
+CREATE TABLE IF NOT EXISTS actuals (
+    id SERIAL PRIMARY KEY,
+    booking_date DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    reference VARCHAR(255),
+    status ENUM('matched', 'deviation', 'unmatched') NOT NULL DEFAULT 'unmatched',
+    -- The planned occurrence and amount (in the currency of the actual) the booking was matched to
+    planned_date DATE,
+    planned_amount BIGINT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    transaction_id BIGINT UNSIGNED,
+    bank_account_id BIGINT UNSIGNED,
+    bank_statement_import_id BIGINT UNSIGNED,
+    currency_id BIGINT UNSIGNED NOT NULL,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_Actual_Transaction FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT FK_Actual_BankAccount FOREIGN KEY (bank_account_id) REFERENCES bank_accounts (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_Actual_BankStatementImport FOREIGN KEY (bank_statement_import_id) REFERENCES bank_statement_imports (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT FK_Actual_Currency FOREIGN KEY (currency_id) REFERENCES currencies (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT FK_Actual_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    -- Re-importing an overlapping statement must not duplicate bookings
+    CONSTRAINT UQ_Actual_Reference UNIQUE (bank_account_id, reference),
+
+    INDEX IDX_Actual_Transaction_PlannedDate (transaction_id, planned_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS actuals;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bank_statement_imports;
+-- +goose StatementEnd
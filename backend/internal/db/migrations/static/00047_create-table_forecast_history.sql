@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keeps the plan of every month as it stood when the month began, recalculations do not change started months
+CREATE TABLE IF NOT EXISTS forecast_history (
+    id SERIAL PRIMARY KEY,
+    month VARCHAR(7) NOT NULL,
+    revenue BIGINT NOT NULL,
+    expense BIGINT NOT NULL,
+    cashflow BIGINT NOT NULL,
+    revenue_details JSON NOT NULL,
+    expense_details JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_ForecastHistory_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT UQ_ForecastHistory_Month UNIQUE (organisation_id, month)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Start the history with the forecasts that are still available
+INSERT INTO forecast_history (month, revenue, expense, cashflow, revenue_details, expense_details, organisation_id)
+SELECT f.month, f.revenue, f.expense, f.cashflow, COALESCE(fd.revenue, JSON_ARRAY()), COALESCE(fd.expense, JSON_ARRAY()), f.organisation_id
+FROM forecasts f
+LEFT JOIN forecast_details fd ON fd.forecast_id = f.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS forecast_history;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshots are immutable copies of a forecast run, recalculations never touch them
+CREATE TABLE IF NOT EXISTS forecast_snapshots (
+    id SERIAL PRIMARY KEY,
+    label VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    opening_balance BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    created_by BIGINT UNSIGNED,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_ForecastSnapshot_User FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT FK_ForecastSnapshot_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS forecast_snapshot_months (
+    id SERIAL PRIMARY KEY,
+    month VARCHAR(7) NOT NULL,
+    revenue BIGINT NOT NULL,
+    expense BIGINT NOT NULL,
+    cashflow BIGINT NOT NULL,
+    opening_balance BIGINT NOT NULL,
+    closing_balance BIGINT NOT NULL,
+    revenue_details JSON NOT NULL,
+    expense_details JSON NOT NULL,
+
+    snapshot_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_ForecastSnapshotMonth_Snapshot FOREIGN KEY (snapshot_id) REFERENCES forecast_snapshots (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT UQ_ForecastSnapshotMonth_Month UNIQUE (snapshot_id, month)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS forecast_snapshot_months;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS forecast_snapshots;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Imports keep the parsed rows of an uploaded file between the preview and the commit
+CREATE TABLE IF NOT EXISTS imports (
+    id SERIAL PRIMARY KEY,
+    entity ENUM('transactions', 'employees') NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    status ENUM('preview', 'committed') NOT NULL DEFAULT 'preview',
+    `rows` JSON NOT NULL,
+    row_count INT UNSIGNED NOT NULL DEFAULT 0,
+    error_count INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    committed_at TIMESTAMP NULL,
+
+    created_by BIGINT UNSIGNED,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_Import_User FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT FK_Import_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS imports;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The first forecast_monthly_years stay monthly, the rest of the horizon is aggregated into quarters or years
+ALTER TABLE organisations
+    ADD COLUMN IF NOT EXISTS forecast_years TINYINT UNSIGNED NOT NULL DEFAULT 3 AFTER main_currency_id,
+    ADD COLUMN IF NOT EXISTS forecast_monthly_years TINYINT UNSIGNED NOT NULL DEFAULT 3 AFTER forecast_years,
+    ADD COLUMN IF NOT EXISTS forecast_bucket ENUM('quarter', 'year') NOT NULL DEFAULT 'quarter' AFTER forecast_monthly_years;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- A forecast row covers the months from month to end_month
+ALTER TABLE forecasts
+    ADD COLUMN IF NOT EXISTS period ENUM('month', 'quarter', 'year') NOT NULL DEFAULT 'month' AFTER month,
+    ADD COLUMN IF NOT EXISTS end_month VARCHAR(7) AFTER period;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE forecasts SET end_month = month WHERE end_month IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE forecasts
+    MODIFY COLUMN end_month VARCHAR(7) NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE forecast_snapshot_months
+    ADD COLUMN IF NOT EXISTS period ENUM('month', 'quarter', 'year') NOT NULL DEFAULT 'month' AFTER month,
+    ADD COLUMN IF NOT EXISTS end_month VARCHAR(7) AFTER period;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE forecast_snapshot_months SET end_month = month WHERE end_month IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE forecast_snapshot_months
+    MODIFY COLUMN end_month VARCHAR(7) NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS forecast_snapshot_months
+    DROP COLUMN IF EXISTS end_month,
+    DROP COLUMN IF EXISTS period;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS forecasts
+    DROP COLUMN IF EXISTS end_month,
+    DROP COLUMN IF EXISTS period;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS organisations
+    DROP COLUMN IF EXISTS forecast_bucket,
+    DROP COLUMN IF EXISTS forecast_monthly_years,
+    DROP COLUMN IF EXISTS forecast_years;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every mutation of an organisation is recorded along with the changed fields
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id SERIAL PRIMARY KEY,
+    source ENUM('web', 'mcp') NOT NULL DEFAULT 'web',
+    oauth_client_id VARCHAR(255),
+    entity VARCHAR(50) NOT NULL,
+    entity_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    parent_id BIGINT UNSIGNED,
+    action ENUM('created', 'updated', 'deleted') NOT NULL,
+    changes JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    user_id BIGINT UNSIGNED,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    INDEX IDX_AuditLog_Organisation_CreatedAt (organisation_id, created_at),
+    CONSTRAINT FK_AuditLog_User FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT FK_AuditLog_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_logs;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL never collides in a unique key, so the global permission (entity_type NULL) could be inserted
+-- multiple times. Keep the latest one and make the key unique with an empty entity key instead.
+DELETE older FROM member_permissions older
+    JOIN member_permissions newer
+        ON newer.user_id = older.user_id
+        AND newer.organisation_id = older.organisation_id
+        AND newer.entity_type <=> older.entity_type
+        AND newer.id > older.id;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE member_permissions
+    ADD COLUMN entity_key VARCHAR(50) AS (COALESCE(entity_type, '')) STORED,
+    ADD CONSTRAINT UQ_MemberPermission_Entity UNIQUE (user_id, organisation_id, entity_key);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE member_permissions DROP INDEX user_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE member_permissions ADD CONSTRAINT user_id UNIQUE (user_id, organisation_id, entity_type);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE member_permissions
+    DROP INDEX UQ_MemberPermission_Entity,
+    DROP COLUMN entity_key;
+-- +goose StatementEnd
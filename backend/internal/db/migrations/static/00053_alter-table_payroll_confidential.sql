@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- In a payroll confidential organisation only owners, admins and cleared members see single salaries
+ALTER TABLE organisations
+    ADD COLUMN IF NOT EXISTS payroll_confidential BOOLEAN NOT NULL DEFAULT FALSE AFTER forecast_bucket;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE users_2_organisations
+    ADD COLUMN IF NOT EXISTS payroll_clearance BOOLEAN NOT NULL DEFAULT FALSE AFTER role;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS users_2_organisations
+    DROP COLUMN IF EXISTS payroll_clearance;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS organisations
+    DROP COLUMN IF EXISTS payroll_confidential;
+-- +goose StatementEnd
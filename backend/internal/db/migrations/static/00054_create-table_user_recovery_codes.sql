@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The TOTP secret is stored as soon as the setup starts, it only counts once totp_enabled is set.
+-- totp_last_step is the last accepted time step, older codes can't be replayed.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) AFTER password,
+    ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE AFTER totp_secret,
+    ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0 AFTER totp_enabled;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Single use codes to log in without the authenticator app, only their hash is stored
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    user_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT UQ_UserRecoveryCode_Hash UNIQUE (user_id, code_hash),
+    CONSTRAINT FK_UserRecoveryCode_User FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE organisations
+    ADD COLUMN IF NOT EXISTS require_two_factor BOOLEAN NOT NULL DEFAULT FALSE AFTER payroll_confidential;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS organisations
+    DROP COLUMN IF EXISTS require_two_factor;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_recovery_codes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS users
+    DROP COLUMN IF EXISTS totp_last_step,
+    DROP COLUMN IF EXISTS totp_enabled,
+    DROP COLUMN IF EXISTS totp_secret;
+-- +goose StatementEnd
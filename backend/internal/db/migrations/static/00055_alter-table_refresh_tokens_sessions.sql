@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- last_used_at and ip_address are updated whenever the refresh token renews the access token
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45) AFTER device_name,
+    ADD COLUMN IF NOT EXISTS last_used_at DATETIME AFTER created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS refresh_tokens
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS ip_address;
+-- +goose StatementEnd
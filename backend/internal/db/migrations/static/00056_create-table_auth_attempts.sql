@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Failed attempts per scope (login, codes) and identifier (email, user or IP address).
+-- blocked_until is set by the exponential backoff and the lockout, locked_at marks a lockout
+-- of which the account owner was notified already.
+CREATE TABLE IF NOT EXISTS auth_attempts (
+    id SERIAL PRIMARY KEY,
+    scope VARCHAR(32) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failures INT UNSIGNED NOT NULL DEFAULT 0,
+    last_failure_at DATETIME NOT NULL,
+    blocked_until DATETIME,
+    locked_at DATETIME,
+
+    CONSTRAINT UQ_AuthAttempt_Scope_Identifier UNIQUE (scope, identifier),
+    INDEX IDX_AuthAttempt_Identifier (identifier)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS auth_attempts;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Links a user to the subject of an OpenID Connect provider, the subject stays the same when the email changes
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_login_at DATETIME,
+
+    user_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT UQ_UserIdentity_Provider_Subject UNIQUE (provider, subject),
+    CONSTRAINT FK_UserIdentity_User FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Users signing in through SSO with an email of sso_domain join the organisation with sso_default_role
+ALTER TABLE organisations
+    ADD COLUMN IF NOT EXISTS sso_domain VARCHAR(255) AFTER require_two_factor,
+    ADD COLUMN IF NOT EXISTS sso_default_role ENUM('editor', 'read-only') NOT NULL DEFAULT 'read-only' AFTER sso_domain,
+    ADD CONSTRAINT UQ_Organisation_SSODomain UNIQUE (sso_domain);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS organisations
+    DROP INDEX IF EXISTS UQ_Organisation_SSODomain,
+    DROP COLUMN IF EXISTS sso_default_role,
+    DROP COLUMN IF EXISTS sso_domain;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Personal API tokens for scripts, bound to the membership of the user in one organisation.
+-- Only the SHA-256 hash is stored, the prefix identifies the token in the list.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    scope ENUM('editor', 'read-only') NOT NULL DEFAULT 'read-only',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME,
+    last_used_ip VARCHAR(45),
+    expires_at DATETIME NOT NULL,
+
+    user_id BIGINT UNSIGNED NOT NULL,
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT UQ_APIToken_TokenHash UNIQUE (token_hash),
+    INDEX IDX_APIToken_User (user_id),
+    CONSTRAINT FK_APIToken_Membership FOREIGN KEY (user_id, organisation_id) REFERENCES users_2_organisations (user_id, organisation_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE audit_logs
+    MODIFY COLUMN source ENUM('web', 'mcp', 'api-token') NOT NULL DEFAULT 'web';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE audit_logs SET source = 'web' WHERE source = 'api-token';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS audit_logs
+    MODIFY COLUMN source ENUM('web', 'mcp') NOT NULL DEFAULT 'web';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Subscriptions of an organisation to its change events. entities is a JSON array of entity
+-- names, an empty array receives every event. The secret signs the deliveries (HMAC-SHA256).
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    entities JSON NOT NULL,
+    enabled BOOL NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_Webhook_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Every event sent to a webhook along with the result of the last attempt. Pending deliveries
+-- are claimed by one instance through locked_until and retried at next_attempt_at.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    entity VARCHAR(50) NOT NULL,
+    action ENUM('created', 'updated', 'deleted') NOT NULL,
+    payload JSON NOT NULL,
+    status ENUM('pending', 'succeeded', 'failed') NOT NULL DEFAULT 'pending',
+    attempts INT UNSIGNED NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    locked_until DATETIME,
+    claim_token CHAR(26),
+    last_attempt_at DATETIME,
+    response_status INT,
+    last_error VARCHAR(1000),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    webhook_id BIGINT UNSIGNED NOT NULL,
+    replay_of BIGINT UNSIGNED,
+
+    INDEX IDX_WebhookDelivery_Status_NextAttempt (status, next_attempt_at),
+    INDEX IDX_WebhookDelivery_ClaimToken (claim_token),
+    INDEX IDX_WebhookDelivery_Webhook_CreatedAt (webhook_id, created_at),
+    CONSTRAINT FK_WebhookDelivery_Webhook FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhooks;
+-- +goose StatementEnd
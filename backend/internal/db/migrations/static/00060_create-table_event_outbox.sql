@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Messages of the event hub for the other backend instances (EVENT_BROKER=mysql). Every instance
+-- polls the rows after the last id it has seen, old rows are removed after an hour.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    payload JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX IDX_EventOutbox_CreatedAt (created_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS event_outbox;
+-- +goose StatementEnd
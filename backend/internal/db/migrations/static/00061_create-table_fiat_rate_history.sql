@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One rate per currency pair and day, fiat_rates only keeps the latest one. Reports convert
+-- past amounts with the rate of their date, so they don't change with every fetch.
+CREATE TABLE IF NOT EXISTS fiat_rate_history (
+    id SERIAL PRIMARY KEY,
+    base CHAR(3) NOT NULL,
+    target CHAR(3) NOT NULL,
+    rate DECIMAL(18, 6) NOT NULL,
+    date DATE NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    CONSTRAINT UQ_FiatRateHistory_BaseTargetDate UNIQUE (base, target, date)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- The current rates start the history
+INSERT IGNORE INTO fiat_rate_history (base, target, rate, date)
+SELECT base, target, rate, DATE(updated_at)
+FROM fiat_rates;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Planning rates of an organisation, they replace the market rate for forecasts and exports
+CREATE TABLE IF NOT EXISTS organisation_fiat_rates (
+    id SERIAL PRIMARY KEY,
+    base CHAR(3) NOT NULL,
+    target CHAR(3) NOT NULL,
+    rate DECIMAL(18, 6) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT UQ_OrganisationFiatRate_BaseTarget UNIQUE (organisation_id, base, target),
+    CONSTRAINT FK_OrganisationFiatRate_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS organisation_fiat_rates;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fiat_rate_history;
+-- +goose StatementEnd
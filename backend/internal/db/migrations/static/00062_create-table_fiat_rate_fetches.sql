@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every fetch of the rate provider, successful or not
+CREATE TABLE IF NOT EXISTS fiat_rate_fetches (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    base CHAR(3),
+    rate_date DATE,
+    rate_count INT UNSIGNED NOT NULL DEFAULT 0,
+    status ENUM('succeeded', 'failed') NOT NULL,
+    error VARCHAR(1000),
+    fetched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX IDX_FiatRateFetch_FetchedAt (fetched_at)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE fiat_rates
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'fixer' AFTER rate;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE fiat_rate_history
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'fixer' AFTER rate;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fiat_rate_history
+    DROP COLUMN source;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE fiat_rates
+    DROP COLUMN source;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS fiat_rate_fetches;
+-- +goose StatementEnd
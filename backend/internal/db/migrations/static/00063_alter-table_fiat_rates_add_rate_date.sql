@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The day the provider published the rate, an unchanged rate doesn't touch updated_at
+ALTER TABLE fiat_rates
+    ADD COLUMN rate_date DATE AFTER source;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE fiat_rates
+SET rate_date = DATE(updated_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE fiat_rates
+    MODIFY COLUMN rate_date DATE NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fiat_rates
+    DROP COLUMN rate_date;
+-- +goose StatementEnd
@@ -0,0 +1,90 @@
+-- +goose Up
+-- +goose StatementBegin
+-- BVG contributions depend on the age of the employee
+ALTER TABLE employees
+    ADD COLUMN birth_date DATE AFTER name;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Yearly limits of the base (threshold, ceiling, coordination deduction, minimum) and age dependent
+-- percentages. All limits are optional, the age rates are a JSON list of {"fromAge", "amount"}.
+ALTER TABLE salary_costs
+    ADD COLUMN base_threshold BIGINT UNSIGNED AFTER target_date,
+    ADD COLUMN base_ceiling BIGINT UNSIGNED AFTER base_threshold,
+    ADD COLUMN base_deduction BIGINT UNSIGNED AFTER base_ceiling,
+    ADD COLUMN base_minimum BIGINT UNSIGNED AFTER base_deduction,
+    ADD COLUMN age_rates JSON AFTER base_minimum;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- One version per organisation, name and year, the federal values change on January 1st
+CREATE TABLE IF NOT EXISTS payroll_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    year SMALLINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    organisation_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_PayrollTemplate_Organisation FOREIGN KEY (organisation_id) REFERENCES organisations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT CK_PayrollTemplate_Name_Not_Empty CHECK (name <> ''),
+
+    CONSTRAINT UQ_PayrollTemplate_Organisation_Name_Year UNIQUE (organisation_id, name, year)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Items with a statutory key take the federal values of the year when a new version is created
+CREATE TABLE IF NOT EXISTS payroll_template_items (
+    id SERIAL PRIMARY KEY,
+    statutory_key VARCHAR(20),
+    label VARCHAR(255) NOT NULL,
+    cycle ENUM('once', 'monthly', 'quarterly', 'biannually', 'yearly') NOT NULL DEFAULT 'monthly',
+    amount_type ENUM('fixed', 'percentage') NOT NULL DEFAULT 'percentage',
+    amount BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    distribution_type ENUM('employer', 'employee', 'both') NOT NULL DEFAULT 'both',
+    relative_offset BIGINT NOT NULL DEFAULT 1,
+    base_threshold BIGINT UNSIGNED,
+    base_ceiling BIGINT UNSIGNED,
+    base_deduction BIGINT UNSIGNED,
+    base_minimum BIGINT UNSIGNED,
+    age_rates JSON,
+    sort_order INT NOT NULL DEFAULT 0,
+
+    template_id BIGINT UNSIGNED NOT NULL,
+
+    CONSTRAINT FK_PayrollTemplateItem_Template FOREIGN KEY (template_id) REFERENCES payroll_templates (id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT CK_PayrollTemplateItem_Label_Not_Empty CHECK (label <> ''),
+    CONSTRAINT CK_PayrollTemplateItem_Amount_Type CHECK (
+        amount_type = 'fixed' OR (amount_type = 'percentage' AND amount <= 100000)
+    ),
+    CONSTRAINT CK_PayrollTemplateItem_Relative_Offset CHECK (relative_offset > 0),
+
+    INDEX IDX_PayrollTemplateItem_Template_SortOrder (template_id, sort_order)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payroll_template_items;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payroll_templates;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE salary_costs
+    DROP COLUMN age_rates,
+    DROP COLUMN base_minimum,
+    DROP COLUMN base_deduction,
+    DROP COLUMN base_ceiling,
+    DROP COLUMN base_threshold;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE employees
+    DROP COLUMN birth_date;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 13th/14th month salaries and one-off bonuses. The bonuses are a JSON list of {"name", "date", "amount"}.
+ALTER TABLE salaries
+    ADD COLUMN extra_salaries TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER cycle,
+    ADD COLUMN extra_salary_payout ENUM('december', 'june_december', 'monthly') NOT NULL DEFAULT 'december' AFTER extra_salaries,
+    ADD COLUMN bonuses JSON AFTER extra_salary_payout;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- The part of the amount that comes from the extra payments of the salary
+ALTER TABLE salary_cost_details
+    ADD COLUMN extra_payment_amount BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER amount;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE salary_cost_details
+    DROP COLUMN IF EXISTS extra_payment_amount;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE salaries
+    DROP COLUMN IF EXISTS bonuses,
+    DROP COLUMN IF EXISTS extra_salary_payout,
+    DROP COLUMN IF EXISTS extra_salaries;
+-- +goose StatementEnd
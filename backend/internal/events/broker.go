@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Broker carries the messages of the hub between backend instances. A hub always delivers
+// to its own subscribers directly, the broker only has to reach the other instances.
+// Payloads are opaque to the broker, but it orders them: every payload gets a position that
+// is the same on every instance and increases with the order of publishing.
+type Broker interface {
+	// Publish hands the payload to every instance, including the publishing one, and
+	// returns its position
+	Publish(payload []byte) (int64, error)
+	// Run passes the payloads published by any instance to receive until ctx is done. start
+	// is called once with the position after which the payloads are received.
+	Run(ctx context.Context, start func(position int64), receive func(position int64, payload []byte))
+}
+
+// brokerMessage is the payload of the broker. Unlike the Event sent to clients it carries
+// the organisation and origin, each instance filters and computes Own at delivery time.
+type brokerMessage struct {
+	// Instance identifies the publishing hub, which skips its own messages
+	Instance    string       `json:"instance"`
+	Event       *brokerEvent `json:"event,omitempty"`
+	CloseUserID int64        `json:"closeUserId,omitempty"`
+}
+
+type brokerEvent struct {
+	Entity         string `json:"entity"`
+	Action         string `json:"action"`
+	ID             int64  `json:"id,omitempty"`
+	ParentID       int64  `json:"parentId,omitempty"`
+	OrganisationID int64  `json:"organisationId"`
+	OriginUserID   int64  `json:"originUserId,omitempty"`
+	OriginClientID string `json:"originClientId,omitempty"`
+}
+
+func encodeEventMessage(instance string, event Event) ([]byte, error) {
+	return json.Marshal(brokerMessage{
+		Instance: instance,
+		Event: &brokerEvent{
+			Entity:         event.Entity,
+			Action:         event.Action,
+			ID:             event.ID,
+			ParentID:       event.ParentID,
+			OrganisationID: event.OrganisationID,
+			OriginUserID:   event.OriginUserID,
+			OriginClientID: event.OriginClientID,
+		},
+	})
+}
+
+func encodeCloseUserMessage(instance string, userID int64) ([]byte, error) {
+	return json.Marshal(brokerMessage{Instance: instance, CloseUserID: userID})
+}
+
+func (e *brokerEvent) toEvent() Event {
+	return Event{
+		Entity:         e.Entity,
+		Action:         e.Action,
+		ID:             e.ID,
+		ParentID:       e.ParentID,
+		OrganisationID: e.OrganisationID,
+		OriginUserID:   e.OriginUserID,
+		OriginClientID: e.OriginClientID,
+	}
+}
@@ -1,7 +1,12 @@
 package events
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"liquiswiss/pkg/logger"
 	"sync"
+	"time"
 )
 
 // Action constants for entity events
@@ -29,24 +34,45 @@ type Event struct {
 	// Own is set at delivery time per connection: true when this exact
 	// connection (user + client id) caused the change
 	Own bool `json:"own"`
+	// Seq is assigned by the hub on delivery, increasing by one per event. It
+	// orders the events of a single hub.
+	Seq uint64 `json:"-"`
+	// Position orders the events across instances: the position of the broker,
+	// or Seq without one. It is sent as part of the SSE id, see Hub.EventID.
+	// Zero when the broker failed, the event can't be resumed from.
+	Position int64 `json:"-"`
+	// deliveredAt is when the hub delivered the event
+	deliveredAt time.Time
 }
 
 // subscriberBuffer bounds the per-connection channel; slow consumers miss
-// events instead of blocking publishers (clients recover via reconnect)
+// events instead of blocking publishers and catch up from the replay ring
 const subscriberBuffer = 32
 
 // MaxConnectionsPerUser caps concurrent event streams per user
 const MaxConnectionsPerUser = 5
 
+// brokerEpoch prefixes the event ids of hubs with a broker, their positions
+// are shared by every instance
+const brokerEpoch = "broker"
+
 type Subscription struct {
 	// Events is never closed; consumers must also select on Done
 	Events chan Event
 	// Done is closed when the subscription is terminated (unsubscribe or forced close)
-	Done   chan struct{}
-	userID int64
-	seq    uint64
-	hub    *Hub
-	once   sync.Once
+	Done chan struct{}
+	// Lagged is signalled when events were dropped because Events was full
+	Lagged chan struct{}
+	// Seq is the sequence number of the hub at subscription time, Events
+	// receives every event after it
+	Seq uint64
+	// Position is the position of the hub at subscription time, the stream
+	// resumes from it until it handled an event
+	Position int64
+	userID   int64
+	order    uint64
+	hub      *Hub
+	once     sync.Once
 }
 
 // Close unsubscribes and signals Done. Safe to call multiple times.
@@ -57,18 +83,56 @@ func (s *Subscription) Close() {
 	})
 }
 
-// Hub is an in-process pub/sub for change events, keyed by userID.
-// Kept intentionally narrow so an external broker could replace it later.
+// Hub is a pub/sub for change events, keyed by userID. Without a broker it is
+// in-process, with one it also reaches the subscribers of the other instances.
 type Hub struct {
 	mu     sync.RWMutex
 	subs   map[int64]map[*Subscription]struct{}
 	nextID uint64
+	// seq numbers the delivered events, rings keeps the latest ones per organisation
+	seq   uint64
+	rings map[int64]*replayRing
+	// deliveries locates the broker positions of the rings in the delivery order
+	deliveries map[int64]delivery
+	// position is the highest delivered position, since the position after which
+	// the hub received every event (-1 until the broker started)
+	position int64
+	since    int64
+	// broker is nil for a single instance
+	broker Broker
+	// instance identifies the hub in broker messages
+	instance string
+	// epoch prefixes the event ids, positions only compare within one epoch
+	epoch string
 }
 
 func NewHub() *Hub {
+	instance := rand.Text()
 	return &Hub{
-		subs: make(map[int64]map[*Subscription]struct{}),
+		subs:       make(map[int64]map[*Subscription]struct{}),
+		rings:      make(map[int64]*replayRing),
+		deliveries: make(map[int64]delivery),
+		instance:   instance,
+		epoch:      instance,
+	}
+}
+
+// NewHubWithBroker shares events and stream closes with the hubs of the other
+// instances through the broker. Run must be started to receive them.
+func NewHubWithBroker(broker Broker) *Hub {
+	hub := NewHub()
+	hub.broker = broker
+	hub.epoch = brokerEpoch
+	hub.since = -1
+	return hub
+}
+
+// Run receives the messages of the other instances until ctx is done
+func (h *Hub) Run(ctx context.Context) {
+	if h.broker == nil {
+		return
 	}
+	h.broker.Run(ctx, h.start, h.receive)
 }
 
 // Subscribe registers a new event stream for the given user. When the user is
@@ -79,18 +143,21 @@ func (h *Hub) Subscribe(userID int64) *Subscription {
 	var evict *Subscription
 	if len(h.subs[userID]) >= MaxConnectionsPerUser {
 		for sub := range h.subs[userID] {
-			if evict == nil || sub.seq < evict.seq {
+			if evict == nil || sub.order < evict.order {
 				evict = sub
 			}
 		}
 	}
 	h.nextID++
 	sub := &Subscription{
-		Events: make(chan Event, subscriberBuffer),
-		Done:   make(chan struct{}),
-		userID: userID,
-		seq:    h.nextID,
-		hub:    h,
+		Events:   make(chan Event, subscriberBuffer),
+		Done:     make(chan struct{}),
+		Lagged:   make(chan struct{}, 1),
+		Seq:      h.seq,
+		Position: h.position,
+		userID:   userID,
+		order:    h.nextID,
+		hub:      h,
 	}
 	if h.subs[userID] == nil {
 		h.subs[userID] = make(map[*Subscription]struct{})
@@ -116,23 +183,56 @@ func (h *Hub) remove(sub *Subscription) {
 }
 
 // Publish fans the event out to every subscriber. Delivery-time filtering by
-// organisation happens in the SSE handler; a full buffer skips the subscriber
-// instead of blocking the mutation path.
+// organisation happens in the SSE handler; a full buffer signals Lagged
+// instead of blocking the mutation path. With a broker the event is published
+// there first, its position is part of the event id on every instance.
 func (h *Hub) Publish(event Event) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	if h.broker != nil {
+		position, err := h.forward(encodeEventMessage(h.instance, event))
+		if err == nil {
+			event.Position = position
+		}
+	}
+	h.deliver(event)
+}
+
+// deliver numbers the event and fans it out under the write lock, so every
+// subscriber receives the events in the order of their sequence numbers
+func (h *Hub) deliver(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	event.Seq = h.seq
+	event.deliveredAt = time.Now()
+	if h.broker == nil {
+		event.Position = int64(h.seq)
+	}
+	h.position = max(h.position, event.Position)
+	h.record(event)
 	for _, set := range h.subs {
 		for sub := range set {
 			select {
 			case sub.Events <- event:
 			default:
+				select {
+				case sub.Lagged <- struct{}{}:
+				default:
+				}
 			}
 		}
 	}
 }
 
 // CloseUser terminates all streams of a user (logout, org switch, member removal)
+// on every instance
 func (h *Hub) CloseUser(userID int64) {
+	h.closeUser(userID)
+	if h.broker != nil {
+		_, _ = h.forward(encodeCloseUserMessage(h.instance, userID))
+	}
+}
+
+func (h *Hub) closeUser(userID int64) {
 	h.mu.RLock()
 	subs := make([]*Subscription, 0, len(h.subs[userID]))
 	for sub := range h.subs[userID] {
@@ -143,3 +243,45 @@ func (h *Hub) CloseUser(userID int64) {
 		sub.Close()
 	}
 }
+
+// forward publishes a message to the other instances and returns its position.
+// A failing broker only costs the other instances the message, local
+// subscribers still get it, without a position to resume from.
+func (h *Hub) forward(payload []byte, err error) (int64, error) {
+	var position int64
+	if err == nil {
+		position, err = h.broker.Publish(payload)
+	}
+	if err != nil {
+		logger.Logger.Warnf("events: could not forward message to the broker: %v", err)
+	}
+	return position, err
+}
+
+// start records the position from which on the broker passes every message
+func (h *Hub) start(position int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.since = position
+	h.position = max(h.position, position)
+}
+
+// receive applies a message of the broker, the own messages were applied on publish
+func (h *Hub) receive(position int64, payload []byte) {
+	var message brokerMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		logger.Logger.Warnf("events: invalid broker message: %v", err)
+		return
+	}
+	if message.Instance == h.instance {
+		return
+	}
+	if message.Event != nil {
+		event := message.Event.toEvent()
+		event.Position = position
+		h.deliver(event)
+	}
+	if message.CloseUserID != 0 {
+		h.closeUser(message.CloseUserID)
+	}
+}
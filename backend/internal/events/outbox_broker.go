@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"time"
+)
+
+const (
+	// outboxBatchSize bounds the entries read per poll
+	outboxBatchSize = 500
+	// outboxGapTimeout is how long a missing id is waited for. Auto increment ids are assigned
+	// before the commit, so a lower id can become visible after a higher one.
+	outboxGapTimeout = 5 * time.Second
+	// outboxCleanupInterval is how often entries older than the retention are removed
+	outboxCleanupInterval = 10 * time.Minute
+)
+
+// OutboxStore persists the entries of the OutboxBroker, implemented by the database adapter
+type OutboxStore interface {
+	CreateEventOutboxEntry(payload []byte) (int64, error)
+	ListEventOutboxEntries(afterID int64, limit int64) ([]models.EventOutboxEntry, error)
+	GetLatestEventOutboxID() (int64, error)
+	DeleteEventOutboxEntriesBefore(before time.Time) (int64, error)
+}
+
+// OutboxBroker shares the messages through a database table that every instance polls.
+// It needs no infrastructure besides the database, at the cost of the poll latency.
+type OutboxBroker struct {
+	store        OutboxStore
+	pollInterval time.Duration
+	retention    time.Duration
+}
+
+func NewOutboxBroker(store OutboxStore, pollInterval time.Duration, retention time.Duration) *OutboxBroker {
+	return &OutboxBroker{
+		store:        store,
+		pollInterval: pollInterval,
+		retention:    retention,
+	}
+}
+
+// Publish returns the id of the entry as position
+func (b *OutboxBroker) Publish(payload []byte) (int64, error) {
+	return b.store.CreateEventOutboxEntry(payload)
+}
+
+// Run starts at the latest entry, messages published before the instance started are not replayed
+func (b *OutboxBroker) Run(ctx context.Context, start func(position int64), receive func(position int64, payload []byte)) {
+	poll := time.NewTicker(b.pollInterval)
+	defer poll.Stop()
+	cleanup := time.NewTicker(outboxCleanupInterval)
+	defer cleanup.Stop()
+
+	var cursor *outboxCursor
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			_, err := b.store.DeleteEventOutboxEntriesBefore(time.Now().Add(-b.retention))
+			if err != nil {
+				logger.Logger.Warnf("events: could not clean up the outbox: %v", err)
+			}
+		case <-poll.C:
+			if cursor == nil {
+				latestID, err := b.store.GetLatestEventOutboxID()
+				if err != nil {
+					logger.Logger.Warnf("events: could not read the outbox: %v", err)
+					continue
+				}
+				cursor = newOutboxCursor(latestID)
+				start(latestID)
+			}
+			err := b.poll(cursor, receive)
+			if err != nil {
+				logger.Logger.Warnf("events: could not read the outbox: %v", err)
+			}
+		}
+	}
+}
+
+// poll reads from the position of the cursor, so the ids it still waits for are picked up
+func (b *OutboxBroker) poll(cursor *outboxCursor, receive func(position int64, payload []byte)) error {
+	defer cursor.advance(time.Now())
+
+	afterID := cursor.position
+	for {
+		entries, err := b.store.ListEventOutboxEntries(afterID, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if cursor.markSeen(entry.ID) {
+				receive(entry.ID, entry.Payload)
+			}
+		}
+		if len(entries) < outboxBatchSize {
+			return nil
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+}
+
+// outboxCursor tracks the entries that were received. The position only moves past an id
+// once it was received or stayed missing for outboxGapTimeout.
+type outboxCursor struct {
+	position int64
+	// seen holds the received ids after the position
+	seen map[int64]struct{}
+	// gaps holds when a missing id after the position was noticed
+	gaps map[int64]time.Time
+}
+
+func newOutboxCursor(position int64) *outboxCursor {
+	return &outboxCursor{
+		position: position,
+		seen:     make(map[int64]struct{}),
+		gaps:     make(map[int64]time.Time),
+	}
+}
+
+// markSeen returns false for ids that were received already
+func (c *outboxCursor) markSeen(id int64) bool {
+	if id <= c.position {
+		return false
+	}
+	if _, ok := c.seen[id]; ok {
+		return false
+	}
+	c.seen[id] = struct{}{}
+	delete(c.gaps, id)
+	return true
+}
+
+func (c *outboxCursor) advance(now time.Time) {
+	highest := c.position
+	for id := range c.seen {
+		highest = max(highest, id)
+	}
+	for id := c.position + 1; id <= highest; id++ {
+		if _, ok := c.seen[id]; ok {
+			delete(c.seen, id)
+			c.position = id
+			continue
+		}
+		noticedAt, ok := c.gaps[id]
+		if !ok {
+			c.gaps[id] = now
+			noticedAt = now
+		}
+		if now.Sub(noticedAt) < outboxGapTimeout {
+			// Later ids must still be tracked as gaps, the position waits here
+			for later := id + 1; later <= highest; later++ {
+				if _, ok := c.seen[later]; !ok {
+					if _, ok := c.gaps[later]; !ok {
+						c.gaps[later] = now
+					}
+				}
+			}
+			return
+		}
+		delete(c.gaps, id)
+		c.position = id
+	}
+}
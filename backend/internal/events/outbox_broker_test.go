@@ -0,0 +1,220 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"liquiswiss/pkg/models"
+)
+
+// memoryOutbox is an OutboxStore shared by the hubs of a test, hidden ids are not listed yet
+type memoryOutbox struct {
+	mu      sync.Mutex
+	entries []models.EventOutboxEntry
+	hidden  map[int64]bool
+}
+
+func (m *memoryOutbox) CreateEventOutboxEntry(payload []byte) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := int64(len(m.entries) + 1)
+	m.entries = append(m.entries, models.EventOutboxEntry{ID: id, Payload: payload})
+	return id, nil
+}
+
+func (m *memoryOutbox) ListEventOutboxEntries(afterID int64, limit int64) ([]models.EventOutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := []models.EventOutboxEntry{}
+	for _, entry := range m.entries {
+		if entry.ID > afterID && !m.hidden[entry.ID] && int64(len(entries)) < limit {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *memoryOutbox) GetLatestEventOutboxID() (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.entries)), nil
+}
+
+func (m *memoryOutbox) DeleteEventOutboxEntriesBefore(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func startOutboxHub(t *testing.T, store OutboxStore) *Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	hub := NewHubWithBroker(NewOutboxBroker(store, 10*time.Millisecond, time.Hour))
+	go hub.Run(ctx)
+	// Let the broker pick its starting position
+	time.Sleep(30 * time.Millisecond)
+	return hub
+}
+
+func TestOutboxBrokerReachesOtherInstances(t *testing.T) {
+	store := &memoryOutbox{}
+	first := startOutboxHub(t, store)
+	second := startOutboxHub(t, store)
+	local := first.Subscribe(1)
+	defer local.Close()
+	remote := second.Subscribe(1)
+	defer remote.Close()
+
+	first.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 42, OrganisationID: 7, OriginUserID: 1, OriginClientID: "tab"})
+
+	select {
+	case event := <-remote.Events:
+		if event.ID != 42 || event.OrganisationID != 7 || event.OriginUserID != 1 || event.OriginClientID != "tab" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected event on the other instance")
+	}
+	select {
+	case <-local.Events:
+	case <-time.After(time.Second):
+		t.Fatal("expected event on the publishing instance")
+	}
+	// The publishing instance skips its own message of the broker
+	select {
+	case event := <-local.Events:
+		t.Fatalf("event delivered twice: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOutboxBrokerResumesOnOtherInstances(t *testing.T) {
+	store := &memoryOutbox{}
+	first := startOutboxHub(t, store)
+	second := startOutboxHub(t, store)
+	local := first.Subscribe(1)
+	defer local.Close()
+	remote := second.Subscribe(1)
+	defer remote.Close()
+
+	first.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 1, OrganisationID: 7})
+	received := <-local.Events
+	first.Publish(Event{Entity: "transaction", Action: ActionUpdated, ID: 1, OrganisationID: 7})
+	for range 2 {
+		select {
+		case <-remote.Events:
+		case <-time.After(time.Second):
+			t.Fatal("expected events on the other instance")
+		}
+	}
+
+	// The stream reconnects to the other instance with the id of the first event
+	missed, ok := second.Resume(7, first.EventID(7, received.Position))
+	if !ok || len(missed) != 1 || missed[0].Action != ActionUpdated {
+		t.Fatalf("unexpected replay: %v %+v", ok, missed)
+	}
+}
+
+func TestOutboxBrokerRequiresResyncBeforeStart(t *testing.T) {
+	store := &memoryOutbox{}
+	first := startOutboxHub(t, store)
+	first.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 1, OrganisationID: 7})
+	second := startOutboxHub(t, store)
+
+	// The other instance never received the event after position 0
+	if _, ok := second.Resume(7, first.EventID(7, 0)); ok {
+		t.Fatal("expected resync for a position before the instance started")
+	}
+	if _, ok := second.Resume(7, first.EventID(7, 1)); !ok {
+		t.Fatal("expected resume from the position the instance started at")
+	}
+}
+
+func TestOutboxBrokerResumeReplaysLateIDs(t *testing.T) {
+	store := &memoryOutbox{hidden: map[int64]bool{1: true}}
+	first := startOutboxHub(t, store)
+	second := startOutboxHub(t, store)
+	remote := second.Subscribe(1)
+	defer remote.Close()
+
+	// The first event commits after the second one
+	first.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 1, OrganisationID: 7})
+	first.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 2, OrganisationID: 7})
+	received := <-remote.Events
+	if received.Position != 2 {
+		t.Fatalf("expected position 2 first, got %d", received.Position)
+	}
+	store.mu.Lock()
+	store.hidden[1] = false
+	store.mu.Unlock()
+	select {
+	case <-remote.Events:
+	case <-time.After(time.Second):
+		t.Fatal("expected the late event")
+	}
+
+	// The stream stopped after the id 2, the lower id was delivered after it
+	missed, ok := second.Resume(7, second.EventID(7, 2))
+	if !ok || len(missed) != 1 || missed[0].Position != 1 {
+		t.Fatalf("unexpected replay: %v %+v", ok, missed)
+	}
+}
+
+func TestOutboxBrokerClosesStreamsOnOtherInstances(t *testing.T) {
+	store := &memoryOutbox{}
+	first := startOutboxHub(t, store)
+	second := startOutboxHub(t, store)
+	remote := second.Subscribe(1)
+
+	first.CloseUser(1)
+
+	select {
+	case <-remote.Done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream on the other instance to be closed")
+	}
+}
+
+func TestOutboxCursorWaitsForLateIDs(t *testing.T) {
+	store := &memoryOutbox{hidden: map[int64]bool{1: true}}
+	broker := NewOutboxBroker(store, time.Second, time.Hour)
+	cursor := newOutboxCursor(0)
+	received := []string{}
+	receive := func(position int64, payload []byte) { received = append(received, string(payload)) }
+
+	_, _ = store.CreateEventOutboxEntry([]byte("late"))
+	_, _ = store.CreateEventOutboxEntry([]byte("early"))
+	if err := broker.poll(cursor, receive); err != nil {
+		t.Fatal(err)
+	}
+	if cursor.position != 0 {
+		t.Fatalf("position must wait for the missing id, got %d", cursor.position)
+	}
+
+	// The earlier transaction commits after the later one
+	store.hidden[1] = false
+	if err := broker.poll(cursor, receive); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 2 || received[0] != "early" || received[1] != "late" {
+		t.Fatalf("unexpected messages: %v", received)
+	}
+	if cursor.position != 2 {
+		t.Fatalf("expected position 2, got %d", cursor.position)
+	}
+}
+
+func TestOutboxCursorSkipsGapsAfterTimeout(t *testing.T) {
+	cursor := newOutboxCursor(0)
+	now := time.Now()
+	cursor.markSeen(2)
+	cursor.advance(now)
+	if cursor.position != 0 {
+		t.Fatalf("position must wait for the missing id, got %d", cursor.position)
+	}
+
+	cursor.advance(now.Add(outboxGapTimeout))
+	if cursor.position != 2 || len(cursor.gaps) != 0 || len(cursor.seen) != 0 {
+		t.Fatalf("expected the gap to be skipped: %+v", cursor)
+	}
+}
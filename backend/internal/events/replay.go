@@ -0,0 +1,120 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// replayBufferSize is the number of recent events kept per organisation for
+// streams that resume after a reconnect or a full buffer
+const replayBufferSize = 256
+
+// lateEventWindow replays the events a hub with broker delivered shortly before
+// the resumed one as well. Broker positions can become visible out of order, so
+// another instance may have delivered them after it.
+const lateEventWindow = outboxGapTimeout
+
+// replayRing holds the latest events of an organisation in delivery order
+type replayRing struct {
+	events []Event
+	// evicted is the highest sequence number dropped from the ring, evictedPosition
+	// the highest position. Broker positions may arrive out of order.
+	evicted         uint64
+	evictedPosition int64
+}
+
+// delivery is where a broker position was delivered by the hub
+type delivery struct {
+	seq uint64
+	at  time.Time
+}
+
+// record keeps the event for replay, callers hold the write lock
+func (h *Hub) record(event Event) {
+	ring := h.rings[event.OrganisationID]
+	if ring == nil {
+		ring = &replayRing{events: make([]Event, 0, replayBufferSize)}
+		h.rings[event.OrganisationID] = ring
+	}
+	if len(ring.events) == replayBufferSize {
+		dropped := ring.events[0]
+		ring.evicted = dropped.Seq
+		ring.evictedPosition = max(ring.evictedPosition, dropped.Position)
+		delete(h.deliveries, dropped.Position)
+		ring.events = append(ring.events[:0], ring.events[1:]...)
+	}
+	ring.events = append(ring.events, event)
+	if h.broker != nil && event.Position != 0 {
+		h.deliveries[event.Position] = delivery{seq: event.Seq, at: event.deliveredAt}
+	}
+}
+
+// EventID identifies an event of an organisation by its position, the SSE
+// stream sends it as id and the client returns it as Last-Event-ID on
+// reconnect. With a broker every instance shares the positions, so the stream
+// can resume on any of them.
+func (h *Hub) EventID(organisationID int64, position int64) string {
+	return fmt.Sprintf("%s-%d-%d", h.epoch, organisationID, position)
+}
+
+// Position returns the highest position the hub delivered
+func (h *Hub) Position() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.position
+}
+
+// Resume returns the events of the organisation the hub delivered after the
+// given event id, in delivery order. Events with a higher position and, with a
+// broker, the ones delivered within the lateEventWindow before it are replayed
+// too, clients refetch on every event so a repeated one costs nothing.
+// It returns false when they can't be replayed: the id belongs to a hub without
+// broker that restarted, another organisation or a position before the hub
+// started, or the ring already dropped some of the events in between.
+func (h *Hub) Resume(organisationID int64, lastEventID string) ([]Event, bool) {
+	parts := strings.Split(lastEventID, "-")
+	if len(parts) != 3 || parts[0] != h.epoch || parts[1] != strconv.FormatInt(organisationID, 10) {
+		return nil, false
+	}
+	position, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.since < 0 || position < h.since {
+		return nil, false
+	}
+	// A broker position this hub didn't deliver yet is still on its way, the
+	// events after it follow live
+	anchorSeq, anchorAt := h.seq, time.Now()
+	if h.broker == nil {
+		if position > h.position {
+			return nil, false
+		}
+		anchorSeq = uint64(position)
+	} else if delivered, ok := h.deliveries[position]; ok {
+		anchorSeq, anchorAt = delivered.seq, delivered.at
+	}
+	ring := h.rings[organisationID]
+	if ring == nil {
+		return nil, true
+	}
+	if anchorSeq < ring.evicted || position < ring.evictedPosition {
+		return nil, false
+	}
+	missed := []Event{}
+	for _, event := range ring.events {
+		// The resumed event itself was sent, events without position never were
+		resumed := event.Position == position && position != 0
+		late := h.broker != nil && !resumed && !event.deliveredAt.Before(anchorAt.Add(-lateEventWindow))
+		if event.Seq > anchorSeq || event.Position > position || late {
+			missed = append(missed, event)
+		}
+	}
+	return missed, true
+}
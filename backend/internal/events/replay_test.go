@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"liquiswiss/pkg/logger"
+)
+
+func TestHubResumeReplaysMissedEventsOfTheOrganisation(t *testing.T) {
+	hub := NewHub()
+	hub.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 1, OrganisationID: 7})
+	lastEventID := hub.EventID(7, 1)
+	hub.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 2, OrganisationID: 8})
+	hub.Publish(Event{Entity: "transaction", Action: ActionUpdated, ID: 1, OrganisationID: 7})
+
+	missed, ok := hub.Resume(7, lastEventID)
+	if !ok || len(missed) != 1 || missed[0].Action != ActionUpdated || missed[0].Seq != 3 {
+		t.Fatalf("unexpected replay: %v %+v", ok, missed)
+	}
+}
+
+func TestHubResumeRequiresResyncForUnknownPositions(t *testing.T) {
+	hub := NewHub()
+	hub.Publish(Event{Entity: "transaction", Action: ActionCreated, OrganisationID: 7})
+
+	for _, lastEventID := range []string{
+		"invalid",
+		NewHub().EventID(7, 1),
+		hub.EventID(8, 1),
+		hub.EventID(7, 99),
+	} {
+		if _, ok := hub.Resume(7, lastEventID); ok {
+			t.Fatalf("expected resync for %q", lastEventID)
+		}
+	}
+}
+
+func TestHubResumeRequiresResyncAfterEviction(t *testing.T) {
+	hub := NewHub()
+	for range replayBufferSize + 1 {
+		hub.Publish(Event{Entity: "transaction", Action: ActionUpdated, OrganisationID: 7})
+	}
+
+	if _, ok := hub.Resume(7, hub.EventID(7, 0)); ok {
+		t.Fatal("expected resync once the ring dropped missed events")
+	}
+	missed, ok := hub.Resume(7, hub.EventID(7, 1))
+	if !ok || len(missed) != replayBufferSize {
+		t.Fatalf("expected the full ring, got %v %d", ok, len(missed))
+	}
+}
+
+func TestHubOverflowSignalsLagged(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	defer sub.Close()
+
+	for range subscriberBuffer + 1 {
+		hub.Publish(Event{Entity: "transaction", Action: ActionUpdated, OrganisationID: 7})
+	}
+
+	select {
+	case <-sub.Lagged:
+	case <-time.After(time.Second):
+		t.Fatal("expected Lagged after the buffer overflowed")
+	}
+	missed, ok := hub.Resume(7, hub.EventID(7, sub.Position))
+	if !ok || len(missed) != subscriberBuffer+1 {
+		t.Fatalf("expected every event in the ring, got %v %d", ok, len(missed))
+	}
+}
+
+// failingBroker loses every message
+type failingBroker struct{}
+
+func (failingBroker) Publish(payload []byte) (int64, error) {
+	return 0, errors.New("broker unavailable")
+}
+
+func (failingBroker) Run(ctx context.Context, start func(position int64), receive func(position int64, payload []byte)) {
+	start(0)
+	<-ctx.Done()
+}
+
+func TestHubResumeReplaysEventsWithoutPosition(t *testing.T) {
+	logger.Logger = zap.NewNop().Sugar()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := NewHubWithBroker(failingBroker{})
+	go hub.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+	sub := hub.Subscribe(1)
+	defer sub.Close()
+
+	hub.Publish(Event{Entity: "transaction", Action: ActionCreated, ID: 1, OrganisationID: 7})
+	event := <-sub.Events
+	if event.Position != 0 {
+		t.Fatalf("expected no position, got %d", event.Position)
+	}
+
+	// The stream sent no id for it, the reconnect resumes from before it
+	missed, ok := hub.Resume(7, hub.EventID(7, sub.Position))
+	if !ok || len(missed) != 1 || missed[0].ID != 1 {
+		t.Fatalf("unexpected replay: %v %+v", ok, missed)
+	}
+}
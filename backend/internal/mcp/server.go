@@ -22,7 +22,8 @@ type contextKey string
 const userIDKey contextKey = "userID"
 
 var errNotAuthenticated = errors.New("not authenticated")
-var errInsufficientRole = errors.New("your role in this organisation does not allow modifications")
+var errInsufficientPermission = errors.New("your permissions in this organisation do not allow this action")
+var errTwoFactorRequired = errors.New("this organisation requires two-factor authentication, enable it in the profile first")
 
 // GinHandler mounts the MCP server on a gin route. The OAuthBearerMiddleware
 // must run before this handler so the user ID is present in the gin context.
@@ -52,18 +53,27 @@ type toolDeps struct {
 	dbService  db_adapter.IDatabaseAdapter
 }
 
-// requireEditor ensures the user's role in their current organisation allows mutations
-func (d *toolDeps) requireEditor(userID int64) error {
+// requirePermission ensures the user may perform action on entityType in their current organisation
+func (d *toolDeps) requirePermission(userID int64, entityType string, action string) error {
 	role, err := d.dbService.GetCurrentUserRole(userID)
 	if err != nil {
 		return err
 	}
-	switch role {
-	case "owner", "admin", "editor":
-		return nil
-	default:
-		return errInsufficientRole
+	compliant, err := d.dbService.CheckCurrentUserTwoFactorCompliance(userID)
+	if err != nil {
+		return err
 	}
+	if !compliant {
+		return errTwoFactorRequired
+	}
+	permissions, err := d.dbService.ListCurrentMemberPermissions(userID)
+	if err != nil {
+		return err
+	}
+	if !models.ResolvePermission(role, permissions, entityType).Allows(action) {
+		return errInsufficientPermission
+	}
+	return nil
 }
 
 func validate(payload any) error {
@@ -359,6 +369,9 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		setting, err := deps.apiService.GetVatSetting(ctx, userID)
 		if err != nil {
 			return nil, nil, errors.New("no VAT settings configured for this organisation")
@@ -368,13 +381,13 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_vat_setting",
-		Description: "Create or update the organisation's automatic VAT billing settings (partial: only provided fields change). Fields: enabled, billingDate (YYYY-MM-DD, first billing), transactionMonthOffset (0-12 months between billing and money movement), interval (monthly, quarterly, biannually, yearly). When no settings exist yet, enabled, billingDate and interval are required. Requires editor role or higher.",
+		Description: "Create or update the organisation's automatic VAT billing settings (partial: only provided fields change). Fields: enabled, billingDate (YYYY-MM-DD, first billing), transactionMonthOffset (0-12 months between billing and money movement), interval (monthly, quarterly, biannually, yearly). When no settings exist yet, enabled, billingDate and interval are required. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.UpdateVatSetting) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -418,6 +431,9 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityCategory, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		categories, _, err := deps.apiService.ListCategories(ctx, userID, 1, 1000)
 		if err != nil {
 			return nil, nil, err
@@ -454,13 +470,13 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_category",
-		Description: "Create an organisation-owned transaction category. Global preset categories (canEdit=false) are shared; own categories (canEdit=true) can be renamed and deleted. Requires editor role or higher.",
+		Description: "Create an organisation-owned transaction category. Global preset categories (canEdit=false) are shared; own categories (canEdit=true) can be renamed and deleted. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateCategory) (*sdk.CallToolResult, *models.Category, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityCategory, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -475,7 +491,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_category",
-		Description: "Rename an organisation-owned category (canEdit=true only; global presets cannot be changed). Requires editor role or higher.",
+		Description: "Rename an organisation-owned category (canEdit=true only; global presets cannot be changed). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"category ID"`
 		models.UpdateCategory
@@ -484,7 +500,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityCategory, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateCategory); err != nil {
@@ -506,13 +522,13 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_category",
-		Description: "Delete an organisation-owned category permanently (canEdit=true only; global presets cannot be deleted). Requires editor role or higher.",
+		Description: "Delete an organisation-owned category permanently (canEdit=true only; global presets cannot be deleted). Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityCategory, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteCategory(ctx, userID, in.ID); err != nil {
@@ -523,7 +539,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "reassign_category_transactions",
-		Description: "Move ALL transactions of the current organisation from one category to another (e.g. to free up a category before delete_category). Requires editor role or higher.",
+		Description: "Move ALL transactions of the current organisation from one category to another (e.g. to free up a category before delete_category). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		FromID int64 `json:"fromId" jsonschema:"source category id whose transactions get moved"`
 		ToID   int64 `json:"toId" jsonschema:"target category id the transactions get assigned to"`
@@ -532,7 +548,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityCategory, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		affected, err := deps.apiService.ReassignCategoryTransactions(ctx, userID, in.FromID, in.ToID)
@@ -583,6 +599,9 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		vats, err := deps.apiService.ListVats(ctx, userID)
 		if err != nil {
 			return nil, nil, err
@@ -592,13 +611,13 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_vat",
-		Description: "Create a VAT rate for the organisation. Value in basis points of a percent: 810 = 8.1%. Requires editor role or higher.",
+		Description: "Create a VAT rate for the organisation. Value in basis points of a percent: 810 = 8.1%. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateVat) (*sdk.CallToolResult, *models.Vat, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -613,7 +632,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_vat",
-		Description: "Update a VAT rate's value (810 = 8.1%). Only organisation-owned rates can be edited (canEdit=true). Requires editor role or higher.",
+		Description: "Update a VAT rate's value (810 = 8.1%). Only organisation-owned rates can be edited (canEdit=true). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"VAT ID"`
 		models.UpdateVat
@@ -622,7 +641,7 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateVat); err != nil {
@@ -644,13 +663,13 @@ func registerOrganisationTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_vat",
-		Description: "Delete an organisation-owned VAT rate permanently (canEdit=true only). Requires editor role or higher.",
+		Description: "Delete an organisation-owned VAT rate permanently (canEdit=true only). Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityVat, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		existing, err := deps.apiService.GetVat(ctx, userID, in.ID)
@@ -678,6 +697,9 @@ func registerBankAccountTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityBankAccount, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		in.normalize()
 		backendSearch, page, limit := in.Search, in.Page, in.Limit
 		if in.Search != "" {
@@ -702,13 +724,13 @@ func registerBankAccountTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_bank_account",
-		Description: "Create a bank account. Amount is the current balance in Rappen/cents. Requires editor role or higher.",
+		Description: "Create a bank account. Amount is the current balance in Rappen/cents. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateBankAccount) (*sdk.CallToolResult, *models.BankAccount, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityBankAccount, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -723,7 +745,7 @@ func registerBankAccountTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_bank_account",
-		Description: "Update a bank account (partial: only provided fields change). Requires editor role or higher.",
+		Description: "Update a bank account (partial: only provided fields change). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"bank account ID"`
 		models.UpdateBankAccount
@@ -732,7 +754,7 @@ func registerBankAccountTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityBankAccount, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateBankAccount); err != nil {
@@ -747,13 +769,13 @@ func registerBankAccountTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_bank_account",
-		Description: "Delete a bank account permanently. Requires editor role or higher.",
+		Description: "Delete a bank account permanently. Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityBankAccount, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteBankAccount(ctx, userID, in.ID); err != nil {
@@ -803,6 +825,9 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityTransaction, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		in.normalize()
 		hasExtraFilters := in.EmployeeID != 0 || in.CategoryID != 0 || in.Type != "" || in.Direction != ""
 		useFuzzy := in.Search != ""
@@ -853,6 +878,9 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityTransaction, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		transaction, err := deps.apiService.GetTransaction(ctx, userID, in.ID)
 		if err != nil {
 			return nil, nil, notFound(err, "transaction")
@@ -862,13 +890,13 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_transaction",
-		Description: "Create a transaction. Amount in Rappen/cents (negative = expense, positive = revenue). Type 'single' or 'repeating' (cycle required if repeating: monthly, quarterly, biannually, yearly). Dates as YYYY-MM-DD. Category and currency are IDs from list_categories / list_currencies. Requires editor role or higher.",
+		Description: "Create a transaction. Amount in Rappen/cents (negative = expense, positive = revenue). Type 'single' or 'repeating' (cycle required if repeating: monthly, quarterly, biannually, yearly). Dates as YYYY-MM-DD. Category and currency are IDs from list_categories / list_currencies. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateTransaction) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityTransaction, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -883,7 +911,7 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_transaction",
-		Description: "Update a transaction. WARNING, special partial-update semantics: when isDisabled is provided (true/false), nullable fields sent as null are PRESERVED; when isDisabled is omitted/null, nullable fields sent as null (link, cycle, endDate, employee, vat) are CLEARED. To safely change single fields without wiping others, always pass isDisabled (e.g. its current value). Requires editor role or higher.",
+		Description: "Update a transaction. WARNING, special partial-update semantics: when isDisabled is provided (true/false), nullable fields sent as null are PRESERVED; when isDisabled is omitted/null, nullable fields sent as null (link, cycle, endDate, employee, vat) are CLEARED. To safely change single fields without wiping others, always pass isDisabled (e.g. its current value). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"transaction ID"`
 		models.UpdateTransaction
@@ -892,7 +920,7 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityTransaction, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateTransaction); err != nil {
@@ -907,13 +935,13 @@ func registerTransactionTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_transaction",
-		Description: "Delete a transaction permanently. Requires editor role or higher.",
+		Description: "Delete a transaction permanently. Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityTransaction, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteTransaction(ctx, userID, in.ID); err != nil {
@@ -933,12 +961,15 @@ type listEmployeesInput struct {
 func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "list_employees",
-		Description: "List employees with their current salary summary (salary amounts in Rappen/cents).",
+		Description: "List employees with their current salary summary (salary amounts in Rappen/cents). In payroll confidential organisations the summary stays empty without payroll clearance.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in listEmployeesInput) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityEmployee, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		in.normalize()
 		backendSearch, page, limit := in.Search, in.Page, in.Limit
 		if in.Search != "" {
@@ -969,6 +1000,9 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityEmployee, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		employee, err := deps.apiService.GetEmployee(ctx, userID, in.ID)
 		if err != nil {
 			return nil, nil, notFound(err, "employee")
@@ -978,13 +1012,13 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_employee",
-		Description: "Create an employee (name only; salaries are managed separately). Requires editor role or higher.",
+		Description: "Create an employee (name only; salaries are managed separately). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateEmployee) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityEmployee, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -999,7 +1033,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_employee",
-		Description: "Rename an employee. Requires editor role or higher.",
+		Description: "Rename an employee. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"employee ID"`
 		models.UpdateEmployee
@@ -1008,7 +1042,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityEmployee, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateEmployee); err != nil {
@@ -1023,13 +1057,13 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_employee",
-		Description: "Delete an employee and their salaries permanently. Requires editor role or higher.",
+		Description: "Delete an employee and their salaries permanently. Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityEmployee, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteEmployee(ctx, userID, in.ID); err != nil {
@@ -1040,7 +1074,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "list_salaries",
-		Description: "List the salary history of one employee (amounts in Rappen/cents, with employer cost details). Salaries form a contiguous timeline of employment periods, ordered by fromDate: each entry is valid from its fromDate until its toDate (null = open-ended). Entries with isTermination=true mark an employment end at their fromDate.",
+		Description: "List the salary history of one employee (amounts in Rappen/cents, with employer cost details). Salaries form a contiguous timeline of employment periods, ordered by fromDate: each entry is valid from its fromDate until its toDate (null = open-ended). Entries with isTermination=true mark an employment end at their fromDate. Requires payroll clearance in payroll confidential organisations.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		EmployeeID int64 `json:"employeeId" jsonschema:"employee ID"`
 	}) (*sdk.CallToolResult, map[string]any, error) {
@@ -1048,6 +1082,9 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		salaries, total, err := deps.apiService.ListSalaries(ctx, userID, in.EmployeeID, 1, 1000)
 		if err != nil {
 			return nil, nil, err
@@ -1063,6 +1100,9 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		salary, err := deps.apiService.GetSalary(ctx, userID, in.ID)
 		if err != nil {
 			return nil, nil, notFound(err, "salary")
@@ -1072,7 +1112,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_salary",
-		Description: "Create a salary entry for an employee. Amount is the gross salary per cycle in Rappen/cents (e.g. 1000000 = 10'000.00), cycle one of monthly, quarterly, biannually, yearly. Dates as YYYY-MM-DD. IMPORTANT concept: salaries form a contiguous employment timeline and the system auto-adjusts neighbours. Inserting a salary automatically caps the previous salary's toDate at one cycle before the new fromDate, and the new salary itself gets capped by the next existing salary. Leave toDate null; it is managed automatically. The latest salary stays open-ended. To model an employment end (Austritt), create an entry with isTermination=true, amount 0 and fromDate = end boundary; the employee then shows willBeTerminated/isTerminated. Multiple exits and re-entries are supported: a salary created after a termination models a rehire and caps the termination entry, enabling employment gaps. Only ONE entry per employee per fromDate (salary or termination); creating a second one on the same date fails. Requires editor role or higher.",
+		Description: "Create a salary entry for an employee. Amount is the gross salary per cycle in Rappen/cents (e.g. 1000000 = 10'000.00), cycle one of monthly, quarterly, biannually, yearly. Dates as YYYY-MM-DD. IMPORTANT concept: salaries form a contiguous employment timeline and the system auto-adjusts neighbours. Inserting a salary automatically caps the previous salary's toDate at one cycle before the new fromDate, and the new salary itself gets capped by the next existing salary. Leave toDate null; it is managed automatically. The latest salary stays open-ended. To model an employment end (Austritt), create an entry with isTermination=true, amount 0 and fromDate = end boundary; the employee then shows willBeTerminated/isTerminated. Multiple exits and re-entries are supported: a salary created after a termination models a rehire and caps the termination entry, enabling employment gaps. Only ONE entry per employee per fromDate (salary or termination); creating a second one on the same date fails. Extra payments: extraSalaries 1 or 2 adds a 13th/14th month salary (monthly salaries only) paid per extraSalaryPayout (december, june_december or monthly), prorated in entry and exit years; bonuses are one-off payments [{name, date, amount}]. Both raise the base of the percentage costs. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		EmployeeID int64 `json:"employeeId" jsonschema:"employee ID"`
 		models.CreateSalary
@@ -1081,7 +1121,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.CreateSalary); err != nil {
@@ -1096,7 +1136,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_salary",
-		Description: "Update a salary entry (partial: only provided fields change). Note: changing fromDate re-triggers the automatic timeline shifts of neighbouring salaries (see create_salary). Requires editor role or higher.",
+		Description: "Update a salary entry (partial: only provided fields change). Note: changing fromDate re-triggers the automatic timeline shifts of neighbouring salaries (see create_salary). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"salary ID"`
 		models.UpdateSalary
@@ -1105,7 +1145,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.UpdateSalary); err != nil {
@@ -1128,6 +1168,9 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		costs, total, err := deps.apiService.ListSalaryCosts(ctx, userID, in.SalaryID, 1, 1000, false)
 		if err != nil {
 			return nil, nil, err
@@ -1145,7 +1188,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_salary_cost",
-		Description: "Add a cost entry (Lohnnebenkosten) to a salary. amountType 'fixed' (amount in Rappen/cents) or 'percentage' in thousandths of a percent with 3 decimals supported (5325 = 5.325% of the salary). distributionType: 'employee' (deducted from gross), 'employer' (on top of gross) or 'both'. Cycle 'once' needs targetDate (YYYY-MM-DD); recurring cycles use relativeOffset (>=1, e.g. 1 = every cycle). Optionally labelID from list_salary_cost_labels and baseSalaryCostIDs to compute the percentage on top of other cost entries instead of the salary. Requires editor role or higher.",
+		Description: "Add a cost entry (Lohnnebenkosten) to a salary. amountType 'fixed' (amount in Rappen/cents) or 'percentage' in thousandths of a percent with 3 decimals supported (5325 = 5.325% of the salary). distributionType: 'employee' (deducted from gross), 'employer' (on top of gross) or 'both'. Cycle 'once' needs targetDate (YYYY-MM-DD); recurring cycles use relativeOffset (>=1, e.g. 1 = every cycle). Optionally labelID from list_salary_cost_labels and baseSalaryCostIDs to compute the percentage on top of other cost entries instead of the salary. Percentages can limit their yearly base with baseThreshold, baseCeiling, baseDeduction and baseMinimum (Rappen/cents) and vary by age with ageRates ([{fromAge, amount}], needs the birth date of the employee). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		SalaryID int64 `json:"salaryId" jsonschema:"salary ID"`
 		models.CreateSalaryCost
@@ -1154,7 +1197,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.CreateSalaryCost); err != nil {
@@ -1172,7 +1215,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_salary_cost",
-		Description: "Update a salary cost entry. Full replace: send ALL fields (cycle, amountType, amount, distributionType, relativeOffset, and targetDate/labelID/baseSalaryCostIDs as applicable), not a partial patch. Requires editor role or higher.",
+		Description: "Update a salary cost entry. Full replace: send ALL fields (cycle, amountType, amount, distributionType, relativeOffset, and targetDate/labelID/baseSalaryCostIDs as applicable), not a partial patch. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID int64 `json:"id" jsonschema:"salary cost ID"`
 		models.CreateSalaryCost
@@ -1181,7 +1224,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.CreateSalaryCost); err != nil {
@@ -1199,13 +1242,13 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_salary_cost",
-		Description: "Delete a salary cost entry permanently. Requires editor role or higher.",
+		Description: "Delete a salary cost entry permanently. Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteSalaryCost(ctx, userID, in.ID); err != nil {
@@ -1216,7 +1259,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "copy_salary_costs",
-		Description: "Copy cost entries from another salary onto the target salary. Provide sourceSalaryID (copies all its costs) or specific cost ids. Useful when a new salary period should keep the same Lohnnebenkosten. Requires editor role or higher.",
+		Description: "Copy cost entries from another salary onto the target salary. Provide sourceSalaryID (copies all its costs) or specific cost ids. Useful when a new salary period should keep the same Lohnnebenkosten. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		SalaryID int64 `json:"salaryId" jsonschema:"target salary ID"`
 		models.CopySalaryCosts
@@ -1225,7 +1268,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in.CopySalaryCosts); err != nil {
@@ -1243,7 +1286,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "duplicate_salary",
-		Description: "Duplicate an existing salary entry as a new period starting at fromDate, including ALL its cost entries (Lohnnebenkosten). Mirrors the frontend's salary copy function: same amount, cycle, currency, hours and vacation days; the timeline auto-adjusts neighbouring salaries (see create_salary). Requires editor role or higher.",
+		Description: "Duplicate an existing salary entry as a new period starting at fromDate, including ALL its cost entries (Lohnnebenkosten). Mirrors the frontend's salary copy function: same amount, cycle, extra salaries, currency, hours and vacation days (bonuses are one-off and not copied); the timeline auto-adjusts neighbouring salaries (see create_salary). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID       int64  `json:"id" jsonschema:"source salary ID"`
 		FromDate string `json:"fromDate" jsonschema:"start date of the new salary period (YYYY-MM-DD)"`
@@ -1252,7 +1295,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		source, err := deps.apiService.GetSalary(ctx, userID, in.ID)
@@ -1266,6 +1309,8 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 			HoursPerMonth:       source.HoursPerMonth,
 			Amount:              source.Amount,
 			Cycle:               source.Cycle,
+			ExtraSalaries:       source.ExtraSalaries,
+			ExtraSalaryPayout:   source.ExtraSalaryPayout,
 			CurrencyID:          *source.Currency.ID,
 			VacationDaysPerYear: source.VacationDaysPerYear,
 			FromDate:            in.FromDate,
@@ -1295,6 +1340,9 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		labels, _, err := deps.apiService.ListSalaryCostLabels(ctx, userID, 1, 1000)
 		if err != nil {
 			return nil, nil, err
@@ -1311,13 +1359,13 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "create_salary_cost_label",
-		Description: "Create a salary cost label (category for Lohnnebenkosten). Requires editor role or higher.",
+		Description: "Create a salary cost label (category for Lohnnebenkosten). Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.CreateSalaryCostLabel) (*sdk.CallToolResult, *models.SalaryCostLabel, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -1332,7 +1380,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "update_salary_cost_label",
-		Description: "Rename a salary cost label. Requires editor role or higher.",
+		Description: "Rename a salary cost label. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
 		ID   int64  `json:"id" jsonschema:"label ID"`
 		Name string `json:"name" jsonschema:"new label name"`
@@ -1341,7 +1389,7 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		payload := models.CreateSalaryCostLabel{Name: in.Name}
@@ -1357,13 +1405,13 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_salary_cost_label",
-		Description: "Delete a salary cost label permanently. Cost entries using it keep working but lose the label. Requires editor role or higher.",
+		Description: "Delete a salary cost label permanently. Cost entries using it keep working but lose the label. Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteSalaryCostLabel(ctx, userID, in.ID); err != nil {
@@ -1372,15 +1420,57 @@ func registerEmployeeTools(server *sdk.Server, deps *toolDeps) {
 		return nil, &deleteOutput{Deleted: true, ID: in.ID}, nil
 	})
 
+	sdk.AddTool(server, &sdk.Tool{
+		Name:        "list_payroll_templates",
+		Description: "List the organisation's payroll templates (Lohnkostenvorlagen), one version per name and year. Each item is a salary cost with label, rate and optional yearly base limits (baseThreshold, baseCeiling, baseDeduction, baseMinimum in Rappen/cents) and ageRates for the BVG. Items with a statutoryKey (ahv_iv_eo, alv, bvg, uvg_bu, uvg_nbu, ktg, fak) take the federal values of the year.",
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct{}) (*sdk.CallToolResult, map[string]any, error) {
+		userID, err := userIDFrom(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
+		templates, err := deps.apiService.ListPayrollTemplates(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, map[string]any{"items": templates, "total": len(templates)}, nil
+	})
+
+	sdk.AddTool(server, &sdk.Tool{
+		Name:        "apply_payroll_template",
+		Description: "Create the cost entries (Lohnnebenkosten) of a payroll template on a salary in one call. Labels are matched by name and created when missing. replace=true removes the existing costs of the salary first, otherwise the costs are added. Requires the edit permission.",
+	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
+		SalaryID int64 `json:"salaryId" jsonschema:"target salary ID"`
+		models.ApplyPayrollTemplate
+	}) (*sdk.CallToolResult, map[string]any, error) {
+		userID, err := userIDFrom(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionEdit); err != nil {
+			return nil, nil, err
+		}
+		if err := validate(in.ApplyPayrollTemplate); err != nil {
+			return nil, nil, err
+		}
+		costs, err := deps.apiService.ApplyPayrollTemplate(ctx, in.ApplyPayrollTemplate, userID, in.SalaryID)
+		if err != nil {
+			return nil, nil, notFound(err, "salary or payroll template")
+		}
+		return nil, map[string]any{"applied": true, "count": len(costs)}, nil
+	})
+
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "delete_salary",
-		Description: "Delete a salary entry permanently. The timeline auto-heals: the previous salary re-expands up to the next remaining salary (or open-ended). Requires editor role or higher.",
+		Description: "Delete a salary entry permanently. The timeline auto-heals: the previous salary re-expands up to the next remaining salary (or open-ended). Requires the delete permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in idInput) (*sdk.CallToolResult, *deleteOutput, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntitySalary, models.PermissionActionDelete); err != nil {
 			return nil, nil, err
 		}
 		if err := deps.apiService.DeleteSalary(ctx, userID, in.ID); err != nil {
@@ -1400,12 +1490,15 @@ type forecastInput struct {
 func registerForecastTools(server *sdk.Server, deps *toolDeps) {
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "get_forecast",
-		Description: "Recalculate and return the liquidity forecast: per month revenue, expense and cashflow (in Rappen/cents) for the current organisation. Use includeDetails to see exactly which transactions and salaries drive each month, ideal for spotting outdated entries or saving potential.",
+		Description: "Return the stored liquidity forecast: per month revenue, expense and cashflow plus the projected opening and closing liquidity (in Rappen/cents, organisation currency) for the current organisation. The running balance starts from today's bank account balances. The status tells whether a recalculation is 'pending' or 'running' after recent changes, a forecast calculated before today is recalculated in the background; call again once the status is 'idle' for the latest numbers. Use includeDetails to see exactly which transactions and salaries drive each month, ideal for spotting outdated entries or saving potential. Without payroll clearance in a payroll confidential organisation all salaries and salary costs of a month are aggregated into one 'Personalkosten' entry.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in forecastInput) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityForecast, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		months := in.Months
 		if months < 1 {
 			months = 12
@@ -1414,15 +1507,23 @@ func registerForecastTools(server *sdk.Server, deps *toolDeps) {
 			months = int64(utils.MaxForecastYears) * 12
 		}
 
-		if _, err := deps.apiService.CalculateForecast(ctx, userID); err != nil {
-			return nil, nil, fmt.Errorf("forecast calculation failed: %w", err)
+		// Changes already schedule a recalculation, the tool only triggers one for an outdated forecast
+		if _, err := deps.apiService.ScheduleForecastIfStale(ctx, userID); err != nil {
+			return nil, nil, fmt.Errorf("forecast recalculation failed: %w", err)
 		}
 		forecasts, err := deps.apiService.ListForecasts(ctx, userID, months)
 		if err != nil {
 			return nil, nil, err
 		}
+		status, err := deps.apiService.GetForecastStatus(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
 
-		result := map[string]any{"months": forecasts}
+		result := map[string]any{"months": forecasts, "status": status}
+		if len(forecasts) > 0 {
+			result["openingBalance"] = forecasts[0].Data.OpeningBalance
+		}
 		if in.IncludeDetails {
 			details, err := deps.apiService.ListForecastDetails(ctx, userID, months)
 			if err != nil {
@@ -1435,13 +1536,13 @@ func registerForecastTools(server *sdk.Server, deps *toolDeps) {
 
 	sdk.AddTool(server, &sdk.Tool{
 		Name:        "set_forecast_exclusions",
-		Description: "Exclude or re-include specific entries from the forecast for specific months, without disabling them. Each update needs relatedID + relatedTable (from the get_forecast details tree, e.g. 'transactions' or 'salaries'), month as YYYY-MM and isExcluded. Accepts multiple updates at once. Requires editor role or higher.",
+		Description: "Exclude or re-include specific entries from the forecast for specific months, without disabling them. Each update needs relatedID + relatedTable (from the get_forecast details tree, e.g. 'transactions' or 'salaries'), month as YYYY-MM and isExcluded. Accepts multiple updates at once. Requires the edit permission.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in models.UpdateForecastExclusions) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if err := deps.requireEditor(userID); err != nil {
+		if err := deps.requirePermission(userID, models.PermissionEntityForecast, models.PermissionActionEdit); err != nil {
 			return nil, nil, err
 		}
 		if err := validate(in); err != nil {
@@ -1464,6 +1565,9 @@ func registerForecastTools(server *sdk.Server, deps *toolDeps) {
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := deps.requirePermission(userID, models.PermissionEntityForecast, models.PermissionActionView); err != nil {
+			return nil, nil, err
+		}
 		exclusions, err := deps.apiService.ListAllForecastExclusions(ctx, userID)
 		if err != nil {
 			return nil, nil, err
@@ -1506,7 +1610,7 @@ func registerForecastTools(server *sdk.Server, deps *toolDeps) {
 		Name:        "update_forecast_settings",
 		Description: "Update the user's forecast view settings for the current organisation: forecastMonths (1-60) and/or forecastPerformance (0-200, percent scaling applied to revenue in the web forecast view). Only provided fields change.",
 	}, func(ctx context.Context, req *sdk.CallToolRequest, in struct {
-		ForecastMonths      *int `json:"forecastMonths,omitempty" jsonschema:"months shown in the web forecast, 1-60"`
+		ForecastMonths      *int `json:"forecastMonths,omitempty" jsonschema:"months shown in the web forecast, 1-121"`
 		ForecastPerformance *int `json:"forecastPerformance,omitempty" jsonschema:"revenue performance scaling in percent, 0-200"`
 	}) (*sdk.CallToolResult, map[string]any, error) {
 		userID, err := userIDFrom(ctx)
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/reqctx"
+	"liquiswiss/pkg/utils"
+)
+
+// apiTokenScopeKey is set for requests authenticated with a personal API token
+const apiTokenScopeKey = "apiTokenScope"
+
+// scopedDatabaseKey holds the database adapter scoped to the organisation of the API token
+const scopedDatabaseKey = "scopedDatabase"
+
+// authenticateAPIToken authenticates scripts that send a personal API token as bearer token
+func authenticateAPIToken(c *gin.Context, token string) {
+	tokenAuth, err := databaseService.GetAPITokenAuth(auth.HashAPIToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API-Token ungültig, abgelaufen oder widerrufen"})
+			return
+		}
+		logger.Logger.Error("Error checking the API token", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	// Read-only tokens never change anything, also not on routes without permission check
+	if tokenAuth.Scope == RoleReadOnly && c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Das API-Token erlaubt nur lesenden Zugriff"})
+		return
+	}
+
+	if err := databaseService.TouchAPIToken(tokenAuth.ID, c.ClientIP(), utils.APITokenTouchInterval); err != nil {
+		logger.Logger.Error("Error updating the API token usage", err)
+	}
+
+	// The request acts on the organisation of the token, whichever one the user selected in the web app
+	scoped, release, err := databaseService.ScopeToOrganisation(tokenAuth.OrganisationID)
+	if err != nil {
+		logger.Logger.Error("Error scoping the API token to its organisation", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	defer release()
+
+	c.Set("userID", tokenAuth.UserID)
+	c.Set(apiTokenScopeKey, tokenAuth.Scope)
+	c.Set(scopedDatabaseKey, scoped)
+	// The audit log attributes changes to the token
+	c.Request = c.Request.WithContext(reqctx.WithAPITokenID(c.Request.Context(), tokenAuth.ID))
+	c.Next()
+}
+
+// ScopedDatabase returns the database adapter of requests that are scoped to the organisation of an API token
+func ScopedDatabase(c *gin.Context) (db_adapter.IDatabaseAdapter, bool) {
+	scoped, ok := c.Get(scopedDatabaseKey)
+	if !ok {
+		return nil, false
+	}
+	return scoped.(db_adapter.IDatabaseAdapter), true
+}
+
+// requestDatabase returns the database adapter the checks of the request have to run on
+func requestDatabase(c *gin.Context) db_adapter.IDatabaseAdapter {
+	if scoped, ok := ScopedDatabase(c); ok {
+		return scoped
+	}
+	return databaseService
+}
+
+// RejectAPITokens keeps the account, its sessions and tokens to the web login
+func RejectAPITokens(c *gin.Context) {
+	if c.GetString(apiTokenScopeKey) != "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Mit einem API-Token nicht verfügbar"})
+		return
+	}
+	c.Next()
+}
+
+// capAPITokenRole limits the role of the member to the scope of the API token of the request
+func capAPITokenRole(c *gin.Context, role string) string {
+	scope := c.GetString(apiTokenScopeKey)
+	if scope != "" && roleRank(scope) < roleRank(role) {
+		return scope
+	}
+	return role
+}
+
+// capAPITokenPermission limits the permission to the default permission of the token scope
+func capAPITokenPermission(c *gin.Context, permission models.EffectivePermission) models.EffectivePermission {
+	scope := c.GetString(apiTokenScopeKey)
+	if scope == "" {
+		return permission
+	}
+	return permission.Intersect(models.DefaultPermissionForRole(scope))
+}
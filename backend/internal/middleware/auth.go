@@ -8,6 +8,7 @@ import (
 	"liquiswiss/pkg/reqctx"
 	"liquiswiss/pkg/utils"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,12 @@ import (
 var databaseService db_adapter.IDatabaseAdapter
 
 func AuthMiddleware(c *gin.Context) {
+	// Scripts send a personal API token instead of the session cookies
+	if token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found && strings.HasPrefix(token, utils.APITokenPrefix) {
+		authenticateAPIToken(c, token)
+		return
+	}
+
 	var accessClaims *auth.Claims
 
 	// Get AccessToken and if possible verify it
@@ -30,6 +37,8 @@ func AuthMiddleware(c *gin.Context) {
 		}
 	}
 
+	// Set once the session behind the access token is known to be active
+	sessionChecked := false
 	if accessClaims == nil {
 		// If access token is invalid, check if a refresh token exists
 		refreshToken, err := c.Cookie(utils.RefreshTokenName)
@@ -59,8 +68,14 @@ func AuthMiddleware(c *gin.Context) {
 			return
 		}
 
+		sessionChecked = true
+		// Shown in the session list of the user
+		if err := databaseService.TouchRefreshToken(refreshClaims.UserID, refreshClaims.ID, c.ClientIP()); err != nil {
+			logger.Logger.Error("Error updating the refresh token usage", err)
+		}
+
 		// Generate a new access token since the refresh token is valid
-		newAccessToken, accessExpirationTime, newAccessClaims, err := auth.GenerateAccessToken(models.User{ID: refreshClaims.UserID})
+		newAccessToken, accessExpirationTime, newAccessClaims, err := auth.GenerateSessionAccessToken(models.User{ID: refreshClaims.UserID}, refreshClaims.ID)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Erstellen eines neuen Access-Tokens", "logout": true})
 			return
@@ -74,23 +89,45 @@ func AuthMiddleware(c *gin.Context) {
 		accessClaims = newAccessClaims
 	}
 
-	exists, err := databaseService.CheckUserExistence(accessClaims.UserID)
-	if err != nil {
-		auth.ClearAuthCookies(c)
-		// TODO: Report as exception to Sentry
-		logger.Logger.Error("Error checking user existence", err)
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Fehler beim Überprüfen der Benutzerexistenz", "logout": true})
-		return
+	// A revoked session must not keep working until its access token expires. Sessions are
+	// deleted together with the user, so an active session implies the user still exists.
+	if accessClaims.SessionID != "" && !sessionChecked {
+		active, err := databaseService.CheckRefreshToken(accessClaims.UserID, accessClaims.SessionID)
+		if err != nil {
+			auth.ClearAuthCookies(c)
+			logger.Logger.Error("Error checking the session", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Überprüfen der Sitzung", "logout": true})
+			return
+		}
+		if !active {
+			auth.ClearAuthCookies(c)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Nicht angemeldet, Sitzung wurde beendet", "logout": true})
+			return
+		}
+		sessionChecked = true
 	}
-	if !exists {
-		// If the user no longer exists, delete both tokens and abort
-		auth.ClearAuthCookies(c)
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Nicht erlaubt, Benutzer existiert nicht mehr", "logout": true})
-		return
+
+	if !sessionChecked {
+		exists, err := databaseService.CheckUserExistence(accessClaims.UserID)
+		if err != nil {
+			auth.ClearAuthCookies(c)
+			// TODO: Report as exception to Sentry
+			logger.Logger.Error("Error checking user existence", err)
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Fehler beim Überprüfen der Benutzerexistenz", "logout": true})
+			return
+		}
+		if !exists {
+			// If the user no longer exists, delete both tokens and abort
+			auth.ClearAuthCookies(c)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Nicht erlaubt, Benutzer existiert nicht mehr", "logout": true})
+			return
+		}
 	}
 
 	// Pass the user ID to the next middleware or handler
 	c.Set("userID", accessClaims.UserID)
+	// Empty for access tokens without session (issued before sessions were tracked)
+	c.Set("sessionID", accessClaims.SessionID)
 	// Carry the browser tab's client id into the service layer so published
 	// events can be tagged with their origin (server-computed "own" flag)
 	if clientID := c.GetHeader("X-Client-ID"); clientID != "" {
@@ -103,6 +140,12 @@ func AuthMiddleware(c *gin.Context) {
 	c.Next()
 }
 
+// ClientIPMiddleware carries the caller's IP address into the service layer, new sessions store it
+func ClientIPMiddleware(c *gin.Context) {
+	c.Request = c.Request.WithContext(reqctx.WithClientIP(c.Request.Context(), c.ClientIP()))
+	c.Next()
+}
+
 func InjectUserService(s db_adapter.IDatabaseAdapter) {
 	databaseService = s
 }
@@ -9,6 +9,7 @@ import (
 
 	"liquiswiss/config"
 	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/reqctx"
 )
 
 const mcpAudience = "liquiswiss-mcp"
@@ -57,5 +58,7 @@ func OAuthBearerMiddleware(c *gin.Context) {
 	}
 
 	c.Set("userID", claims.UserID)
+	// The audit log attributes changes to the connected client
+	c.Request = c.Request.WithContext(reqctx.WithOAuthClientID(c.Request.Context(), claims.Subject))
 	c.Next()
 }
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"liquiswiss/pkg/models"
 )
 
 // Role hierarchy: owner > admin > editor > read-only
@@ -40,11 +42,12 @@ func RequireMinRole(minRole string) gin.HandlerFunc {
 			return
 		}
 
-		role, err := databaseService.GetCurrentUserRole(userID)
+		role, err := requestDatabase(c).GetCurrentUserRole(userID)
 		if err != nil || role == "" {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Keine Berechtigung für diese Organisation"})
 			return
 		}
+		role = capAPITokenRole(c, role)
 
 		if roleRank(role) < roleRank(minRole) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Ihre Rolle erlaubt diese Aktion nicht"})
@@ -55,3 +58,77 @@ func RequireMinRole(minRole string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePermission blocks requests whose authenticated user may not perform action on
+// entityType in their current organisation. The permission of the entity type wins over
+// the global one, members without any fall back to the default of their role.
+func RequirePermission(entityType string, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		if userID == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Nicht angemeldet"})
+			return
+		}
+
+		role, err := requestDatabase(c).GetCurrentUserRole(userID)
+		if err != nil || role == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Keine Berechtigung für diese Organisation"})
+			return
+		}
+		permissions, err := requestDatabase(c).ListCurrentMemberPermissions(userID)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		permission := capAPITokenPermission(c, models.ResolvePermission(role, permissions, entityType))
+		if !permission.Allows(action) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Ihre Berechtigungen erlauben diese Aktion nicht"})
+			return
+		}
+
+		c.Set("currentOrgRole", capAPITokenRole(c, role))
+		c.Next()
+	}
+}
+
+// twoFactorExemptRoutes stay reachable for members that still have to enable two-factor authentication.
+// They cover the profile, the enrolment itself and switching to another organisation.
+var twoFactorExemptRoutes = map[string]bool{
+	"GET /api/profile":                            true,
+	"PATCH /api/profile":                          true,
+	"POST /api/profile/password":                  true,
+	"GET /api/profile/organisation":               true,
+	"PATCH /api/profile/organisation":             true,
+	"GET /api/profile/two-factor":                 true,
+	"POST /api/profile/two-factor/setup":          true,
+	"POST /api/profile/two-factor/enable":         true,
+	"POST /api/profile/two-factor/recovery-codes": true,
+	"GET /api/access-token":                       true,
+	"GET /api/organisations":                      true,
+}
+
+// RequireTwoFactorCompliance blocks every route but twoFactorExemptRoutes when the current organisation
+// requires two-factor authentication and the user did not enable it yet. AuthMiddleware must run first.
+func RequireTwoFactorCompliance(c *gin.Context) {
+	if twoFactorExemptRoutes[c.Request.Method+" "+c.FullPath()] {
+		c.Next()
+		return
+	}
+	userID := c.GetInt64("userID")
+	if userID == 0 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Nicht angemeldet"})
+		return
+	}
+
+	compliant, err := requestDatabase(c).CheckCurrentUserTwoFactorCompliance(userID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	if !compliant {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Ihre Organisation verlangt die Zwei-Faktor-Authentifizierung", "twoFactorRequired": true})
+		return
+	}
+	c.Next()
+}
@@ -11,7 +11,10 @@ package mocks
 
 import (
 	context "context"
+	db_adapter "liquiswiss/internal/adapter/db_adapter"
 	events "liquiswiss/internal/events"
+	api_service "liquiswiss/internal/service/api_service"
+	export "liquiswiss/pkg/export"
 	models "liquiswiss/pkg/models"
 	reflect "reflect"
 	time "time"
@@ -62,6 +65,21 @@ func (mr *MockIAPIServiceMockRecorder) AcceptInvitation(ctx, payload, deviceName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockIAPIService)(nil).AcceptInvitation), ctx, payload, deviceName, authenticatedUserID)
 }
 
+// ApplyPayrollTemplate mocks base method.
+func (m *MockIAPIService) ApplyPayrollTemplate(ctx context.Context, payload models.ApplyPayrollTemplate, userID, salaryID int64) ([]models.SalaryCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPayrollTemplate", ctx, payload, userID, salaryID)
+	ret0, _ := ret[0].([]models.SalaryCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPayrollTemplate indicates an expected call of ApplyPayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) ApplyPayrollTemplate(ctx, payload, userID, salaryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).ApplyPayrollTemplate), ctx, payload, userID, salaryID)
+}
+
 // CalculateForecast mocks base method.
 func (m *MockIAPIService) CalculateForecast(ctx context.Context, userID int64) ([]models.Forecast, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +95,21 @@ func (mr *MockIAPIServiceMockRecorder) CalculateForecast(ctx, userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateForecast", reflect.TypeOf((*MockIAPIService)(nil).CalculateForecast), ctx, userID)
 }
 
+// CalculateScenarioForecast mocks base method.
+func (m *MockIAPIService) CalculateScenarioForecast(ctx context.Context, userID, scenarioID int64) (*models.ScenarioForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateScenarioForecast", ctx, userID, scenarioID)
+	ret0, _ := ret[0].(*models.ScenarioForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateScenarioForecast indicates an expected call of CalculateScenarioForecast.
+func (mr *MockIAPIServiceMockRecorder) CalculateScenarioForecast(ctx, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateScenarioForecast", reflect.TypeOf((*MockIAPIService)(nil).CalculateScenarioForecast), ctx, userID, scenarioID)
+}
+
 // CheckInvitation mocks base method.
 func (m *MockIAPIService) CheckInvitation(ctx context.Context, token string) (*models.CheckInvitationResponse, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +154,21 @@ func (mr *MockIAPIServiceMockRecorder) CheckResetPasswordCode(ctx, payload any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResetPasswordCode", reflect.TypeOf((*MockIAPIService)(nil).CheckResetPasswordCode), ctx, payload)
 }
 
+// CommitImport mocks base method.
+func (m *MockIAPIService) CommitImport(ctx context.Context, userID, importID int64) (*models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitImport", ctx, userID, importID)
+	ret0, _ := ret[0].(*models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitImport indicates an expected call of CommitImport.
+func (mr *MockIAPIServiceMockRecorder) CommitImport(ctx, userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitImport", reflect.TypeOf((*MockIAPIService)(nil).CommitImport), ctx, userID, importID)
+}
+
 // CopySalaryCosts mocks base method.
 func (m *MockIAPIService) CopySalaryCosts(ctx context.Context, payload models.CopySalaryCosts, userID, salaryID int64) error {
 	m.ctrl.T.Helper()
@@ -180,6 +228,36 @@ func (mr *MockIAPIServiceMockRecorder) CountUniqueCurrenciesInFiatRates(ctx any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUniqueCurrenciesInFiatRates", reflect.TypeOf((*MockIAPIService)(nil).CountUniqueCurrenciesInFiatRates), ctx)
 }
 
+// CreateAPIToken mocks base method.
+func (m *MockIAPIService) CreateAPIToken(ctx context.Context, payload models.CreateAPIToken, userID int64) (*models.CreatedAPIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.CreatedAPIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockIAPIServiceMockRecorder) CreateAPIToken(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockIAPIService)(nil).CreateAPIToken), ctx, payload, userID)
+}
+
+// CreateActual mocks base method.
+func (m *MockIAPIService) CreateActual(ctx context.Context, payload models.CreateManualActual, userID int64) (*models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActual", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActual indicates an expected call of CreateActual.
+func (mr *MockIAPIServiceMockRecorder) CreateActual(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActual", reflect.TypeOf((*MockIAPIService)(nil).CreateActual), ctx, payload, userID)
+}
+
 // CreateBankAccount mocks base method.
 func (m *MockIAPIService) CreateBankAccount(ctx context.Context, payload models.CreateBankAccount, userID int64) (*models.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -195,6 +273,21 @@ func (mr *MockIAPIServiceMockRecorder) CreateBankAccount(ctx, payload, userID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankAccount", reflect.TypeOf((*MockIAPIService)(nil).CreateBankAccount), ctx, payload, userID)
 }
 
+// CreateBankAccountBalance mocks base method.
+func (m *MockIAPIService) CreateBankAccountBalance(ctx context.Context, payload models.CreateBankAccountBalance, userID, bankAccountID int64) (*models.BankAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBankAccountBalance", ctx, payload, userID, bankAccountID)
+	ret0, _ := ret[0].(*models.BankAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBankAccountBalance indicates an expected call of CreateBankAccountBalance.
+func (mr *MockIAPIServiceMockRecorder) CreateBankAccountBalance(ctx, payload, userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankAccountBalance", reflect.TypeOf((*MockIAPIService)(nil).CreateBankAccountBalance), ctx, payload, userID, bankAccountID)
+}
+
 // CreateCategory mocks base method.
 func (m *MockIAPIService) CreateCategory(ctx context.Context, payload models.CreateCategory, userID *int64) (*models.Category, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +348,21 @@ func (mr *MockIAPIServiceMockRecorder) CreateForecastExclusion(ctx, payload, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForecastExclusion", reflect.TypeOf((*MockIAPIService)(nil).CreateForecastExclusion), ctx, payload, userID)
 }
 
+// CreateForecastSnapshot mocks base method.
+func (m *MockIAPIService) CreateForecastSnapshot(ctx context.Context, payload models.CreateForecastSnapshot, userID int64) (*models.ForecastSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForecastSnapshot", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.ForecastSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForecastSnapshot indicates an expected call of CreateForecastSnapshot.
+func (mr *MockIAPIServiceMockRecorder) CreateForecastSnapshot(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForecastSnapshot", reflect.TypeOf((*MockIAPIService)(nil).CreateForecastSnapshot), ctx, payload, userID)
+}
+
 // CreateOrganisation mocks base method.
 func (m *MockIAPIService) CreateOrganisation(ctx context.Context, payload models.CreateOrganisation, userID int64) (*models.Organisation, error) {
 	m.ctrl.T.Helper()
@@ -285,6 +393,36 @@ func (mr *MockIAPIServiceMockRecorder) CreateOrganisationInvitation(ctx, payload
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganisationInvitation", reflect.TypeOf((*MockIAPIService)(nil).CreateOrganisationInvitation), ctx, payload, userID, organisationID)
 }
 
+// CreatePayrollTemplate mocks base method.
+func (m *MockIAPIService) CreatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayrollTemplate", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayrollTemplate indicates an expected call of CreatePayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) CreatePayrollTemplate(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).CreatePayrollTemplate), ctx, payload, userID)
+}
+
+// CreatePayrollTemplateVersion mocks base method.
+func (m *MockIAPIService) CreatePayrollTemplateVersion(ctx context.Context, payload models.CreatePayrollTemplateVersion, userID, templateID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayrollTemplateVersion", ctx, payload, userID, templateID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayrollTemplateVersion indicates an expected call of CreatePayrollTemplateVersion.
+func (mr *MockIAPIServiceMockRecorder) CreatePayrollTemplateVersion(ctx, payload, userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollTemplateVersion", reflect.TypeOf((*MockIAPIService)(nil).CreatePayrollTemplateVersion), ctx, payload, userID, templateID)
+}
+
 // CreateRegistration mocks base method.
 func (m *MockIAPIService) CreateRegistration(ctx context.Context, payload models.CreateRegistration, code string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -345,6 +483,51 @@ func (mr *MockIAPIServiceMockRecorder) CreateSalaryCostLabel(ctx, payload, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSalaryCostLabel", reflect.TypeOf((*MockIAPIService)(nil).CreateSalaryCostLabel), ctx, payload, userID)
 }
 
+// CreateScenario mocks base method.
+func (m *MockIAPIService) CreateScenario(ctx context.Context, payload models.CreateScenario, userID int64) (*models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScenario", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScenario indicates an expected call of CreateScenario.
+func (mr *MockIAPIServiceMockRecorder) CreateScenario(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScenario", reflect.TypeOf((*MockIAPIService)(nil).CreateScenario), ctx, payload, userID)
+}
+
+// CreateScenarioItem mocks base method.
+func (m *MockIAPIService) CreateScenarioItem(ctx context.Context, payload models.CreateScenarioItem, userID, scenarioID int64) (*models.ScenarioItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScenarioItem", ctx, payload, userID, scenarioID)
+	ret0, _ := ret[0].(*models.ScenarioItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScenarioItem indicates an expected call of CreateScenarioItem.
+func (mr *MockIAPIServiceMockRecorder) CreateScenarioItem(ctx, payload, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScenarioItem", reflect.TypeOf((*MockIAPIService)(nil).CreateScenarioItem), ctx, payload, userID, scenarioID)
+}
+
+// CreateStatutoryPayrollTemplate mocks base method.
+func (m *MockIAPIService) CreateStatutoryPayrollTemplate(ctx context.Context, payload models.CreateStatutoryPayrollTemplate, userID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatutoryPayrollTemplate", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatutoryPayrollTemplate indicates an expected call of CreateStatutoryPayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) CreateStatutoryPayrollTemplate(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatutoryPayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).CreateStatutoryPayrollTemplate), ctx, payload, userID)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockIAPIService) CreateTransaction(ctx context.Context, payload models.CreateTransaction, userID int64) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -390,6 +573,21 @@ func (mr *MockIAPIServiceMockRecorder) CreateVatSetting(ctx, payload, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVatSetting", reflect.TypeOf((*MockIAPIService)(nil).CreateVatSetting), ctx, payload, userID)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockIAPIService) CreateWebhook(ctx context.Context, payload models.CreateWebhook, userID int64) (*models.WebhookWithSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.WebhookWithSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockIAPIServiceMockRecorder) CreateWebhook(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockIAPIService)(nil).CreateWebhook), ctx, payload, userID)
+}
+
 // DeclineMyInvitation mocks base method.
 func (m *MockIAPIService) DeclineMyInvitation(ctx context.Context, userID, invitationID int64) error {
 	m.ctrl.T.Helper()
@@ -404,6 +602,34 @@ func (mr *MockIAPIServiceMockRecorder) DeclineMyInvitation(ctx, userID, invitati
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineMyInvitation", reflect.TypeOf((*MockIAPIService)(nil).DeclineMyInvitation), ctx, userID, invitationID)
 }
 
+// DeleteAPIToken mocks base method.
+func (m *MockIAPIService) DeleteAPIToken(ctx context.Context, userID, tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIToken", ctx, userID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockIAPIServiceMockRecorder) DeleteAPIToken(ctx, userID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockIAPIService)(nil).DeleteAPIToken), ctx, userID, tokenID)
+}
+
+// DeleteActual mocks base method.
+func (m *MockIAPIService) DeleteActual(ctx context.Context, userID, actualID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActual", ctx, userID, actualID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActual indicates an expected call of DeleteActual.
+func (mr *MockIAPIServiceMockRecorder) DeleteActual(ctx, userID, actualID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActual", reflect.TypeOf((*MockIAPIService)(nil).DeleteActual), ctx, userID, actualID)
+}
+
 // DeleteBankAccount mocks base method.
 func (m *MockIAPIService) DeleteBankAccount(ctx context.Context, userID, bankAccountID int64) error {
 	m.ctrl.T.Helper()
@@ -418,6 +644,34 @@ func (mr *MockIAPIServiceMockRecorder) DeleteBankAccount(ctx, userID, bankAccoun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankAccount", reflect.TypeOf((*MockIAPIService)(nil).DeleteBankAccount), ctx, userID, bankAccountID)
 }
 
+// DeleteBankAccountBalance mocks base method.
+func (m *MockIAPIService) DeleteBankAccountBalance(ctx context.Context, userID, bankAccountID, balanceID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBankAccountBalance", ctx, userID, bankAccountID, balanceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBankAccountBalance indicates an expected call of DeleteBankAccountBalance.
+func (mr *MockIAPIServiceMockRecorder) DeleteBankAccountBalance(ctx, userID, bankAccountID, balanceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankAccountBalance", reflect.TypeOf((*MockIAPIService)(nil).DeleteBankAccountBalance), ctx, userID, bankAccountID, balanceID)
+}
+
+// DeleteBankStatementImport mocks base method.
+func (m *MockIAPIService) DeleteBankStatementImport(ctx context.Context, userID, bankAccountID, importID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBankStatementImport", ctx, userID, bankAccountID, importID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBankStatementImport indicates an expected call of DeleteBankStatementImport.
+func (mr *MockIAPIServiceMockRecorder) DeleteBankStatementImport(ctx, userID, bankAccountID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankStatementImport", reflect.TypeOf((*MockIAPIService)(nil).DeleteBankStatementImport), ctx, userID, bankAccountID, importID)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockIAPIService) DeleteCategory(ctx context.Context, userID, categoryID int64) error {
 	m.ctrl.T.Helper()
@@ -461,6 +715,48 @@ func (mr *MockIAPIServiceMockRecorder) DeleteForecastExclusion(ctx, payload, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForecastExclusion", reflect.TypeOf((*MockIAPIService)(nil).DeleteForecastExclusion), ctx, payload, userID)
 }
 
+// DeleteForecastSnapshot mocks base method.
+func (m *MockIAPIService) DeleteForecastSnapshot(ctx context.Context, userID, snapshotID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForecastSnapshot", ctx, userID, snapshotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForecastSnapshot indicates an expected call of DeleteForecastSnapshot.
+func (mr *MockIAPIServiceMockRecorder) DeleteForecastSnapshot(ctx, userID, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForecastSnapshot", reflect.TypeOf((*MockIAPIService)(nil).DeleteForecastSnapshot), ctx, userID, snapshotID)
+}
+
+// DeleteImport mocks base method.
+func (m *MockIAPIService) DeleteImport(ctx context.Context, userID, importID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImport", ctx, userID, importID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImport indicates an expected call of DeleteImport.
+func (mr *MockIAPIServiceMockRecorder) DeleteImport(ctx, userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImport", reflect.TypeOf((*MockIAPIService)(nil).DeleteImport), ctx, userID, importID)
+}
+
+// DeleteOrganisationFiatRate mocks base method.
+func (m *MockIAPIService) DeleteOrganisationFiatRate(ctx context.Context, userID int64, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganisationFiatRate", ctx, userID, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganisationFiatRate indicates an expected call of DeleteOrganisationFiatRate.
+func (mr *MockIAPIServiceMockRecorder) DeleteOrganisationFiatRate(ctx, userID, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganisationFiatRate", reflect.TypeOf((*MockIAPIService)(nil).DeleteOrganisationFiatRate), ctx, userID, target)
+}
+
 // DeleteOrganisationInvitation mocks base method.
 func (m *MockIAPIService) DeleteOrganisationInvitation(ctx context.Context, userID, organisationID, invitationID int64) error {
 	m.ctrl.T.Helper()
@@ -475,6 +771,34 @@ func (mr *MockIAPIServiceMockRecorder) DeleteOrganisationInvitation(ctx, userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganisationInvitation", reflect.TypeOf((*MockIAPIService)(nil).DeleteOrganisationInvitation), ctx, userID, organisationID, invitationID)
 }
 
+// DeleteOrganisationMemberPermission mocks base method.
+func (m *MockIAPIService) DeleteOrganisationMemberPermission(ctx context.Context, userID, organisationID, memberUserID int64, entityType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganisationMemberPermission", ctx, userID, organisationID, memberUserID, entityType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganisationMemberPermission indicates an expected call of DeleteOrganisationMemberPermission.
+func (mr *MockIAPIServiceMockRecorder) DeleteOrganisationMemberPermission(ctx, userID, organisationID, memberUserID, entityType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganisationMemberPermission", reflect.TypeOf((*MockIAPIService)(nil).DeleteOrganisationMemberPermission), ctx, userID, organisationID, memberUserID, entityType)
+}
+
+// DeletePayrollTemplate mocks base method.
+func (m *MockIAPIService) DeletePayrollTemplate(ctx context.Context, userID, templateID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayrollTemplate", ctx, userID, templateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayrollTemplate indicates an expected call of DeletePayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) DeletePayrollTemplate(ctx, userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).DeletePayrollTemplate), ctx, userID, templateID)
+}
+
 // DeleteRegistration mocks base method.
 func (m *MockIAPIService) DeleteRegistration(ctx context.Context, registrationID int64, email string) error {
 	m.ctrl.T.Helper()
@@ -531,6 +855,34 @@ func (mr *MockIAPIServiceMockRecorder) DeleteSalaryCostLabel(ctx, userID, salary
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSalaryCostLabel", reflect.TypeOf((*MockIAPIService)(nil).DeleteSalaryCostLabel), ctx, userID, salaryCostLabelID)
 }
 
+// DeleteScenario mocks base method.
+func (m *MockIAPIService) DeleteScenario(ctx context.Context, userID, scenarioID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScenario", ctx, userID, scenarioID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScenario indicates an expected call of DeleteScenario.
+func (mr *MockIAPIServiceMockRecorder) DeleteScenario(ctx, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScenario", reflect.TypeOf((*MockIAPIService)(nil).DeleteScenario), ctx, userID, scenarioID)
+}
+
+// DeleteScenarioItem mocks base method.
+func (m *MockIAPIService) DeleteScenarioItem(ctx context.Context, userID, scenarioID, scenarioItemID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScenarioItem", ctx, userID, scenarioID, scenarioItemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScenarioItem indicates an expected call of DeleteScenarioItem.
+func (mr *MockIAPIServiceMockRecorder) DeleteScenarioItem(ctx, userID, scenarioID, scenarioItemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScenarioItem", reflect.TypeOf((*MockIAPIService)(nil).DeleteScenarioItem), ctx, userID, scenarioID, scenarioItemID)
+}
+
 // DeleteTransaction mocks base method.
 func (m *MockIAPIService) DeleteTransaction(ctx context.Context, userID, transactionID int64) error {
 	m.ctrl.T.Helper()
@@ -573,6 +925,133 @@ func (mr *MockIAPIServiceMockRecorder) DeleteVatSetting(ctx, userID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVatSetting", reflect.TypeOf((*MockIAPIService)(nil).DeleteVatSetting), ctx, userID)
 }
 
+// DeleteWebhook mocks base method.
+func (m *MockIAPIService) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockIAPIServiceMockRecorder) DeleteWebhook(ctx, userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockIAPIService)(nil).DeleteWebhook), ctx, userID, webhookID)
+}
+
+// DiffForecastSnapshots mocks base method.
+func (m *MockIAPIService) DiffForecastSnapshots(ctx context.Context, userID, fromSnapshotID, toSnapshotID int64) (*models.ForecastSnapshotDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffForecastSnapshots", ctx, userID, fromSnapshotID, toSnapshotID)
+	ret0, _ := ret[0].(*models.ForecastSnapshotDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffForecastSnapshots indicates an expected call of DiffForecastSnapshots.
+func (mr *MockIAPIServiceMockRecorder) DiffForecastSnapshots(ctx, userID, fromSnapshotID, toSnapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffForecastSnapshots", reflect.TypeOf((*MockIAPIService)(nil).DiffForecastSnapshots), ctx, userID, fromSnapshotID, toSnapshotID)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockIAPIService) DisableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", ctx, payload, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockIAPIServiceMockRecorder) DisableTwoFactor(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockIAPIService)(nil).DisableTwoFactor), ctx, payload, userID)
+}
+
+// EnableForecastScheduler mocks base method.
+func (m *MockIAPIService) EnableForecastScheduler(debounce time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableForecastScheduler", debounce)
+}
+
+// EnableForecastScheduler indicates an expected call of EnableForecastScheduler.
+func (mr *MockIAPIServiceMockRecorder) EnableForecastScheduler(debounce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableForecastScheduler", reflect.TypeOf((*MockIAPIService)(nil).EnableForecastScheduler), debounce)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockIAPIService) EnableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.TwoFactorRecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockIAPIServiceMockRecorder) EnableTwoFactor(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockIAPIService)(nil).EnableTwoFactor), ctx, payload, userID)
+}
+
+// EnableWebhooks mocks base method.
+func (m *MockIAPIService) EnableWebhooks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableWebhooks")
+}
+
+// EnableWebhooks indicates an expected call of EnableWebhooks.
+func (mr *MockIAPIServiceMockRecorder) EnableWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableWebhooks", reflect.TypeOf((*MockIAPIService)(nil).EnableWebhooks))
+}
+
+// ExportEmployees mocks base method.
+func (m *MockIAPIService) ExportEmployees(ctx context.Context, userID int64) (*export.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEmployees", ctx, userID)
+	ret0, _ := ret[0].(*export.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportEmployees indicates an expected call of ExportEmployees.
+func (mr *MockIAPIServiceMockRecorder) ExportEmployees(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEmployees", reflect.TypeOf((*MockIAPIService)(nil).ExportEmployees), ctx, userID)
+}
+
+// ExportForecast mocks base method.
+func (m *MockIAPIService) ExportForecast(ctx context.Context, userID, months int64) (*export.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportForecast", ctx, userID, months)
+	ret0, _ := ret[0].(*export.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportForecast indicates an expected call of ExportForecast.
+func (mr *MockIAPIServiceMockRecorder) ExportForecast(ctx, userID, months any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportForecast", reflect.TypeOf((*MockIAPIService)(nil).ExportForecast), ctx, userID, months)
+}
+
+// ExportTransactions mocks base method.
+func (m *MockIAPIService) ExportTransactions(ctx context.Context, userID int64) (*export.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", ctx, userID)
+	ret0, _ := ret[0].(*export.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockIAPIServiceMockRecorder) ExportTransactions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockIAPIService)(nil).ExportTransactions), ctx, userID)
+}
+
 // FinishRegistration mocks base method.
 func (m *MockIAPIService) FinishRegistration(ctx context.Context, payload models.FinishRegistration, deviceName string, validity time.Duration) (*models.User, *string, *time.Time, *string, *time.Time, error) {
 	m.ctrl.T.Helper()
@@ -606,6 +1085,21 @@ func (mr *MockIAPIServiceMockRecorder) ForgotPassword(ctx, payload, code any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockIAPIService)(nil).ForgotPassword), ctx, payload, code)
 }
 
+// GetActual mocks base method.
+func (m *MockIAPIService) GetActual(ctx context.Context, userID, actualID int64) (*models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActual", ctx, userID, actualID)
+	ret0, _ := ret[0].(*models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActual indicates an expected call of GetActual.
+func (mr *MockIAPIServiceMockRecorder) GetActual(ctx, userID, actualID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActual", reflect.TypeOf((*MockIAPIService)(nil).GetActual), ctx, userID, actualID)
+}
+
 // GetBankAccount mocks base method.
 func (m *MockIAPIService) GetBankAccount(ctx context.Context, userID, bankAccountID int64) (*models.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -621,6 +1115,21 @@ func (mr *MockIAPIServiceMockRecorder) GetBankAccount(ctx, userID, bankAccountID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankAccount", reflect.TypeOf((*MockIAPIService)(nil).GetBankAccount), ctx, userID, bankAccountID)
 }
 
+// GetBankStatementImport mocks base method.
+func (m *MockIAPIService) GetBankStatementImport(ctx context.Context, userID, bankAccountID, importID int64) (*models.BankStatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankStatementImport", ctx, userID, bankAccountID, importID)
+	ret0, _ := ret[0].(*models.BankStatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankStatementImport indicates an expected call of GetBankStatementImport.
+func (mr *MockIAPIServiceMockRecorder) GetBankStatementImport(ctx, userID, bankAccountID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankStatementImport", reflect.TypeOf((*MockIAPIService)(nil).GetBankStatementImport), ctx, userID, bankAccountID, importID)
+}
+
 // GetCategory mocks base method.
 func (m *MockIAPIService) GetCategory(ctx context.Context, userID, categoryID int64) (*models.Category, error) {
 	m.ctrl.T.Helper()
@@ -696,6 +1205,81 @@ func (mr *MockIAPIServiceMockRecorder) GetFiatRate(ctx, base, target any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiatRate", reflect.TypeOf((*MockIAPIService)(nil).GetFiatRate), ctx, base, target)
 }
 
+// GetFiatRateAt mocks base method.
+func (m *MockIAPIService) GetFiatRateAt(ctx context.Context, base, target string, date time.Time) (*models.FiatRateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiatRateAt", ctx, base, target, date)
+	ret0, _ := ret[0].(*models.FiatRateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiatRateAt indicates an expected call of GetFiatRateAt.
+func (mr *MockIAPIServiceMockRecorder) GetFiatRateAt(ctx, base, target, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiatRateAt", reflect.TypeOf((*MockIAPIService)(nil).GetFiatRateAt), ctx, base, target, date)
+}
+
+// GetFiatRateHealth mocks base method.
+func (m *MockIAPIService) GetFiatRateHealth(ctx context.Context) (*models.FiatRateHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiatRateHealth", ctx)
+	ret0, _ := ret[0].(*models.FiatRateHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiatRateHealth indicates an expected call of GetFiatRateHealth.
+func (mr *MockIAPIServiceMockRecorder) GetFiatRateHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiatRateHealth", reflect.TypeOf((*MockIAPIService)(nil).GetFiatRateHealth), ctx)
+}
+
+// GetForecastSnapshot mocks base method.
+func (m *MockIAPIService) GetForecastSnapshot(ctx context.Context, userID, snapshotID int64) (*models.ForecastSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecastSnapshot", ctx, userID, snapshotID)
+	ret0, _ := ret[0].(*models.ForecastSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForecastSnapshot indicates an expected call of GetForecastSnapshot.
+func (mr *MockIAPIServiceMockRecorder) GetForecastSnapshot(ctx, userID, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastSnapshot", reflect.TypeOf((*MockIAPIService)(nil).GetForecastSnapshot), ctx, userID, snapshotID)
+}
+
+// GetForecastStatus mocks base method.
+func (m *MockIAPIService) GetForecastStatus(ctx context.Context, userID int64) (*models.ForecastStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecastStatus", ctx, userID)
+	ret0, _ := ret[0].(*models.ForecastStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForecastStatus indicates an expected call of GetForecastStatus.
+func (mr *MockIAPIServiceMockRecorder) GetForecastStatus(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastStatus", reflect.TypeOf((*MockIAPIService)(nil).GetForecastStatus), ctx, userID)
+}
+
+// GetImport mocks base method.
+func (m *MockIAPIService) GetImport(ctx context.Context, userID, importID int64) (*models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", ctx, userID, importID)
+	ret0, _ := ret[0].(*models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockIAPIServiceMockRecorder) GetImport(ctx, userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockIAPIService)(nil).GetImport), ctx, userID, importID)
+}
+
 // GetOrganisation mocks base method.
 func (m *MockIAPIService) GetOrganisation(ctx context.Context, userID, organisationID int64) (*models.Organisation, error) {
 	m.ctrl.T.Helper()
@@ -711,6 +1295,21 @@ func (mr *MockIAPIServiceMockRecorder) GetOrganisation(ctx, userID, organisation
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisation", reflect.TypeOf((*MockIAPIService)(nil).GetOrganisation), ctx, userID, organisationID)
 }
 
+// GetPayrollTemplate mocks base method.
+func (m *MockIAPIService) GetPayrollTemplate(ctx context.Context, userID, templateID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollTemplate", ctx, userID, templateID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollTemplate indicates an expected call of GetPayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) GetPayrollTemplate(ctx, userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).GetPayrollTemplate), ctx, userID, templateID)
+}
+
 // GetProfile mocks base method.
 func (m *MockIAPIService) GetProfile(ctx context.Context, userID int64) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -771,6 +1370,21 @@ func (mr *MockIAPIServiceMockRecorder) GetSalaryCostLabel(ctx, userID, salaryCos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalaryCostLabel", reflect.TypeOf((*MockIAPIService)(nil).GetSalaryCostLabel), ctx, userID, salaryCostLabelID)
 }
 
+// GetScenario mocks base method.
+func (m *MockIAPIService) GetScenario(ctx context.Context, userID, scenarioID int64) (*models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenario", ctx, userID, scenarioID)
+	ret0, _ := ret[0].(*models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenario indicates an expected call of GetScenario.
+func (mr *MockIAPIServiceMockRecorder) GetScenario(ctx, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenario", reflect.TypeOf((*MockIAPIService)(nil).GetScenario), ctx, userID, scenarioID)
+}
+
 // GetTransaction mocks base method.
 func (m *MockIAPIService) GetTransaction(ctx context.Context, userID, transactionID int64) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -786,6 +1400,21 @@ func (mr *MockIAPIServiceMockRecorder) GetTransaction(ctx, userID, transactionID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockIAPIService)(nil).GetTransaction), ctx, userID, transactionID)
 }
 
+// GetTwoFactorStatus mocks base method.
+func (m *MockIAPIService) GetTwoFactorStatus(ctx context.Context, userID int64) (*models.TwoFactorStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactorStatus", ctx, userID)
+	ret0, _ := ret[0].(*models.TwoFactorStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactorStatus indicates an expected call of GetTwoFactorStatus.
+func (mr *MockIAPIServiceMockRecorder) GetTwoFactorStatus(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactorStatus", reflect.TypeOf((*MockIAPIService)(nil).GetTwoFactorStatus), ctx, userID)
+}
+
 // GetUserOrganisationSetting mocks base method.
 func (m *MockIAPIService) GetUserOrganisationSetting(ctx context.Context, userID int64) (*models.UserOrganisationSetting, error) {
 	m.ctrl.T.Helper()
@@ -816,6 +1445,21 @@ func (mr *MockIAPIServiceMockRecorder) GetUserSetting(ctx, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSetting", reflect.TypeOf((*MockIAPIService)(nil).GetUserSetting), ctx, userID)
 }
 
+// GetVarianceReport mocks base method.
+func (m *MockIAPIService) GetVarianceReport(ctx context.Context, userID int64, from, to string) (*models.VarianceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVarianceReport", ctx, userID, from, to)
+	ret0, _ := ret[0].(*models.VarianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVarianceReport indicates an expected call of GetVarianceReport.
+func (mr *MockIAPIServiceMockRecorder) GetVarianceReport(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVarianceReport", reflect.TypeOf((*MockIAPIService)(nil).GetVarianceReport), ctx, userID, from, to)
+}
+
 // GetVat mocks base method.
 func (m *MockIAPIService) GetVat(ctx context.Context, userID, vatID int64) (*models.Vat, error) {
 	m.ctrl.T.Helper()
@@ -846,19 +1490,110 @@ func (mr *MockIAPIServiceMockRecorder) GetVatSetting(ctx, userID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVatSetting", reflect.TypeOf((*MockIAPIService)(nil).GetVatSetting), ctx, userID)
 }
 
+// ImportActuals mocks base method.
+func (m *MockIAPIService) ImportActuals(ctx context.Context, userID int64, data []byte) (*models.ActualsImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportActuals", ctx, userID, data)
+	ret0, _ := ret[0].(*models.ActualsImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportActuals indicates an expected call of ImportActuals.
+func (mr *MockIAPIServiceMockRecorder) ImportActuals(ctx, userID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportActuals", reflect.TypeOf((*MockIAPIService)(nil).ImportActuals), ctx, userID, data)
+}
+
+// ImportBankStatement mocks base method.
+func (m *MockIAPIService) ImportBankStatement(ctx context.Context, userID, bankAccountID int64, fileName, format string, data []byte) (*models.BankStatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankStatement", ctx, userID, bankAccountID, fileName, format, data)
+	ret0, _ := ret[0].(*models.BankStatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankStatement indicates an expected call of ImportBankStatement.
+func (mr *MockIAPIServiceMockRecorder) ImportBankStatement(ctx, userID, bankAccountID, fileName, format, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankStatement", reflect.TypeOf((*MockIAPIService)(nil).ImportBankStatement), ctx, userID, bankAccountID, fileName, format, data)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockIAPIService) ListAPITokens(ctx context.Context, userID int64) ([]models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", ctx, userID)
+	ret0, _ := ret[0].([]models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockIAPIServiceMockRecorder) ListAPITokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockIAPIService)(nil).ListAPITokens), ctx, userID)
+}
+
+// ListActuals mocks base method.
+func (m *MockIAPIService) ListActuals(ctx context.Context, userID int64, from, to string) ([]models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActuals", ctx, userID, from, to)
+	ret0, _ := ret[0].([]models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActuals indicates an expected call of ListActuals.
+func (mr *MockIAPIServiceMockRecorder) ListActuals(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActuals", reflect.TypeOf((*MockIAPIService)(nil).ListActuals), ctx, userID, from, to)
+}
+
 // ListAllForecastExclusions mocks base method.
 func (m *MockIAPIService) ListAllForecastExclusions(ctx context.Context, userID int64) ([]models.ForecastExclusionInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllForecastExclusions", ctx, userID)
-	ret0, _ := ret[0].([]models.ForecastExclusionInfo)
+	ret := m.ctrl.Call(m, "ListAllForecastExclusions", ctx, userID)
+	ret0, _ := ret[0].([]models.ForecastExclusionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllForecastExclusions indicates an expected call of ListAllForecastExclusions.
+func (mr *MockIAPIServiceMockRecorder) ListAllForecastExclusions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllForecastExclusions", reflect.TypeOf((*MockIAPIService)(nil).ListAllForecastExclusions), ctx, userID)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockIAPIService) ListAuditLogs(ctx context.Context, userID int64, filter models.AuditLogFilter, page, limit int64) ([]models.AuditLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", ctx, userID, filter, page, limit)
+	ret0, _ := ret[0].([]models.AuditLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockIAPIServiceMockRecorder) ListAuditLogs(ctx, userID, filter, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockIAPIService)(nil).ListAuditLogs), ctx, userID, filter, page, limit)
+}
+
+// ListBankAccountBalances mocks base method.
+func (m *MockIAPIService) ListBankAccountBalances(ctx context.Context, userID, bankAccountID int64) ([]models.BankAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankAccountBalances", ctx, userID, bankAccountID)
+	ret0, _ := ret[0].([]models.BankAccountBalance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllForecastExclusions indicates an expected call of ListAllForecastExclusions.
-func (mr *MockIAPIServiceMockRecorder) ListAllForecastExclusions(ctx, userID any) *gomock.Call {
+// ListBankAccountBalances indicates an expected call of ListBankAccountBalances.
+func (mr *MockIAPIServiceMockRecorder) ListBankAccountBalances(ctx, userID, bankAccountID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllForecastExclusions", reflect.TypeOf((*MockIAPIService)(nil).ListAllForecastExclusions), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankAccountBalances", reflect.TypeOf((*MockIAPIService)(nil).ListBankAccountBalances), ctx, userID, bankAccountID)
 }
 
 // ListBankAccounts mocks base method.
@@ -877,6 +1612,21 @@ func (mr *MockIAPIServiceMockRecorder) ListBankAccounts(ctx, userID, page, limit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankAccounts", reflect.TypeOf((*MockIAPIService)(nil).ListBankAccounts), ctx, userID, page, limit, sortBy, sortOrder, search)
 }
 
+// ListBankStatementImports mocks base method.
+func (m *MockIAPIService) ListBankStatementImports(ctx context.Context, userID, bankAccountID int64) ([]models.BankStatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankStatementImports", ctx, userID, bankAccountID)
+	ret0, _ := ret[0].([]models.BankStatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankStatementImports indicates an expected call of ListBankStatementImports.
+func (mr *MockIAPIServiceMockRecorder) ListBankStatementImports(ctx, userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankStatementImports", reflect.TypeOf((*MockIAPIService)(nil).ListBankStatementImports), ctx, userID, bankAccountID)
+}
+
 // ListCategories mocks base method.
 func (m *MockIAPIService) ListCategories(ctx context.Context, userID, page, limit int64) ([]models.Category, int64, error) {
 	m.ctrl.T.Helper()
@@ -969,6 +1719,36 @@ func (mr *MockIAPIServiceMockRecorder) ListForecastExclusions(ctx, userID, relat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastExclusions", reflect.TypeOf((*MockIAPIService)(nil).ListForecastExclusions), ctx, userID, relatedID, relatedTable)
 }
 
+// ListForecastSnapshots mocks base method.
+func (m *MockIAPIService) ListForecastSnapshots(ctx context.Context, userID int64) ([]models.ForecastSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForecastSnapshots", ctx, userID)
+	ret0, _ := ret[0].([]models.ForecastSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForecastSnapshots indicates an expected call of ListForecastSnapshots.
+func (mr *MockIAPIServiceMockRecorder) ListForecastSnapshots(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastSnapshots", reflect.TypeOf((*MockIAPIService)(nil).ListForecastSnapshots), ctx, userID)
+}
+
+// ListForecastWarnings mocks base method.
+func (m *MockIAPIService) ListForecastWarnings(ctx context.Context, userID int64) ([]models.ForecastWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForecastWarnings", ctx, userID)
+	ret0, _ := ret[0].([]models.ForecastWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForecastWarnings indicates an expected call of ListForecastWarnings.
+func (mr *MockIAPIServiceMockRecorder) ListForecastWarnings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastWarnings", reflect.TypeOf((*MockIAPIService)(nil).ListForecastWarnings), ctx, userID)
+}
+
 // ListForecasts mocks base method.
 func (m *MockIAPIService) ListForecasts(ctx context.Context, userID, limit int64) ([]models.Forecast, error) {
 	m.ctrl.T.Helper()
@@ -984,6 +1764,21 @@ func (mr *MockIAPIServiceMockRecorder) ListForecasts(ctx, userID, limit any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecasts", reflect.TypeOf((*MockIAPIService)(nil).ListForecasts), ctx, userID, limit)
 }
 
+// ListImports mocks base method.
+func (m *MockIAPIService) ListImports(ctx context.Context, userID int64) ([]models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", ctx, userID)
+	ret0, _ := ret[0].([]models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockIAPIServiceMockRecorder) ListImports(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockIAPIService)(nil).ListImports), ctx, userID)
+}
+
 // ListMyPendingInvitations mocks base method.
 func (m *MockIAPIService) ListMyPendingInvitations(ctx context.Context, userID int64) ([]models.UserPendingInvitation, error) {
 	m.ctrl.T.Helper()
@@ -999,6 +1794,21 @@ func (mr *MockIAPIServiceMockRecorder) ListMyPendingInvitations(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyPendingInvitations", reflect.TypeOf((*MockIAPIService)(nil).ListMyPendingInvitations), ctx, userID)
 }
 
+// ListOrganisationFiatRates mocks base method.
+func (m *MockIAPIService) ListOrganisationFiatRates(ctx context.Context, userID int64) ([]models.OrganisationFiatRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganisationFiatRates", ctx, userID)
+	ret0, _ := ret[0].([]models.OrganisationFiatRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganisationFiatRates indicates an expected call of ListOrganisationFiatRates.
+func (mr *MockIAPIServiceMockRecorder) ListOrganisationFiatRates(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisationFiatRates", reflect.TypeOf((*MockIAPIService)(nil).ListOrganisationFiatRates), ctx, userID)
+}
+
 // ListOrganisationInvitations mocks base method.
 func (m *MockIAPIService) ListOrganisationInvitations(ctx context.Context, userID, organisationID int64) ([]models.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -1045,6 +1855,21 @@ func (mr *MockIAPIServiceMockRecorder) ListOrganisations(ctx, userID, page, limi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisations", reflect.TypeOf((*MockIAPIService)(nil).ListOrganisations), ctx, userID, page, limit)
 }
 
+// ListPayrollTemplates mocks base method.
+func (m *MockIAPIService) ListPayrollTemplates(ctx context.Context, userID int64) ([]models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollTemplates", ctx, userID)
+	ret0, _ := ret[0].([]models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollTemplates indicates an expected call of ListPayrollTemplates.
+func (mr *MockIAPIServiceMockRecorder) ListPayrollTemplates(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollTemplates", reflect.TypeOf((*MockIAPIService)(nil).ListPayrollTemplates), ctx, userID)
+}
+
 // ListSalaries mocks base method.
 func (m *MockIAPIService) ListSalaries(ctx context.Context, userID, employeeID, page, limit int64) ([]models.Salary, int64, error) {
 	m.ctrl.T.Helper()
@@ -1093,6 +1918,51 @@ func (mr *MockIAPIServiceMockRecorder) ListSalaryCosts(ctx, userID, salaryID, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalaryCosts", reflect.TypeOf((*MockIAPIService)(nil).ListSalaryCosts), ctx, userID, salaryID, page, limit, skipPrevious)
 }
 
+// ListScenarios mocks base method.
+func (m *MockIAPIService) ListScenarios(ctx context.Context, userID int64) ([]models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScenarios", ctx, userID)
+	ret0, _ := ret[0].([]models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScenarios indicates an expected call of ListScenarios.
+func (mr *MockIAPIServiceMockRecorder) ListScenarios(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScenarios", reflect.TypeOf((*MockIAPIService)(nil).ListScenarios), ctx, userID)
+}
+
+// ListSessions mocks base method.
+func (m *MockIAPIService) ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID, currentSessionID)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockIAPIServiceMockRecorder) ListSessions(ctx, userID, currentSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockIAPIService)(nil).ListSessions), ctx, userID, currentSessionID)
+}
+
+// ListStatutoryPayrollItems mocks base method.
+func (m *MockIAPIService) ListStatutoryPayrollItems(ctx context.Context, year uint16) ([]models.CreatePayrollTemplateItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatutoryPayrollItems", ctx, year)
+	ret0, _ := ret[0].([]models.CreatePayrollTemplateItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatutoryPayrollItems indicates an expected call of ListStatutoryPayrollItems.
+func (mr *MockIAPIServiceMockRecorder) ListStatutoryPayrollItems(ctx, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatutoryPayrollItems", reflect.TypeOf((*MockIAPIService)(nil).ListStatutoryPayrollItems), ctx, year)
+}
+
 // ListTransactions mocks base method.
 func (m *MockIAPIService) ListTransactions(ctx context.Context, userID, page, limit int64, sortBy, sortOrder, search string, hideDisabled, hideExpired bool) ([]models.Transaction, int64, error) {
 	m.ctrl.T.Helper()
@@ -1124,6 +1994,37 @@ func (mr *MockIAPIServiceMockRecorder) ListVats(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVats", reflect.TypeOf((*MockIAPIService)(nil).ListVats), ctx, userID)
 }
 
+// ListWebhookDeliveries mocks base method.
+func (m *MockIAPIService) ListWebhookDeliveries(ctx context.Context, userID, webhookID, page, limit int64) ([]models.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, userID, webhookID, page, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockIAPIServiceMockRecorder) ListWebhookDeliveries(ctx, userID, webhookID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockIAPIService)(nil).ListWebhookDeliveries), ctx, userID, webhookID, page, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockIAPIService) ListWebhooks(ctx context.Context, userID int64) ([]models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockIAPIServiceMockRecorder) ListWebhooks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockIAPIService)(nil).ListWebhooks), ctx, userID)
+}
+
 // Login mocks base method.
 func (m *MockIAPIService) Login(ctx context.Context, payload models.Login, deviceName, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
 	m.ctrl.T.Helper()
@@ -1143,6 +2044,44 @@ func (mr *MockIAPIServiceMockRecorder) Login(ctx, payload, deviceName, existingR
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAPIService)(nil).Login), ctx, payload, deviceName, existingRefreshToken)
 }
 
+// LoginSSO mocks base method.
+func (m *MockIAPIService) LoginSSO(ctx context.Context, identity models.SSOIdentity, deviceName, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginSSO", ctx, identity, deviceName, existingRefreshToken)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(*time.Time)
+	ret3, _ := ret[3].(*string)
+	ret4, _ := ret[4].(*time.Time)
+	ret5, _ := ret[5].(error)
+	return ret0, ret1, ret2, ret3, ret4, ret5
+}
+
+// LoginSSO indicates an expected call of LoginSSO.
+func (mr *MockIAPIServiceMockRecorder) LoginSSO(ctx, identity, deviceName, existingRefreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginSSO", reflect.TypeOf((*MockIAPIService)(nil).LoginSSO), ctx, identity, deviceName, existingRefreshToken)
+}
+
+// LoginTwoFactor mocks base method.
+func (m *MockIAPIService) LoginTwoFactor(ctx context.Context, payload models.LoginTwoFactor, deviceName, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginTwoFactor", ctx, payload, deviceName, existingRefreshToken)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(*time.Time)
+	ret3, _ := ret[3].(*string)
+	ret4, _ := ret[4].(*time.Time)
+	ret5, _ := ret[5].(error)
+	return ret0, ret1, ret2, ret3, ret4, ret5
+}
+
+// LoginTwoFactor indicates an expected call of LoginTwoFactor.
+func (mr *MockIAPIServiceMockRecorder) LoginTwoFactor(ctx, payload, deviceName, existingRefreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginTwoFactor", reflect.TypeOf((*MockIAPIService)(nil).LoginTwoFactor), ctx, payload, deviceName, existingRefreshToken)
+}
+
 // Logout mocks base method.
 func (m *MockIAPIService) Logout(ctx context.Context, existingRefreshToken string) {
 	m.ctrl.T.Helper()
@@ -1155,6 +2094,36 @@ func (mr *MockIAPIServiceMockRecorder) Logout(ctx, existingRefreshToken any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIAPIService)(nil).Logout), ctx, existingRefreshToken)
 }
 
+// PreviewEmployeeImport mocks base method.
+func (m *MockIAPIService) PreviewEmployeeImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewEmployeeImport", ctx, userID, fileName, data)
+	ret0, _ := ret[0].(*models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewEmployeeImport indicates an expected call of PreviewEmployeeImport.
+func (mr *MockIAPIServiceMockRecorder) PreviewEmployeeImport(ctx, userID, fileName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewEmployeeImport", reflect.TypeOf((*MockIAPIService)(nil).PreviewEmployeeImport), ctx, userID, fileName, data)
+}
+
+// PreviewTransactionImport mocks base method.
+func (m *MockIAPIService) PreviewTransactionImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTransactionImport", ctx, userID, fileName, data)
+	ret0, _ := ret[0].(*models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTransactionImport indicates an expected call of PreviewTransactionImport.
+func (mr *MockIAPIServiceMockRecorder) PreviewTransactionImport(ctx, userID, fileName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTransactionImport", reflect.TypeOf((*MockIAPIService)(nil).PreviewTransactionImport), ctx, userID, fileName, data)
+}
+
 // ReassignCategoryTransactions mocks base method.
 func (m *MockIAPIService) ReassignCategoryTransactions(ctx context.Context, userID, fromCategoryID, toCategoryID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1170,6 +2139,35 @@ func (mr *MockIAPIServiceMockRecorder) ReassignCategoryTransactions(ctx, userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignCategoryTransactions", reflect.TypeOf((*MockIAPIService)(nil).ReassignCategoryTransactions), ctx, userID, fromCategoryID, toCategoryID)
 }
 
+// RecordFiatRateFetch mocks base method.
+func (m *MockIAPIService) RecordFiatRateFetch(ctx context.Context, payload models.CreateFiatRateFetch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFiatRateFetch", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFiatRateFetch indicates an expected call of RecordFiatRateFetch.
+func (mr *MockIAPIServiceMockRecorder) RecordFiatRateFetch(ctx, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFiatRateFetch", reflect.TypeOf((*MockIAPIService)(nil).RecordFiatRateFetch), ctx, payload)
+}
+
+// RegenerateTwoFactorRecoveryCodes mocks base method.
+func (m *MockIAPIService) RegenerateTwoFactorRecoveryCodes(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateTwoFactorRecoveryCodes", ctx, payload, userID)
+	ret0, _ := ret[0].(*models.TwoFactorRecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateTwoFactorRecoveryCodes indicates an expected call of RegenerateTwoFactorRecoveryCodes.
+func (mr *MockIAPIServiceMockRecorder) RegenerateTwoFactorRecoveryCodes(ctx, payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateTwoFactorRecoveryCodes", reflect.TypeOf((*MockIAPIService)(nil).RegenerateTwoFactorRecoveryCodes), ctx, payload, userID)
+}
+
 // RemoveOrganisationMember mocks base method.
 func (m *MockIAPIService) RemoveOrganisationMember(ctx context.Context, userID, organisationID, memberUserID int64) error {
 	m.ctrl.T.Helper()
@@ -1184,6 +2182,21 @@ func (mr *MockIAPIServiceMockRecorder) RemoveOrganisationMember(ctx, userID, org
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrganisationMember", reflect.TypeOf((*MockIAPIService)(nil).RemoveOrganisationMember), ctx, userID, organisationID, memberUserID)
 }
 
+// ReplayWebhookDelivery mocks base method.
+func (m *MockIAPIService) ReplayWebhookDelivery(ctx context.Context, userID, webhookID, deliveryID int64) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", ctx, userID, webhookID, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockIAPIServiceMockRecorder) ReplayWebhookDelivery(ctx, userID, webhookID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockIAPIService)(nil).ReplayWebhookDelivery), ctx, userID, webhookID, deliveryID)
+}
+
 // ResendOrganisationInvitation mocks base method.
 func (m *MockIAPIService) ResendOrganisationInvitation(ctx context.Context, userID, organisationID, invitationID int64) error {
 	m.ctrl.T.Helper()
@@ -1212,6 +2225,64 @@ func (mr *MockIAPIServiceMockRecorder) ResetPassword(ctx, payload any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIAPIService)(nil).ResetPassword), ctx, payload)
 }
 
+// RevokeOtherSessions mocks base method.
+func (m *MockIAPIService) RevokeOtherSessions(ctx context.Context, userID int64, currentSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, userID, currentSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockIAPIServiceMockRecorder) RevokeOtherSessions(ctx, userID, currentSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockIAPIService)(nil).RevokeOtherSessions), ctx, userID, currentSessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIAPIService) RevokeSession(ctx context.Context, userID, sessionID int64, currentSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID, currentSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIAPIServiceMockRecorder) RevokeSession(ctx, userID, sessionID, currentSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIAPIService)(nil).RevokeSession), ctx, userID, sessionID, currentSessionID)
+}
+
+// RotateWebhookSecret mocks base method.
+func (m *MockIAPIService) RotateWebhookSecret(ctx context.Context, userID, webhookID int64) (*models.WebhookWithSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateWebhookSecret", ctx, userID, webhookID)
+	ret0, _ := ret[0].(*models.WebhookWithSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhookSecret indicates an expected call of RotateWebhookSecret.
+func (mr *MockIAPIServiceMockRecorder) RotateWebhookSecret(ctx, userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhookSecret", reflect.TypeOf((*MockIAPIService)(nil).RotateWebhookSecret), ctx, userID, webhookID)
+}
+
+// ScheduleForecastIfStale mocks base method.
+func (m *MockIAPIService) ScheduleForecastIfStale(ctx context.Context, userID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleForecastIfStale", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleForecastIfStale indicates an expected call of ScheduleForecastIfStale.
+func (mr *MockIAPIServiceMockRecorder) ScheduleForecastIfStale(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleForecastIfStale", reflect.TypeOf((*MockIAPIService)(nil).ScheduleForecastIfStale), ctx, userID)
+}
+
 // SetEventHub mocks base method.
 func (m *MockIAPIService) SetEventHub(hub *events.Hub) {
 	m.ctrl.T.Helper()
@@ -1238,6 +2309,50 @@ func (mr *MockIAPIServiceMockRecorder) SetUserCurrentOrganisation(ctx, payload,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCurrentOrganisation", reflect.TypeOf((*MockIAPIService)(nil).SetUserCurrentOrganisation), ctx, payload, userID)
 }
 
+// SetupTwoFactor mocks base method.
+func (m *MockIAPIService) SetupTwoFactor(ctx context.Context, userID int64) (*models.TwoFactorSetup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(*models.TwoFactorSetup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupTwoFactor indicates an expected call of SetupTwoFactor.
+func (mr *MockIAPIServiceMockRecorder) SetupTwoFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupTwoFactor", reflect.TypeOf((*MockIAPIService)(nil).SetupTwoFactor), ctx, userID)
+}
+
+// UnlockOrganisationMember mocks base method.
+func (m *MockIAPIService) UnlockOrganisationMember(ctx context.Context, userID, organisationID, memberUserID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockOrganisationMember", ctx, userID, organisationID, memberUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockOrganisationMember indicates an expected call of UnlockOrganisationMember.
+func (mr *MockIAPIServiceMockRecorder) UnlockOrganisationMember(ctx, userID, organisationID, memberUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockOrganisationMember", reflect.TypeOf((*MockIAPIService)(nil).UnlockOrganisationMember), ctx, userID, organisationID, memberUserID)
+}
+
+// UpdateActual mocks base method.
+func (m *MockIAPIService) UpdateActual(ctx context.Context, payload models.UpdateActual, userID, actualID int64) (*models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActual", ctx, payload, userID, actualID)
+	ret0, _ := ret[0].(*models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActual indicates an expected call of UpdateActual.
+func (mr *MockIAPIServiceMockRecorder) UpdateActual(ctx, payload, userID, actualID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActual", reflect.TypeOf((*MockIAPIService)(nil).UpdateActual), ctx, payload, userID, actualID)
+}
+
 // UpdateBankAccount mocks base method.
 func (m *MockIAPIService) UpdateBankAccount(ctx context.Context, payload models.UpdateBankAccount, userID, bankAccountID int64) (*models.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -1355,6 +2470,21 @@ func (mr *MockIAPIServiceMockRecorder) UpdatePassword(ctx, payload, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockIAPIService)(nil).UpdatePassword), ctx, payload, userID)
 }
 
+// UpdatePayrollTemplate mocks base method.
+func (m *MockIAPIService) UpdatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID, templateID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollTemplate", ctx, payload, userID, templateID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayrollTemplate indicates an expected call of UpdatePayrollTemplate.
+func (mr *MockIAPIServiceMockRecorder) UpdatePayrollTemplate(ctx, payload, userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollTemplate", reflect.TypeOf((*MockIAPIService)(nil).UpdatePayrollTemplate), ctx, payload, userID, templateID)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockIAPIService) UpdateProfile(ctx context.Context, payload models.UpdateUser, userID int64) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -1415,6 +2545,21 @@ func (mr *MockIAPIServiceMockRecorder) UpdateSalaryCostLabel(ctx, payload, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSalaryCostLabel", reflect.TypeOf((*MockIAPIService)(nil).UpdateSalaryCostLabel), ctx, payload, userID, salaryCostLabelID)
 }
 
+// UpdateScenario mocks base method.
+func (m *MockIAPIService) UpdateScenario(ctx context.Context, payload models.UpdateScenario, userID, scenarioID int64) (*models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScenario", ctx, payload, userID, scenarioID)
+	ret0, _ := ret[0].(*models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScenario indicates an expected call of UpdateScenario.
+func (mr *MockIAPIServiceMockRecorder) UpdateScenario(ctx, payload, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScenario", reflect.TypeOf((*MockIAPIService)(nil).UpdateScenario), ctx, payload, userID, scenarioID)
+}
+
 // UpdateTransaction mocks base method.
 func (m *MockIAPIService) UpdateTransaction(ctx context.Context, payload models.UpdateTransaction, userID, transactionID int64) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -1490,6 +2635,21 @@ func (mr *MockIAPIServiceMockRecorder) UpdateVatSetting(ctx, payload, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVatSetting", reflect.TypeOf((*MockIAPIService)(nil).UpdateVatSetting), ctx, payload, userID)
 }
 
+// UpdateWebhook mocks base method.
+func (m *MockIAPIService) UpdateWebhook(ctx context.Context, payload models.UpdateWebhook, userID, webhookID int64) (*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, payload, userID, webhookID)
+	ret0, _ := ret[0].(*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockIAPIServiceMockRecorder) UpdateWebhook(ctx, payload, userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockIAPIService)(nil).UpdateWebhook), ctx, payload, userID, webhookID)
+}
+
 // UpsertFiatRate mocks base method.
 func (m *MockIAPIService) UpsertFiatRate(ctx context.Context, payload models.CreateFiatRate) error {
 	m.ctrl.T.Helper()
@@ -1503,3 +2663,32 @@ func (mr *MockIAPIServiceMockRecorder) UpsertFiatRate(ctx, payload any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFiatRate", reflect.TypeOf((*MockIAPIService)(nil).UpsertFiatRate), ctx, payload)
 }
+
+// UpsertOrganisationFiatRate mocks base method.
+func (m *MockIAPIService) UpsertOrganisationFiatRate(ctx context.Context, payload models.UpsertOrganisationFiatRate, userID int64, target string) (*models.OrganisationFiatRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrganisationFiatRate", ctx, payload, userID, target)
+	ret0, _ := ret[0].(*models.OrganisationFiatRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrganisationFiatRate indicates an expected call of UpsertOrganisationFiatRate.
+func (mr *MockIAPIServiceMockRecorder) UpsertOrganisationFiatRate(ctx, payload, userID, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrganisationFiatRate", reflect.TypeOf((*MockIAPIService)(nil).UpsertOrganisationFiatRate), ctx, payload, userID, target)
+}
+
+// WithDatabase mocks base method.
+func (m *MockIAPIService) WithDatabase(dbService db_adapter.IDatabaseAdapter) api_service.IAPIService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithDatabase", dbService)
+	ret0, _ := ret[0].(api_service.IAPIService)
+	return ret0
+}
+
+// WithDatabase indicates an expected call of WithDatabase.
+func (mr *MockIAPIServiceMockRecorder) WithDatabase(dbService any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithDatabase", reflect.TypeOf((*MockIAPIService)(nil).WithDatabase), dbService)
+}
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	db_adapter "liquiswiss/internal/adapter/db_adapter"
 	models "liquiswiss/pkg/models"
 	reflect "reflect"
 	time "time"
@@ -41,6 +42,20 @@ func (m *MockIDatabaseAdapter) EXPECT() *MockIDatabaseAdapterMockRecorder {
 	return m.recorder
 }
 
+// ArchiveForecasts mocks base method.
+func (m *MockIDatabaseAdapter) ArchiveForecasts(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveForecasts", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveForecasts indicates an expected call of ArchiveForecasts.
+func (mr *MockIDatabaseAdapterMockRecorder) ArchiveForecasts(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveForecasts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ArchiveForecasts), userID)
+}
+
 // AssignUserToOrganisation mocks base method.
 func (m *MockIDatabaseAdapter) AssignUserToOrganisation(userID, organisationID int64, role string, isDefault bool) error {
 	m.ctrl.T.Helper()
@@ -55,6 +70,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) AssignUserToOrganisation(userID, org
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUserToOrganisation", reflect.TypeOf((*MockIDatabaseAdapter)(nil).AssignUserToOrganisation), userID, organisationID, role, isDefault)
 }
 
+// BlockAuthAttempts mocks base method.
+func (m *MockIDatabaseAdapter) BlockAuthAttempts(scope, identifier string, duration time.Duration, lockout bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAuthAttempts", scope, identifier, duration, lockout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockAuthAttempts indicates an expected call of BlockAuthAttempts.
+func (mr *MockIDatabaseAdapterMockRecorder) BlockAuthAttempts(scope, identifier, duration, lockout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAuthAttempts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).BlockAuthAttempts), scope, identifier, duration, lockout)
+}
+
 // CalculateSalaryCostDetails mocks base method.
 func (m *MockIDatabaseAdapter) CalculateSalaryCostDetails(userID, salaryCostID int64) error {
 	m.ctrl.T.Helper()
@@ -69,6 +98,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) CalculateSalaryCostDetails(userID, s
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateSalaryCostDetails", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CalculateSalaryCostDetails), userID, salaryCostID)
 }
 
+// CheckCurrentUserTwoFactorCompliance mocks base method.
+func (m *MockIDatabaseAdapter) CheckCurrentUserTwoFactorCompliance(userID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCurrentUserTwoFactorCompliance", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCurrentUserTwoFactorCompliance indicates an expected call of CheckCurrentUserTwoFactorCompliance.
+func (mr *MockIDatabaseAdapterMockRecorder) CheckCurrentUserTwoFactorCompliance(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCurrentUserTwoFactorCompliance", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CheckCurrentUserTwoFactorCompliance), userID)
+}
+
 // CheckRefreshToken mocks base method.
 func (m *MockIDatabaseAdapter) CheckRefreshToken(userID int64, tokenID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +158,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) CheckUserInOrganisation(userID, orga
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserInOrganisation", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CheckUserInOrganisation), userID, organisationID)
 }
 
+// CheckUserTwoFactorRequired mocks base method.
+func (m *MockIDatabaseAdapter) CheckUserTwoFactorRequired(userID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserTwoFactorRequired", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserTwoFactorRequired indicates an expected call of CheckUserTwoFactorRequired.
+func (mr *MockIDatabaseAdapterMockRecorder) CheckUserTwoFactorRequired(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserTwoFactorRequired", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CheckUserTwoFactorRequired), userID)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockIDatabaseAdapter) ClaimWebhookDeliveries(claimToken string, limit int64, lease time.Duration) ([]models.WebhookDeliveryJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", claimToken, limit, lease)
+	ret0, _ := ret[0].([]models.WebhookDeliveryJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockIDatabaseAdapterMockRecorder) ClaimWebhookDeliveries(claimToken, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ClaimWebhookDeliveries), claimToken, limit, lease)
+}
+
 // ClearForecasts mocks base method.
 func (m *MockIDatabaseAdapter) ClearForecasts(userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +203,50 @@ func (mr *MockIDatabaseAdapterMockRecorder) ClearForecasts(userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearForecasts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ClearForecasts), userID)
 }
 
+// CommitEmployeeImport mocks base method.
+func (m *MockIDatabaseAdapter) CommitEmployeeImport(userID, importID int64, employees []models.ImportEmployee) ([]models.ImportEmployeeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitEmployeeImport", userID, importID, employees)
+	ret0, _ := ret[0].([]models.ImportEmployeeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitEmployeeImport indicates an expected call of CommitEmployeeImport.
+func (mr *MockIDatabaseAdapterMockRecorder) CommitEmployeeImport(userID, importID, employees any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitEmployeeImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CommitEmployeeImport), userID, importID, employees)
+}
+
+// CommitTransactionImport mocks base method.
+func (m *MockIDatabaseAdapter) CommitTransactionImport(userID, importID int64, payloads []models.CreateTransaction) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTransactionImport", userID, importID, payloads)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitTransactionImport indicates an expected call of CommitTransactionImport.
+func (mr *MockIDatabaseAdapterMockRecorder) CommitTransactionImport(userID, importID, payloads any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTransactionImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CommitTransactionImport), userID, importID, payloads)
+}
+
+// CompleteWebhookDelivery mocks base method.
+func (m *MockIDatabaseAdapter) CompleteWebhookDelivery(deliveryID int64, result models.WebhookDeliveryResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteWebhookDelivery", deliveryID, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteWebhookDelivery indicates an expected call of CompleteWebhookDelivery.
+func (mr *MockIDatabaseAdapterMockRecorder) CompleteWebhookDelivery(deliveryID, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteWebhookDelivery", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CompleteWebhookDelivery), deliveryID, result)
+}
+
 // CopySalaryCosts mocks base method.
 func (m *MockIDatabaseAdapter) CopySalaryCosts(payload models.CopySalaryCosts, userID, salaryID int64) error {
 	m.ctrl.T.Helper()
@@ -218,6 +336,66 @@ func (mr *MockIDatabaseAdapterMockRecorder) CountUniqueCurrenciesInFiatRates() *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUniqueCurrenciesInFiatRates", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CountUniqueCurrenciesInFiatRates))
 }
 
+// CountUserRecoveryCodes mocks base method.
+func (m *MockIDatabaseAdapter) CountUserRecoveryCodes(userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserRecoveryCodes", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserRecoveryCodes indicates an expected call of CountUserRecoveryCodes.
+func (mr *MockIDatabaseAdapterMockRecorder) CountUserRecoveryCodes(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRecoveryCodes", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CountUserRecoveryCodes), userID)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockIDatabaseAdapter) CreateAPIToken(payload models.CreateAPIToken, userID, organisationID int64, tokenHash, tokenPrefix string, expiresAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", payload, userID, organisationID, tokenHash, tokenPrefix, expiresAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateAPIToken(payload, userID, organisationID, tokenHash, tokenPrefix, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateAPIToken), payload, userID, organisationID, tokenHash, tokenPrefix, expiresAt)
+}
+
+// CreateActuals mocks base method.
+func (m *MockIDatabaseAdapter) CreateActuals(actuals []models.CreateActual, userID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActuals", actuals, userID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActuals indicates an expected call of CreateActuals.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateActuals(actuals, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActuals", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateActuals), actuals, userID)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockIDatabaseAdapter) CreateAuditLog(payload models.CreateAuditLog) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateAuditLog(payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateAuditLog), payload)
+}
+
 // CreateBankAccount mocks base method.
 func (m *MockIDatabaseAdapter) CreateBankAccount(payload models.CreateBankAccount, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -233,6 +411,22 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateBankAccount(payload, userID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankAccount", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateBankAccount), payload, userID)
 }
 
+// CreateBankStatementImport mocks base method.
+func (m *MockIDatabaseAdapter) CreateBankStatementImport(payload models.CreateBankStatementImport, actuals []models.CreateActual, userID, bankAccountID int64) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBankStatementImport", payload, actuals, userID, bankAccountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBankStatementImport indicates an expected call of CreateBankStatementImport.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateBankStatementImport(payload, actuals, userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankStatementImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateBankStatementImport), payload, actuals, userID, bankAccountID)
+}
+
 // CreateCategory mocks base method.
 func (m *MockIDatabaseAdapter) CreateCategory(payload models.CreateCategory, userID *int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -278,6 +472,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateEmployee(payload, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateEmployee), payload, userID)
 }
 
+// CreateEventOutboxEntry mocks base method.
+func (m *MockIDatabaseAdapter) CreateEventOutboxEntry(payload []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventOutboxEntry", payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventOutboxEntry indicates an expected call of CreateEventOutboxEntry.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateEventOutboxEntry(payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventOutboxEntry", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateEventOutboxEntry), payload)
+}
+
+// CreateFiatRateFetch mocks base method.
+func (m *MockIDatabaseAdapter) CreateFiatRateFetch(payload models.CreateFiatRateFetch) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFiatRateFetch", payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFiatRateFetch indicates an expected call of CreateFiatRateFetch.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateFiatRateFetch(payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFiatRateFetch", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateFiatRateFetch), payload)
+}
+
 // CreateForecastExclusion mocks base method.
 func (m *MockIDatabaseAdapter) CreateForecastExclusion(payload models.CreateForecastExclusion, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -293,6 +517,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateForecastExclusion(payload, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForecastExclusion", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateForecastExclusion), payload, userID)
 }
 
+// CreateForecastSnapshot mocks base method.
+func (m *MockIDatabaseAdapter) CreateForecastSnapshot(payload models.CreateForecastSnapshot, forecasts []models.Forecast, details []models.ForecastDatabaseDetails, openingBalance, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForecastSnapshot", payload, forecasts, details, openingBalance, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForecastSnapshot indicates an expected call of CreateForecastSnapshot.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateForecastSnapshot(payload, forecasts, details, openingBalance, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForecastSnapshot", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateForecastSnapshot), payload, forecasts, details, openingBalance, userID)
+}
+
+// CreateImport mocks base method.
+func (m *MockIDatabaseAdapter) CreateImport(payload models.CreateImport, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImport", payload, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImport indicates an expected call of CreateImport.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateImport(payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateImport), payload, userID)
+}
+
 // CreateInvitation mocks base method.
 func (m *MockIDatabaseAdapter) CreateInvitation(organisationID int64, email, role, token string, invitedBy int64, expiresAt time.Time) (int64, error) {
 	m.ctrl.T.Helper()
@@ -365,6 +619,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateOrganisation(name any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganisation", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateOrganisation), name)
 }
 
+// CreatePayrollTemplate mocks base method.
+func (m *MockIDatabaseAdapter) CreatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayrollTemplate", payload, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayrollTemplate indicates an expected call of CreatePayrollTemplate.
+func (mr *MockIDatabaseAdapterMockRecorder) CreatePayrollTemplate(payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollTemplate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreatePayrollTemplate), payload, userID)
+}
+
 // CreateRegistration mocks base method.
 func (m *MockIDatabaseAdapter) CreateRegistration(email, code string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -442,6 +711,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateSalaryCostLabel(payload, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSalaryCostLabel", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateSalaryCostLabel), payload, userID)
 }
 
+// CreateScenario mocks base method.
+func (m *MockIDatabaseAdapter) CreateScenario(payload models.CreateScenario, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScenario", payload, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScenario indicates an expected call of CreateScenario.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateScenario(payload, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScenario", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateScenario), payload, userID)
+}
+
+// CreateScenarioItem mocks base method.
+func (m *MockIDatabaseAdapter) CreateScenarioItem(payload models.CreateScenarioItem, userID, scenarioID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScenarioItem", payload, userID, scenarioID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScenarioItem indicates an expected call of CreateScenarioItem.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateScenarioItem(payload, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScenarioItem", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateScenarioItem), payload, userID, scenarioID)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockIDatabaseAdapter) CreateTransaction(payload models.CreateTransaction, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -472,6 +771,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateUser(email, password any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateUser), email, password)
 }
 
+// CreateUserIdentity mocks base method.
+func (m *MockIDatabaseAdapter) CreateUserIdentity(userID int64, provider, subject, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserIdentity", userID, provider, subject, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateUserIdentity(userID, provider, subject, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateUserIdentity), userID, provider, subject, email)
+}
+
 // CreateUserOrganisationSetting mocks base method.
 func (m *MockIDatabaseAdapter) CreateUserOrganisationSetting(userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -532,6 +845,49 @@ func (mr *MockIDatabaseAdapterMockRecorder) CreateVatSetting(payload, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVatSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateVatSetting), payload, userID)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockIDatabaseAdapter) CreateWebhook(payload models.CreateWebhook, userID int64, secret string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", payload, userID, secret)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockIDatabaseAdapterMockRecorder) CreateWebhook(payload, userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockIDatabaseAdapter)(nil).CreateWebhook), payload, userID, secret)
+}
+
+// DeleteAPIToken mocks base method.
+func (m *MockIDatabaseAdapter) DeleteAPIToken(userID, tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIToken", userID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteAPIToken(userID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteAPIToken), userID, tokenID)
+}
+
+// DeleteActual mocks base method.
+func (m *MockIDatabaseAdapter) DeleteActual(userID, actualID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActual", userID, actualID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActual indicates an expected call of DeleteActual.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteActual(userID, actualID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActual", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteActual), userID, actualID)
+}
+
 // DeleteBankAccount mocks base method.
 func (m *MockIDatabaseAdapter) DeleteBankAccount(userID, bankAccountID int64) error {
 	m.ctrl.T.Helper()
@@ -546,6 +902,34 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteBankAccount(userID, bankAccoun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankAccount", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteBankAccount), userID, bankAccountID)
 }
 
+// DeleteBankAccountBalance mocks base method.
+func (m *MockIDatabaseAdapter) DeleteBankAccountBalance(userID, bankAccountID, balanceID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBankAccountBalance", userID, bankAccountID, balanceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBankAccountBalance indicates an expected call of DeleteBankAccountBalance.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteBankAccountBalance(userID, bankAccountID, balanceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankAccountBalance", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteBankAccountBalance), userID, bankAccountID, balanceID)
+}
+
+// DeleteBankStatementImport mocks base method.
+func (m *MockIDatabaseAdapter) DeleteBankStatementImport(userID, bankAccountID, importID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBankStatementImport", userID, bankAccountID, importID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBankStatementImport indicates an expected call of DeleteBankStatementImport.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteBankStatementImport(userID, bankAccountID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankStatementImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteBankStatementImport), userID, bankAccountID, importID)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockIDatabaseAdapter) DeleteCategory(userID, categoryID int64) error {
 	m.ctrl.T.Helper()
@@ -574,6 +958,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteEmployee(userID, employeeID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteEmployee), userID, employeeID)
 }
 
+// DeleteEventOutboxEntriesBefore mocks base method.
+func (m *MockIDatabaseAdapter) DeleteEventOutboxEntriesBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventOutboxEntriesBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventOutboxEntriesBefore indicates an expected call of DeleteEventOutboxEntriesBefore.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteEventOutboxEntriesBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventOutboxEntriesBefore", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteEventOutboxEntriesBefore), before)
+}
+
 // DeleteForecastExclusion mocks base method.
 func (m *MockIDatabaseAdapter) DeleteForecastExclusion(payload models.CreateForecastExclusion, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -589,6 +988,34 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteForecastExclusion(payload, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForecastExclusion", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteForecastExclusion), payload, userID)
 }
 
+// DeleteForecastSnapshot mocks base method.
+func (m *MockIDatabaseAdapter) DeleteForecastSnapshot(userID, snapshotID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForecastSnapshot", userID, snapshotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForecastSnapshot indicates an expected call of DeleteForecastSnapshot.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteForecastSnapshot(userID, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForecastSnapshot", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteForecastSnapshot), userID, snapshotID)
+}
+
+// DeleteImport mocks base method.
+func (m *MockIDatabaseAdapter) DeleteImport(userID, importID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImport", userID, importID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImport indicates an expected call of DeleteImport.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteImport(userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteImport), userID, importID)
+}
+
 // DeleteInvitation mocks base method.
 func (m *MockIDatabaseAdapter) DeleteInvitation(organisationID, invitationID int64) error {
 	m.ctrl.T.Helper()
@@ -631,18 +1058,75 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteMember(organisationID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteMember), organisationID, userID)
 }
 
-// DeleteMemberPermissions mocks base method.
-func (m *MockIDatabaseAdapter) DeleteMemberPermissions(userID, organisationID int64) error {
+// DeleteMemberEntityPermission mocks base method.
+func (m *MockIDatabaseAdapter) DeleteMemberEntityPermission(userID, organisationID int64, entityType string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMemberPermissions", userID, organisationID)
+	ret := m.ctrl.Call(m, "DeleteMemberEntityPermission", userID, organisationID, entityType)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteMemberPermissions indicates an expected call of DeleteMemberPermissions.
-func (mr *MockIDatabaseAdapterMockRecorder) DeleteMemberPermissions(userID, organisationID any) *gomock.Call {
+// DeleteMemberEntityPermission indicates an expected call of DeleteMemberEntityPermission.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteMemberEntityPermission(userID, organisationID, entityType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMemberPermissions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteMemberPermissions), userID, organisationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMemberEntityPermission", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteMemberEntityPermission), userID, organisationID, entityType)
+}
+
+// DeleteMemberPermissions mocks base method.
+func (m *MockIDatabaseAdapter) DeleteMemberPermissions(userID, organisationID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMemberPermissions", userID, organisationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMemberPermissions indicates an expected call of DeleteMemberPermissions.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteMemberPermissions(userID, organisationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMemberPermissions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteMemberPermissions), userID, organisationID)
+}
+
+// DeleteOrganisationFiatRate mocks base method.
+func (m *MockIDatabaseAdapter) DeleteOrganisationFiatRate(userID int64, base, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganisationFiatRate", userID, base, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganisationFiatRate indicates an expected call of DeleteOrganisationFiatRate.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteOrganisationFiatRate(userID, base, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganisationFiatRate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteOrganisationFiatRate), userID, base, target)
+}
+
+// DeleteOtherSessions mocks base method.
+func (m *MockIDatabaseAdapter) DeleteOtherSessions(userID int64, currentTokenID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOtherSessions", userID, currentTokenID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOtherSessions indicates an expected call of DeleteOtherSessions.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteOtherSessions(userID, currentTokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOtherSessions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteOtherSessions), userID, currentTokenID)
+}
+
+// DeletePayrollTemplate mocks base method.
+func (m *MockIDatabaseAdapter) DeletePayrollTemplate(userID, templateID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayrollTemplate", userID, templateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayrollTemplate indicates an expected call of DeletePayrollTemplate.
+func (mr *MockIDatabaseAdapterMockRecorder) DeletePayrollTemplate(userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayrollTemplate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeletePayrollTemplate), userID, templateID)
 }
 
 // DeleteRefreshToken mocks base method.
@@ -745,6 +1229,48 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteSalaryCostsBySalaryID(salaryID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSalaryCostsBySalaryID", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteSalaryCostsBySalaryID), salaryID)
 }
 
+// DeleteScenario mocks base method.
+func (m *MockIDatabaseAdapter) DeleteScenario(userID, scenarioID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScenario", userID, scenarioID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScenario indicates an expected call of DeleteScenario.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteScenario(userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScenario", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteScenario), userID, scenarioID)
+}
+
+// DeleteScenarioItem mocks base method.
+func (m *MockIDatabaseAdapter) DeleteScenarioItem(userID, scenarioID, scenarioItemID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScenarioItem", userID, scenarioID, scenarioItemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScenarioItem indicates an expected call of DeleteScenarioItem.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteScenarioItem(userID, scenarioID, scenarioItemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScenarioItem", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteScenarioItem), userID, scenarioID, scenarioItemID)
+}
+
+// DeleteSession mocks base method.
+func (m *MockIDatabaseAdapter) DeleteSession(userID, sessionID int64, currentTokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", userID, sessionID, currentTokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteSession(userID, sessionID, currentTokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteSession), userID, sessionID, currentTokenID)
+}
+
 // DeleteTransaction mocks base method.
 func (m *MockIDatabaseAdapter) DeleteTransaction(userID, transactionID int64) error {
 	m.ctrl.T.Helper()
@@ -787,6 +1313,110 @@ func (mr *MockIDatabaseAdapterMockRecorder) DeleteVatSetting(userID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVatSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteVatSetting), userID)
 }
 
+// DeleteWebhook mocks base method.
+func (m *MockIDatabaseAdapter) DeleteWebhook(userID, webhookID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteWebhook(userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteWebhook), userID, webhookID)
+}
+
+// DeleteWebhookDeliveriesBefore mocks base method.
+func (m *MockIDatabaseAdapter) DeleteWebhookDeliveriesBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDeliveriesBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookDeliveriesBefore indicates an expected call of DeleteWebhookDeliveriesBefore.
+func (mr *MockIDatabaseAdapterMockRecorder) DeleteWebhookDeliveriesBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDeliveriesBefore", reflect.TypeOf((*MockIDatabaseAdapter)(nil).DeleteWebhookDeliveriesBefore), before)
+}
+
+// EnqueueWebhookDeliveries mocks base method.
+func (m *MockIDatabaseAdapter) EnqueueWebhookDeliveries(organisationID int64, entity, action string, payload []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDeliveries", organisationID, entity, action, payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookDeliveries indicates an expected call of EnqueueWebhookDeliveries.
+func (mr *MockIDatabaseAdapterMockRecorder) EnqueueWebhookDeliveries(organisationID, entity, action, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDeliveries", reflect.TypeOf((*MockIDatabaseAdapter)(nil).EnqueueWebhookDeliveries), organisationID, entity, action, payload)
+}
+
+// GetAPIToken mocks base method.
+func (m *MockIDatabaseAdapter) GetAPIToken(userID, tokenID int64) (*models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIToken", userID, tokenID)
+	ret0, _ := ret[0].(*models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIToken indicates an expected call of GetAPIToken.
+func (mr *MockIDatabaseAdapterMockRecorder) GetAPIToken(userID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetAPIToken), userID, tokenID)
+}
+
+// GetAPITokenAuth mocks base method.
+func (m *MockIDatabaseAdapter) GetAPITokenAuth(tokenHash string) (*models.APITokenAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenAuth", tokenHash)
+	ret0, _ := ret[0].(*models.APITokenAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenAuth indicates an expected call of GetAPITokenAuth.
+func (mr *MockIDatabaseAdapterMockRecorder) GetAPITokenAuth(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenAuth", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetAPITokenAuth), tokenHash)
+}
+
+// GetActual mocks base method.
+func (m *MockIDatabaseAdapter) GetActual(userID, actualID int64) (*models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActual", userID, actualID)
+	ret0, _ := ret[0].(*models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActual indicates an expected call of GetActual.
+func (mr *MockIDatabaseAdapterMockRecorder) GetActual(userID, actualID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActual", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetActual), userID, actualID)
+}
+
+// GetAuthBlock mocks base method.
+func (m *MockIDatabaseAdapter) GetAuthBlock(scope, identifier, otherIdentifier string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthBlock", scope, identifier, otherIdentifier)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthBlock indicates an expected call of GetAuthBlock.
+func (mr *MockIDatabaseAdapterMockRecorder) GetAuthBlock(scope, identifier, otherIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthBlock", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetAuthBlock), scope, identifier, otherIdentifier)
+}
+
 // GetBankAccount mocks base method.
 func (m *MockIDatabaseAdapter) GetBankAccount(userID, bankAccountID int64) (*models.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -802,6 +1432,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetBankAccount(userID, bankAccountID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankAccount", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetBankAccount), userID, bankAccountID)
 }
 
+// GetBankAccountBalance mocks base method.
+func (m *MockIDatabaseAdapter) GetBankAccountBalance(userID, bankAccountID, balanceID int64) (*models.BankAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankAccountBalance", userID, bankAccountID, balanceID)
+	ret0, _ := ret[0].(*models.BankAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankAccountBalance indicates an expected call of GetBankAccountBalance.
+func (mr *MockIDatabaseAdapterMockRecorder) GetBankAccountBalance(userID, bankAccountID, balanceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankAccountBalance", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetBankAccountBalance), userID, bankAccountID, balanceID)
+}
+
+// GetBankStatementImport mocks base method.
+func (m *MockIDatabaseAdapter) GetBankStatementImport(userID, bankAccountID, importID int64) (*models.BankStatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankStatementImport", userID, bankAccountID, importID)
+	ret0, _ := ret[0].(*models.BankStatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankStatementImport indicates an expected call of GetBankStatementImport.
+func (mr *MockIDatabaseAdapterMockRecorder) GetBankStatementImport(userID, bankAccountID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankStatementImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetBankStatementImport), userID, bankAccountID, importID)
+}
+
 // GetCategory mocks base method.
 func (m *MockIDatabaseAdapter) GetCategory(userID, categoryID int64) (*models.Category, error) {
 	m.ctrl.T.Helper()
@@ -832,6 +1492,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetCurrency(currencyID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetCurrency), currencyID)
 }
 
+// GetCurrentUserPayrollAccess mocks base method.
+func (m *MockIDatabaseAdapter) GetCurrentUserPayrollAccess(userID int64) (*models.PayrollAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUserPayrollAccess", userID)
+	ret0, _ := ret[0].(*models.PayrollAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUserPayrollAccess indicates an expected call of GetCurrentUserPayrollAccess.
+func (mr *MockIDatabaseAdapterMockRecorder) GetCurrentUserPayrollAccess(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUserPayrollAccess", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetCurrentUserPayrollAccess), userID)
+}
+
 // GetCurrentUserRole mocks base method.
 func (m *MockIDatabaseAdapter) GetCurrentUserRole(userID int64) (string, error) {
 	m.ctrl.T.Helper()
@@ -877,6 +1552,51 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetFiatRate(base, target any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiatRate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetFiatRate), base, target)
 }
 
+// GetFiatRateAt mocks base method.
+func (m *MockIDatabaseAdapter) GetFiatRateAt(base, target string, date time.Time) (*models.FiatRateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiatRateAt", base, target, date)
+	ret0, _ := ret[0].(*models.FiatRateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiatRateAt indicates an expected call of GetFiatRateAt.
+func (mr *MockIDatabaseAdapterMockRecorder) GetFiatRateAt(base, target, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiatRateAt", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetFiatRateAt), base, target, date)
+}
+
+// GetForecastSnapshot mocks base method.
+func (m *MockIDatabaseAdapter) GetForecastSnapshot(userID, snapshotID int64) (*models.ForecastSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecastSnapshot", userID, snapshotID)
+	ret0, _ := ret[0].(*models.ForecastSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForecastSnapshot indicates an expected call of GetForecastSnapshot.
+func (mr *MockIDatabaseAdapterMockRecorder) GetForecastSnapshot(userID, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastSnapshot", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetForecastSnapshot), userID, snapshotID)
+}
+
+// GetImport mocks base method.
+func (m *MockIDatabaseAdapter) GetImport(userID, importID int64) (*models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", userID, importID)
+	ret0, _ := ret[0].(*models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockIDatabaseAdapterMockRecorder) GetImport(userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetImport), userID, importID)
+}
+
 // GetInvitationByID mocks base method.
 func (m *MockIDatabaseAdapter) GetInvitationByID(organisationID, invitationID int64) (*models.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -907,6 +1627,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetInvitationByToken(token any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetInvitationByToken), token)
 }
 
+// GetLastSucceededFiatRateFetch mocks base method.
+func (m *MockIDatabaseAdapter) GetLastSucceededFiatRateFetch() (*models.FiatRateFetch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSucceededFiatRateFetch")
+	ret0, _ := ret[0].(*models.FiatRateFetch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSucceededFiatRateFetch indicates an expected call of GetLastSucceededFiatRateFetch.
+func (mr *MockIDatabaseAdapterMockRecorder) GetLastSucceededFiatRateFetch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSucceededFiatRateFetch", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetLastSucceededFiatRateFetch))
+}
+
+// GetLatestEventOutboxID mocks base method.
+func (m *MockIDatabaseAdapter) GetLatestEventOutboxID() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEventOutboxID")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEventOutboxID indicates an expected call of GetLatestEventOutboxID.
+func (mr *MockIDatabaseAdapterMockRecorder) GetLatestEventOutboxID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEventOutboxID", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetLatestEventOutboxID))
+}
+
 // GetMember mocks base method.
 func (m *MockIDatabaseAdapter) GetMember(organisationID, userID int64) (*models.OrganisationMember, error) {
 	m.ctrl.T.Helper()
@@ -997,6 +1747,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetOrganisation(userID, organisation
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisation", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetOrganisation), userID, organisationID)
 }
 
+// GetOrganisationBySSODomain mocks base method.
+func (m *MockIDatabaseAdapter) GetOrganisationBySSODomain(domain string) (*models.SSOOrganisation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganisationBySSODomain", domain)
+	ret0, _ := ret[0].(*models.SSOOrganisation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganisationBySSODomain indicates an expected call of GetOrganisationBySSODomain.
+func (mr *MockIDatabaseAdapterMockRecorder) GetOrganisationBySSODomain(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisationBySSODomain", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetOrganisationBySSODomain), domain)
+}
+
+// GetOrganisationFiatRate mocks base method.
+func (m *MockIDatabaseAdapter) GetOrganisationFiatRate(userID int64, base, target string) (*models.OrganisationFiatRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganisationFiatRate", userID, base, target)
+	ret0, _ := ret[0].(*models.OrganisationFiatRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganisationFiatRate indicates an expected call of GetOrganisationFiatRate.
+func (mr *MockIDatabaseAdapterMockRecorder) GetOrganisationFiatRate(userID, base, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisationFiatRate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetOrganisationFiatRate), userID, base, target)
+}
+
 // GetOrganisationName mocks base method.
 func (m *MockIDatabaseAdapter) GetOrganisationName(organisationID int64) (string, error) {
 	m.ctrl.T.Helper()
@@ -1012,6 +1792,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetOrganisationName(organisationID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganisationName", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetOrganisationName), organisationID)
 }
 
+// GetPayrollTemplate mocks base method.
+func (m *MockIDatabaseAdapter) GetPayrollTemplate(userID, templateID int64) (*models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollTemplate", userID, templateID)
+	ret0, _ := ret[0].(*models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollTemplate indicates an expected call of GetPayrollTemplate.
+func (mr *MockIDatabaseAdapterMockRecorder) GetPayrollTemplate(userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollTemplate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetPayrollTemplate), userID, templateID)
+}
+
 // GetProfile mocks base method.
 func (m *MockIDatabaseAdapter) GetProfile(userID int64) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -1072,6 +1867,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetSalaryCostLabel(userID, salaryCos
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalaryCostLabel", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetSalaryCostLabel), userID, salaryCostLabelID)
 }
 
+// GetScenario mocks base method.
+func (m *MockIDatabaseAdapter) GetScenario(userID, scenarioID int64) (*models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenario", userID, scenarioID)
+	ret0, _ := ret[0].(*models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenario indicates an expected call of GetScenario.
+func (mr *MockIDatabaseAdapterMockRecorder) GetScenario(userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenario", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetScenario), userID, scenarioID)
+}
+
+// GetScenarioItem mocks base method.
+func (m *MockIDatabaseAdapter) GetScenarioItem(userID, scenarioID, scenarioItemID int64) (*models.ScenarioItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenarioItem", userID, scenarioID, scenarioItemID)
+	ret0, _ := ret[0].(*models.ScenarioItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenarioItem indicates an expected call of GetScenarioItem.
+func (mr *MockIDatabaseAdapterMockRecorder) GetScenarioItem(userID, scenarioID, scenarioItemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenarioItem", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetScenarioItem), userID, scenarioID, scenarioItemID)
+}
+
 // GetTransaction mocks base method.
 func (m *MockIDatabaseAdapter) GetTransaction(userID, transactionID int64) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -1102,6 +1927,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetUserIDByEmail(email any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByEmail", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetUserIDByEmail), email)
 }
 
+// GetUserIDBySSOIdentity mocks base method.
+func (m *MockIDatabaseAdapter) GetUserIDBySSOIdentity(provider, subject string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDBySSOIdentity", provider, subject)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDBySSOIdentity indicates an expected call of GetUserIDBySSOIdentity.
+func (mr *MockIDatabaseAdapterMockRecorder) GetUserIDBySSOIdentity(provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDBySSOIdentity", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetUserIDBySSOIdentity), provider, subject)
+}
+
 // GetUserOrganisationSetting mocks base method.
 func (m *MockIDatabaseAdapter) GetUserOrganisationSetting(userID int64) (*models.UserOrganisationSetting, error) {
 	m.ctrl.T.Helper()
@@ -1147,6 +1987,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetUserSetting(userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetUserSetting), userID)
 }
 
+// GetUserTwoFactor mocks base method.
+func (m *MockIDatabaseAdapter) GetUserTwoFactor(userID int64) (*models.UserTwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTwoFactor", userID)
+	ret0, _ := ret[0].(*models.UserTwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTwoFactor indicates an expected call of GetUserTwoFactor.
+func (mr *MockIDatabaseAdapterMockRecorder) GetUserTwoFactor(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTwoFactor", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetUserTwoFactor), userID)
+}
+
 // GetVat mocks base method.
 func (m *MockIDatabaseAdapter) GetVat(userID, vatID int64) (*models.Vat, error) {
 	m.ctrl.T.Helper()
@@ -1177,6 +2032,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) GetVatSetting(userID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVatSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetVatSetting), userID)
 }
 
+// GetWebhook mocks base method.
+func (m *MockIDatabaseAdapter) GetWebhook(userID, webhookID int64) (*models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", userID, webhookID)
+	ret0, _ := ret[0].(*models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockIDatabaseAdapterMockRecorder) GetWebhook(userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetWebhook), userID, webhookID)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockIDatabaseAdapter) GetWebhookDelivery(userID, webhookID, deliveryID int64) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", userID, webhookID, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockIDatabaseAdapterMockRecorder) GetWebhookDelivery(userID, webhookID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockIDatabaseAdapter)(nil).GetWebhookDelivery), userID, webhookID, deliveryID)
+}
+
 // HasActiveOAuthConnection mocks base method.
 func (m *MockIDatabaseAdapter) HasActiveOAuthConnection(userID int64, clientID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1192,6 +2077,51 @@ func (mr *MockIDatabaseAdapterMockRecorder) HasActiveOAuthConnection(userID, cli
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveOAuthConnection", reflect.TypeOf((*MockIDatabaseAdapter)(nil).HasActiveOAuthConnection), userID, clientID)
 }
 
+// ListAPITokens mocks base method.
+func (m *MockIDatabaseAdapter) ListAPITokens(userID int64) ([]models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", userID)
+	ret0, _ := ret[0].([]models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockIDatabaseAdapterMockRecorder) ListAPITokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListAPITokens), userID)
+}
+
+// ListActuals mocks base method.
+func (m *MockIDatabaseAdapter) ListActuals(userID int64, from, to string) ([]models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActuals", userID, from, to)
+	ret0, _ := ret[0].([]models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActuals indicates an expected call of ListActuals.
+func (mr *MockIDatabaseAdapterMockRecorder) ListActuals(userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActuals", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListActuals), userID, from, to)
+}
+
+// ListActualsByImport mocks base method.
+func (m *MockIDatabaseAdapter) ListActualsByImport(userID, importID int64) ([]models.Actual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActualsByImport", userID, importID)
+	ret0, _ := ret[0].([]models.Actual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActualsByImport indicates an expected call of ListActualsByImport.
+func (mr *MockIDatabaseAdapterMockRecorder) ListActualsByImport(userID, importID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActualsByImport", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListActualsByImport), userID, importID)
+}
+
 // ListAllForecastExclusions mocks base method.
 func (m *MockIDatabaseAdapter) ListAllForecastExclusions(userID int64) ([]models.ForecastExclusionInfo, error) {
 	m.ctrl.T.Helper()
@@ -1207,6 +2137,37 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListAllForecastExclusions(userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllForecastExclusions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListAllForecastExclusions), userID)
 }
 
+// ListAuditLogs mocks base method.
+func (m *MockIDatabaseAdapter) ListAuditLogs(userID int64, filter models.AuditLogFilter, page, limit int64) ([]models.AuditLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", userID, filter, page, limit)
+	ret0, _ := ret[0].([]models.AuditLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockIDatabaseAdapterMockRecorder) ListAuditLogs(userID, filter, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListAuditLogs), userID, filter, page, limit)
+}
+
+// ListBankAccountBalances mocks base method.
+func (m *MockIDatabaseAdapter) ListBankAccountBalances(userID, bankAccountID int64) ([]models.BankAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankAccountBalances", userID, bankAccountID)
+	ret0, _ := ret[0].([]models.BankAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankAccountBalances indicates an expected call of ListBankAccountBalances.
+func (mr *MockIDatabaseAdapterMockRecorder) ListBankAccountBalances(userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankAccountBalances", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListBankAccountBalances), userID, bankAccountID)
+}
+
 // ListBankAccounts mocks base method.
 func (m *MockIDatabaseAdapter) ListBankAccounts(userID, page, limit int64, sortBy, sortOrder, search string) ([]models.BankAccount, int64, error) {
 	m.ctrl.T.Helper()
@@ -1223,6 +2184,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListBankAccounts(userID, page, limit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankAccounts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListBankAccounts), userID, page, limit, sortBy, sortOrder, search)
 }
 
+// ListBankAccountsAtDate mocks base method.
+func (m *MockIDatabaseAdapter) ListBankAccountsAtDate(userID int64, date string) ([]models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankAccountsAtDate", userID, date)
+	ret0, _ := ret[0].([]models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankAccountsAtDate indicates an expected call of ListBankAccountsAtDate.
+func (mr *MockIDatabaseAdapterMockRecorder) ListBankAccountsAtDate(userID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankAccountsAtDate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListBankAccountsAtDate), userID, date)
+}
+
+// ListBankStatementImports mocks base method.
+func (m *MockIDatabaseAdapter) ListBankStatementImports(userID, bankAccountID int64) ([]models.BankStatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankStatementImports", userID, bankAccountID)
+	ret0, _ := ret[0].([]models.BankStatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankStatementImports indicates an expected call of ListBankStatementImports.
+func (mr *MockIDatabaseAdapterMockRecorder) ListBankStatementImports(userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankStatementImports", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListBankStatementImports), userID, bankAccountID)
+}
+
 // ListCategories mocks base method.
 func (m *MockIDatabaseAdapter) ListCategories(userID, page, limit int64) ([]models.Category, int64, error) {
 	m.ctrl.T.Helper()
@@ -1254,6 +2245,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListCurrencies(userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListCurrencies), userID)
 }
 
+// ListCurrentMemberPermissions mocks base method.
+func (m *MockIDatabaseAdapter) ListCurrentMemberPermissions(userID int64) ([]models.MemberPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrentMemberPermissions", userID)
+	ret0, _ := ret[0].([]models.MemberPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrentMemberPermissions indicates an expected call of ListCurrentMemberPermissions.
+func (mr *MockIDatabaseAdapterMockRecorder) ListCurrentMemberPermissions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrentMemberPermissions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListCurrentMemberPermissions), userID)
+}
+
 // ListEmployees mocks base method.
 func (m *MockIDatabaseAdapter) ListEmployees(userID, page, limit int64, sortBy, sortOrder, search string, hideTerminated bool) ([]models.Employee, int64, error) {
 	m.ctrl.T.Helper()
@@ -1270,6 +2276,51 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListEmployees(userID, page, limit, s
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployees", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListEmployees), userID, page, limit, sortBy, sortOrder, search, hideTerminated)
 }
 
+// ListEventOutboxEntries mocks base method.
+func (m *MockIDatabaseAdapter) ListEventOutboxEntries(afterID, limit int64) ([]models.EventOutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventOutboxEntries", afterID, limit)
+	ret0, _ := ret[0].([]models.EventOutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventOutboxEntries indicates an expected call of ListEventOutboxEntries.
+func (mr *MockIDatabaseAdapterMockRecorder) ListEventOutboxEntries(afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventOutboxEntries", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListEventOutboxEntries), afterID, limit)
+}
+
+// ListFiatRateFetches mocks base method.
+func (m *MockIDatabaseAdapter) ListFiatRateFetches(limit int64) ([]models.FiatRateFetch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiatRateFetches", limit)
+	ret0, _ := ret[0].([]models.FiatRateFetch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiatRateFetches indicates an expected call of ListFiatRateFetches.
+func (mr *MockIDatabaseAdapterMockRecorder) ListFiatRateFetches(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiatRateFetches", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListFiatRateFetches), limit)
+}
+
+// ListFiatRateHistory mocks base method.
+func (m *MockIDatabaseAdapter) ListFiatRateHistory(base string, from time.Time) ([]models.FiatRateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiatRateHistory", base, from)
+	ret0, _ := ret[0].([]models.FiatRateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiatRateHistory indicates an expected call of ListFiatRateHistory.
+func (mr *MockIDatabaseAdapterMockRecorder) ListFiatRateHistory(base, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiatRateHistory", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListFiatRateHistory), base, from)
+}
+
 // ListFiatRates mocks base method.
 func (m *MockIDatabaseAdapter) ListFiatRates(base string) ([]models.FiatRate, error) {
 	m.ctrl.T.Helper()
@@ -1315,6 +2366,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListForecastExclusions(userID, relat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastExclusions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListForecastExclusions), userID, relatedID, relatedTable)
 }
 
+// ListForecastHistory mocks base method.
+func (m *MockIDatabaseAdapter) ListForecastHistory(userID int64, from, to string) ([]models.ForecastHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForecastHistory", userID, from, to)
+	ret0, _ := ret[0].([]models.ForecastHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForecastHistory indicates an expected call of ListForecastHistory.
+func (mr *MockIDatabaseAdapterMockRecorder) ListForecastHistory(userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastHistory", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListForecastHistory), userID, from, to)
+}
+
+// ListForecastSnapshots mocks base method.
+func (m *MockIDatabaseAdapter) ListForecastSnapshots(userID int64) ([]models.ForecastSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForecastSnapshots", userID)
+	ret0, _ := ret[0].([]models.ForecastSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForecastSnapshots indicates an expected call of ListForecastSnapshots.
+func (mr *MockIDatabaseAdapterMockRecorder) ListForecastSnapshots(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecastSnapshots", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListForecastSnapshots), userID)
+}
+
 // ListForecasts mocks base method.
 func (m *MockIDatabaseAdapter) ListForecasts(userID, limit int64) ([]models.Forecast, error) {
 	m.ctrl.T.Helper()
@@ -1330,6 +2411,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListForecasts(userID, limit any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForecasts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListForecasts), userID, limit)
 }
 
+// ListImports mocks base method.
+func (m *MockIDatabaseAdapter) ListImports(userID int64) ([]models.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", userID)
+	ret0, _ := ret[0].([]models.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockIDatabaseAdapterMockRecorder) ListImports(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListImports), userID)
+}
+
 // ListInvitations mocks base method.
 func (m *MockIDatabaseAdapter) ListInvitations(organisationID int64) ([]models.Invitation, error) {
 	m.ctrl.T.Helper()
@@ -1342,7 +2438,37 @@ func (m *MockIDatabaseAdapter) ListInvitations(organisationID int64) ([]models.I
 // ListInvitations indicates an expected call of ListInvitations.
 func (mr *MockIDatabaseAdapterMockRecorder) ListInvitations(organisationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListInvitations), organisationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListInvitations), organisationID)
+}
+
+// ListMatchedTransactionOccurrences mocks base method.
+func (m *MockIDatabaseAdapter) ListMatchedTransactionOccurrences(userID int64) (map[models.TransactionOccurrence]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMatchedTransactionOccurrences", userID)
+	ret0, _ := ret[0].(map[models.TransactionOccurrence]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMatchedTransactionOccurrences indicates an expected call of ListMatchedTransactionOccurrences.
+func (mr *MockIDatabaseAdapterMockRecorder) ListMatchedTransactionOccurrences(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMatchedTransactionOccurrences", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListMatchedTransactionOccurrences), userID)
+}
+
+// ListMemberPermissions mocks base method.
+func (m *MockIDatabaseAdapter) ListMemberPermissions(userID, organisationID int64) ([]models.MemberPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberPermissions", userID, organisationID)
+	ret0, _ := ret[0].([]models.MemberPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberPermissions indicates an expected call of ListMemberPermissions.
+func (mr *MockIDatabaseAdapterMockRecorder) ListMemberPermissions(userID, organisationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberPermissions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListMemberPermissions), userID, organisationID)
 }
 
 // ListMembers mocks base method.
@@ -1375,6 +2501,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListOAuthConnections(userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthConnections", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListOAuthConnections), userID)
 }
 
+// ListOrganisationFiatRates mocks base method.
+func (m *MockIDatabaseAdapter) ListOrganisationFiatRates(userID int64) ([]models.OrganisationFiatRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganisationFiatRates", userID)
+	ret0, _ := ret[0].([]models.OrganisationFiatRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganisationFiatRates indicates an expected call of ListOrganisationFiatRates.
+func (mr *MockIDatabaseAdapterMockRecorder) ListOrganisationFiatRates(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisationFiatRates", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListOrganisationFiatRates), userID)
+}
+
 // ListOrganisations mocks base method.
 func (m *MockIDatabaseAdapter) ListOrganisations(userID, page, limit int64) ([]models.Organisation, int64, error) {
 	m.ctrl.T.Helper()
@@ -1391,6 +2532,36 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListOrganisations(userID, page, limi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganisations", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListOrganisations), userID, page, limit)
 }
 
+// ListPayrollTemplateItems mocks base method.
+func (m *MockIDatabaseAdapter) ListPayrollTemplateItems(templateID int64) ([]models.PayrollTemplateItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollTemplateItems", templateID)
+	ret0, _ := ret[0].([]models.PayrollTemplateItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollTemplateItems indicates an expected call of ListPayrollTemplateItems.
+func (mr *MockIDatabaseAdapterMockRecorder) ListPayrollTemplateItems(templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollTemplateItems", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListPayrollTemplateItems), templateID)
+}
+
+// ListPayrollTemplates mocks base method.
+func (m *MockIDatabaseAdapter) ListPayrollTemplates(userID int64) ([]models.PayrollTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollTemplates", userID)
+	ret0, _ := ret[0].([]models.PayrollTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollTemplates indicates an expected call of ListPayrollTemplates.
+func (mr *MockIDatabaseAdapterMockRecorder) ListPayrollTemplates(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollTemplates", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListPayrollTemplates), userID)
+}
+
 // ListPendingInvitationsByEmail mocks base method.
 func (m *MockIDatabaseAdapter) ListPendingInvitationsByEmail(email string) ([]models.UserPendingInvitation, error) {
 	m.ctrl.T.Helper()
@@ -1484,6 +2655,51 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListSalaryCosts(userID, salaryID, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalaryCosts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListSalaryCosts), userID, salaryID, page, limit)
 }
 
+// ListScenarioItems mocks base method.
+func (m *MockIDatabaseAdapter) ListScenarioItems(userID, scenarioID int64) ([]models.ScenarioItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScenarioItems", userID, scenarioID)
+	ret0, _ := ret[0].([]models.ScenarioItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScenarioItems indicates an expected call of ListScenarioItems.
+func (mr *MockIDatabaseAdapterMockRecorder) ListScenarioItems(userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScenarioItems", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListScenarioItems), userID, scenarioID)
+}
+
+// ListScenarios mocks base method.
+func (m *MockIDatabaseAdapter) ListScenarios(userID int64) ([]models.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScenarios", userID)
+	ret0, _ := ret[0].([]models.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScenarios indicates an expected call of ListScenarios.
+func (mr *MockIDatabaseAdapterMockRecorder) ListScenarios(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScenarios", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListScenarios), userID)
+}
+
+// ListSessions mocks base method.
+func (m *MockIDatabaseAdapter) ListSessions(userID int64) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", userID)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockIDatabaseAdapterMockRecorder) ListSessions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListSessions), userID)
+}
+
 // ListTransactions mocks base method.
 func (m *MockIDatabaseAdapter) ListTransactions(userID, page, limit int64, sortBy, sortOrder, search string, hideDisabled, hideExpired bool) ([]models.Transaction, int64, error) {
 	m.ctrl.T.Helper()
@@ -1515,6 +2731,37 @@ func (mr *MockIDatabaseAdapterMockRecorder) ListVats(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVats", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListVats), userID)
 }
 
+// ListWebhookDeliveries mocks base method.
+func (m *MockIDatabaseAdapter) ListWebhookDeliveries(userID, webhookID, page, limit int64) ([]models.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", userID, webhookID, page, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockIDatabaseAdapterMockRecorder) ListWebhookDeliveries(userID, webhookID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListWebhookDeliveries), userID, webhookID, page, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockIDatabaseAdapter) ListWebhooks(userID int64) ([]models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", userID)
+	ret0, _ := ret[0].([]models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockIDatabaseAdapterMockRecorder) ListWebhooks(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ListWebhooks), userID)
+}
+
 // MarkOAuthAuthCodeUsed mocks base method.
 func (m *MockIDatabaseAdapter) MarkOAuthAuthCodeUsed(codeHash string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1545,6 +2792,22 @@ func (mr *MockIDatabaseAdapterMockRecorder) ReassignTransactionsCategory(userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignTransactionsCategory", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ReassignTransactionsCategory), userID, fromCategoryID, toCategoryID)
 }
 
+// RecordAuthFailure mocks base method.
+func (m *MockIDatabaseAdapter) RecordAuthFailure(scope, identifier string, window time.Duration) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAuthFailure", scope, identifier, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecordAuthFailure indicates an expected call of RecordAuthFailure.
+func (mr *MockIDatabaseAdapterMockRecorder) RecordAuthFailure(scope, identifier, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAuthFailure", reflect.TypeOf((*MockIDatabaseAdapter)(nil).RecordAuthFailure), scope, identifier, window)
+}
+
 // RefreshSalaryCostDetails mocks base method.
 func (m *MockIDatabaseAdapter) RefreshSalaryCostDetails(userID, salaryID int64) error {
 	m.ctrl.T.Helper()
@@ -1559,6 +2822,49 @@ func (mr *MockIDatabaseAdapterMockRecorder) RefreshSalaryCostDetails(userID, sal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSalaryCostDetails", reflect.TypeOf((*MockIDatabaseAdapter)(nil).RefreshSalaryCostDetails), userID, salaryID)
 }
 
+// ReplaceUserRecoveryCodes mocks base method.
+func (m *MockIDatabaseAdapter) ReplaceUserRecoveryCodes(userID int64, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUserRecoveryCodes", userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceUserRecoveryCodes indicates an expected call of ReplaceUserRecoveryCodes.
+func (mr *MockIDatabaseAdapterMockRecorder) ReplaceUserRecoveryCodes(userID, codeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUserRecoveryCodes", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ReplaceUserRecoveryCodes), userID, codeHashes)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockIDatabaseAdapter) ReplayWebhookDelivery(userID, webhookID, deliveryID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", userID, webhookID, deliveryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockIDatabaseAdapterMockRecorder) ReplayWebhookDelivery(userID, webhookID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ReplayWebhookDelivery), userID, webhookID, deliveryID)
+}
+
+// ResetAuthAttempts mocks base method.
+func (m *MockIDatabaseAdapter) ResetAuthAttempts(scope, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAuthAttempts", scope, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAuthAttempts indicates an expected call of ResetAuthAttempts.
+func (mr *MockIDatabaseAdapterMockRecorder) ResetAuthAttempts(scope, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAuthAttempts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ResetAuthAttempts), scope, identifier)
+}
+
 // ResetPassword mocks base method.
 func (m *MockIDatabaseAdapter) ResetPassword(password, email string) error {
 	m.ctrl.T.Helper()
@@ -1601,6 +2907,22 @@ func (mr *MockIDatabaseAdapterMockRecorder) RevokeOAuthRefreshToken(tokenHash an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuthRefreshToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).RevokeOAuthRefreshToken), tokenHash)
 }
 
+// ScopeToOrganisation mocks base method.
+func (m *MockIDatabaseAdapter) ScopeToOrganisation(organisationID int64) (db_adapter.IDatabaseAdapter, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScopeToOrganisation", organisationID)
+	ret0, _ := ret[0].(db_adapter.IDatabaseAdapter)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ScopeToOrganisation indicates an expected call of ScopeToOrganisation.
+func (mr *MockIDatabaseAdapterMockRecorder) ScopeToOrganisation(organisationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScopeToOrganisation", reflect.TypeOf((*MockIDatabaseAdapter)(nil).ScopeToOrganisation), organisationID)
+}
+
 // SetSalaryCostBaseLinks mocks base method.
 func (m *MockIDatabaseAdapter) SetSalaryCostBaseLinks(costID int64, baseIDs []int64) error {
 	m.ctrl.T.Helper()
@@ -1630,17 +2952,101 @@ func (mr *MockIDatabaseAdapterMockRecorder) SetUserCurrentOrganisation(userID, o
 }
 
 // StoreRefreshTokenID mocks base method.
-func (m *MockIDatabaseAdapter) StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName string) error {
+func (m *MockIDatabaseAdapter) StoreRefreshTokenID(userID int64, tokenId string, expirationTime time.Time, deviceName, ipAddress string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreRefreshTokenID", userID, tokenId, expirationTime, deviceName)
+	ret := m.ctrl.Call(m, "StoreRefreshTokenID", userID, tokenId, expirationTime, deviceName, ipAddress)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StoreRefreshTokenID indicates an expected call of StoreRefreshTokenID.
-func (mr *MockIDatabaseAdapterMockRecorder) StoreRefreshTokenID(userID, tokenId, expirationTime, deviceName any) *gomock.Call {
+func (mr *MockIDatabaseAdapterMockRecorder) StoreRefreshTokenID(userID, tokenId, expirationTime, deviceName, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRefreshTokenID", reflect.TypeOf((*MockIDatabaseAdapter)(nil).StoreRefreshTokenID), userID, tokenId, expirationTime, deviceName, ipAddress)
+}
+
+// SyncBankAccountAmount mocks base method.
+func (m *MockIDatabaseAdapter) SyncBankAccountAmount(userID, bankAccountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncBankAccountAmount", userID, bankAccountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncBankAccountAmount indicates an expected call of SyncBankAccountAmount.
+func (mr *MockIDatabaseAdapterMockRecorder) SyncBankAccountAmount(userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncBankAccountAmount", reflect.TypeOf((*MockIDatabaseAdapter)(nil).SyncBankAccountAmount), userID, bankAccountID)
+}
+
+// TouchAPIToken mocks base method.
+func (m *MockIDatabaseAdapter) TouchAPIToken(tokenID int64, ipAddress string, interval time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIToken", tokenID, ipAddress, interval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIToken indicates an expected call of TouchAPIToken.
+func (mr *MockIDatabaseAdapterMockRecorder) TouchAPIToken(tokenID, ipAddress, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).TouchAPIToken), tokenID, ipAddress, interval)
+}
+
+// TouchRefreshToken mocks base method.
+func (m *MockIDatabaseAdapter) TouchRefreshToken(userID int64, tokenID, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchRefreshToken", userID, tokenID, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchRefreshToken indicates an expected call of TouchRefreshToken.
+func (mr *MockIDatabaseAdapterMockRecorder) TouchRefreshToken(userID, tokenID, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchRefreshToken", reflect.TypeOf((*MockIDatabaseAdapter)(nil).TouchRefreshToken), userID, tokenID, ipAddress)
+}
+
+// TouchUserIdentity mocks base method.
+func (m *MockIDatabaseAdapter) TouchUserIdentity(provider, subject, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUserIdentity", provider, subject, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchUserIdentity indicates an expected call of TouchUserIdentity.
+func (mr *MockIDatabaseAdapterMockRecorder) TouchUserIdentity(provider, subject, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUserIdentity", reflect.TypeOf((*MockIDatabaseAdapter)(nil).TouchUserIdentity), provider, subject, email)
+}
+
+// UnlockAuthAttempts mocks base method.
+func (m *MockIDatabaseAdapter) UnlockAuthAttempts(identifier, otherIdentifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAuthAttempts", identifier, otherIdentifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAuthAttempts indicates an expected call of UnlockAuthAttempts.
+func (mr *MockIDatabaseAdapterMockRecorder) UnlockAuthAttempts(identifier, otherIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAuthAttempts", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UnlockAuthAttempts), identifier, otherIdentifier)
+}
+
+// UpdateActual mocks base method.
+func (m *MockIDatabaseAdapter) UpdateActual(payload models.CreateActual, userID, actualID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActual", payload, userID, actualID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActual indicates an expected call of UpdateActual.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateActual(payload, userID, actualID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRefreshTokenID", reflect.TypeOf((*MockIDatabaseAdapter)(nil).StoreRefreshTokenID), userID, tokenId, expirationTime, deviceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActual", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateActual), payload, userID, actualID)
 }
 
 // UpdateBankAccount mocks base method.
@@ -1713,6 +3119,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpdateInvitationLastSentAt(organisat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitationLastSentAt", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateInvitationLastSentAt), organisationID, invitationID)
 }
 
+// UpdateMemberPayrollClearance mocks base method.
+func (m *MockIDatabaseAdapter) UpdateMemberPayrollClearance(organisationID, userID int64, payrollClearance bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberPayrollClearance", organisationID, userID, payrollClearance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberPayrollClearance indicates an expected call of UpdateMemberPayrollClearance.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateMemberPayrollClearance(organisationID, userID, payrollClearance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberPayrollClearance", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateMemberPayrollClearance), organisationID, userID, payrollClearance)
+}
+
 // UpdateMemberRole mocks base method.
 func (m *MockIDatabaseAdapter) UpdateMemberRole(organisationID, userID int64, role string) error {
 	m.ctrl.T.Helper()
@@ -1755,6 +3175,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpdatePassword(userID, password any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdatePassword), userID, password)
 }
 
+// UpdatePayrollTemplate mocks base method.
+func (m *MockIDatabaseAdapter) UpdatePayrollTemplate(payload models.CreatePayrollTemplate, userID, templateID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollTemplate", payload, userID, templateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayrollTemplate indicates an expected call of UpdatePayrollTemplate.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdatePayrollTemplate(payload, userID, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollTemplate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdatePayrollTemplate), payload, userID, templateID)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockIDatabaseAdapter) UpdateProfile(payload models.UpdateUser, userID int64) error {
 	m.ctrl.T.Helper()
@@ -1813,6 +3247,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpdateSalaryCostLabel(payload, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSalaryCostLabel", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateSalaryCostLabel), payload, userID, salaryCostLabelID)
 }
 
+// UpdateScenario mocks base method.
+func (m *MockIDatabaseAdapter) UpdateScenario(payload models.UpdateScenario, userID, scenarioID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScenario", payload, userID, scenarioID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScenario indicates an expected call of UpdateScenario.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateScenario(payload, userID, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScenario", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateScenario), payload, userID, scenarioID)
+}
+
 // UpdateTransaction mocks base method.
 func (m *MockIDatabaseAdapter) UpdateTransaction(payload models.UpdateTransaction, userID, transactionID int64) error {
 	m.ctrl.T.Helper()
@@ -1855,6 +3303,35 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpdateUserSetting(payload, userID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateUserSetting), payload, userID)
 }
 
+// UpdateUserTwoFactor mocks base method.
+func (m *MockIDatabaseAdapter) UpdateUserTwoFactor(userID int64, secret *string, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTwoFactor", userID, secret, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTwoFactor indicates an expected call of UpdateUserTwoFactor.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateUserTwoFactor(userID, secret, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTwoFactor", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateUserTwoFactor), userID, secret, enabled)
+}
+
+// UpdateUserTwoFactorLastStep mocks base method.
+func (m *MockIDatabaseAdapter) UpdateUserTwoFactorLastStep(userID, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTwoFactorLastStep", userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTwoFactorLastStep indicates an expected call of UpdateUserTwoFactorLastStep.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateUserTwoFactorLastStep(userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTwoFactorLastStep", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateUserTwoFactorLastStep), userID, step)
+}
+
 // UpdateVat mocks base method.
 func (m *MockIDatabaseAdapter) UpdateVat(payload models.UpdateVat, userID, vatID int64) error {
 	m.ctrl.T.Helper()
@@ -1883,6 +3360,49 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpdateVatSetting(payload, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVatSetting", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateVatSetting), payload, userID)
 }
 
+// UpdateWebhook mocks base method.
+func (m *MockIDatabaseAdapter) UpdateWebhook(payload models.UpdateWebhook, userID, webhookID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", payload, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateWebhook(payload, userID, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateWebhook), payload, userID, webhookID)
+}
+
+// UpdateWebhookSecret mocks base method.
+func (m *MockIDatabaseAdapter) UpdateWebhookSecret(userID, webhookID int64, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSecret", userID, webhookID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookSecret indicates an expected call of UpdateWebhookSecret.
+func (mr *MockIDatabaseAdapterMockRecorder) UpdateWebhookSecret(userID, webhookID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSecret", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpdateWebhookSecret), userID, webhookID, secret)
+}
+
+// UpsertBankAccountBalance mocks base method.
+func (m *MockIDatabaseAdapter) UpsertBankAccountBalance(payload models.CreateBankAccountBalance, userID, bankAccountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBankAccountBalance", payload, userID, bankAccountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertBankAccountBalance indicates an expected call of UpsertBankAccountBalance.
+func (mr *MockIDatabaseAdapterMockRecorder) UpsertBankAccountBalance(payload, userID, bankAccountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBankAccountBalance", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertBankAccountBalance), payload, userID, bankAccountID)
+}
+
 // UpsertFiatRate mocks base method.
 func (m *MockIDatabaseAdapter) UpsertFiatRate(payload models.CreateFiatRate) error {
 	m.ctrl.T.Helper()
@@ -1897,6 +3417,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpsertFiatRate(payload any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFiatRate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertFiatRate), payload)
 }
 
+// UpsertFiatRateHistory mocks base method.
+func (m *MockIDatabaseAdapter) UpsertFiatRateHistory(payload models.CreateFiatRate, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertFiatRateHistory", payload, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertFiatRateHistory indicates an expected call of UpsertFiatRateHistory.
+func (mr *MockIDatabaseAdapterMockRecorder) UpsertFiatRateHistory(payload, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFiatRateHistory", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertFiatRateHistory), payload, date)
+}
+
 // UpsertForecast mocks base method.
 func (m *MockIDatabaseAdapter) UpsertForecast(payload models.CreateForecast, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1927,6 +3461,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpsertForecastDetail(payload, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertForecastDetail", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertForecastDetail), payload, userID, forecastID)
 }
 
+// UpsertMemberEntityPermission mocks base method.
+func (m *MockIDatabaseAdapter) UpsertMemberEntityPermission(userID, organisationID int64, entityType string, canView, canEdit, canDelete bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMemberEntityPermission", userID, organisationID, entityType, canView, canEdit, canDelete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMemberEntityPermission indicates an expected call of UpsertMemberEntityPermission.
+func (mr *MockIDatabaseAdapterMockRecorder) UpsertMemberEntityPermission(userID, organisationID, entityType, canView, canEdit, canDelete any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMemberEntityPermission", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertMemberEntityPermission), userID, organisationID, entityType, canView, canEdit, canDelete)
+}
+
 // UpsertMemberPermission mocks base method.
 func (m *MockIDatabaseAdapter) UpsertMemberPermission(userID, organisationID int64, canView, canEdit, canDelete bool) error {
 	m.ctrl.T.Helper()
@@ -1941,6 +3489,20 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpsertMemberPermission(userID, organ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMemberPermission", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertMemberPermission), userID, organisationID, canView, canEdit, canDelete)
 }
 
+// UpsertOrganisationFiatRate mocks base method.
+func (m *MockIDatabaseAdapter) UpsertOrganisationFiatRate(userID int64, base, target string, rate float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrganisationFiatRate", userID, base, target, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertOrganisationFiatRate indicates an expected call of UpsertOrganisationFiatRate.
+func (mr *MockIDatabaseAdapterMockRecorder) UpsertOrganisationFiatRate(userID, base, target, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrganisationFiatRate", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertOrganisationFiatRate), userID, base, target, rate)
+}
+
 // UpsertSalaryCostDetails mocks base method.
 func (m *MockIDatabaseAdapter) UpsertSalaryCostDetails(payload models.CreateSalaryCostDetail) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1956,6 +3518,21 @@ func (mr *MockIDatabaseAdapterMockRecorder) UpsertSalaryCostDetails(payload any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSalaryCostDetails", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UpsertSalaryCostDetails), payload)
 }
 
+// UseUserRecoveryCode mocks base method.
+func (m *MockIDatabaseAdapter) UseUserRecoveryCode(userID int64, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserRecoveryCode", userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseUserRecoveryCode indicates an expected call of UseUserRecoveryCode.
+func (mr *MockIDatabaseAdapterMockRecorder) UseUserRecoveryCode(userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserRecoveryCode", reflect.TypeOf((*MockIDatabaseAdapter)(nil).UseUserRecoveryCode), userID, codeHash)
+}
+
 // ValidateRegistration mocks base method.
 func (m *MockIDatabaseAdapter) ValidateRegistration(email, code string, validity time.Duration) (int64, error) {
 	m.ctrl.T.Helper()
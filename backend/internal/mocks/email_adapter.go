@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,6 +40,20 @@ func (m *MockIEmailAdapter) EXPECT() *MockIEmailAdapterMockRecorder {
 	return m.recorder
 }
 
+// SendAccountLockedMail mocks base method.
+func (m *MockIEmailAdapter) SendAccountLockedMail(email string, lockedFor time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAccountLockedMail", email, lockedFor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAccountLockedMail indicates an expected call of SendAccountLockedMail.
+func (mr *MockIEmailAdapterMockRecorder) SendAccountLockedMail(email, lockedFor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAccountLockedMail", reflect.TypeOf((*MockIEmailAdapter)(nil).SendAccountLockedMail), email, lockedFor)
+}
+
 // SendInvitationMail mocks base method.
 func (m *MockIEmailAdapter) SendInvitationMail(email, token, organisationName, invitedByName string) error {
 	m.ctrl.T.Helper()
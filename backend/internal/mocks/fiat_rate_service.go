@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: liquiswiss/internal/service/fiat_rate_service (interfaces: IFiatRateService)
+//
+// Generated by this command:
+//
+//	mockgen -package=mocks -destination ../../mocks/fiat_rate_service.go liquiswiss/internal/service/fiat_rate_service IFiatRateService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIFiatRateService is a mock of IFiatRateService interface.
+type MockIFiatRateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFiatRateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIFiatRateServiceMockRecorder is the mock recorder for MockIFiatRateService.
+type MockIFiatRateServiceMockRecorder struct {
+	mock *MockIFiatRateService
+}
+
+// NewMockIFiatRateService creates a new mock instance.
+func NewMockIFiatRateService(ctrl *gomock.Controller) *MockIFiatRateService {
+	mock := &MockIFiatRateService{ctrl: ctrl}
+	mock.recorder = &MockIFiatRateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFiatRateService) EXPECT() *MockIFiatRateServiceMockRecorder {
+	return m.recorder
+}
+
+// FetchFiatRates mocks base method.
+func (m *MockIFiatRateService) FetchFiatRates() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FetchFiatRates")
+}
+
+// FetchFiatRates indicates an expected call of FetchFiatRates.
+func (mr *MockIFiatRateServiceMockRecorder) FetchFiatRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFiatRates", reflect.TypeOf((*MockIFiatRateService)(nil).FetchFiatRates))
+}
+
+// RequiresInitialFetch mocks base method.
+func (m *MockIFiatRateService) RequiresInitialFetch() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiresInitialFetch")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequiresInitialFetch indicates an expected call of RequiresInitialFetch.
+func (mr *MockIFiatRateServiceMockRecorder) RequiresInitialFetch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresInitialFetch", reflect.TypeOf((*MockIFiatRateService)(nil).RequiresInitialFetch))
+}
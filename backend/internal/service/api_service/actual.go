@@ -0,0 +1,272 @@
+package api_service
+
+import (
+	"context"
+	"fmt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/bankstatement"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"math"
+	"time"
+)
+
+func (a *APIService) ListActuals(ctx context.Context, userID int64, from string, to string) ([]models.Actual, error) {
+	actuals, err := a.dbService.ListActuals(userID, from, to)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(actuals, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return actuals, nil
+}
+
+func (a *APIService) GetActual(ctx context.Context, userID int64, actualID int64) (*models.Actual, error) {
+	actual, err := a.dbService.GetActual(userID, actualID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(actual); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return actual, nil
+}
+
+func (a *APIService) CreateActual(ctx context.Context, payload models.CreateManualActual, userID int64) (*models.Actual, error) {
+	actual := models.CreateActual{
+		BookingDate: payload.BookingDate,
+		Amount:      payload.Amount,
+		Name:        payload.Name,
+		Reference:   payload.Reference,
+		Transaction: payload.Transaction,
+		PlannedDate: payload.PlannedDate,
+		Currency:    payload.Currency,
+	}
+	if err := a.reconcileActual(ctx, userID, &actual); err != nil {
+		return nil, err
+	}
+
+	ids, err := a.dbService.CreateActuals([]models.CreateActual{actual}, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("invalid reference: already recorded")
+	}
+
+	created, err := a.GetActual(ctx, userID, ids[0])
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "actual", events.ActionCreated, ids[0], 0, nil, created)
+	return created, nil
+}
+
+func (a *APIService) UpdateActual(ctx context.Context, payload models.UpdateActual, userID int64, actualID int64) (*models.Actual, error) {
+	existing, err := a.dbService.GetActual(userID, actualID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	actual := models.CreateActual{
+		BookingDate: time.Time(existing.BookingDate).Format(utils.InternalDateFormat),
+		Amount:      existing.Amount,
+		Name:        existing.Name,
+		Reference:   existing.Reference,
+		Currency:    *existing.Currency.ID,
+	}
+	if existing.Transaction != nil && existing.PlannedDate != nil {
+		transactionID := existing.Transaction.ID
+		plannedDate := time.Time(*existing.PlannedDate).Format(utils.InternalDateFormat)
+		actual.Transaction = &transactionID
+		actual.PlannedDate = &plannedDate
+	}
+
+	if payload.BookingDate != nil {
+		actual.BookingDate = *payload.BookingDate
+	}
+	if payload.Amount != nil {
+		actual.Amount = *payload.Amount
+	}
+	if payload.Name != nil {
+		actual.Name = *payload.Name
+	}
+	if payload.Reference != nil {
+		actual.Reference = payload.Reference
+	}
+	if payload.Currency != nil {
+		actual.Currency = *payload.Currency
+	}
+	if payload.Transaction != nil {
+		if *payload.Transaction == 0 {
+			actual.Transaction = nil
+			actual.PlannedDate = nil
+		} else {
+			actual.Transaction = payload.Transaction
+			actual.PlannedDate = payload.PlannedDate
+		}
+	} else if payload.PlannedDate != nil {
+		actual.PlannedDate = payload.PlannedDate
+	}
+
+	if err := a.reconcileActual(ctx, userID, &actual); err != nil {
+		return nil, err
+	}
+
+	err = a.dbService.UpdateActual(actual, userID, actualID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	updated, err := a.GetActual(ctx, userID, actualID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "actual", events.ActionUpdated, actualID, 0, existing, updated)
+	return updated, nil
+}
+
+func (a *APIService) DeleteActual(ctx context.Context, userID int64, actualID int64) error {
+	existing, err := a.dbService.GetActual(userID, actualID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeleteActual(userID, actualID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "actual", events.ActionDeleted, actualID, 0, existing, nil)
+	return nil
+}
+
+// ImportActuals stores the rows of a simple CSV (date, amount, name and optionally reference and currency)
+// as actuals in the organisation currency and matches them to planned transactions like a bank statement
+func (a *APIService) ImportActuals(ctx context.Context, userID int64, data []byte) (*models.ActualsImport, error) {
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	baseCurrency := *organisation.Currency.Code
+
+	statement, err := bankstatement.ParseCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range statement.Entries {
+		if entry.Currency != "" && entry.Currency != baseCurrency {
+			return nil, fmt.Errorf("invalid currency: only %s is supported", baseCurrency)
+		}
+	}
+
+	transactions, _, err := a.ListTransactions(ctx, userID, 1, 100000, "name", "ASC", "", true, false)
+	if err != nil {
+		return nil, err
+	}
+	fiatRates, err := a.statementFiatRates(ctx, baseCurrency, statement.Entries)
+	if err != nil {
+		return nil, err
+	}
+	settled, err := a.dbService.ListMatchedTransactionOccurrences(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	actuals := matchStatementEntries(statement.Entries, transactions, fiatRates, settled)
+	for i := range actuals {
+		actuals[i].Currency = *organisation.Currency.ID
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(actuals, "dive"); err != nil {
+		return nil, err
+	}
+
+	ids, err := a.dbService.CreateActuals(actuals, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	result := &models.ActualsImport{
+		Skipped: int64(len(actuals) - len(ids)),
+		Actuals: make([]models.Actual, 0, len(ids)),
+	}
+	for _, id := range ids {
+		actual, err := a.GetActual(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+		switch actual.Status {
+		case utils.ActualStatusMatched:
+			result.Matched++
+		case utils.ActualStatusDeviation:
+			result.Deviations++
+		default:
+			result.Unmatched++
+		}
+		result.Actuals = append(result.Actuals, *actual)
+	}
+
+	a.notifyChange(ctx, userID, "actual", events.ActionCreated, 0)
+	return result, nil
+}
+
+// reconcileActual derives status and planned amount from the linked transaction occurrence
+func (a *APIService) reconcileActual(ctx context.Context, userID int64, actual *models.CreateActual) error {
+	actual.Status = utils.ActualStatusUnmatched
+	actual.PlannedAmount = nil
+
+	currency, err := a.dbService.GetCurrency(actual.Currency)
+	if err != nil {
+		logger.Logger.Error(err)
+		return fmt.Errorf("invalid currency: not found")
+	}
+
+	if actual.Transaction == nil {
+		actual.PlannedDate = nil
+	} else {
+		if actual.PlannedDate == nil {
+			return fmt.Errorf("invalid plannedDate: required when linking a transaction")
+		}
+		transaction, err := a.dbService.GetTransaction(userID, *actual.Transaction)
+		if err != nil {
+			logger.Logger.Error(err)
+			return fmt.Errorf("invalid transaction: not found")
+		}
+		plannedDate, err := time.Parse(utils.InternalDateFormat, *actual.PlannedDate)
+		if err != nil {
+			return fmt.Errorf("invalid plannedDate: %w", err)
+		}
+		fiatRates, err := a.historicalFiatRates(ctx, *currency.Code, plannedDate)
+		if err != nil {
+			return err
+		}
+
+		occurrences := plannedOccurrences([]models.Transaction{*transaction}, fiatRates, plannedDate, plannedDate)
+		if len(occurrences) == 0 {
+			return fmt.Errorf("invalid plannedDate: the transaction is not planned on %s", *actual.PlannedDate)
+		}
+		plannedAmount := occurrences[0].amount
+		actual.PlannedAmount = &plannedAmount
+		actual.Status = utils.ActualStatusDeviation
+		if math.Abs(float64(actual.Amount-plannedAmount)) <= math.Abs(float64(plannedAmount))*statementAmountTolerance {
+			actual.Status = utils.ActualStatusMatched
+		}
+	}
+
+	validator := utils.GetValidator()
+	return validator.Struct(actual)
+}
@@ -6,6 +6,7 @@ import (
 	"liquiswiss/internal/adapter/db_adapter"
 	"liquiswiss/internal/adapter/email_adapter"
 	"liquiswiss/internal/events"
+	"liquiswiss/pkg/export"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/reqctx"
@@ -23,6 +24,22 @@ type IAPIService interface {
 	FinishRegistration(ctx context.Context, payload models.FinishRegistration, deviceName string, validity time.Duration) (*models.User, *string, *time.Time, *string, *time.Time, error)
 	DeleteRegistration(ctx context.Context, registrationID int64, email string) error
 
+	LoginTwoFactor(ctx context.Context, payload models.LoginTwoFactor, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error)
+	LoginSSO(ctx context.Context, identity models.SSOIdentity, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error)
+	GetTwoFactorStatus(ctx context.Context, userID int64) (*models.TwoFactorStatus, error)
+	SetupTwoFactor(ctx context.Context, userID int64) (*models.TwoFactorSetup, error)
+	EnableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error)
+	DisableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) error
+	RegenerateTwoFactorRecoveryCodes(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error)
+
+	ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID int64, currentSessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID int64, currentSessionID string) error
+
+	ListAPITokens(ctx context.Context, userID int64) ([]models.APIToken, error)
+	CreateAPIToken(ctx context.Context, payload models.CreateAPIToken, userID int64) (*models.CreatedAPIToken, error)
+	DeleteAPIToken(ctx context.Context, userID int64, tokenID int64) error
+
 	GetProfile(ctx context.Context, userID int64) (*models.User, error)
 	UpdateProfile(ctx context.Context, payload models.UpdateUser, userID int64) (*models.User, error)
 	UpdatePassword(ctx context.Context, payload models.UpdateUserPassword, userID int64) error
@@ -71,6 +88,16 @@ type IAPIService interface {
 	UpdateSalaryCostLabel(ctx context.Context, payload models.CreateSalaryCostLabel, userID int64, salaryCostLabelID int64) (*models.SalaryCostLabel, error)
 	DeleteSalaryCostLabel(ctx context.Context, userID int64, salaryCostLabelID int64) error
 
+	ListStatutoryPayrollItems(ctx context.Context, year uint16) ([]models.CreatePayrollTemplateItem, error)
+	ListPayrollTemplates(ctx context.Context, userID int64) ([]models.PayrollTemplate, error)
+	GetPayrollTemplate(ctx context.Context, userID int64, templateID int64) (*models.PayrollTemplate, error)
+	CreatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID int64) (*models.PayrollTemplate, error)
+	CreateStatutoryPayrollTemplate(ctx context.Context, payload models.CreateStatutoryPayrollTemplate, userID int64) (*models.PayrollTemplate, error)
+	CreatePayrollTemplateVersion(ctx context.Context, payload models.CreatePayrollTemplateVersion, userID int64, templateID int64) (*models.PayrollTemplate, error)
+	UpdatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID int64, templateID int64) (*models.PayrollTemplate, error)
+	DeletePayrollTemplate(ctx context.Context, userID int64, templateID int64) error
+	ApplyPayrollTemplate(ctx context.Context, payload models.ApplyPayrollTemplate, userID int64, salaryID int64) ([]models.SalaryCost, error)
+
 	ListForecasts(ctx context.Context, userID int64, limit int64) ([]models.Forecast, error)
 	ListForecastDetails(ctx context.Context, userID int64, limit int64) ([]models.ForecastDatabaseDetails, error)
 	ListForecastExclusions(ctx context.Context, userID int64, relatedID int64, relatedTable string) (map[string]bool, error)
@@ -79,12 +106,54 @@ type IAPIService interface {
 	DeleteForecastExclusion(ctx context.Context, payload models.CreateForecastExclusion, userID int64) (int64, error)
 	UpdateForecastExclusions(ctx context.Context, payload models.UpdateForecastExclusions, userID int64) error
 	CalculateForecast(ctx context.Context, userID int64) ([]models.Forecast, error)
+	GetForecastStatus(ctx context.Context, userID int64) (*models.ForecastStatus, error)
+	ScheduleForecastIfStale(ctx context.Context, userID int64) (bool, error)
+	ListForecastWarnings(ctx context.Context, userID int64) ([]models.ForecastWarning, error)
+	ListForecastSnapshots(ctx context.Context, userID int64) ([]models.ForecastSnapshot, error)
+	GetForecastSnapshot(ctx context.Context, userID int64, snapshotID int64) (*models.ForecastSnapshot, error)
+	CreateForecastSnapshot(ctx context.Context, payload models.CreateForecastSnapshot, userID int64) (*models.ForecastSnapshot, error)
+	DeleteForecastSnapshot(ctx context.Context, userID int64, snapshotID int64) error
+	DiffForecastSnapshots(ctx context.Context, userID int64, fromSnapshotID int64, toSnapshotID int64) (*models.ForecastSnapshotDiff, error)
+
+	ListScenarios(ctx context.Context, userID int64) ([]models.Scenario, error)
+	GetScenario(ctx context.Context, userID int64, scenarioID int64) (*models.Scenario, error)
+	CreateScenario(ctx context.Context, payload models.CreateScenario, userID int64) (*models.Scenario, error)
+	UpdateScenario(ctx context.Context, payload models.UpdateScenario, userID int64, scenarioID int64) (*models.Scenario, error)
+	DeleteScenario(ctx context.Context, userID int64, scenarioID int64) error
+	CreateScenarioItem(ctx context.Context, payload models.CreateScenarioItem, userID int64, scenarioID int64) (*models.ScenarioItem, error)
+	DeleteScenarioItem(ctx context.Context, userID int64, scenarioID int64, scenarioItemID int64) error
+	CalculateScenarioForecast(ctx context.Context, userID int64, scenarioID int64) (*models.ScenarioForecast, error)
 
 	ListBankAccounts(ctx context.Context, userID int64, page int64, limit int64, sortBy string, sortOrder string, search string) ([]models.BankAccount, int64, error)
 	GetBankAccount(ctx context.Context, userID int64, bankAccountID int64) (*models.BankAccount, error)
 	CreateBankAccount(ctx context.Context, payload models.CreateBankAccount, userID int64) (*models.BankAccount, error)
 	UpdateBankAccount(ctx context.Context, payload models.UpdateBankAccount, userID int64, bankAccountID int64) (*models.BankAccount, error)
 	DeleteBankAccount(ctx context.Context, userID int64, bankAccountID int64) error
+	ListBankAccountBalances(ctx context.Context, userID int64, bankAccountID int64) ([]models.BankAccountBalance, error)
+	CreateBankAccountBalance(ctx context.Context, payload models.CreateBankAccountBalance, userID int64, bankAccountID int64) (*models.BankAccountBalance, error)
+	DeleteBankAccountBalance(ctx context.Context, userID int64, bankAccountID int64, balanceID int64) error
+	ListBankStatementImports(ctx context.Context, userID int64, bankAccountID int64) ([]models.BankStatementImport, error)
+	GetBankStatementImport(ctx context.Context, userID int64, bankAccountID int64, importID int64) (*models.BankStatementImport, error)
+	ImportBankStatement(ctx context.Context, userID int64, bankAccountID int64, fileName string, format string, data []byte) (*models.BankStatementImport, error)
+	DeleteBankStatementImport(ctx context.Context, userID int64, bankAccountID int64, importID int64) error
+	ListActuals(ctx context.Context, userID int64, from string, to string) ([]models.Actual, error)
+	GetActual(ctx context.Context, userID int64, actualID int64) (*models.Actual, error)
+	CreateActual(ctx context.Context, payload models.CreateManualActual, userID int64) (*models.Actual, error)
+	UpdateActual(ctx context.Context, payload models.UpdateActual, userID int64, actualID int64) (*models.Actual, error)
+	DeleteActual(ctx context.Context, userID int64, actualID int64) error
+	ImportActuals(ctx context.Context, userID int64, data []byte) (*models.ActualsImport, error)
+	GetVarianceReport(ctx context.Context, userID int64, from string, to string) (*models.VarianceReport, error)
+	ExportForecast(ctx context.Context, userID int64, months int64) (*export.Report, error)
+	ExportTransactions(ctx context.Context, userID int64) (*export.Report, error)
+	ExportEmployees(ctx context.Context, userID int64) (*export.Report, error)
+	ListImports(ctx context.Context, userID int64) ([]models.Import, error)
+	GetImport(ctx context.Context, userID int64, importID int64) (*models.Import, error)
+	PreviewTransactionImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error)
+	PreviewEmployeeImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error)
+	CommitImport(ctx context.Context, userID int64, importID int64) (*models.Import, error)
+	DeleteImport(ctx context.Context, userID int64, importID int64) error
+
+	ListAuditLogs(ctx context.Context, userID int64, filter models.AuditLogFilter, page int64, limit int64) ([]models.AuditLog, int64, error)
 
 	ListVats(ctx context.Context, userID int64) ([]models.Vat, error)
 	GetVat(ctx context.Context, userID int64, vatID int64) (*models.Vat, error)
@@ -119,6 +188,12 @@ type IAPIService interface {
 	ListFiatRates(ctx context.Context, base string) ([]models.FiatRate, error)
 	GetFiatRate(ctx context.Context, base, target string) (*models.FiatRate, error)
 	UpsertFiatRate(ctx context.Context, payload models.CreateFiatRate) error
+	GetFiatRateAt(ctx context.Context, base, target string, date time.Time) (*models.FiatRateHistory, error)
+	ListOrganisationFiatRates(ctx context.Context, userID int64) ([]models.OrganisationFiatRate, error)
+	UpsertOrganisationFiatRate(ctx context.Context, payload models.UpsertOrganisationFiatRate, userID int64, target string) (*models.OrganisationFiatRate, error)
+	DeleteOrganisationFiatRate(ctx context.Context, userID int64, target string) error
+	RecordFiatRateFetch(ctx context.Context, payload models.CreateFiatRateFetch) error
+	GetFiatRateHealth(ctx context.Context) (*models.FiatRateHealth, error)
 	CountUniqueCurrenciesInFiatRates(ctx context.Context) (int64, error)
 
 	ListOrganisationInvitations(ctx context.Context, userID int64, organisationID int64) ([]models.Invitation, error)
@@ -133,14 +208,29 @@ type IAPIService interface {
 	ListOrganisationMembers(ctx context.Context, userID int64, organisationID int64) ([]models.OrganisationMember, error)
 	UpdateOrganisationMember(ctx context.Context, payload models.UpdateMember, userID int64, organisationID int64, memberUserID int64) error
 	RemoveOrganisationMember(ctx context.Context, userID int64, organisationID int64, memberUserID int64) error
+	DeleteOrganisationMemberPermission(ctx context.Context, userID int64, organisationID int64, memberUserID int64, entityType string) error
+	UnlockOrganisationMember(ctx context.Context, userID int64, organisationID int64, memberUserID int64) error
+
+	ListWebhooks(ctx context.Context, userID int64) ([]models.Webhook, error)
+	CreateWebhook(ctx context.Context, payload models.CreateWebhook, userID int64) (*models.WebhookWithSecret, error)
+	UpdateWebhook(ctx context.Context, payload models.UpdateWebhook, userID int64, webhookID int64) (*models.Webhook, error)
+	RotateWebhookSecret(ctx context.Context, userID int64, webhookID int64) (*models.WebhookWithSecret, error)
+	DeleteWebhook(ctx context.Context, userID int64, webhookID int64) error
+	ListWebhookDeliveries(ctx context.Context, userID int64, webhookID int64, page int64, limit int64) ([]models.WebhookDelivery, int64, error)
+	ReplayWebhookDelivery(ctx context.Context, userID int64, webhookID int64, deliveryID int64) (*models.WebhookDelivery, error)
 
 	SetEventHub(hub *events.Hub)
+	EnableForecastScheduler(debounce time.Duration)
+	EnableWebhooks()
+	WithDatabase(dbService db_adapter.IDatabaseAdapter) IAPIService
 }
 
 type APIService struct {
-	dbService    db_adapter.IDatabaseAdapter
-	emailAdapter email_adapter.IEmailAdapter
-	eventHub     *events.Hub
+	dbService         db_adapter.IDatabaseAdapter
+	emailAdapter      email_adapter.IEmailAdapter
+	eventHub          *events.Hub
+	forecastScheduler *forecastScheduler
+	webhooksEnabled   bool
 }
 
 func NewAPIService(dbService db_adapter.IDatabaseAdapter, emailAdapter email_adapter.IEmailAdapter) IAPIService {
@@ -150,14 +240,22 @@ func NewAPIService(dbService db_adapter.IDatabaseAdapter, emailAdapter email_ada
 	}
 }
 
+// WithDatabase returns a copy of the service that runs its queries on dbService, e.g. an adapter
+// scoped to the organisation of an API token. The event hub and the schedulers stay shared.
+func (a *APIService) WithDatabase(dbService db_adapter.IDatabaseAdapter) IAPIService {
+	scoped := *a
+	scoped.dbService = dbService
+	return &scoped
+}
+
 // SetEventHub wires the real-time event hub. A nil hub (default, e.g. in tests)
 // turns all event publishing into a no-op.
 func (a *APIService) SetEventHub(hub *events.Hub) {
 	a.eventHub = hub
 }
 
-// notifyChange publishes a change event scoped to the acting user's current
-// organisation. Failures only affect real-time refresh, never the mutation.
+// notifyChange records the change in the audit log and publishes a change event scoped to
+// the acting user's current organisation. Failures never affect the mutation.
 func (a *APIService) notifyChange(ctx context.Context, userID int64, entity string, action string, id int64) {
 	a.notifyChangeWithParent(ctx, userID, entity, action, id, 0)
 }
@@ -165,36 +263,46 @@ func (a *APIService) notifyChange(ctx context.Context, userID int64, entity stri
 // notifyChangeWithParent additionally links the event to a parent entity id
 // (e.g. salary cost → owning salary) for targeted client-side highlighting
 func (a *APIService) notifyChangeWithParent(ctx context.Context, userID int64, entity string, action string, id int64, parentID int64) {
-	if a.eventHub == nil {
-		return
-	}
+	a.notifyChangeWithDiff(ctx, userID, entity, action, id, parentID, nil, nil)
+}
+
+// notifyChangeWithDiff additionally stores the fields that differ between the entity before
+// and after the mutation (nil before a creation and after a deletion) in the audit log
+func (a *APIService) notifyChangeWithDiff(ctx context.Context, userID int64, entity string, action string, id int64, parentID int64, before any, after any) {
 	user, err := a.dbService.GetProfile(userID)
 	if err != nil {
 		logger.Logger.Warnf("events: could not resolve organisation for user %d: %v", userID, err)
 		return
 	}
-	a.eventHub.Publish(events.Event{
-		Entity:         entity,
-		Action:         action,
-		ID:             id,
-		ParentID:       parentID,
-		OrganisationID: user.CurrentOrganisationID,
-		OriginUserID:   userID,
-		OriginClientID: reqctx.ClientID(ctx),
-	})
+	a.notifyOrganisationChangeWithDiff(ctx, userID, user.CurrentOrganisationID, entity, action, id, parentID, before, after)
 }
 
-// notifyOrganisationChange publishes a change event for an explicit
+// notifyOrganisationChange records and publishes a change for an explicit
 // organisation (invitation/member mutations carry the org id directly).
 // actorUserID is the user who performed the mutation (event origin).
 func (a *APIService) notifyOrganisationChange(ctx context.Context, actorUserID int64, organisationID int64, entity string, action string, id int64) {
-	if a.eventHub == nil || organisationID == 0 {
+	a.notifyOrganisationChangeWithDiff(ctx, actorUserID, organisationID, entity, action, id, 0, nil, nil)
+}
+
+func (a *APIService) notifyOrganisationChangeWithDiff(ctx context.Context, actorUserID int64, organisationID int64, entity string, action string, id int64, parentID int64, before any, after any) {
+	if organisationID == 0 {
+		return
+	}
+	a.recordAudit(ctx, actorUserID, organisationID, entity, action, id, parentID, auditDiff(before, after))
+	a.publishChange(ctx, actorUserID, organisationID, entity, action, id, parentID)
+	a.enqueueWebhooks(organisationID, entity, action, id, parentID)
+}
+
+// publishChange sends the change event to the hub, if any
+func (a *APIService) publishChange(ctx context.Context, actorUserID int64, organisationID int64, entity string, action string, id int64, parentID int64) {
+	if a.eventHub == nil {
 		return
 	}
 	a.eventHub.Publish(events.Event{
 		Entity:         entity,
 		Action:         action,
 		ID:             id,
+		ParentID:       parentID,
 		OrganisationID: organisationID,
 		OriginUserID:   actorUserID,
 		OriginClientID: reqctx.ClientID(ctx),
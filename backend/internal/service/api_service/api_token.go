@@ -0,0 +1,70 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"time"
+)
+
+// ErrAPITokenScopeExceedsRole is returned when a token would allow more than the role of the member
+var ErrAPITokenScopeExceedsRole = errors.New("api token scope exceeds role")
+
+func (a *APIService) ListAPITokens(ctx context.Context, userID int64) ([]models.APIToken, error) {
+	tokens, err := a.dbService.ListAPITokens(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// CreateAPIToken issues a personal API token for an organisation of the user, the secret is only returned here
+func (a *APIService) CreateAPIToken(ctx context.Context, payload models.CreateAPIToken, userID int64) (*models.CreatedAPIToken, error) {
+	organisationID := int64(0)
+	if payload.OrganisationID != nil {
+		organisationID = *payload.OrganisationID
+	} else {
+		user, err := a.dbService.GetProfile(userID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		organisationID = user.CurrentOrganisationID
+	}
+	// Returns sql.ErrNoRows unless the user is a member
+	organisation, err := a.dbService.GetOrganisation(userID, organisationID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if payload.Scope == "editor" && organisation.Role == "read-only" {
+		return nil, ErrAPITokenScopeExceedsRole
+	}
+
+	token, prefix := auth.GenerateAPIToken()
+	expiresAt := time.Now().AddDate(0, 0, payload.ExpiresInDays)
+	tokenID, err := a.dbService.CreateAPIToken(payload, userID, organisationID, auth.HashAPIToken(token), prefix, expiresAt)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	apiToken, err := a.dbService.GetAPIToken(userID, tokenID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return &models.CreatedAPIToken{APIToken: *apiToken, Token: token}, nil
+}
+
+// DeleteAPIToken revokes the token, the next request with it is rejected
+func (a *APIService) DeleteAPIToken(ctx context.Context, userID int64, tokenID int64) error {
+	err := a.dbService.DeleteAPIToken(userID, tokenID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	return nil
+}
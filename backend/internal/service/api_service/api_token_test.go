@@ -0,0 +1,60 @@
+package api_service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/models"
+)
+
+func TestCreateAPIToken_StoresOnlyTheHash(t *testing.T) {
+	userID := int64(42)
+	organisationID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	payload := models.CreateAPIToken{Name: "Nightly export", Scope: "editor", ExpiresInDays: 30}
+
+	var storedHash, storedPrefix string
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: organisationID}, nil)
+	mockDB.EXPECT().GetOrganisation(userID, organisationID).Return(&models.Organisation{ID: organisationID, Role: "admin"}, nil)
+	mockDB.EXPECT().CreateAPIToken(payload, userID, organisationID, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(payload models.CreateAPIToken, userID int64, organisationID int64, tokenHash string, tokenPrefix string, expiresAt time.Time) (int64, error) {
+			storedHash, storedPrefix = tokenHash, tokenPrefix
+			require.WithinDuration(t, time.Now().AddDate(0, 0, 30), expiresAt, time.Minute)
+			return 3, nil
+		})
+	mockDB.EXPECT().GetAPIToken(userID, int64(3)).Return(&models.APIToken{ID: 3, Scope: "editor"}, nil)
+
+	token, err := service.CreateAPIToken(context.Background(), payload, userID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token.Token, storedPrefix))
+	require.Equal(t, auth.HashAPIToken(token.Token), storedHash)
+}
+
+func TestCreateAPIToken_RejectsEditorScopeForReadOnlyMember(t *testing.T) {
+	userID := int64(42)
+	organisationID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetOrganisation(userID, organisationID).Return(&models.Organisation{ID: organisationID, Role: "read-only"}, nil)
+
+	_, err := service.CreateAPIToken(context.Background(), models.CreateAPIToken{
+		Name: "Nightly import", Scope: "editor", OrganisationID: &organisationID, ExpiresInDays: 30,
+	}, userID)
+	require.ErrorIs(t, err, api_service.ErrAPITokenScopeExceedsRole)
+}
@@ -0,0 +1,91 @@
+package api_service
+
+import (
+	"context"
+	"encoding/json"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/reqctx"
+	"reflect"
+	"sort"
+)
+
+func (a *APIService) ListAuditLogs(ctx context.Context, userID int64, filter models.AuditLogFilter, page int64, limit int64) ([]models.AuditLog, int64, error) {
+	auditLogs, totalCount, err := a.dbService.ListAuditLogs(userID, filter, page, limit)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, 0, err
+	}
+	return auditLogs, totalCount, nil
+}
+
+// recordAudit persists a mutation in the audit trail of the organisation. Failures are only
+// logged, the mutation itself already happened.
+func (a *APIService) recordAudit(ctx context.Context, userID int64, organisationID int64, entity string, action string, id int64, parentID int64, changes []models.AuditFieldChange) {
+	payload := models.CreateAuditLog{
+		OrganisationID: organisationID,
+		UserID:         userID,
+		Source:         models.AuditSourceWeb,
+		Entity:         entity,
+		EntityID:       id,
+		Action:         action,
+		Changes:        changes,
+	}
+	if oauthClientID := reqctx.OAuthClientID(ctx); oauthClientID != "" {
+		payload.Source = models.AuditSourceMCP
+		payload.OAuthClientID = &oauthClientID
+	} else if reqctx.APITokenID(ctx) != 0 {
+		payload.Source = models.AuditSourceAPIToken
+	}
+	if parentID != 0 {
+		payload.ParentID = &parentID
+	}
+	if _, err := a.dbService.CreateAuditLog(payload); err != nil {
+		logger.Logger.Warnf("audit: could not record %s %s %d for organisation %d: %v", entity, action, id, organisationID, err)
+	}
+}
+
+// auditDiff lists the JSON fields that differ between before and after. A nil side (creation
+// or deletion) turns every field of the other side into a change.
+func auditDiff(before any, after any) []models.AuditFieldChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	fields := make([]string, 0, len(beforeFields)+len(afterFields))
+	for field := range beforeFields {
+		fields = append(fields, field)
+	}
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]models.AuditFieldChange, 0)
+	for _, field := range fields {
+		beforeValue, afterValue := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		changes = append(changes, models.AuditFieldChange{Field: field, Before: beforeValue, After: afterValue})
+	}
+	return changes
+}
+
+// auditFields flattens an entity into its top-level JSON fields
+func auditFields(entity any) map[string]any {
+	fields := make(map[string]any)
+	if entity == nil || (reflect.ValueOf(entity).Kind() == reflect.Ptr && reflect.ValueOf(entity).IsNil()) {
+		return fields
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		logger.Logger.Warnf("audit: could not serialise %T: %v", entity, err)
+		return fields
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		logger.Logger.Warnf("audit: could not serialise %T: %v", entity, err)
+	}
+	return fields
+}
@@ -0,0 +1,97 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/reqctx"
+	"liquiswiss/pkg/utils"
+)
+
+func TestUpdateEmployee_RecordsAuditLogWithDiff(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	employeeID := int64(7)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	hoursPerMonth := uint16(160)
+	gomock.InOrder(
+		mockDB.EXPECT().GetEmployee(userID, employeeID).
+			Return(&models.Employee{ID: employeeID, Name: "Anna Muster", HoursPerMonth: &hoursPerMonth}, nil),
+		mockDB.EXPECT().UpdateEmployee(gomock.Any(), userID, employeeID).Return(nil),
+		mockDB.EXPECT().GetEmployee(userID, employeeID).
+			Return(&models.Employee{ID: employeeID, Name: "Anna Beispiel", HoursPerMonth: &hoursPerMonth}, nil),
+	)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: 500}, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+
+	var recorded models.CreateAuditLog
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).
+		DoAndReturn(func(payload models.CreateAuditLog) (int64, error) {
+			recorded = payload
+			return 1, nil
+		})
+
+	// A request over MCP carries the OAuth client id
+	ctx := reqctx.WithOAuthClientID(context.Background(), "claude-desktop")
+	name := "Anna Beispiel"
+	_, err := service.UpdateEmployee(ctx, models.UpdateEmployee{Name: &name}, userID, employeeID)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(500), recorded.OrganisationID)
+	require.Equal(t, userID, recorded.UserID)
+	require.Equal(t, models.AuditSourceMCP, recorded.Source)
+	require.NotNil(t, recorded.OAuthClientID)
+	require.Equal(t, "claude-desktop", *recorded.OAuthClientID)
+	require.Equal(t, "employee", recorded.Entity)
+	require.Equal(t, employeeID, recorded.EntityID)
+	require.Equal(t, "updated", recorded.Action)
+	require.Nil(t, recorded.ParentID)
+	// Only the changed field is recorded
+	require.Equal(t, []models.AuditFieldChange{{Field: "name", Before: "Anna Muster", After: "Anna Beispiel"}}, recorded.Changes)
+}
+
+func TestDeleteEmployee_RecordsAuditLogFromWeb(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	employeeID := int64(7)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetEmployee(userID, employeeID).Return(&models.Employee{ID: employeeID, Name: "Anna Muster"}, nil)
+	mockDB.EXPECT().DeleteEmployee(userID, employeeID).Return(nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: 500}, nil)
+
+	var recorded models.CreateAuditLog
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).
+		DoAndReturn(func(payload models.CreateAuditLog) (int64, error) {
+			recorded = payload
+			return 1, nil
+		})
+
+	require.NoError(t, service.DeleteEmployee(context.Background(), userID, employeeID))
+
+	require.Equal(t, models.AuditSourceWeb, recorded.Source)
+	require.Nil(t, recorded.OAuthClientID)
+	require.Equal(t, "deleted", recorded.Action)
+	// A deletion keeps every field of the removed entity
+	require.Contains(t, recorded.Changes, models.AuditFieldChange{Field: "name", Before: "Anna Muster", After: nil})
+	require.Contains(t, recorded.Changes, models.AuditFieldChange{Field: "id", Before: float64(employeeID), After: nil})
+}
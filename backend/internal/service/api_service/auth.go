@@ -9,6 +9,7 @@ import (
 	"liquiswiss/pkg/auth"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/reqctx"
 	"time"
 )
 
@@ -18,9 +19,17 @@ import (
 var ErrInvalidCredentials = errors.New("invalid credentials")
 
 func (a *APIService) Login(ctx context.Context, payload models.Login, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	// Unknown emails are counted the same way, the limiter must not reveal which accounts exist
+	attemptIdentifier := emailAttemptIdentifier(payload.Email)
+	err := a.checkAuthAttempts(ctx, authScopeLogin, attemptIdentifier)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
 	loginUser, err := a.dbService.GetUserPasswordByEMail(payload.Email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			a.recordAuthFailure(ctx, authScopeLogin, attemptIdentifier)
 			return nil, nil, nil, nil, nil, ErrInvalidCredentials
 		}
 		logger.Logger.Error(err)
@@ -29,16 +38,38 @@ func (a *APIService) Login(ctx context.Context, payload models.Login, deviceName
 
 	err = bcrypt.CompareHashAndPassword([]byte(loginUser.Password), []byte(payload.Password))
 	if err != nil {
+		if a.recordAuthFailure(ctx, authScopeLogin, attemptIdentifier) {
+			a.notifyAccountLocked(payload.Email)
+		}
 		return nil, nil, nil, nil, nil, ErrInvalidCredentials
 	}
+	a.resetAuthAttempts(authScopeLogin, attemptIdentifier)
 
-	user, err := a.dbService.GetProfile(loginUser.ID)
+	twoFactor, err := a.dbService.GetUserTwoFactor(loginUser.ID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, nil, nil, nil, nil, err
 	}
+	// The password alone only unlocks the second step
+	if twoFactor.Enabled {
+		challengeToken, expiresAt, err := auth.GenerateTwoFactorChallengeToken(loginUser.ID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, nil, nil, nil, nil, err
+		}
+		return nil, nil, nil, nil, nil, &TwoFactorChallenge{Challenge: models.LoginChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+			ExpiresAt:         expiresAt,
+		}}
+	}
+
+	return a.issueLoginSession(ctx, loginUser.ID, deviceName, existingRefreshToken)
+}
 
-	accessToken, accessExpirationTime, _, err := auth.GenerateAccessToken(*user)
+// issueLoginSession creates the access and refresh token of a completed login
+func (a *APIService) issueLoginSession(ctx context.Context, userID int64, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	user, err := a.dbService.GetProfile(userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, nil, nil, nil, nil, err
@@ -50,11 +81,18 @@ func (a *APIService) Login(ctx context.Context, payload models.Login, deviceName
 		return nil, nil, nil, nil, nil, err
 	}
 
+	// The access token belongs to the session of the refresh token, so revoking it takes effect immediately
+	accessToken, accessExpirationTime, _, err := auth.GenerateSessionAccessToken(*user, tokenId)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, nil, nil, nil, nil, err
+	}
+
 	// Clear the pre-existing refreshToken first
 	a.clearRefreshTokenFromDatabase(existingRefreshToken)
 
 	// Store the refresh token in the database
-	err = a.dbService.StoreRefreshTokenID(loginUser.ID, tokenId, refreshExpirationTime, deviceName)
+	err = a.dbService.StoreRefreshTokenID(userID, tokenId, refreshExpirationTime, deviceName, reqctx.ClientIP(ctx))
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, nil, nil, nil, nil, err
@@ -72,6 +110,14 @@ func (a *APIService) Logout(ctx context.Context, existingRefreshToken string) {
 }
 
 func (a *APIService) ForgotPassword(ctx context.Context, payload models.ForgotPassword, code string) error {
+	// Every request counts, it sends an email
+	attemptIdentifier := emailAttemptIdentifier(payload.Email)
+	err := a.checkAuthAttempts(ctx, authScopeForgotPassword, attemptIdentifier)
+	if err != nil {
+		return err
+	}
+	a.recordAuthFailure(ctx, authScopeForgotPassword, attemptIdentifier)
+
 	hasCreated, err := a.dbService.CreateResetPassword(payload.Email, code, config.GetConfig().ResetPasswordDelay)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -95,9 +141,8 @@ func (a *APIService) ForgotPassword(ctx context.Context, payload models.ForgotPa
 }
 
 func (a *APIService) ResetPassword(ctx context.Context, payload models.ResetPassword) error {
-	_, err := a.dbService.ValidateResetPassword(payload.Email, payload.Code, config.GetConfig().ResetPasswordValidity)
+	err := a.validateResetPasswordCode(ctx, payload.Email, payload.Code)
 	if err != nil {
-		logger.Logger.Error(err)
 		return err
 	}
 
@@ -119,15 +164,32 @@ func (a *APIService) ResetPassword(ctx context.Context, payload models.ResetPass
 		return err
 	}
 
+	// The owner proved access to the email, a lockout of the account ends with the new password
+	a.resetAuthAttempts(authScopeLogin, emailAttemptIdentifier(payload.Email))
+
 	return nil
 }
 
 func (a *APIService) CheckResetPasswordCode(ctx context.Context, payload models.CheckResetPasswordCode) error {
-	_, err := a.dbService.ValidateResetPassword(payload.Email, payload.Code, config.GetConfig().ResetPasswordValidity)
+	return a.validateResetPasswordCode(ctx, payload.Email, payload.Code)
+}
+
+// validateResetPasswordCode checks the code of a password reset, guessing is limited per email and IP address
+func (a *APIService) validateResetPasswordCode(ctx context.Context, email string, code string) error {
+	attemptIdentifier := emailAttemptIdentifier(email)
+	err := a.checkAuthAttempts(ctx, authScopeResetPasswordCode, attemptIdentifier)
 	if err != nil {
+		return err
+	}
+	_, err = a.dbService.ValidateResetPassword(email, code, config.GetConfig().ResetPasswordValidity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			a.recordAuthFailure(ctx, authScopeResetPasswordCode, attemptIdentifier)
+		}
 		logger.Logger.Error(err)
 		return err
 	}
+	a.resetAuthAttempts(authScopeResetPasswordCode, attemptIdentifier)
 	return nil
 }
 
@@ -158,11 +220,20 @@ func (a *APIService) CreateRegistration(ctx context.Context, payload models.Crea
 }
 
 func (a *APIService) CheckRegistrationCode(ctx context.Context, payload models.CheckRegistrationCode, validity time.Duration) (int64, error) {
+	attemptIdentifier := emailAttemptIdentifier(payload.Email)
+	err := a.checkAuthAttempts(ctx, authScopeRegistrationCode, attemptIdentifier)
+	if err != nil {
+		return 0, err
+	}
 	registrationID, err := a.dbService.ValidateRegistration(payload.Email, payload.Code, validity)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			a.recordAuthFailure(ctx, authScopeRegistrationCode, attemptIdentifier)
+		}
 		logger.Logger.Error(err)
 		return 0, err
 	}
+	a.resetAuthAttempts(authScopeRegistrationCode, attemptIdentifier)
 	return registrationID, nil
 }
 
@@ -216,32 +287,7 @@ func (a *APIService) FinishRegistration(ctx context.Context, payload models.Fini
 		return nil, nil, nil, nil, nil, err
 	}
 
-	user, err := a.dbService.GetProfile(userId)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	accessToken, accessExpirationTime, _, err := auth.GenerateAccessToken(*user)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	refreshToken, tokenId, refreshExpirationTime, err := auth.GenerateRefreshToken(*user)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	// Store the refresh token in the database
-	err = a.dbService.StoreRefreshTokenID(user.ID, tokenId, refreshExpirationTime, deviceName)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	return user, &accessToken, &accessExpirationTime, &refreshToken, &refreshExpirationTime, nil
+	return a.issueLoginSession(ctx, userId, deviceName, "")
 }
 
 func (a *APIService) DeleteRegistration(ctx context.Context, registrationID int64, email string) error {
@@ -0,0 +1,123 @@
+package api_service
+
+import (
+	"context"
+	"fmt"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/reqctx"
+	"liquiswiss/pkg/utils"
+	"strings"
+	"time"
+)
+
+// Scopes of the attempt limiter, each one counts its failures separately
+const (
+	authScopeLogin             = "login"
+	authScopeTwoFactor         = "two-factor"
+	authScopeForgotPassword    = "forgot-password"
+	authScopeResetPasswordCode = "reset-password-code"
+	authScopeRegistrationCode  = "registration-code"
+)
+
+// TooManyAttemptsError is returned while the account or the IP address is blocked after failed attempts
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+func emailAttemptIdentifier(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func userAttemptIdentifier(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func ipAttemptIdentifier(ctx context.Context) string {
+	if ip := reqctx.ClientIP(ctx); ip != "" {
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// checkAuthAttempts rejects the attempt while the identifier or the IP address of the request is blocked
+func (a *APIService) checkAuthAttempts(ctx context.Context, scope string, identifier string) error {
+	blocked, err := a.dbService.GetAuthBlock(scope, identifier, ipAttemptIdentifier(ctx))
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if blocked > 0 {
+		return &TooManyAttemptsError{RetryAfter: blocked}
+	}
+	return nil
+}
+
+// recordAuthFailure counts a failed attempt for the identifier and the IP address of the request,
+// it returns true when this attempt locked the identifier out
+func (a *APIService) recordAuthFailure(ctx context.Context, scope string, identifier string) bool {
+	lockedOut, err := a.recordAuthFailureOf(scope, identifier, 1)
+	if err != nil {
+		logger.Logger.Error(err)
+	}
+	if ip := ipAttemptIdentifier(ctx); ip != "" {
+		if _, err := a.recordAuthFailureOf(scope, ip, utils.AuthIPAttemptFactor); err != nil {
+			logger.Logger.Error(err)
+		}
+	}
+	return lockedOut
+}
+
+func (a *APIService) recordAuthFailureOf(scope string, identifier string, factor int64) (bool, error) {
+	failures, locked, err := a.dbService.RecordAuthFailure(scope, identifier, utils.AuthAttemptWindow)
+	if err != nil {
+		return false, err
+	}
+	// A running lockout is neither extended nor reported twice
+	if locked {
+		return false, nil
+	}
+	delay, lockout := authAttemptDelay(failures, factor)
+	if delay == 0 {
+		return false, nil
+	}
+	err = a.dbService.BlockAuthAttempts(scope, identifier, delay, lockout)
+	if err != nil {
+		return false, err
+	}
+	return lockout, nil
+}
+
+// resetAuthAttempts forgets the failures of the identifier after a successful attempt. The IP
+// address keeps its failures, a valid account of the attacker must not reset them.
+func (a *APIService) resetAuthAttempts(scope string, identifier string) {
+	if err := a.dbService.ResetAuthAttempts(scope, identifier); err != nil {
+		logger.Logger.Error(err)
+	}
+}
+
+// notifyAccountLocked tells the owner of the account about the lockout, failures are only logged
+func (a *APIService) notifyAccountLocked(email string) {
+	if err := a.emailAdapter.SendAccountLockedMail(email, utils.AuthLockoutDuration); err != nil {
+		logger.Logger.Error(err)
+	}
+}
+
+// authAttemptDelay is the exponential backoff of the failures, factor raises the limits for IP addresses
+func authAttemptDelay(failures int64, factor int64) (time.Duration, bool) {
+	if failures >= utils.AuthLockoutAfter*factor {
+		return utils.AuthLockoutDuration, true
+	}
+	exceeded := failures - utils.AuthBackoffAfter*factor
+	if exceeded <= 0 {
+		return 0, false
+	}
+	delay := utils.AuthBackoffBase
+	for i := int64(1); i < exceeded && delay < utils.AuthBackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, utils.AuthBackoffMax), false
+}
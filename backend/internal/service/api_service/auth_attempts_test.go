@@ -0,0 +1,65 @@
+package api_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/reqctx"
+	"liquiswiss/pkg/utils"
+)
+
+func TestLogin_BacksOffAndLocksOutAfterFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	mockEmail := mocks.NewMockIEmailAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, mockEmail)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	ctx := reqctx.WithClientIP(context.Background(), "203.0.113.7")
+	payload := models.Login{Email: "Test@Example.com", Password: "wrong-password"}
+
+	mockDB.EXPECT().GetAuthBlock("login", "email:test@example.com", "ip:203.0.113.7").Return(time.Duration(0), nil).Times(2)
+	mockDB.EXPECT().GetUserPasswordByEMail(payload.Email).Return(&models.Login{ID: 42, Password: string(hash)}, nil).Times(2)
+
+	// Two failures past the free ones double the base delay, the IP address is far from its limit
+	mockDB.EXPECT().RecordAuthFailure("login", "email:test@example.com", utils.AuthAttemptWindow).Return(int64(utils.AuthBackoffAfter+2), false, nil)
+	mockDB.EXPECT().BlockAuthAttempts("login", "email:test@example.com", 2*utils.AuthBackoffBase, false).Return(nil)
+	mockDB.EXPECT().RecordAuthFailure("login", "ip:203.0.113.7", utils.AuthAttemptWindow).Return(int64(utils.AuthBackoffAfter+2), false, nil)
+	_, _, _, _, _, err = service.Login(ctx, payload, "test", "")
+	require.ErrorIs(t, err, api_service.ErrInvalidCredentials)
+
+	// Reaching the threshold locks the account and tells its owner
+	mockDB.EXPECT().RecordAuthFailure("login", "email:test@example.com", utils.AuthAttemptWindow).Return(int64(utils.AuthLockoutAfter), false, nil)
+	mockDB.EXPECT().BlockAuthAttempts("login", "email:test@example.com", utils.AuthLockoutDuration, true).Return(nil)
+	mockDB.EXPECT().RecordAuthFailure("login", "ip:203.0.113.7", utils.AuthAttemptWindow).Return(int64(utils.AuthLockoutAfter), false, nil)
+	mockEmail.EXPECT().SendAccountLockedMail(payload.Email, utils.AuthLockoutDuration).Return(nil)
+	_, _, _, _, _, err = service.Login(ctx, payload, "test", "")
+	require.ErrorIs(t, err, api_service.ErrInvalidCredentials)
+}
+
+func TestCheckRegistrationCode_RejectsWhileBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetAuthBlock("registration-code", "email:test@example.com", "").Return(30*time.Second, nil)
+
+	_, err := service.CheckRegistrationCode(context.Background(), models.CheckRegistrationCode{Email: "test@example.com", Code: "guess"}, utils.RegistrationCodeValidity)
+	var tooManyAttempts *api_service.TooManyAttemptsError
+	require.True(t, errors.As(err, &tooManyAttempts))
+	require.Equal(t, 30*time.Second, tooManyAttempts.RetryAfter)
+}
@@ -2,10 +2,12 @@ package api_service
 
 import (
 	"context"
+	"fmt"
 	"liquiswiss/internal/events"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
+	"time"
 )
 
 func (a *APIService) ListBankAccounts(ctx context.Context, userID int64, page int64, limit int64, sortBy string, sortOrder string, search string) ([]models.BankAccount, int64, error) {
@@ -42,6 +44,15 @@ func (a *APIService) CreateBankAccount(ctx context.Context, payload models.Creat
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	// Start the balance history with the initial amount
+	_, err = a.dbService.UpsertBankAccountBalance(models.CreateBankAccountBalance{
+		Date:   utils.GetTodayAsUTC().Format(utils.InternalDateFormat),
+		Amount: &payload.Amount,
+	}, userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
 	bankAccount, err := a.dbService.GetBankAccount(userID, bankAccountID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -52,12 +63,12 @@ func (a *APIService) CreateBankAccount(ctx context.Context, payload models.Creat
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "bank_account", events.ActionCreated, bankAccountID)
+	a.notifyChangeWithDiff(ctx, userID, "bank_account", events.ActionCreated, bankAccountID, 0, nil, bankAccount)
 	return bankAccount, nil
 }
 
 func (a *APIService) UpdateBankAccount(ctx context.Context, payload models.UpdateBankAccount, userID int64, bankAccountID int64) (*models.BankAccount, error) {
-	_, err := a.dbService.GetBankAccount(userID, bankAccountID)
+	existingBankAccount, err := a.dbService.GetBankAccount(userID, bankAccountID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -67,6 +78,17 @@ func (a *APIService) UpdateBankAccount(ctx context.Context, payload models.Updat
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	// A changed amount is the balance as of today
+	if payload.Amount != nil {
+		_, err = a.dbService.UpsertBankAccountBalance(models.CreateBankAccountBalance{
+			Date:   utils.GetTodayAsUTC().Format(utils.InternalDateFormat),
+			Amount: payload.Amount,
+		}, userID, bankAccountID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+	}
 	bankAccount, err := a.dbService.GetBankAccount(userID, bankAccountID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -77,12 +99,12 @@ func (a *APIService) UpdateBankAccount(ctx context.Context, payload models.Updat
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "bank_account", events.ActionUpdated, bankAccountID)
+	a.notifyChangeWithDiff(ctx, userID, "bank_account", events.ActionUpdated, bankAccountID, 0, existingBankAccount, bankAccount)
 	return bankAccount, nil
 }
 
 func (a *APIService) DeleteBankAccount(ctx context.Context, userID int64, bankAccountID int64) error {
-	_, err := a.dbService.GetBankAccount(userID, bankAccountID)
+	existingBankAccount, err := a.dbService.GetBankAccount(userID, bankAccountID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -92,6 +114,86 @@ func (a *APIService) DeleteBankAccount(ctx context.Context, userID int64, bankAc
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "bank_account", events.ActionDeleted, bankAccountID)
+	a.notifyChangeWithDiff(ctx, userID, "bank_account", events.ActionDeleted, bankAccountID, 0, existingBankAccount, nil)
+	return nil
+}
+
+func (a *APIService) ListBankAccountBalances(ctx context.Context, userID int64, bankAccountID int64) ([]models.BankAccountBalance, error) {
+	_, err := a.dbService.GetBankAccount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	balances, err := a.dbService.ListBankAccountBalances(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(balances, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (a *APIService) CreateBankAccountBalance(ctx context.Context, payload models.CreateBankAccountBalance, userID int64, bankAccountID int64) (*models.BankAccountBalance, error) {
+	balanceDate, err := time.Parse(utils.InternalDateFormat, payload.Date)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if balanceDate.After(utils.GetTodayAsUTC()) {
+		return nil, fmt.Errorf("invalid date: balances cannot be in the future")
+	}
+	_, err = a.dbService.GetBankAccount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	balanceID, err := a.dbService.UpsertBankAccountBalance(payload, userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	// The bank account always reflects its latest known balance
+	err = a.dbService.SyncBankAccountAmount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	balance, err := a.dbService.GetBankAccountBalance(userID, bankAccountID, balanceID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(balance); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithParent(ctx, userID, "bank_account_balance", events.ActionCreated, balanceID, bankAccountID)
+	a.notifyChange(ctx, userID, "bank_account", events.ActionUpdated, bankAccountID)
+	return balance, nil
+}
+
+func (a *APIService) DeleteBankAccountBalance(ctx context.Context, userID int64, bankAccountID int64, balanceID int64) error {
+	_, err := a.dbService.GetBankAccountBalance(userID, bankAccountID, balanceID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeleteBankAccountBalance(userID, bankAccountID, balanceID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.SyncBankAccountAmount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithParent(ctx, userID, "bank_account_balance", events.ActionDeleted, balanceID, bankAccountID)
+	a.notifyChange(ctx, userID, "bank_account", events.ActionUpdated, bankAccountID)
 	return nil
 }
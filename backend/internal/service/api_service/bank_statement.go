@@ -0,0 +1,369 @@
+package api_service
+
+import (
+	"context"
+	"fmt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/bankstatement"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// statementMatchDays is how many days a booking may be away from the planned date
+	statementMatchDays = 7
+	// statementAmountTolerance is the relative difference still considered the planned amount (e.g. fees, rounding)
+	statementAmountTolerance = 0.01
+)
+
+func (a *APIService) ListBankStatementImports(ctx context.Context, userID int64, bankAccountID int64) ([]models.BankStatementImport, error) {
+	_, err := a.dbService.GetBankAccount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	imports, err := a.dbService.ListBankStatementImports(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(imports, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return imports, nil
+}
+
+func (a *APIService) GetBankStatementImport(ctx context.Context, userID int64, bankAccountID int64, importID int64) (*models.BankStatementImport, error) {
+	statementImport, err := a.dbService.GetBankStatementImport(userID, bankAccountID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	actuals, err := a.dbService.ListActualsByImport(userID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	statementImport.Actuals = actuals
+	validator := utils.GetValidator()
+	if err := validator.Struct(statementImport); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return statementImport, nil
+}
+
+// ImportBankStatement parses the statement, matches its bookings to planned transaction occurrences
+// and stores them as actuals. The statement's closing balance becomes a balance snapshot of the account.
+func (a *APIService) ImportBankStatement(ctx context.Context, userID int64, bankAccountID int64, fileName string, format string, data []byte) (*models.BankStatementImport, error) {
+	bankAccount, err := a.dbService.GetBankAccount(userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	accountCurrency := *bankAccount.Currency.Code
+
+	statement, err := bankstatement.Parse(format, data)
+	if err != nil {
+		return nil, err
+	}
+	if statement.Currency != "" && statement.Currency != accountCurrency {
+		return nil, fmt.Errorf("invalid statement: currency %s does not match bank account currency %s", statement.Currency, accountCurrency)
+	}
+
+	transactions, _, err := a.ListTransactions(ctx, userID, 1, 100000, "name", "ASC", "", true, false)
+	if err != nil {
+		return nil, err
+	}
+	fiatRates, err := a.statementFiatRates(ctx, accountCurrency, statement.Entries)
+	if err != nil {
+		return nil, err
+	}
+	settled, err := a.dbService.ListMatchedTransactionOccurrences(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	actuals := matchStatementEntries(statement.Entries, transactions, fiatRates, settled)
+	for i := range actuals {
+		actuals[i].BankAccount = &bankAccountID
+		actuals[i].Currency = *bankAccount.Currency.ID
+	}
+
+	payload := models.CreateBankStatementImport{
+		FileName:       fileName,
+		Format:         statement.Format,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+	}
+	if statement.ClosingDate != nil {
+		closingDate := statement.ClosingDate.Format(utils.InternalDateFormat)
+		payload.ClosingDate = &closingDate
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		return nil, err
+	}
+	if err := validator.Var(actuals, "dive"); err != nil {
+		return nil, err
+	}
+
+	importID, skipped, err := a.dbService.CreateBankStatementImport(payload, actuals, userID, bankAccountID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	// Statements may cover the past only, a closing balance dated in the future is ignored
+	balanceUpdated := false
+	if statement.ClosingBalance != nil && statement.ClosingDate != nil && !statement.ClosingDate.After(utils.GetTodayAsUTC()) {
+		_, err = a.dbService.UpsertBankAccountBalance(models.CreateBankAccountBalance{
+			Date:   *payload.ClosingDate,
+			Amount: statement.ClosingBalance,
+		}, userID, bankAccountID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		err = a.dbService.SyncBankAccountAmount(userID, bankAccountID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		balanceUpdated = true
+	}
+
+	statementImport, err := a.GetBankStatementImport(ctx, userID, bankAccountID, importID)
+	if err != nil {
+		return nil, err
+	}
+	statementImport.Skipped = skipped
+
+	a.notifyChangeWithParent(ctx, userID, "bank_statement_import", events.ActionCreated, importID, bankAccountID)
+	if balanceUpdated {
+		a.notifyChange(ctx, userID, "bank_account", events.ActionUpdated, bankAccountID)
+	}
+	return statementImport, nil
+}
+
+// DeleteBankStatementImport removes the import along with its actuals, balance snapshots are kept
+func (a *APIService) DeleteBankStatementImport(ctx context.Context, userID int64, bankAccountID int64, importID int64) error {
+	_, err := a.dbService.GetBankStatementImport(userID, bankAccountID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeleteBankStatementImport(userID, bankAccountID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithParent(ctx, userID, "bank_statement_import", events.ActionDeleted, importID, bankAccountID)
+	return nil
+}
+
+// plannedOccurrence is a single planned execution of a transaction in the currency of the bank account
+type plannedOccurrence struct {
+	transaction models.Transaction
+	date        time.Time
+	amount      int64
+}
+
+// statementFiatRates loads the rates for the match window of the entries
+func (a *APIService) statementFiatRates(ctx context.Context, currencyCode string, entries []bankstatement.Entry) (*models.FiatRateTable, error) {
+	from := utils.GetTodayAsUTC()
+	for _, entry := range entries {
+		if entry.BookingDate.Before(from) {
+			from = entry.BookingDate
+		}
+	}
+	return a.historicalFiatRates(ctx, currencyCode, from.AddDate(0, 0, -statementMatchDays))
+}
+
+// matchStatementEntries links every booking to the closest planned occurrence within a few days.
+// Same amount counts as matched, same name but a different amount as deviation. An occurrence
+// settles at most one booking and settled ones from previous imports are not matched again.
+func matchStatementEntries(entries []bankstatement.Entry, transactions []models.Transaction, fiatRates *models.FiatRateTable, settled map[models.TransactionOccurrence]bool) []models.CreateActual {
+	actuals := make([]models.CreateActual, 0, len(entries))
+	if len(entries) == 0 {
+		return actuals
+	}
+
+	sortedEntries := make([]bankstatement.Entry, len(entries))
+	copy(sortedEntries, entries)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].BookingDate.Before(sortedEntries[j].BookingDate)
+	})
+	windowStart := sortedEntries[0].BookingDate.AddDate(0, 0, -statementMatchDays)
+	windowEnd := sortedEntries[len(sortedEntries)-1].BookingDate.AddDate(0, 0, statementMatchDays)
+
+	occurrences := plannedOccurrences(transactions, fiatRates, windowStart, windowEnd)
+	used := make(map[models.TransactionOccurrence]bool)
+	for key := range settled {
+		used[key] = true
+	}
+
+	for _, entry := range sortedEntries {
+		actual := models.CreateActual{
+			BookingDate: entry.BookingDate.Format(utils.InternalDateFormat),
+			Amount:      entry.Amount,
+			Name:        actualName(entry),
+			Status:      utils.ActualStatusUnmatched,
+		}
+		if entry.Reference != "" {
+			reference := truncate(entry.Reference, 255)
+			actual.Reference = &reference
+		}
+
+		var best *plannedOccurrence
+		bestRank := 0
+		bestDistance := 0.0
+		for i := range occurrences {
+			occurrence := &occurrences[i]
+			key := models.TransactionOccurrence{TransactionID: occurrence.transaction.ID, Date: occurrence.date.Format(utils.InternalDateFormat)}
+			if used[key] || (occurrence.amount < 0) != (entry.Amount < 0) {
+				continue
+			}
+			distance := math.Abs(entry.BookingDate.Sub(occurrence.date).Hours() / 24)
+			if distance > statementMatchDays {
+				continue
+			}
+
+			amountMatches := math.Abs(float64(entry.Amount-occurrence.amount)) <= math.Abs(float64(occurrence.amount))*statementAmountTolerance
+			nameMatches := namesMatch(entry.Name, occurrence.transaction.Name)
+			rank := 0
+			switch {
+			case amountMatches && nameMatches:
+				rank = 3
+			case amountMatches:
+				rank = 2
+			case nameMatches:
+				rank = 1
+			}
+			if rank == 0 {
+				continue
+			}
+			if rank > bestRank || (rank == bestRank && distance < bestDistance) {
+				best = occurrence
+				bestRank = rank
+				bestDistance = distance
+			}
+		}
+
+		if best != nil {
+			plannedDate := best.date.Format(utils.InternalDateFormat)
+			plannedAmount := best.amount
+			transactionID := best.transaction.ID
+			actual.Transaction = &transactionID
+			actual.PlannedDate = &plannedDate
+			actual.PlannedAmount = &plannedAmount
+			actual.Status = utils.ActualStatusMatched
+			if bestRank == 1 {
+				actual.Status = utils.ActualStatusDeviation
+			}
+			used[models.TransactionOccurrence{TransactionID: transactionID, Date: plannedDate}] = true
+		}
+
+		actuals = append(actuals, actual)
+	}
+
+	return actuals
+}
+
+// plannedOccurrences lists all executions of the enabled transactions between from and to,
+// each converted with the rate of its date
+func plannedOccurrences(transactions []models.Transaction, fiatRates *models.FiatRateTable, from time.Time, to time.Time) []plannedOccurrence {
+	occurrences := make([]plannedOccurrence, 0)
+	for _, transaction := range transactions {
+		if transaction.IsDisabled {
+			continue
+		}
+		amount := transaction.Amount
+		if transaction.Vat != nil && !transaction.VatIncluded {
+			amount += transaction.VatAmount
+		}
+		startDate := time.Time(transaction.StartDate)
+		if transaction.Type == "single" || transaction.Cycle == nil {
+			if !startDate.Before(from) && !startDate.After(to) {
+				convertedAmount := models.CalculateAmountWithFiatRate(amount, fiatRates.Rate(*transaction.Currency.Code, startDate))
+				occurrences = append(occurrences, plannedOccurrence{transaction: transaction, date: startDate, amount: convertedAmount})
+			}
+			continue
+		}
+
+		endDate := to
+		if transaction.EndDate != nil && time.Time(*transaction.EndDate).Before(endDate) {
+			endDate = time.Time(*transaction.EndDate)
+		}
+		for current := startDate; !current.After(endDate); current = addOffset(*transaction.Cycle, startDate, current, 1) {
+			if current.Before(from) {
+				continue
+			}
+			convertedAmount := models.CalculateAmountWithFiatRate(amount, fiatRates.Rate(*transaction.Currency.Code, current))
+			occurrences = append(occurrences, plannedOccurrence{transaction: transaction, date: current, amount: convertedAmount})
+		}
+	}
+	return occurrences
+}
+
+// namesMatch compares the booking text with the transaction name ignoring case and punctuation.
+// Either one containing the other or sharing a significant word is enough.
+func namesMatch(bookingText string, transactionName string) bool {
+	bookingWords := normalizedWords(bookingText)
+	transactionWords := normalizedWords(transactionName)
+	if len(bookingWords) == 0 || len(transactionWords) == 0 {
+		return false
+	}
+
+	booking := strings.Join(bookingWords, " ")
+	transaction := strings.Join(transactionWords, " ")
+	if strings.Contains(booking, transaction) || strings.Contains(transaction, booking) {
+		return true
+	}
+
+	for _, transactionWord := range transactionWords {
+		if len([]rune(transactionWord)) < 4 {
+			continue
+		}
+		for _, bookingWord := range bookingWords {
+			if bookingWord == transactionWord {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizedWords(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func actualName(entry bankstatement.Entry) string {
+	name := strings.TrimSpace(entry.Name)
+	if name == "" {
+		name = strings.TrimSpace(entry.Reference)
+	}
+	if name == "" {
+		name = "Buchung"
+	}
+	return truncate(name, 255)
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length])
+}
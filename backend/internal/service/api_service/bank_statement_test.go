@@ -0,0 +1,142 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func TestImportBankStatement_MatchesPlannedTransactions(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(55)
+	bankAccountID := int64(3)
+	importID := int64(9)
+	fixedToday := time.Date(2024, time.February, 5, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	currencyID := int64(1)
+	chf := models.Currency{ID: &currencyID, Code: &chfCode}
+	monthly := utils.CycleMonthly
+	transactions := []models.Transaction{
+		{
+			ID:          1,
+			Name:        "Client X",
+			Amount:      2500_50,
+			VatIncluded: true,
+			Type:        "single",
+			StartDate:   types.AsDate(time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)),
+			Currency:    chf,
+		},
+		{
+			ID:          2,
+			Name:        "Office Rent",
+			Amount:      -550_00,
+			VatIncluded: true,
+			Type:        "repeating",
+			Cycle:       &monthly,
+			StartDate:   types.AsDate(time.Date(2023, time.June, 20, 0, 0, 0, 0, time.UTC)),
+			Currency:    chf,
+		},
+		{
+			ID:          3,
+			Name:        "Insurance",
+			Amount:      -80_00,
+			VatIncluded: true,
+			Type:        "repeating",
+			Cycle:       &monthly,
+			StartDate:   types.AsDate(time.Date(2023, time.June, 25, 0, 0, 0, 0, time.UTC)),
+			Currency:    chf,
+		},
+	}
+
+	mockDB.EXPECT().
+		GetBankAccount(userID, bankAccountID).
+		Return(&models.BankAccount{ID: bankAccountID, Name: "Main", Amount: 10000_00, Currency: chf}, nil)
+	mockDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		Return(transactions, int64(len(transactions)), nil)
+	mockDB.EXPECT().ListFiatRates(chfCode).Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().ListFiatRateHistory(chfCode, gomock.Any()).Return([]models.FiatRateHistory{}, nil)
+	// The insurance of January was already reconciled by an earlier import
+	mockDB.EXPECT().
+		ListMatchedTransactionOccurrences(userID).
+		Return(map[models.TransactionOccurrence]bool{{TransactionID: 3, Date: "2024-01-25"}: true}, nil)
+
+	var capturedActuals []models.CreateActual
+	var capturedImport models.CreateBankStatementImport
+	mockDB.EXPECT().
+		CreateBankStatementImport(gomock.Any(), gomock.Any(), userID, bankAccountID).
+		DoAndReturn(func(payload models.CreateBankStatementImport, actuals []models.CreateActual, _ int64, _ int64) (int64, int64, error) {
+			capturedImport = payload
+			capturedActuals = actuals
+			return importID, 0, nil
+		})
+
+	closingBalance := int64(11870_50)
+	mockDB.EXPECT().
+		UpsertBankAccountBalance(models.CreateBankAccountBalance{Date: "2024-01-25", Amount: &closingBalance}, userID, bankAccountID).
+		Return(int64(1), nil)
+	mockDB.EXPECT().SyncBankAccountAmount(userID, bankAccountID).Return(nil)
+	mockDB.EXPECT().
+		GetBankStatementImport(userID, bankAccountID, importID).
+		Return(&models.BankStatementImport{ID: importID, FileName: "january.csv", Format: "csv", BankAccountID: bankAccountID}, nil)
+	mockDB.EXPECT().ListActualsByImport(userID, importID).Return([]models.Actual{}, nil)
+	// Import and updated balance are both recorded in the audit log
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: 500}, nil).Times(2)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil).Times(2)
+
+	data := "Datum;Buchungstext;Betrag;Referenz;Saldo\n" +
+		"15.01.2024;Client X AG;2500.50;REF-1;12500.50\n" +
+		"20.01.2024;Office Rent January;-600.00;REF-2;11900.50\n" +
+		"25.01.2024;Insurance;-80.00;;11820.50\n" +
+		"25.01.2024;Coffee Shop;50.00;;11870.50\n"
+
+	statementImport, err := service.ImportBankStatement(context.Background(), userID, bankAccountID, "january.csv", "", []byte(data))
+	require.NoError(t, err)
+	require.Equal(t, importID, statementImport.ID)
+
+	require.Equal(t, "csv", capturedImport.Format)
+	require.Equal(t, "2024-01-25", *capturedImport.ClosingDate)
+	require.Len(t, capturedActuals, 4)
+
+	// Same amount a day after the planned date
+	require.Equal(t, utils.ActualStatusMatched, capturedActuals[0].Status)
+	require.EqualValues(t, 1, *capturedActuals[0].Transaction)
+	require.Equal(t, "2024-01-14", *capturedActuals[0].PlannedDate)
+	require.Equal(t, "REF-1", *capturedActuals[0].Reference)
+	require.Equal(t, bankAccountID, *capturedActuals[0].BankAccount)
+	require.Equal(t, currencyID, capturedActuals[0].Currency)
+
+	// Same name but a higher rent is flagged
+	require.Equal(t, utils.ActualStatusDeviation, capturedActuals[1].Status)
+	require.EqualValues(t, 2, *capturedActuals[1].Transaction)
+	require.Equal(t, "2024-01-20", *capturedActuals[1].PlannedDate)
+	require.EqualValues(t, -550_00, *capturedActuals[1].PlannedAmount)
+
+	// Settled occurrences are not matched twice and unknown bookings stay unmatched
+	require.Equal(t, utils.ActualStatusUnmatched, capturedActuals[2].Status)
+	require.Nil(t, capturedActuals[2].Transaction)
+	require.Nil(t, capturedActuals[2].Reference)
+	require.Equal(t, utils.ActualStatusUnmatched, capturedActuals[3].Status)
+}
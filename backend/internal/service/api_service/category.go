@@ -68,13 +68,18 @@ func (a *APIService) CreateCategory(ctx context.Context, payload models.CreateCa
 		return nil, err
 	}
 	if userID != nil {
-		a.notifyChange(ctx, *userID, "category", events.ActionCreated, categoryID)
+		a.notifyChangeWithDiff(ctx, *userID, "category", events.ActionCreated, categoryID, 0, nil, category)
 	}
 	return category, nil
 }
 
 func (a *APIService) UpdateCategory(ctx context.Context, payload models.UpdateCategory, userID int64, categoryID int64) (*models.Category, error) {
-	err := a.dbService.UpdateCategory(payload, userID, categoryID)
+	existingCategory, err := a.dbService.GetCategory(userID, categoryID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.dbService.UpdateCategory(payload, userID, categoryID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -89,7 +94,7 @@ func (a *APIService) UpdateCategory(ctx context.Context, payload models.UpdateCa
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "category", events.ActionUpdated, categoryID)
+	a.notifyChangeWithDiff(ctx, userID, "category", events.ActionUpdated, categoryID, 0, existingCategory, category)
 	return category, nil
 }
 
@@ -115,10 +120,11 @@ func (a *APIService) ReassignCategoryTransactions(ctx context.Context, userID in
 		return 0, err
 	}
 	if affected > 0 {
-		if _, err := a.CalculateForecast(ctx, userID); err != nil {
+		if err := a.scheduleForecast(ctx, userID); err != nil {
 			logger.Logger.Error(err)
 		}
-		a.notifyChange(ctx, userID, "transaction", events.ActionUpdated, 0)
+		a.notifyChangeWithDiff(ctx, userID, "transaction", events.ActionUpdated, 0, 0,
+			map[string]int64{"category": fromCategoryID}, map[string]int64{"category": toCategoryID})
 	}
 	return affected, nil
 }
@@ -145,6 +151,6 @@ func (a *APIService) DeleteCategory(ctx context.Context, userID int64, categoryI
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "category", events.ActionDeleted, categoryID)
+	a.notifyChangeWithDiff(ctx, userID, "category", events.ActionDeleted, categoryID, 0, category, nil)
 	return nil
 }
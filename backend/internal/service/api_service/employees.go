@@ -8,7 +8,28 @@ import (
 	"liquiswiss/pkg/utils"
 )
 
+// ListEmployees hides the salary of each employee from members without payroll clearance
 func (a *APIService) ListEmployees(ctx context.Context, userID int64, page int64, limit int64, sortBy string, sortOrder string, search string, hideTerminated bool) ([]models.Employee, int64, error) {
+	payrollAccess, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !payrollAccess && salarySortKeys[sortBy] {
+		sortBy = "name"
+	}
+	employees, totalCount, err := a.listEmployees(ctx, userID, page, limit, sortBy, sortOrder, search, hideTerminated)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !payrollAccess {
+		for i := range employees {
+			redactEmployeeSalary(&employees[i])
+		}
+	}
+	return employees, totalCount, nil
+}
+
+func (a *APIService) listEmployees(ctx context.Context, userID int64, page int64, limit int64, sortBy string, sortOrder string, search string, hideTerminated bool) ([]models.Employee, int64, error) {
 	employees, totalCount, err := a.dbService.ListEmployees(userID, page, limit, sortBy, sortOrder, search, hideTerminated)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -33,6 +54,10 @@ func (a *APIService) GetEmployee(ctx context.Context, userID int64, employeeID i
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	err = a.redactEmployeeForUser(userID, employee)
+	if err != nil {
+		return nil, err
+	}
 	return employee, nil
 }
 
@@ -52,7 +77,7 @@ func (a *APIService) CreateEmployee(ctx context.Context, payload models.CreateEm
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "employee", events.ActionCreated, employeeID)
+	a.notifyChangeWithDiff(ctx, userID, "employee", events.ActionCreated, employeeID, 0, nil, employee)
 	return employee, nil
 }
 
@@ -81,10 +106,42 @@ func (a *APIService) UpdateEmployee(ctx context.Context, payload models.UpdateEm
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "employee", events.ActionUpdated, employeeID)
+	// Age dependent salary costs follow the birth date
+	if existingEmployee.BirthDate.ToFormattedTime(utils.InternalDateFormat) != employee.BirthDate.ToFormattedTime(utils.InternalDateFormat) {
+		if err := a.refreshEmployeeSalaryCosts(ctx, userID, employeeID); err != nil {
+			return nil, err
+		}
+	}
+	a.notifyChangeWithDiff(ctx, userID, "employee", events.ActionUpdated, employeeID, 0, existingEmployee, employee)
+	err = a.redactEmployeeForUser(userID, employee)
+	if err != nil {
+		return nil, err
+	}
 	return employee, nil
 }
 
+func (a *APIService) refreshEmployeeSalaryCosts(ctx context.Context, userID int64, employeeID int64) error {
+	salaries, _, err := a.dbService.ListSalaries(userID, employeeID, 1, 100000)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	for _, salary := range salaries {
+		err = a.dbService.RefreshSalaryCostDetails(userID, salary.ID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return err
+		}
+	}
+	// Recalculate Forecast
+	err = a.scheduleForecast(ctx, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
 func (a *APIService) DeleteEmployee(ctx context.Context, userID int64, employeeID int64) error {
 	existingEmployee, err := a.dbService.GetEmployee(userID, employeeID)
 	if err != nil {
@@ -96,7 +153,7 @@ func (a *APIService) DeleteEmployee(ctx context.Context, userID int64, employeeI
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "employee", events.ActionDeleted, existingEmployee.ID)
+	a.notifyChangeWithDiff(ctx, userID, "employee", events.ActionDeleted, existingEmployee.ID, 0, existingEmployee, nil)
 	return nil
 }
 
@@ -0,0 +1,381 @@
+package api_service
+
+import (
+	"context"
+	"fmt"
+	"liquiswiss/pkg/export"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+	"sort"
+	"time"
+)
+
+var exportCycleNames = map[string]string{
+	utils.CycleOnce:       "Einmalig",
+	utils.CycleMonthly:    "Monatlich",
+	utils.CycleQuarterly:  "Quartalsweise",
+	utils.CycleBiannually: "Halbjährlich",
+	utils.CycleYearly:     "Jährlich",
+}
+
+var exportDistributionNames = map[string]string{
+	models.SalaryCostDistributionEmployee: "Arbeitnehmer",
+	models.SalaryCostDistributionEmployer: "Arbeitgeber",
+	models.SalaryCostDistributionBoth:     "Beide",
+}
+
+// ExportForecast returns the stored forecast of the given number of months as report with the
+// totals and the nested details per month, all amounts in the organisation currency
+func (a *APIService) ExportForecast(ctx context.Context, userID int64, months int64) (*export.Report, error) {
+	report, _, err := a.newExportReport(ctx, userID, "Liquiditätsplanung")
+	if err != nil {
+		return nil, err
+	}
+	forecasts, err := a.ListForecasts(ctx, userID, months)
+	if err != nil {
+		return nil, err
+	}
+	forecastDetails, err := a.ListForecastDetails(ctx, userID, months)
+	if err != nil {
+		return nil, err
+	}
+
+	monthKeys := make([]string, 0, len(forecasts))
+	columns := []export.Column{{Header: "Position", Kind: export.KindText}}
+	for _, forecast := range forecasts {
+		monthKeys = append(monthKeys, forecast.Data.Month)
+		columns = append(columns, export.Column{Header: forecast.Data.Month, Kind: export.KindAmount})
+	}
+
+	revenue := newExportTreeNode("Einnahmen")
+	expense := newExportTreeNode("Ausgaben")
+	for _, detail := range forecastDetails {
+		revenue.add(detail.Month, detail.Revenue)
+		expense.add(detail.Month, detail.Expense)
+	}
+
+	totalsRow := func(name string, value func(data models.ForecastData) int64) export.Row {
+		cells := []any{name}
+		for _, forecast := range forecasts {
+			cells = append(cells, value(forecast.Data))
+		}
+		return export.Row{Cells: cells, Emphasis: true}
+	}
+
+	rows := []export.Row{totalsRow("Einnahmen", func(data models.ForecastData) int64 { return data.Revenue })}
+	rows = append(rows, revenue.rows(monthKeys, 1)...)
+	rows = append(rows, totalsRow("Ausgaben", func(data models.ForecastData) int64 { return data.Expense }))
+	rows = append(rows, expense.rows(monthKeys, 1)...)
+	rows = append(rows,
+		totalsRow("Cashflow", func(data models.ForecastData) int64 { return data.Cashflow }),
+		totalsRow("Anfangsbestand", func(data models.ForecastData) int64 { return data.OpeningBalance }),
+		totalsRow("Endbestand", func(data models.ForecastData) int64 { return data.ClosingBalance }),
+	)
+
+	report.Tables = []export.Table{{Title: "Prognose", Columns: columns, Rows: rows}}
+	return report, nil
+}
+
+// ExportTransactions returns all transactions including disabled and expired ones along with
+// their next execution date and the amounts converted into the organisation currency
+func (a *APIService) ExportTransactions(ctx context.Context, userID int64) (*export.Report, error) {
+	report, fiatRates, err := a.newExportReport(ctx, userID, "Transaktionen")
+	if err != nil {
+		return nil, err
+	}
+	transactions, _, err := a.ListTransactions(ctx, userID, 1, 100000, "name", "ASC", "", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []export.Column{
+		{Header: "Name", Kind: export.KindText},
+		{Header: "Kategorie", Kind: export.KindText},
+		{Header: "Art", Kind: export.KindText},
+		{Header: "Zyklus", Kind: export.KindText},
+		{Header: "Betrag", Kind: export.KindAmount},
+		{Header: "Währung", Kind: export.KindText},
+		{Header: "Betrag " + report.Currency, Kind: export.KindAmount},
+		{Header: "MWST", Kind: export.KindText},
+		{Header: "MWST-Betrag " + report.Currency, Kind: export.KindAmount},
+		{Header: "Start", Kind: export.KindDate},
+		{Header: "Ende", Kind: export.KindDate},
+		{Header: "Nächste Ausführung", Kind: export.KindDate},
+		{Header: "Mitarbeiter", Kind: export.KindText},
+		{Header: "Deaktiviert", Kind: export.KindText},
+	}
+
+	rows := make([]export.Row, 0, len(transactions))
+	for _, transaction := range transactions {
+		fiatRate := fiatRates.Rate(*transaction.Currency.Code, report.CreatedAt)
+		transactionType := "Einmalig"
+		var cycle any
+		if transaction.Type == "repeating" {
+			transactionType = "Wiederkehrend"
+			if transaction.Cycle != nil {
+				cycle = exportCycleName(*transaction.Cycle)
+			}
+		}
+		var vat, vatAmount any
+		if transaction.Vat != nil {
+			inclusion := "exkl."
+			if transaction.VatIncluded {
+				inclusion = "inkl."
+			}
+			vat = fmt.Sprintf("%s %s", transaction.Vat.FormattedValue, inclusion)
+			vatAmount = models.CalculateAmountWithFiatRate(transaction.VatAmount, fiatRate)
+		}
+		var employee any
+		if transaction.Employee != nil {
+			employee = transaction.Employee.Name
+		}
+
+		rows = append(rows, export.Row{Cells: []any{
+			transaction.Name,
+			transaction.Category.Name,
+			transactionType,
+			cycle,
+			transaction.Amount,
+			*transaction.Currency.Code,
+			models.CalculateAmountWithFiatRate(transaction.Amount, fiatRate),
+			vat,
+			vatAmount,
+			time.Time(transaction.StartDate),
+			exportDate(transaction.EndDate),
+			exportDate(transaction.NextExecutionDate),
+			employee,
+			exportYesNo(transaction.IsDisabled),
+		}})
+	}
+
+	report.Tables = []export.Table{{Title: "Transaktionen", Columns: columns, Rows: rows}}
+	return report, nil
+}
+
+// ExportEmployees returns the employees with all their salaries and the salary costs
+func (a *APIService) ExportEmployees(ctx context.Context, userID int64) (*export.Report, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	report, fiatRates, err := a.newExportReport(ctx, userID, "Mitarbeitende")
+	if err != nil {
+		return nil, err
+	}
+	employees, _, err := a.listEmployees(ctx, userID, 1, 100000, "name", "ASC", "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	salaryColumns := []export.Column{
+		{Header: "Mitarbeiter", Kind: export.KindText},
+		{Header: "Von", Kind: export.KindDate},
+		{Header: "Bis", Kind: export.KindDate},
+		{Header: "Zyklus", Kind: export.KindText},
+		{Header: "Stunden pro Monat", Kind: export.KindNumber},
+		{Header: "Ferientage", Kind: export.KindNumber},
+		{Header: "Lohn", Kind: export.KindAmount},
+		{Header: "Währung", Kind: export.KindText},
+		{Header: "Lohn " + report.Currency, Kind: export.KindAmount},
+		{Header: "Abzüge AN " + report.Currency, Kind: export.KindAmount},
+		{Header: "Kosten AG " + report.Currency, Kind: export.KindAmount},
+		{Header: "Nächste Ausführung", Kind: export.KindDate},
+		{Header: "Austritt", Kind: export.KindText},
+		{Header: "Deaktiviert", Kind: export.KindText},
+	}
+	costColumns := []export.Column{
+		{Header: "Mitarbeiter", Kind: export.KindText},
+		{Header: "Lohn ab", Kind: export.KindDate},
+		{Header: "Bezeichnung", Kind: export.KindText},
+		{Header: "Zyklus", Kind: export.KindText},
+		{Header: "Betrag", Kind: export.KindAmount},
+		{Header: "Prozent", Kind: export.KindText},
+		{Header: "Verteilung", Kind: export.KindText},
+		{Header: "Betrag " + report.Currency, Kind: export.KindAmount},
+		{Header: "Nächste Ausführung", Kind: export.KindDate},
+		{Header: "Nächste Kosten " + report.Currency, Kind: export.KindAmount},
+	}
+
+	salaryRows := make([]export.Row, 0)
+	costRows := make([]export.Row, 0)
+	for _, employee := range employees {
+		salaries, _, err := a.listSalaries(ctx, userID, employee.ID, 1, 100000)
+		if err != nil {
+			return nil, err
+		}
+		if len(salaries) == 0 {
+			salaryRows = append(salaryRows, export.Row{Cells: []any{employee.Name}})
+			continue
+		}
+
+		for _, salary := range salaries {
+			fiatRate := fiatRates.Rate(*salary.Currency.Code, report.CreatedAt)
+			var amount, convertedAmount, deductions, employerCosts any
+			if !salary.IsTermination {
+				amount = salary.Amount
+				convertedAmount = models.CalculateAmountWithFiatRate(int64(salary.Amount), fiatRate)
+				deductions = models.CalculateAmountWithFiatRate(int64(salary.EmployeeDeductions), fiatRate)
+				employerCosts = models.CalculateAmountWithFiatRate(int64(salary.EmployerCosts), fiatRate)
+			}
+			salaryRows = append(salaryRows, export.Row{Cells: []any{
+				employee.Name,
+				time.Time(salary.FromDate),
+				exportDate(salary.ToDate),
+				exportCycleName(salary.Cycle),
+				salary.HoursPerMonth,
+				salary.VacationDaysPerYear,
+				amount,
+				*salary.Currency.Code,
+				convertedAmount,
+				deductions,
+				employerCosts,
+				exportDate(salary.NextExecutionDate),
+				exportYesNo(salary.IsTermination),
+				exportYesNo(salary.IsDisabled),
+			}})
+
+			if salary.IsTermination {
+				continue
+			}
+			salaryCosts, _, err := a.listSalaryCosts(ctx, userID, salary.ID, 1, 1000, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, salaryCost := range salaryCosts {
+				var label, fixedAmount, percentage any
+				if salaryCost.Label != nil {
+					label = salaryCost.Label.Name
+				}
+				if salaryCost.AmountType == "percentage" {
+					percentage = report.Locale.FormatPercent(int64(salaryCost.Amount))
+				} else {
+					fixedAmount = salaryCost.Amount
+				}
+				distributionMultiplier := int64(models.SalaryCostDistributionMultiplier(salaryCost.DistributionType))
+				var nextCost any
+				if salaryCost.CalculatedNextExecutionDate != nil {
+					nextCost = models.CalculateAmountWithFiatRate(int64(salaryCost.CalculatedNextCost)*distributionMultiplier, fiatRate)
+				}
+				costRows = append(costRows, export.Row{Cells: []any{
+					employee.Name,
+					time.Time(salary.FromDate),
+					label,
+					exportCycleName(salaryCost.Cycle),
+					fixedAmount,
+					percentage,
+					exportDistributionNames[salaryCost.DistributionType],
+					models.CalculateAmountWithFiatRate(int64(salaryCost.CalculatedAmount), fiatRate),
+					exportDate(salaryCost.CalculatedNextExecutionDate),
+					nextCost,
+				}})
+			}
+		}
+	}
+
+	report.Tables = []export.Table{
+		{Title: "Löhne", Columns: salaryColumns, Rows: salaryRows},
+		{Title: "Lohnkosten", Columns: costColumns, Rows: costRows},
+	}
+	return report, nil
+}
+
+// newExportReport prepares a report in the currency and locale of the user's organisation
+func (a *APIService) newExportReport(ctx context.Context, userID int64, title string) (*export.Report, *models.FiatRateTable, error) {
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseCurrency := *organisation.Currency.Code
+	fiatRates, err := a.planningFiatRates(ctx, userID, baseCurrency)
+	if err != nil {
+		return nil, nil, err
+	}
+	localeCode := export.DefaultLocaleCode
+	if organisation.Currency.LocaleCode != nil {
+		localeCode = *organisation.Currency.LocaleCode
+	}
+	return &export.Report{
+		Title:        title,
+		Organisation: organisation.Name,
+		Currency:     baseCurrency,
+		Locale:       export.LocaleFor(localeCode),
+		CreatedAt:    utils.GetTodayAsUTC(),
+	}, fiatRates, nil
+}
+
+// exportTreeNode merges the forecast detail trees of all months by name
+type exportTreeNode struct {
+	name     string
+	amounts  map[string]int64
+	children map[string]*exportTreeNode
+}
+
+func newExportTreeNode(name string) *exportTreeNode {
+	return &exportTreeNode{
+		name:     name,
+		amounts:  make(map[string]int64),
+		children: make(map[string]*exportTreeNode),
+	}
+}
+
+func (n *exportTreeNode) add(month string, items []models.ForecastDetailRevenueExpense) {
+	for _, item := range items {
+		child := n.children[item.Name]
+		if child == nil {
+			child = newExportTreeNode(item.Name)
+			n.children[item.Name] = child
+		}
+		child.amounts[month] += item.Amount
+		child.add(month, item.Children)
+	}
+}
+
+func (n *exportTreeNode) total(month string) int64 {
+	total := n.amounts[month]
+	for _, child := range n.children {
+		total += child.total(month)
+	}
+	return total
+}
+
+// rows returns one row per node below n, each followed by its children
+func (n *exportTreeNode) rows(monthKeys []string, level int) []export.Row {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]export.Row, 0)
+	for _, name := range names {
+		child := n.children[name]
+		cells := []any{name}
+		for _, monthKey := range monthKeys {
+			cells = append(cells, child.total(monthKey))
+		}
+		rows = append(rows, export.Row{Cells: cells, Level: level})
+		rows = append(rows, child.rows(monthKeys, level+1)...)
+	}
+	return rows
+}
+
+func exportCycleName(cycle string) string {
+	if name, ok := exportCycleNames[cycle]; ok {
+		return name
+	}
+	return cycle
+}
+
+func exportDate(date *types.AsDate) any {
+	if date == nil {
+		return nil
+	}
+	return time.Time(*date)
+}
+
+func exportYesNo(value bool) string {
+	if value {
+		return "Ja"
+	}
+	return "Nein"
+}
@@ -0,0 +1,96 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/export"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestExportForecast_MergesDetailTreesOfAllMonths(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	fixedToday := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	localeCode := "de-CH"
+	orgCurrency := models.Currency{Code: &chfCode, LocaleCode: &localeCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 400,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil).Times(2)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil).Times(2)
+	mockDB.EXPECT().ListFiatRates(chfCode).Return([]models.FiatRate{}, nil).Times(2)
+	mockDB.EXPECT().ListOrganisationFiatRates(userID).Return([]models.OrganisationFiatRate{}, nil).Times(2)
+	mockDB.EXPECT().ListBankAccountsAtDate(userID, "2025-01-10").Return([]models.BankAccount{}, nil)
+	mockDB.EXPECT().ListForecasts(userID, int64(2)).Return([]models.Forecast{
+		{Data: models.ForecastData{Month: "2025-01", Revenue: 1000_00, Expense: -300_00, Cashflow: 700_00}},
+		{Data: models.ForecastData{Month: "2025-02", Revenue: 0, Expense: -500_00, Cashflow: -500_00}},
+	}, nil)
+	mockDB.EXPECT().ListForecastDetails(userID, int64(2)).Return([]models.ForecastDatabaseDetails{
+		{
+			Month:   "2025-01",
+			Revenue: []models.ForecastDetailRevenueExpense{{Name: "Verkauf", Children: []models.ForecastDetailRevenueExpense{{Name: "Abo", Amount: 1000_00}}}},
+			Expense: []models.ForecastDetailRevenueExpense{{Name: "Miete", Children: []models.ForecastDetailRevenueExpense{{Name: "Büro", Amount: -300_00}}}},
+		},
+		{
+			Month: "2025-02",
+			Expense: []models.ForecastDetailRevenueExpense{
+				{Name: "Löhne", Children: []models.ForecastDetailRevenueExpense{{Name: "Anna", Amount: -500_00}}},
+			},
+		},
+	}, nil)
+
+	report, err := service.ExportForecast(context.Background(), userID, 2)
+	require.NoError(t, err)
+
+	require.Equal(t, "CHF", report.Currency)
+	require.Equal(t, "de-CH", report.Locale.Code)
+	require.Len(t, report.Tables, 1)
+	table := report.Tables[0]
+	require.Equal(t, []export.Column{
+		{Header: "Position", Kind: export.KindText},
+		{Header: "2025-01", Kind: export.KindAmount},
+		{Header: "2025-02", Kind: export.KindAmount},
+	}, table.Columns)
+
+	require.Equal(t, []export.Row{
+		{Cells: []any{"Einnahmen", int64(1000_00), int64(0)}, Emphasis: true},
+		{Cells: []any{"Verkauf", int64(1000_00), int64(0)}, Level: 1},
+		{Cells: []any{"Abo", int64(1000_00), int64(0)}, Level: 2},
+		{Cells: []any{"Ausgaben", int64(-300_00), int64(-500_00)}, Emphasis: true},
+		{Cells: []any{"Löhne", int64(0), int64(-500_00)}, Level: 1},
+		{Cells: []any{"Anna", int64(0), int64(-500_00)}, Level: 2},
+		{Cells: []any{"Miete", int64(-300_00), int64(0)}, Level: 1},
+		{Cells: []any{"Büro", int64(-300_00), int64(0)}, Level: 2},
+		{Cells: []any{"Cashflow", int64(700_00), int64(-500_00)}, Emphasis: true},
+		{Cells: []any{"Anfangsbestand", int64(0), int64(700_00)}, Emphasis: true},
+		{Cells: []any{"Endbestand", int64(700_00), int64(200_00)}, Emphasis: true},
+	}, table.Rows)
+}
@@ -2,11 +2,23 @@ package api_service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"liquiswiss/config"
+	"liquiswiss/internal/events"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/utils"
+	"time"
 )
 
+// ErrInvalidFiatRateTarget is returned for planning rates of unknown currencies or the organisation currency
+var ErrInvalidFiatRateTarget = errors.New("invalid fiat rate target")
+
+// fiatRateHealthFetches is how many of the latest fetches the health lists
+const fiatRateHealthFetches = 20
+
 func (a *APIService) ListFiatRates(ctx context.Context, base string) ([]models.FiatRate, error) {
 	fiatRates, err := a.dbService.ListFiatRates(base)
 	if err != nil {
@@ -35,15 +47,88 @@ func (a *APIService) GetFiatRate(ctx context.Context, base, target string) (*mod
 	return fiatRate, nil
 }
 
+// UpsertFiatRate replaces the current rate and records it in the history of its date
 func (a *APIService) UpsertFiatRate(ctx context.Context, payload models.CreateFiatRate) error {
 	err := a.dbService.UpsertFiatRate(payload)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
 	}
+	date := utils.GetTodayAsUTC()
+	if payload.Date != nil {
+		date = *payload.Date
+	}
+	err = a.dbService.UpsertFiatRateHistory(payload, date)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
 	return nil
 }
 
+// RecordFiatRateFetch logs a fetch of the rate provider
+func (a *APIService) RecordFiatRateFetch(ctx context.Context, payload models.CreateFiatRateFetch) error {
+	_, err := a.dbService.CreateFiatRateFetch(payload)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetFiatRateHealth tells whether the rates are current and the provider is reachable
+func (a *APIService) GetFiatRateHealth(ctx context.Context) (*models.FiatRateHealth, error) {
+	maxAge := config.GetConfig().FXMaxRateAge
+	fetches, err := a.dbService.ListFiatRateFetches(fiatRateHealthFetches)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	health := &models.FiatRateHealth{
+		Status:         models.FiatRateHealthUnknown,
+		MaxRateAgeDays: int64(maxAge / (24 * time.Hour)),
+		RecentFetches:  fetches,
+	}
+	if len(fetches) == 0 {
+		return health, nil
+	}
+
+	lastSuccess, err := a.dbService.GetLastSucceededFiatRateFetch()
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if err == nil {
+		health.LastSuccess = lastSuccess
+	}
+	for _, fetch := range fetches {
+		if fetch.Status != models.FiatRateFetchStatusFailed {
+			break
+		}
+		health.FailedSince++
+	}
+
+	switch {
+	case lastSuccess == nil || lastSuccess.RateDate == nil,
+		utils.GetTodayAsUTC().Sub(time.Time(*lastSuccess.RateDate)) > maxAge:
+		health.Status = models.FiatRateHealthStale
+	case health.FailedSince > 0:
+		health.Status = models.FiatRateHealthFailing
+	default:
+		health.Status = models.FiatRateHealthOK
+	}
+	return health, nil
+}
+
+// ListForecastWarnings returns the items the forecast converts with a missing, stale or fallback rate
+func (a *APIService) ListForecastWarnings(ctx context.Context, userID int64) ([]models.ForecastWarning, error) {
+	result, err := a.buildForecast(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.warnings, nil
+}
+
 func (a *APIService) CountUniqueCurrenciesInFiatRates(ctx context.Context) (int64, error) {
 	totalCount, err := a.dbService.CountUniqueCurrenciesInFiatRates()
 	if err != nil {
@@ -52,3 +137,160 @@ func (a *APIService) CountUniqueCurrenciesInFiatRates(ctx context.Context) (int6
 	}
 	return totalCount, nil
 }
+
+// GetFiatRateAt returns the market rate valid on the date, sql.ErrNoRows before the history started
+func (a *APIService) GetFiatRateAt(ctx context.Context, base, target string, date time.Time) (*models.FiatRateHistory, error) {
+	fiatRate, err := a.dbService.GetFiatRateAt(base, target, date)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return fiatRate, nil
+}
+
+func (a *APIService) ListOrganisationFiatRates(ctx context.Context, userID int64) ([]models.OrganisationFiatRate, error) {
+	fiatRates, err := a.dbService.ListOrganisationFiatRates(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return fiatRates, nil
+}
+
+// UpsertOrganisationFiatRate sets the planning rate from the organisation currency to the target
+func (a *APIService) UpsertOrganisationFiatRate(ctx context.Context, payload models.UpsertOrganisationFiatRate, userID int64, target string) (*models.OrganisationFiatRate, error) {
+	base, err := a.organisationFiatRateBase(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+	before, err := a.dbService.GetOrganisationFiatRate(userID, base, target)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.dbService.UpsertOrganisationFiatRate(userID, base, target, payload.Rate)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	fiatRate, err := a.dbService.GetOrganisationFiatRate(userID, base, target)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	action := events.ActionUpdated
+	if before == nil {
+		action = events.ActionCreated
+	}
+	a.notifyChangeWithDiff(ctx, userID, "organisation_fiat_rate", action, fiatRate.ID, 0, before, fiatRate)
+	if err := a.scheduleForecast(ctx, userID); err != nil {
+		return nil, err
+	}
+	return fiatRate, nil
+}
+
+// DeleteOrganisationFiatRate returns to the market rate for the target
+func (a *APIService) DeleteOrganisationFiatRate(ctx context.Context, userID int64, target string) error {
+	base, err := a.organisationFiatRateBase(ctx, userID, target)
+	if err != nil {
+		return err
+	}
+	before, err := a.dbService.GetOrganisationFiatRate(userID, base, target)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeleteOrganisationFiatRate(userID, base, target)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "organisation_fiat_rate", events.ActionDeleted, before.ID, 0, before, nil)
+	return a.scheduleForecast(ctx, userID)
+}
+
+// organisationFiatRateBase returns the organisation currency, planning rates always convert into it
+func (a *APIService) organisationFiatRateBase(ctx context.Context, userID int64, target string) (string, error) {
+	if err := utils.GetValidator().Var(target, "iso4217"); err != nil {
+		return "", fmt.Errorf("%w: %s is no currency code", ErrInvalidFiatRateTarget, target)
+	}
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	base := *organisation.Currency.Code
+	if target == base {
+		return "", fmt.Errorf("%w: %s is the organisation currency", ErrInvalidFiatRateTarget, target)
+	}
+	return base, nil
+}
+
+// planningFiatRates converts planned amounts: the planning rates of the organisation, otherwise the
+// current rate. Planned amounts are never in the past, so the history isn't needed.
+func (a *APIService) planningFiatRates(ctx context.Context, userID int64, base string) (*models.FiatRateTable, error) {
+	fiatRates, err := a.ListFiatRates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := a.dbService.ListOrganisationFiatRates(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return models.NewFiatRateTable(base, fiatRates, nil, overrides), nil
+}
+
+// historicalFiatRates converts booked amounts with the market rate of their date from the date on
+func (a *APIService) historicalFiatRates(ctx context.Context, base string, from time.Time) (*models.FiatRateTable, error) {
+	fiatRates, err := a.ListFiatRates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	history, err := a.dbService.ListFiatRateHistory(base, from)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return models.NewFiatRateTable(base, fiatRates, history, nil), nil
+}
+
+// forecastWarnings collects the rate warnings of the items a forecast converts, once per item
+type forecastWarnings struct {
+	fiatRates *models.FiatRateTable
+	today     time.Time
+	maxAge    time.Duration
+	seen      map[string]bool
+	list      []models.ForecastWarning
+}
+
+func newForecastWarnings(fiatRates *models.FiatRateTable, today time.Time) *forecastWarnings {
+	return &forecastWarnings{
+		fiatRates: fiatRates,
+		today:     today,
+		maxAge:    config.GetConfig().FXMaxRateAge,
+		seen:      make(map[string]bool),
+		list:      []models.ForecastWarning{},
+	}
+}
+
+// check records a warning if the currency of the item can't be converted reliably, nil skips it
+func (w *forecastWarnings) check(relatedTable string, relatedID int64, name string, currency string) {
+	if w == nil {
+		return
+	}
+	key := fmt.Sprintf("%s-%d", relatedTable, relatedID)
+	if w.seen[key] {
+		return
+	}
+	w.seen[key] = true
+	warning := w.fiatRates.Warning(currency, w.today, w.maxAge)
+	if warning == nil {
+		return
+	}
+	w.list = append(w.list, models.ForecastWarning{
+		RelatedID:       relatedID,
+		RelatedTable:    relatedTable,
+		Name:            name,
+		FiatRateWarning: *warning,
+	})
+}
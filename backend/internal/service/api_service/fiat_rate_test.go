@@ -0,0 +1,197 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func TestUpsertFiatRate_RecordsHistoryOfItsDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	date := time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC)
+	payload := models.CreateFiatRate{Base: "CHF", Target: "EUR", Rate: 1.05, Date: &date}
+	mockDB.EXPECT().UpsertFiatRate(payload).Return(nil)
+	mockDB.EXPECT().UpsertFiatRateHistory(payload, date).Return(nil)
+
+	require.NoError(t, service.UpsertFiatRate(context.Background(), payload))
+}
+
+func TestUpsertOrganisationFiatRate_RejectsInvalidTargets(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	orgCurrency := models.Currency{Code: &chfCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 400,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil)
+
+	payload := models.UpsertOrganisationFiatRate{Rate: 0.95}
+
+	// Unknown codes are rejected before the organisation is loaded
+	_, err := service.UpsertOrganisationFiatRate(context.Background(), payload, userID, "QQQ")
+	require.ErrorIs(t, err, api_service.ErrInvalidFiatRateTarget)
+
+	// The organisation currency always converts with 1.0
+	_, err = service.UpsertOrganisationFiatRate(context.Background(), payload, userID, chfCode)
+	require.ErrorIs(t, err, api_service.ErrInvalidFiatRateTarget)
+}
+
+func TestListForecastWarnings_FlagsMissingStaleAndFallbackRates(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(43)
+	fixedToday := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	currency := func(code string) models.Currency {
+		localeCode := "de-CH"
+		return models.Currency{Code: &code, LocaleCode: &localeCode}
+	}
+	orgCurrency := currency("CHF")
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 401,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil)
+
+	future := types.AsDate(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	past := types.AsDate(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	transaction := func(id int64, code string, startDate types.AsDate) models.Transaction {
+		return models.Transaction{
+			ID:          id,
+			Name:        code + " Transaction",
+			Amount:      100_00,
+			VatIncluded: true,
+			Type:        "single",
+			StartDate:   startDate,
+			Category:    models.Category{Name: "Sales"},
+			Currency:    currency(code),
+		}
+	}
+	transactions := []models.Transaction{
+		transaction(1, "USD", future),
+		transaction(2, "EUR", future),
+		transaction(3, "GBP", future),
+		// Expired, its missing rate doesn't matter anymore
+		transaction(4, "JPY", past),
+	}
+	mockDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		Return(transactions, int64(len(transactions)), nil)
+
+	fresh := types.AsDate(time.Date(2024, time.May, 9, 0, 0, 0, 0, time.UTC))
+	old := types.AsDate(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))
+	mockDB.EXPECT().ListFiatRates("CHF").Return([]models.FiatRate{
+		{Base: "CHF", Target: "USD", Rate: 1.1, Source: "ecb", RateDate: old},
+		{Base: "CHF", Target: "EUR", Rate: 1.05, Source: "ecb", RateDate: fresh},
+		{Base: "CHF", Target: "SEK", Rate: 11.8, Source: models.FiatRateSourceFallback, RateDate: fresh},
+		{Base: "CHF", Target: "NOK", Rate: 11.7, Source: "ecb", RateDate: old},
+	}, nil)
+	mockDB.EXPECT().ListOrganisationFiatRates(userID).Return([]models.OrganisationFiatRate{
+		{Base: "CHF", Target: "NOK", Rate: 12},
+	}, nil)
+	mockDB.EXPECT().ListBankAccountsAtDate(userID, "2024-05-10").Return([]models.BankAccount{
+		{ID: 7, Name: "SEK Account", Amount: 1000_00, Currency: currency("SEK")},
+		// The planning rate replaces the stale one
+		{ID: 8, Name: "NOK Account", Amount: 1000_00, Currency: currency("NOK")},
+	}, nil)
+	mockDB.EXPECT().ListAllForecastExclusions(userID).Return([]models.ForecastExclusionInfo{}, nil)
+	mockDB.EXPECT().ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).Return([]models.Employee{}, int64(0), nil)
+	mockDB.EXPECT().GetVatSetting(userID).Return(nil, nil)
+
+	warnings, err := service.ListForecastWarnings(context.Background(), userID)
+	require.NoError(t, err)
+
+	kinds := make(map[string]string)
+	for _, warning := range warnings {
+		kinds[warning.RelatedTable+"/"+warning.Name] = warning.Kind
+	}
+	require.Equal(t, map[string]string{
+		"bank_accounts/SEK Account":    models.FiatRateWarningFallback,
+		"transactions/USD Transaction": models.FiatRateWarningStale,
+		"transactions/GBP Transaction": models.FiatRateWarningMissing,
+	}, kinds)
+}
+
+func TestGetFiatRateHealth(t *testing.T) {
+	fixedToday := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	fetchError := "unexpected status 503"
+	rateDate := types.AsDate(time.Date(2024, time.May, 9, 0, 0, 0, 0, time.UTC))
+	succeeded := models.FiatRateFetch{ID: 1, Source: "ecb", RateDate: &rateDate, RateCount: 9, Status: models.FiatRateFetchStatusSucceeded}
+	failed := models.FiatRateFetch{ID: 2, Source: "ecb", Status: models.FiatRateFetchStatusFailed, Error: &fetchError}
+
+	// Current rates, but the provider fails since
+	mockDB.EXPECT().ListFiatRateFetches(int64(20)).Return([]models.FiatRateFetch{failed, succeeded}, nil)
+	mockDB.EXPECT().GetLastSucceededFiatRateFetch().Return(&succeeded, nil)
+
+	health, err := service.GetFiatRateHealth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.FiatRateHealthFailing, health.Status)
+	require.EqualValues(t, 1, health.FailedSince)
+	require.EqualValues(t, 5, health.MaxRateAgeDays)
+
+	// A week later the rates are stale
+	utils.DefaultClock = &stubClock{fixed: fixedToday.AddDate(0, 0, 7)}
+	mockDB.EXPECT().ListFiatRateFetches(int64(20)).Return([]models.FiatRateFetch{succeeded}, nil)
+	mockDB.EXPECT().GetLastSucceededFiatRateFetch().Return(&succeeded, nil)
+
+	health, err = service.GetFiatRateHealth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.FiatRateHealthStale, health.Status)
+	require.Zero(t, health.FailedSince)
+}
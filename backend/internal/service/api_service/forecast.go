@@ -2,6 +2,7 @@ package api_service
 
 import (
 	"context"
+	"fmt"
 	"liquiswiss/internal/events"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
@@ -37,11 +38,26 @@ func addMonthsStable(date time.Time, months int) time.Time {
 }
 
 func (a *APIService) ListForecasts(ctx context.Context, userID int64, limit int64) ([]models.Forecast, error) {
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing is calculated beyond the horizon of the organisation
+	limit = min(limit, int64(newForecastHorizon(organisation, utils.GetTodayAsUTC()).totalMonths))
 	forecasts, err := a.dbService.ListForecasts(userID, limit)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	fiatRates, err := a.planningFiatRates(ctx, userID, *organisation.Currency.Code)
+	if err != nil {
+		return nil, err
+	}
+	openingBalance, err := a.calculateOpeningBalance(userID, fiatRates, utils.GetTodayAsUTC(), nil)
+	if err != nil {
+		return nil, err
+	}
+	applyRunningBalance(forecasts, openingBalance)
 	validator := utils.GetValidator()
 	if err := validator.Var(forecasts, "dive"); err != nil {
 		logger.Logger.Error(err)
@@ -61,6 +77,10 @@ func (a *APIService) ListForecastDetails(ctx context.Context, userID int64, limi
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	err = a.redactPayrollDetailsForUser(userID, forecastDetails)
+	if err != nil {
+		return nil, err
+	}
 	return forecastDetails, nil
 }
 
@@ -131,7 +151,102 @@ func (a *APIService) UpdateForecastExclusions(ctx context.Context, payload model
 	return nil
 }
 
+// forecastResult holds a calculated forecast in memory, keyed by month, before
+// it is either persisted (base plan) or returned as-is (scenarios)
+type forecastResult struct {
+	organisation *models.Organisation
+	horizon      forecastHorizon
+	// months and details are keyed by the first month of each period of the horizon
+	months  map[string]map[string]int64
+	details map[string]*models.ForecastDetails
+	// openingBalance is the liquidity of all bank accounts today in the base currency
+	openingBalance int64
+	// warnings flag the items converted with a missing, stale or fallback rate
+	warnings []models.ForecastWarning
+}
+
 func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]models.Forecast, error) {
+	result, err := a.buildForecast(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	forecastMap := result.months
+	forecastDetailMap := result.details
+
+	_, err = a.dbService.ClearForecasts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, period := range result.horizon.periods() {
+		forecast, ok := forecastMap[period.month]
+		// Buckets are always stored, the listing relies on them to hide the months they cover
+		if !ok && period.period == models.ForecastPeriodMonth {
+			continue
+		}
+		revenue := forecast["revenue"]
+		expense := forecast["expense"]
+		forecastID, err := a.dbService.UpsertForecast(models.CreateForecast{
+			Month:    period.month,
+			Period:   period.period,
+			EndMonth: period.endMonth,
+			Revenue:  revenue,
+			Expense:  expense,
+			Cashflow: revenue + expense,
+		}, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Upsert the details along with the forecast
+		revenueList, expenseList := flattenForecastDetails(forecastDetailMap[period.month])
+
+		_, err = a.dbService.UpsertForecastDetail(models.CreateForecastDetail{
+			Month:      period.month,
+			Revenue:    revenueList,
+			Expense:    expenseList,
+			ForecastID: forecastID,
+		}, userID, forecastID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the plan of record for the plan-vs-actual comparison
+	err = a.dbService.ArchiveForecasts(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	forecasts, err := a.dbService.ListForecasts(userID, int64(result.horizon.totalMonths))
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	applyRunningBalance(forecasts, result.openingBalance)
+
+	validator := utils.GetValidator()
+	if err := validator.Var(forecasts, "dive"); err != nil {
+		// Return validation errors
+		return nil, err
+	}
+
+	// Notify streams once per recalculation instead of per affected sub-entity
+	if a.eventHub != nil {
+		a.eventHub.Publish(events.Event{
+			Entity:         "forecast",
+			Action:         events.ActionUpdated,
+			OrganisationID: result.organisation.ID,
+		})
+	}
+
+	return forecasts, nil
+}
+
+// buildForecast projects transactions, salaries, salary costs and VAT settlements into
+// the periods of the organisation's horizon. A non-nil overlay applies a scenario on top of the base plan.
+func (a *APIService) buildForecast(ctx context.Context, userID int64, overlay *scenarioOverlay) (*forecastResult, error) {
 	page := int64(1)
 	limit := int64(100000)
 	sortBy := "name"
@@ -149,16 +264,28 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 	if err != nil {
 		return nil, err
 	}
+	transactions = overlay.applyToTransactions(transactions)
 
-	fiatRates, err := a.ListFiatRates(ctx, baseCurrency)
+	// Planned amounts are converted with the planning rate of the organisation or the latest rate
+	fiatRates, err := a.planningFiatRates(ctx, userID, baseCurrency)
 	if err != nil {
 		return nil, err
 	}
 
 	today := utils.GetTodayAsUTC()
-	maxEndDate := today.AddDate(utils.MaxForecastYears, 0, 0)
-	// We include the whole final month, otherwise the results might be confusing
-	lastDayOfMaxEndDate := time.Date(maxEndDate.Year(), maxEndDate.Month()+1, 0, 23, 59, 59, 999999999, maxEndDate.Location())
+	warnings := newForecastWarnings(fiatRates, today)
+	openingBalance, err := a.calculateOpeningBalance(userID, fiatRates, today, warnings)
+	if err != nil {
+		return nil, err
+	}
+	// One query for all exclusions instead of one per transaction, salary and salary cost
+	exclusionMap, err := a.listForecastExclusionMap(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	horizon := newForecastHorizon(organisation, today)
+	// The horizon includes the whole final month, otherwise the results might be confusing
+	lastDayOfMaxEndDate := horizon.end
 
 	// We need a map for revenues and expenses
 	forecastMap := make(map[string]map[string]int64)
@@ -167,17 +294,17 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 		if transaction.IsDisabled {
 			continue
 		}
-		fiatRate := models.GetFiatRateFromCurrency(fiatRates, baseCurrency, *transaction.Currency.Code)
+		if reachesForecast(transaction, today) {
+			warnings.check(utils.TransactionsTableName, transaction.ID, transaction.Name, *transaction.Currency.Code)
+		}
+		fiatRate := fiatRates.Rate(*transaction.Currency.Code, today)
 		amount := models.CalculateAmountWithFiatRate(transaction.Amount, fiatRate)
 		if transaction.Vat != nil && !transaction.VatIncluded {
 			amount = models.CalculateAmountWithFiatRate(transaction.Amount+transaction.VatAmount, fiatRate)
 		}
 		isRevenue := amount > 0
 
-		exclusions, err := a.ListForecastExclusions(ctx, userID, transaction.ID, utils.TransactionsTableName)
-		if err != nil {
-			return nil, err
-		}
+		exclusions := exclusionMap.months(utils.TransactionsTableName, transaction.ID)
 
 		if transaction.Type == "single" {
 			startDate := time.Time(transaction.StartDate)
@@ -382,17 +509,17 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 	}
 
 	// Collect the employee expenses now
-	employees, _, err := a.ListEmployees(ctx, userID, page, limit, sortBy, sortOrder, "", false)
+	employees, _, err := a.listEmployees(ctx, userID, page, limit, sortBy, sortOrder, "", false)
 	if err != nil {
 		return nil, err
 	}
 	for _, employee := range employees {
-		salaries, _, err := a.ListSalaries(ctx, userID, employee.ID, page, limit)
+		salaries, _, err := a.listSalaries(ctx, userID, employee.ID, page, limit)
 		if err != nil {
 			return nil, err
 		}
 		for _, salary := range salaries {
-			if salary.IsDisabled {
+			if salary.IsDisabled || overlay.isRemoved(utils.SalariesTableName, salary.ID) {
 				continue
 			}
 			fromDate := time.Time(salary.FromDate)
@@ -401,15 +528,18 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 				toDate = time.Time(*salary.ToDate)
 			}
 
-			fiatRate := models.GetFiatRateFromCurrency(fiatRates, baseCurrency, *salary.Currency.Code)
+			if !toDate.Before(today) {
+				warnings.check(utils.SalariesTableName, salary.ID, employee.Name, *salary.Currency.Code)
+			}
+			fiatRate := fiatRates.Rate(*salary.Currency.Code, today)
 			// Must be minus here
 			netAmount := salary.Amount - salary.EmployeeDeductions
+			if override, ok := overlay.override(utils.SalariesTableName, salary.ID); ok && override >= 0 {
+				netAmount = uint64(override)
+			}
 			amount := -models.CalculateAmountWithFiatRate(int64(netAmount), fiatRate)
 
-			salaryExclusions, err := a.ListForecastExclusions(ctx, userID, salary.ID, utils.SalariesTableName)
-			if err != nil {
-				return nil, err
-			}
+			salaryExclusions := exclusionMap.months(utils.SalariesTableName, salary.ID)
 
 			switch salary.Cycle {
 			case utils.CycleMonthly:
@@ -503,21 +633,56 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 			}
 
 			// Always calculate the separate costs; salaries without definitions return an empty list.
-			salaryCosts, _, err := a.ListSalaryCosts(ctx, userID, salary.ID, 1, 1000, false)
+			salaryCosts, _, err := a.listSalaryCosts(ctx, userID, salary.ID, 1, 1000, false)
 			if err != nil {
 				return nil, err
 			}
 
+			// 13th/14th month salaries and bonuses, their costs are part of the salary cost details
+			for _, payment := range salary.ExtraPaymentsBetween(today, lastDayOfMaxEndDate) {
+				paymentDate := time.Time(payment.Date)
+				if paymentDate.Before(today) {
+					continue
+				}
+				netPayment := payment.Amount - a.CalculateExtraPaymentDeductions(salary, salaryCosts, payment)
+				extraAmount := -models.CalculateAmountWithFiatRate(int64(netPayment), fiatRate)
+				extraName := fmt.Sprintf("%s (%s)", employee.Name, payment.Name)
+				monthKey := getYearMonth(paymentDate)
+				if forecastMap[monthKey] == nil {
+					initForecastMapKey(forecastMap, monthKey)
+				}
+				if salaryExclusions[monthKey] {
+					forecastMap[monthKey]["expense"] += 0
+					addForecastDetail(
+						forecastDetailMap, monthKey, 0, false, true,
+						salary.ID, utils.SalariesTableName, "Löhne", extraName,
+					)
+				} else {
+					forecastMap[monthKey]["expense"] += extraAmount
+					addForecastDetail(forecastDetailMap, monthKey, extraAmount, false, false,
+						salary.ID, utils.SalariesTableName, "Löhne", extraName,
+					)
+				}
+			}
+
 			for _, salaryCost := range salaryCosts {
-				salaryCostExclusions, err := a.ListForecastExclusions(ctx, userID, salaryCost.ID, utils.SalaryCostsTableName)
-				if err != nil {
-					return nil, err
+				if overlay.isRemoved(utils.SalaryCostsTableName, salaryCost.ID) {
+					continue
+				}
+				// Salary cost exclusions apply to all costs sharing the label
+				var salaryCostExclusions map[string]bool
+				if salaryCost.Label != nil {
+					salaryCostExclusions = exclusionMap.months(utils.SalaryCostLabelsTableName, salaryCost.Label.ID)
 				}
 
 				if salaryCost.CalculatedNextExecutionDate != nil {
 					costFromDate := time.Time(*salaryCost.CalculatedNextExecutionDate)
 					distributionMultiplier := int64(models.SalaryCostDistributionMultiplier(salaryCost.DistributionType))
 					nextCost := -models.CalculateAmountWithFiatRate(int64(salaryCost.CalculatedNextCost)*distributionMultiplier, fiatRate)
+					overrideCost, hasOverrideCost := overlay.override(utils.SalaryCostsTableName, salaryCost.ID)
+					if hasOverrideCost {
+						nextCost = -models.CalculateAmountWithFiatRate(overrideCost, fiatRate)
+					}
 
 					labelName := "<Kein Label>"
 					if salaryCost.Label != nil {
@@ -559,6 +724,9 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 							}
 							distributionMultiplier := int64(models.SalaryCostDistributionMultiplier(salaryCost.DistributionType))
 							nextCost := -models.CalculateAmountWithFiatRate(int64(matchingDetail.Amount)*distributionMultiplier, fiatRate)
+							if hasOverrideCost {
+								nextCost = -models.CalculateAmountWithFiatRate(overrideCost, fiatRate)
+							}
 							monthKey := getYearMonth(current)
 							if forecastMap[monthKey] == nil {
 								initForecastMapKey(forecastMap, monthKey)
@@ -660,6 +828,11 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 		}
 	}
 
+	// Items that only exist within the scenario
+	if overlay != nil {
+		addScenarioAdditions(forecastMap, forecastDetailMap, overlay.additions, fiatRates, warnings, today, lastDayOfMaxEndDate)
+	}
+
 	// VAT Settlement Calculation
 	vatSetting, err := a.GetVatSetting(ctx, userID)
 	if err != nil {
@@ -678,7 +851,7 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 			}
 
 			// Only collect VAT from positive (revenue) transactions
-			fiatRate := models.GetFiatRateFromCurrency(fiatRates, baseCurrency, *transaction.Currency.Code)
+			fiatRate := fiatRates.Rate(*transaction.Currency.Code, today)
 			amount := models.CalculateAmountWithFiatRate(transaction.Amount, fiatRate)
 
 			if amount <= 0 || transaction.Vat == nil || transaction.VatAmount == 0 {
@@ -793,64 +966,55 @@ func (a *APIService) CalculateForecast(ctx context.Context, userID int64) ([]mod
 		}
 	}
 
-	_, err = a.dbService.ClearForecasts(userID)
+	// Everything beyond the monthly part of the horizon is summed up into quarters or years
+	forecastMap, forecastDetailMap = horizon.aggregate(forecastMap, forecastDetailMap)
+
+	return &forecastResult{
+		organisation:   organisation,
+		horizon:        horizon,
+		months:         forecastMap,
+		details:        forecastDetailMap,
+		openingBalance: openingBalance,
+		warnings:       warnings.list,
+	}, nil
+}
+
+// calculateOpeningBalance sums up the balances all bank accounts had on the given date, converted into the base currency.
+// Warnings are optional, pass nil to skip them.
+func (a *APIService) calculateOpeningBalance(userID int64, fiatRates *models.FiatRateTable, date time.Time, warnings *forecastWarnings) (int64, error) {
+	bankAccounts, err := a.dbService.ListBankAccountsAtDate(userID, date.Format(utils.InternalDateFormat))
 	if err != nil {
-		return nil, err
+		logger.Logger.Error(err)
+		return 0, err
 	}
 
-	for monthKey, forecast := range forecastMap {
-		revenue := forecast["revenue"]
-		expense := forecast["expense"]
-		forecastID, err := a.dbService.UpsertForecast(models.CreateForecast{
-			Month:    monthKey,
-			Revenue:  revenue,
-			Expense:  expense,
-			Cashflow: revenue + expense,
-		}, userID)
-		if err != nil {
-			return nil, err
-		}
-
-		// Upsert the details along with the forecast
-		forecastDetail := forecastDetailMap[monthKey]
-		revenueList := make([]models.ForecastDetailRevenueExpense, 0)
-		expenseList := make([]models.ForecastDetailRevenueExpense, 0)
-
-		iterateForecastDetails(forecastDetail.Revenue, &revenueList)
-		iterateForecastDetails(forecastDetail.Expense, &expenseList)
-
-		_, err = a.dbService.UpsertForecastDetail(models.CreateForecastDetail{
-			Month:      monthKey,
-			Revenue:    revenueList,
-			Expense:    expenseList,
-			ForecastID: forecastID,
-		}, userID, forecastID)
-		if err != nil {
-			return nil, err
-		}
+	openingBalance := int64(0)
+	for _, bankAccount := range bankAccounts {
+		warnings.check(utils.BankAccountsTableName, bankAccount.ID, bankAccount.Name, *bankAccount.Currency.Code)
+		fiatRate := fiatRates.Rate(*bankAccount.Currency.Code, date)
+		openingBalance += models.CalculateAmountWithFiatRate(bankAccount.Amount, fiatRate)
 	}
 
-	forecasts, err := a.ListForecasts(ctx, userID, int64(utils.GetTotalMonthsForMaxForecastYears()))
-	if err != nil {
-		return nil, err
-	}
+	return openingBalance, nil
+}
 
-	validator := utils.GetValidator()
-	if err := validator.Var(forecasts, "dive"); err != nil {
-		// Return validation errors
-		return nil, err
+// reachesForecast tells whether a transaction still occurs from today on
+func reachesForecast(transaction models.Transaction, today time.Time) bool {
+	if transaction.Type == "single" {
+		return !time.Time(transaction.StartDate).Before(today)
 	}
+	return transaction.EndDate == nil || !time.Time(*transaction.EndDate).Before(today)
+}
 
-	// Notify streams once per recalculation instead of per affected sub-entity
-	if a.eventHub != nil {
-		a.eventHub.Publish(events.Event{
-			Entity:         "forecast",
-			Action:         events.ActionUpdated,
-			OrganisationID: organisation.ID,
-		})
+// applyRunningBalance carries the opening balance through the months by adding up the cashflow,
+// so every month starts with the closing balance of the previous one
+func applyRunningBalance(forecasts []models.Forecast, openingBalance int64) {
+	balance := openingBalance
+	for i := range forecasts {
+		forecasts[i].Data.OpeningBalance = balance
+		balance += forecasts[i].Data.Cashflow
+		forecasts[i].Data.ClosingBalance = balance
 	}
-
-	return forecasts, nil
 }
 
 func initForecastMapKey(forecastMap map[string]map[string]int64, monthKey string) {
@@ -901,6 +1065,20 @@ func addForecastDetail(detailMap map[string]*models.ForecastDetails, monthKey st
 	}
 }
 
+// flattenForecastDetails turns the nested detail maps of one month into the sorted revenue and expense trees
+func flattenForecastDetails(forecastDetail *models.ForecastDetails) ([]models.ForecastDetailRevenueExpense, []models.ForecastDetailRevenueExpense) {
+	revenueList := make([]models.ForecastDetailRevenueExpense, 0)
+	expenseList := make([]models.ForecastDetailRevenueExpense, 0)
+	if forecastDetail == nil {
+		return revenueList, expenseList
+	}
+
+	iterateForecastDetails(forecastDetail.Revenue, &revenueList)
+	iterateForecastDetails(forecastDetail.Expense, &expenseList)
+
+	return revenueList, expenseList
+}
+
 func iterateForecastDetails(data map[string]any, result *[]models.ForecastDetailRevenueExpense) {
 	keys := make([]string, 0, len(data))
 	for key := range data {
@@ -953,6 +1131,31 @@ func addOffset(costCycle string, fromDate time.Time, current time.Time, relative
 	return current
 }
 
+// forecastExclusionMap holds the excluded months per related table and id
+type forecastExclusionMap map[string]map[int64]map[string]bool
+
+func (e forecastExclusionMap) months(relatedTable string, relatedID int64) map[string]bool {
+	return e[relatedTable][relatedID]
+}
+
+func (a *APIService) listForecastExclusionMap(ctx context.Context, userID int64) (forecastExclusionMap, error) {
+	exclusions, err := a.ListAllForecastExclusions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exclusionMap := make(forecastExclusionMap)
+	for _, exclusion := range exclusions {
+		if exclusionMap[exclusion.RelatedTable] == nil {
+			exclusionMap[exclusion.RelatedTable] = make(map[int64]map[string]bool)
+		}
+		if exclusionMap[exclusion.RelatedTable][exclusion.RelatedID] == nil {
+			exclusionMap[exclusion.RelatedTable][exclusion.RelatedID] = make(map[string]bool)
+		}
+		exclusionMap[exclusion.RelatedTable][exclusion.RelatedID][exclusion.Month] = true
+	}
+	return exclusionMap, nil
+}
+
 func (a *APIService) ListAllForecastExclusions(ctx context.Context, userID int64) ([]models.ForecastExclusionInfo, error) {
 	exclusions, err := a.dbService.ListAllForecastExclusions(userID)
 	if err != nil {
@@ -0,0 +1,151 @@
+package api_service
+
+import (
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+// forecastHorizon describes the periods the forecast of an organisation covers. The first
+// monthlyMonths are calculated per month, the rest is aggregated into calendar quarters or years.
+type forecastHorizon struct {
+	// start is the first day of the current month
+	start time.Time
+	// end is the last moment of the final month
+	end           time.Time
+	totalMonths   int
+	monthlyMonths int
+	bucket        string
+	bucketMonths  int
+}
+
+// forecastPeriod covers the months from month to endMonth (both YYYY-MM)
+type forecastPeriod struct {
+	month    string
+	endMonth string
+	period   string
+}
+
+// newForecastHorizon reads the horizon settings of the organisation, missing values fall back to
+// the default of monthly values for utils.DefaultForecastYears
+func newForecastHorizon(organisation *models.Organisation, today time.Time) forecastHorizon {
+	years := organisation.ForecastYears
+	if years <= 0 {
+		years = utils.DefaultForecastYears
+	}
+	monthlyYears := organisation.ForecastMonthlyYears
+	if monthlyYears <= 0 || monthlyYears > years {
+		monthlyYears = years
+	}
+	bucket := organisation.ForecastBucket
+	bucketMonths := 3
+	if bucket == models.ForecastPeriodYear {
+		bucketMonths = 12
+	} else {
+		bucket = models.ForecastPeriodQuarter
+	}
+
+	totalMonths := int(utils.GetTotalMonthsForForecastYears(years))
+	start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	return forecastHorizon{
+		start:         start,
+		end:           start.AddDate(0, totalMonths, 0).Add(-time.Nanosecond),
+		totalMonths:   totalMonths,
+		monthlyMonths: min(int(utils.GetTotalMonthsForForecastYears(monthlyYears)), totalMonths),
+		bucket:        bucket,
+		bucketMonths:  bucketMonths,
+	}
+}
+
+// periods lists the monthly periods followed by the buckets. Buckets follow the calendar,
+// so the first and the last one might cover fewer months.
+func (h forecastHorizon) periods() []forecastPeriod {
+	periods := make([]forecastPeriod, 0, h.totalMonths)
+	for i := 0; i < h.totalMonths; {
+		month := h.start.AddDate(0, i, 0)
+		if i < h.monthlyMonths {
+			monthKey := getYearMonth(month)
+			periods = append(periods, forecastPeriod{month: monthKey, endMonth: monthKey, period: models.ForecastPeriodMonth})
+			i++
+			continue
+		}
+		length := h.bucketMonths - (int(month.Month())-1)%h.bucketMonths
+		length = min(length, h.totalMonths-i)
+		periods = append(periods, forecastPeriod{
+			month:    getYearMonth(month),
+			endMonth: getYearMonth(month.AddDate(0, length-1, 0)),
+			period:   h.bucket,
+		})
+		i += length
+	}
+	return periods
+}
+
+// aggregate sums up the months of every period under the key of its first month.
+// Months beyond the horizon are dropped.
+func (h forecastHorizon) aggregate(months map[string]map[string]int64, details map[string]*models.ForecastDetails) (map[string]map[string]int64, map[string]*models.ForecastDetails) {
+	periodKeys := make(map[string]string, h.totalMonths)
+	for _, period := range h.periods() {
+		for monthKey := period.month; monthKey <= period.endMonth; {
+			periodKeys[monthKey] = period.month
+			month, _ := time.Parse("2006-01", monthKey)
+			monthKey = getYearMonth(month.AddDate(0, 1, 0))
+		}
+	}
+
+	aggregatedMonths := make(map[string]map[string]int64)
+	for monthKey, amounts := range months {
+		periodKey, ok := periodKeys[monthKey]
+		if !ok {
+			continue
+		}
+		if aggregatedMonths[periodKey] == nil {
+			initForecastMapKey(aggregatedMonths, periodKey)
+		}
+		aggregatedMonths[periodKey]["revenue"] += amounts["revenue"]
+		aggregatedMonths[periodKey]["expense"] += amounts["expense"]
+	}
+
+	aggregatedDetails := make(map[string]*models.ForecastDetails)
+	for monthKey, detail := range details {
+		periodKey, ok := periodKeys[monthKey]
+		if !ok {
+			continue
+		}
+		if aggregatedDetails[periodKey] == nil {
+			aggregatedDetails[periodKey] = &models.ForecastDetails{
+				Revenue: make(map[string]any),
+				Expense: make(map[string]any),
+			}
+		}
+		mergeForecastDetails(aggregatedDetails[periodKey].Revenue, detail.Revenue)
+		mergeForecastDetails(aggregatedDetails[periodKey].Expense, detail.Expense)
+	}
+
+	return aggregatedMonths, aggregatedDetails
+}
+
+// mergeForecastDetails adds the detail tree of src to dst. A merged item only counts
+// as excluded if it was excluded in every month.
+func mergeForecastDetails(dst map[string]any, src map[string]any) {
+	for key, value := range src {
+		switch v := value.(type) {
+		case models.ForecastDetail:
+			existingDetail, ok := dst[key].(models.ForecastDetail)
+			if !ok {
+				dst[key] = v
+				continue
+			}
+			existingDetail.Amount += v.Amount
+			existingDetail.IsExcluded = existingDetail.IsExcluded && v.IsExcluded
+			dst[key] = existingDetail
+		case map[string]any:
+			children, ok := dst[key].(map[string]any)
+			if !ok {
+				children = make(map[string]any)
+				dst[key] = children
+			}
+			mergeForecastDetails(children, v)
+		}
+	}
+}
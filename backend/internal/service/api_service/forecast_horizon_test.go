@@ -0,0 +1,161 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func TestCalculateForecast_AggregatesBeyondMonthlyHorizon(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(99)
+	fixedToday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	baseCode := "CHF"
+	orgCurrency := models.Currency{Code: &baseCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 500,
+		Currency:              orgCurrency,
+	}
+	// Two years in total, only the first one monthly
+	organisation := models.Organisation{
+		ID:                   user.CurrentOrganisationID,
+		Name:                 "Org",
+		Currency:             orgCurrency,
+		ForecastYears:        2,
+		ForecastMonthlyYears: 1,
+		ForecastBucket:       models.ForecastPeriodQuarter,
+	}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil)
+
+	monthly := utils.CycleMonthly
+	transactions := []models.Transaction{
+		{
+			ID:          1,
+			Name:        "Subscription",
+			Amount:      100_00,
+			VatIncluded: true,
+			Type:        "repeating",
+			Cycle:       &monthly,
+			StartDate:   types.AsDate(fixedToday),
+			Category:    models.Category{Name: "Sales"},
+			Currency:    orgCurrency,
+		},
+	}
+	mockDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		Return(transactions, int64(len(transactions)), nil)
+	mockDB.EXPECT().ListFiatRates(baseCode).Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().ListOrganisationFiatRates(userID).Return([]models.OrganisationFiatRate{}, nil)
+	mockDB.EXPECT().
+		ListAllForecastExclusions(userID).
+		Return([]models.ForecastExclusionInfo{
+			{Month: "2025-05", RelatedTable: utils.TransactionsTableName, RelatedID: 1, Name: "Subscription", Amount: 100_00},
+		}, nil)
+	mockDB.EXPECT().
+		ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
+		Return([]models.Employee{}, int64(0), nil)
+	mockDB.EXPECT().GetVatSetting(userID).Return(nil, nil)
+	mockDB.EXPECT().ListBankAccountsAtDate(userID, "2024-01-01").Return([]models.BankAccount{}, nil)
+	mockDB.EXPECT().ClearForecasts(userID).Return(int64(0), nil)
+	mockDB.EXPECT().ArchiveForecasts(userID).Return(nil)
+
+	upserted := map[string]models.CreateForecast{}
+	mockDB.EXPECT().
+		UpsertForecast(gomock.Any(), userID).
+		DoAndReturn(func(payload models.CreateForecast, _ int64) (int64, error) {
+			upserted[payload.Month] = payload
+			return int64(len(upserted)), nil
+		}).
+		Times(18)
+	details := map[string]models.CreateForecastDetail{}
+	mockDB.EXPECT().
+		UpsertForecastDetail(gomock.Any(), userID, gomock.Any()).
+		DoAndReturn(func(payload models.CreateForecastDetail, _ int64, _ int64) (int64, error) {
+			details[payload.Month] = payload
+			return 0, nil
+		}).
+		Times(18)
+	mockDB.EXPECT().
+		ListForecasts(userID, int64(utils.GetTotalMonthsForForecastYears(2))).
+		Return([]models.Forecast{}, nil)
+
+	_, err := service.CalculateForecast(context.Background(), userID)
+	require.NoError(t, err)
+
+	// 13 months followed by quarters that follow the calendar and stop at the end of the horizon
+	require.Equal(t, models.ForecastPeriodMonth, upserted["2025-01"].Period)
+	require.Equal(t, "2025-01", upserted["2025-01"].EndMonth)
+	require.EqualValues(t, 100_00, upserted["2025-01"].Revenue)
+
+	require.Equal(t, models.ForecastPeriodQuarter, upserted["2025-02"].Period)
+	require.Equal(t, "2025-03", upserted["2025-02"].EndMonth)
+	require.EqualValues(t, 200_00, upserted["2025-02"].Revenue)
+
+	// May is excluded, the quarter still lists the transaction as included
+	require.Equal(t, "2025-06", upserted["2025-04"].EndMonth)
+	require.EqualValues(t, 200_00, upserted["2025-04"].Revenue)
+	require.Len(t, details["2025-04"].Revenue, 1)
+	require.Equal(t, "Sales", details["2025-04"].Revenue[0].Name)
+	require.EqualValues(t, 200_00, details["2025-04"].Revenue[0].Children[0].Amount)
+	require.False(t, details["2025-04"].Revenue[0].Children[0].IsExcluded)
+
+	require.Equal(t, "2025-09", upserted["2025-07"].EndMonth)
+	require.Equal(t, "2025-12", upserted["2025-10"].EndMonth)
+	require.Equal(t, "2026-01", upserted["2026-01"].EndMonth)
+	require.EqualValues(t, 100_00, upserted["2026-01"].Revenue)
+	require.NotContains(t, upserted, "2026-02")
+}
+
+func TestUpdateOrganisation_RejectsMonthlyYearsBeyondHorizon(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(99)
+	organisationID := int64(500)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().
+		GetOrganisation(userID, organisationID).
+		Return(&models.Organisation{
+			ID:                   organisationID,
+			Role:                 "owner",
+			ForecastYears:        3,
+			ForecastMonthlyYears: 3,
+			ForecastBucket:       models.ForecastPeriodQuarter,
+		}, nil)
+
+	// No UpdateOrganisation expectation: nothing must be stored
+	forecastYears := 2
+	_, err := service.UpdateOrganisation(context.Background(), models.UpdateOrganisation{ForecastYears: &forecastYears}, userID, organisationID)
+	require.ErrorIs(t, err, api_service.ErrForecastMonthlyYearsExceedHorizon)
+}
@@ -0,0 +1,180 @@
+package api_service
+
+import (
+	"context"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"sync"
+	"time"
+)
+
+// forecastScheduler recalculates the forecast of an organisation in the background once no further
+// changes arrived for the debounce period. Changes during a run trigger exactly one more run.
+type forecastScheduler struct {
+	mu        sync.Mutex
+	debounce  time.Duration
+	jobs      map[int64]*forecastJob
+	calculate func(ctx context.Context, userID int64, organisationID int64) error
+}
+
+type forecastJob struct {
+	// userID is the latest user that changed the organisation, the calculation runs on their behalf
+	userID  int64
+	timer   *time.Timer
+	running bool
+	// dirty marks changes that arrived during a run
+	dirty  bool
+	status models.ForecastStatus
+}
+
+func newForecastScheduler(debounce time.Duration, calculate func(ctx context.Context, userID int64, organisationID int64) error) *forecastScheduler {
+	return &forecastScheduler{
+		debounce:  debounce,
+		jobs:      make(map[int64]*forecastJob),
+		calculate: calculate,
+	}
+}
+
+// schedule (re)starts the debounce timer of the organisation
+func (s *forecastScheduler) schedule(userID int64, organisationID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[organisationID]
+	if job == nil {
+		job = &forecastJob{status: models.ForecastStatus{Status: models.ForecastStatusIdle}}
+		s.jobs[organisationID] = job
+	}
+	now := time.Now().UTC()
+	job.userID = userID
+	job.status.RequestedAt = &now
+
+	if job.running {
+		job.dirty = true
+		return
+	}
+	job.status.Status = models.ForecastStatusPending
+	if job.timer != nil {
+		// A timer that already fired is about to start the run, which still picks up this change
+		if job.timer.Stop() {
+			job.timer.Reset(s.debounce)
+		}
+		return
+	}
+	job.timer = time.AfterFunc(s.debounce, func() { s.run(organisationID) })
+}
+
+func (s *forecastScheduler) run(organisationID int64) {
+	s.mu.Lock()
+	job := s.jobs[organisationID]
+	job.timer = nil
+	job.running = true
+	job.dirty = false
+	userID := job.userID
+	startedAt := time.Now().UTC()
+	job.status.Status = models.ForecastStatusRunning
+	job.status.StartedAt = &startedAt
+	s.mu.Unlock()
+
+	err := s.calculate(context.Background(), userID, organisationID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	finishedAt := time.Now().UTC()
+	job.running = false
+	job.status.FinishedAt = &finishedAt
+	job.status.Error = nil
+	job.status.Status = models.ForecastStatusIdle
+	if err != nil {
+		logger.Logger.Errorf("forecast recalculation for organisation %d failed: %v", organisationID, err)
+		message := err.Error()
+		job.status.Error = &message
+		job.status.Status = models.ForecastStatusFailed
+	}
+	if job.dirty {
+		job.dirty = false
+		job.status.Status = models.ForecastStatusPending
+		job.timer = time.AfterFunc(s.debounce, func() { s.run(organisationID) })
+	}
+}
+
+func (s *forecastScheduler) status(organisationID int64) models.ForecastStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[organisationID]
+	if job == nil {
+		return models.ForecastStatus{Status: models.ForecastStatusIdle}
+	}
+	return job.status
+}
+
+// EnableForecastScheduler moves forecast recalculations after changes into the background.
+// Without it (default, e.g. in tests) every change recalculates the forecast synchronously.
+func (a *APIService) EnableForecastScheduler(debounce time.Duration) {
+	a.forecastScheduler = newForecastScheduler(debounce, func(ctx context.Context, userID int64, organisationID int64) error {
+		// The user may have switched the organisation since, the calculation targets the scheduled one
+		scoped, release, err := a.dbService.ScopeToOrganisation(organisationID)
+		if err != nil {
+			return err
+		}
+		defer release()
+		_, err = a.WithDatabase(scoped).CalculateForecast(ctx, userID)
+		return err
+	})
+}
+
+// scheduleForecast recalculates the forecast after a change, in the background if the scheduler is enabled
+func (a *APIService) scheduleForecast(ctx context.Context, userID int64) error {
+	if a.forecastScheduler == nil {
+		_, err := a.CalculateForecast(ctx, userID)
+		return err
+	}
+	user, err := a.dbService.GetProfile(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.forecastScheduler.schedule(userID, user.CurrentOrganisationID)
+	return nil
+}
+
+func (a *APIService) GetForecastStatus(ctx context.Context, userID int64) (*models.ForecastStatus, error) {
+	status := models.ForecastStatus{Status: models.ForecastStatusIdle}
+	if a.forecastScheduler == nil {
+		return &status, nil
+	}
+	user, err := a.dbService.GetProfile(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	status = a.forecastScheduler.status(user.CurrentOrganisationID)
+	return &status, nil
+}
+
+// ScheduleForecastIfStale schedules a recalculation when no forecast is stored yet or it was calculated
+// before today, unless one is already on its way. It reports whether a recalculation was scheduled.
+func (a *APIService) ScheduleForecastIfStale(ctx context.Context, userID int64) (bool, error) {
+	status, err := a.GetForecastStatus(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if status.Status == models.ForecastStatusPending || status.Status == models.ForecastStatusRunning {
+		return false, nil
+	}
+	forecasts, err := a.dbService.ListForecasts(userID, 1)
+	if err != nil {
+		logger.Logger.Error(err)
+		return false, err
+	}
+	if len(forecasts) > 0 && forecasts[0].UpdatedAt != nil && !forecasts[0].UpdatedAt.Before(utils.GetTodayAsUTC()) {
+		return false, nil
+	}
+	if err := a.scheduleForecast(ctx, userID); err != nil {
+		logger.Logger.Error(err)
+		return false, err
+	}
+	return true, nil
+}
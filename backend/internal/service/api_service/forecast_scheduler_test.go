@@ -0,0 +1,175 @@
+package api_service_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestForecastScheduler_CoalescesBurstOfChanges(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(99)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	service.EnableForecastScheduler(50 * time.Millisecond)
+
+	baseCode := "CHF"
+	orgCurrency := models.Currency{Code: &baseCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 500,
+		Currency:              orgCurrency,
+	}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil).AnyTimes()
+	mockDB.EXPECT().
+		GetOrganisation(userID, user.CurrentOrganisationID).
+		Return(&models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}, nil).
+		AnyTimes()
+	mockDB.EXPECT().ScopeToOrganisation(user.CurrentOrganisationID).Return(mockDB, func() {}, nil)
+
+	mockDB.EXPECT().GetTransaction(userID, gomock.Any()).Return(&models.Transaction{}, nil).Times(3)
+	mockDB.EXPECT().DeleteTransaction(userID, gomock.Any()).Return(nil).Times(3)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil).Times(3)
+
+	// Three deletions within the debounce period share one recalculation
+	mockDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		Return([]models.Transaction{}, int64(0), nil)
+	mockDB.EXPECT().ListFiatRates(baseCode).Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().ListOrganisationFiatRates(userID).Return([]models.OrganisationFiatRate{}, nil)
+	mockDB.EXPECT().ListAllForecastExclusions(userID).Return([]models.ForecastExclusionInfo{}, nil)
+	mockDB.EXPECT().
+		ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
+		Return([]models.Employee{}, int64(0), nil)
+	mockDB.EXPECT().GetVatSetting(userID).Return(nil, nil)
+	mockDB.EXPECT().ListBankAccountsAtDate(userID, gomock.Any()).Return([]models.BankAccount{}, nil)
+	mockDB.EXPECT().ClearForecasts(userID).Return(int64(0), nil).Times(1)
+	mockDB.EXPECT().ArchiveForecasts(userID).Return(nil)
+	mockDB.EXPECT().ListForecasts(userID, gomock.Any()).Return([]models.Forecast{}, nil)
+
+	for transactionID := int64(1); transactionID <= 3; transactionID++ {
+		require.NoError(t, service.DeleteTransaction(context.Background(), userID, transactionID))
+	}
+
+	status, err := service.GetForecastStatus(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, models.ForecastStatusPending, status.Status)
+	require.NotNil(t, status.RequestedAt)
+
+	require.Eventually(t, func() bool {
+		status, err := service.GetForecastStatus(context.Background(), userID)
+		return err == nil && status.Status == models.ForecastStatusIdle && status.FinishedAt != nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestForecastScheduler_CalculatesScheduledOrganisationAfterSwitch(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(99)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	scopedDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	service.EnableForecastScheduler(50 * time.Millisecond)
+
+	baseCode := "CHF"
+	orgCurrency := models.Currency{Code: &baseCode}
+	scheduled := models.User{ID: userID, Name: "Test User", Email: "test@example.com", CurrentOrganisationID: 500, Currency: orgCurrency}
+	switched := models.User{ID: userID, Name: "Test User", Email: "test@example.com", CurrentOrganisationID: 600, Currency: orgCurrency}
+
+	// The user switches to another organisation right after the change
+	var hasSwitched atomic.Bool
+	mockDB.EXPECT().GetProfile(userID).DoAndReturn(func(int64) (*models.User, error) {
+		if hasSwitched.Load() {
+			return &switched, nil
+		}
+		return &scheduled, nil
+	}).AnyTimes()
+	mockDB.EXPECT().GetTransaction(userID, int64(1)).Return(&models.Transaction{}, nil)
+	mockDB.EXPECT().DeleteTransaction(userID, int64(1)).Return(nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
+
+	// The recalculation still runs for the organisation of the change
+	var released atomic.Bool
+	mockDB.EXPECT().ScopeToOrganisation(scheduled.CurrentOrganisationID).Return(scopedDB, func() { released.Store(true) }, nil)
+	scopedDB.EXPECT().GetProfile(userID).Return(&scheduled, nil).AnyTimes()
+	scopedDB.EXPECT().
+		GetOrganisation(userID, scheduled.CurrentOrganisationID).
+		Return(&models.Organisation{ID: scheduled.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}, nil).
+		AnyTimes()
+	scopedDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		Return([]models.Transaction{}, int64(0), nil)
+	scopedDB.EXPECT().ListFiatRates(baseCode).Return([]models.FiatRate{}, nil)
+	scopedDB.EXPECT().ListOrganisationFiatRates(userID).Return([]models.OrganisationFiatRate{}, nil)
+	scopedDB.EXPECT().ListAllForecastExclusions(userID).Return([]models.ForecastExclusionInfo{}, nil)
+	scopedDB.EXPECT().
+		ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
+		Return([]models.Employee{}, int64(0), nil)
+	scopedDB.EXPECT().GetVatSetting(userID).Return(nil, nil)
+	scopedDB.EXPECT().ListBankAccountsAtDate(userID, gomock.Any()).Return([]models.BankAccount{}, nil)
+	scopedDB.EXPECT().ClearForecasts(userID).Return(int64(0), nil)
+	scopedDB.EXPECT().ArchiveForecasts(userID).Return(nil)
+	scopedDB.EXPECT().ListForecasts(userID, gomock.Any()).Return([]models.Forecast{}, nil)
+
+	require.NoError(t, service.DeleteTransaction(context.Background(), userID, 1))
+	hasSwitched.Store(true)
+
+	require.Eventually(t, released.Load, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestScheduleForecastIfStale(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(99)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	service.EnableForecastScheduler(time.Hour)
+
+	user := models.User{ID: userID, Name: "Test User", Email: "test@example.com", CurrentOrganisationID: 500}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil).AnyTimes()
+
+	today := utils.GetTodayAsUTC()
+	calculatedToday := today.Add(time.Hour)
+	calculatedYesterday := today.Add(-time.Hour)
+	gomock.InOrder(
+		mockDB.EXPECT().ListForecasts(userID, int64(1)).Return([]models.Forecast{{UpdatedAt: &calculatedToday}}, nil),
+		mockDB.EXPECT().ListForecasts(userID, int64(1)).Return([]models.Forecast{{UpdatedAt: &calculatedYesterday}}, nil),
+	)
+
+	scheduled, err := service.ScheduleForecastIfStale(context.Background(), userID)
+	require.NoError(t, err)
+	require.False(t, scheduled)
+
+	scheduled, err = service.ScheduleForecastIfStale(context.Background(), userID)
+	require.NoError(t, err)
+	require.True(t, scheduled)
+	status, err := service.GetForecastStatus(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, models.ForecastStatusPending, status.Status)
+
+	// A pending recalculation is not scheduled again
+	scheduled, err = service.ScheduleForecastIfStale(context.Background(), userID)
+	require.NoError(t, err)
+	require.False(t, scheduled)
+}
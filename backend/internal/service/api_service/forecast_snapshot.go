@@ -0,0 +1,234 @@
+package api_service
+
+import (
+	"context"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"math"
+	"sort"
+	"strings"
+)
+
+func (a *APIService) ListForecastSnapshots(ctx context.Context, userID int64) ([]models.ForecastSnapshot, error) {
+	snapshots, err := a.dbService.ListForecastSnapshots(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(snapshots, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (a *APIService) GetForecastSnapshot(ctx context.Context, userID int64, snapshotID int64) (*models.ForecastSnapshot, error) {
+	snapshot, err := a.dbService.GetForecastSnapshot(userID, snapshotID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(snapshot); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.redactPayrollDetailsForUser(userID, snapshot.Details)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CreateForecastSnapshot runs the forecast and stores the result including the detail tree.
+// The live forecast is left untouched, the snapshot reflects the plan at this very moment.
+func (a *APIService) CreateForecastSnapshot(ctx context.Context, payload models.CreateForecastSnapshot, userID int64) (*models.ForecastSnapshot, error) {
+	result, err := a.buildForecast(ctx, userID, nil)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	forecasts, forecastDetails := result.toForecasts()
+
+	snapshotID, err := a.dbService.CreateForecastSnapshot(payload, forecasts, forecastDetails, result.openingBalance, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	snapshot, err := a.GetForecastSnapshot(ctx, userID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChange(ctx, userID, "forecast_snapshot", events.ActionCreated, snapshotID)
+	return snapshot, nil
+}
+
+func (a *APIService) DeleteForecastSnapshot(ctx context.Context, userID int64, snapshotID int64) error {
+	err := a.dbService.DeleteForecastSnapshot(userID, snapshotID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChange(ctx, userID, "forecast_snapshot", events.ActionDeleted, snapshotID)
+	return nil
+}
+
+// DiffForecastSnapshots compares two snapshots month by month and item by item
+func (a *APIService) DiffForecastSnapshots(ctx context.Context, userID int64, fromSnapshotID int64, toSnapshotID int64) (*models.ForecastSnapshotDiff, error) {
+	from, err := a.GetForecastSnapshot(ctx, userID, fromSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := a.GetForecastSnapshot(ctx, userID, toSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return diffForecastSnapshots(from, to), nil
+}
+
+// snapshotItem is a leaf of the detail tree, identified by its category and the names below it
+type snapshotItem struct {
+	category     string
+	name         string
+	relatedID    int64
+	relatedTable string
+	amount       int64
+}
+
+func diffForecastSnapshots(from *models.ForecastSnapshot, to *models.ForecastSnapshot) *models.ForecastSnapshotDiff {
+	fromMonths := snapshotMonths(from)
+	toMonths := snapshotMonths(to)
+	fromItems := snapshotItems(from)
+	toItems := snapshotItems(to)
+
+	monthKeys := make([]string, 0, len(toMonths))
+	for monthKey := range fromMonths {
+		monthKeys = append(monthKeys, monthKey)
+	}
+	for monthKey := range toMonths {
+		if _, exists := fromMonths[monthKey]; !exists {
+			monthKeys = append(monthKeys, monthKey)
+		}
+	}
+	sort.Strings(monthKeys)
+
+	diff := &models.ForecastSnapshotDiff{
+		From:   *from,
+		To:     *to,
+		Months: make([]models.ForecastSnapshotMonthDiff, 0, len(monthKeys)),
+	}
+	// The months are listed in the diff, there is no need to send both snapshots in full again
+	diff.From.Forecasts, diff.From.Details = []models.Forecast{}, []models.ForecastDatabaseDetails{}
+	diff.To.Forecasts, diff.To.Details = []models.Forecast{}, []models.ForecastDatabaseDetails{}
+
+	for _, monthKey := range monthKeys {
+		fromData, toData := fromMonths[monthKey], toMonths[monthKey]
+		diff.Months = append(diff.Months, models.ForecastSnapshotMonthDiff{
+			Month:          monthKey,
+			Revenue:        valueDiff(fromData.Revenue, toData.Revenue),
+			Expense:        valueDiff(fromData.Expense, toData.Expense),
+			Cashflow:       valueDiff(fromData.Cashflow, toData.Cashflow),
+			ClosingBalance: valueDiff(fromData.ClosingBalance, toData.ClosingBalance),
+			Items:          diffSnapshotItems(fromItems[monthKey], toItems[monthKey]),
+		})
+	}
+
+	return diff
+}
+
+func diffSnapshotItems(fromItems map[string]*snapshotItem, toItems map[string]*snapshotItem) []models.ForecastSnapshotItemDiff {
+	items := make([]models.ForecastSnapshotItemDiff, 0)
+	for key, fromItem := range fromItems {
+		toItem, exists := toItems[key]
+		if !exists {
+			items = append(items, itemDiff(fromItem, "removed", fromItem.amount, 0))
+			continue
+		}
+		if fromItem.amount != toItem.amount {
+			items = append(items, itemDiff(toItem, "changed", fromItem.amount, toItem.amount))
+		}
+	}
+	for key, toItem := range toItems {
+		if _, exists := fromItems[key]; !exists {
+			items = append(items, itemDiff(toItem, "added", 0, toItem.amount))
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		left, right := math.Abs(float64(items[i].Difference)), math.Abs(float64(items[j].Difference))
+		if left != right {
+			return left > right
+		}
+		if items[i].Category != items[j].Category {
+			return items[i].Category < items[j].Category
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+func itemDiff(item *snapshotItem, change string, from int64, to int64) models.ForecastSnapshotItemDiff {
+	return models.ForecastSnapshotItemDiff{
+		Category:     item.category,
+		Name:         item.name,
+		RelatedID:    item.relatedID,
+		RelatedTable: item.relatedTable,
+		Change:       change,
+		From:         from,
+		To:           to,
+		Difference:   to - from,
+	}
+}
+
+func valueDiff(from int64, to int64) models.ForecastValueDiff {
+	return models.ForecastValueDiff{From: from, To: to, Difference: to - from}
+}
+
+func snapshotMonths(snapshot *models.ForecastSnapshot) map[string]models.ForecastData {
+	months := make(map[string]models.ForecastData, len(snapshot.Forecasts))
+	for _, forecast := range snapshot.Forecasts {
+		months[forecast.Data.Month] = forecast.Data
+	}
+	return months
+}
+
+// snapshotItems returns the leaves of every month keyed by their path in the detail tree.
+// Revenue and expense are merged, so an item that changes its sign is still the same item.
+func snapshotItems(snapshot *models.ForecastSnapshot) map[string]map[string]*snapshotItem {
+	items := make(map[string]map[string]*snapshotItem, len(snapshot.Details))
+	for _, details := range snapshot.Details {
+		monthItems := make(map[string]*snapshotItem)
+		for _, category := range append(details.Revenue, details.Expense...) {
+			collectSnapshotItems(monthItems, category.Name, nil, category)
+		}
+		items[details.Month] = monthItems
+	}
+	return items
+}
+
+func collectSnapshotItems(items map[string]*snapshotItem, category string, path []string, node models.ForecastDetailRevenueExpense) {
+	if len(node.Children) > 0 {
+		for _, child := range node.Children {
+			collectSnapshotItems(items, category, append(path, child.Name), child)
+		}
+		return
+	}
+
+	name := strings.Join(path, " / ")
+	if name == "" {
+		name = node.Name
+	}
+	key := category + "\x00" + name
+	if items[key] == nil {
+		items[key] = &snapshotItem{
+			category:     category,
+			name:         name,
+			relatedID:    node.RelatedID,
+			relatedTable: node.RelatedTable,
+		}
+	}
+	items[key].amount += node.Amount
+}
@@ -0,0 +1,108 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestDiffForecastSnapshots_ExplainsChangesPerMonthAndItem(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	salaries := func(amounts map[string]int64) models.ForecastDetailRevenueExpense {
+		children := make([]models.ForecastDetailRevenueExpense, 0)
+		for _, name := range []string{"Anna", "Yves"} {
+			if amount, ok := amounts[name]; ok {
+				children = append(children, models.ForecastDetailRevenueExpense{
+					Name: name, Amount: amount, RelatedID: int64(len(name)), RelatedTable: utils.SalariesTableName,
+				})
+			}
+		}
+		return models.ForecastDetailRevenueExpense{Name: "Löhne", Children: children}
+	}
+	sales := models.ForecastDetailRevenueExpense{
+		Name: "Verkauf",
+		Children: []models.ForecastDetailRevenueExpense{
+			{Name: "Abo", Amount: 5000_00, RelatedID: 7, RelatedTable: utils.TransactionsTableName},
+		},
+	}
+
+	createdAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	from := &models.ForecastSnapshot{
+		ID: 1, Label: "Board Q1", OpeningBalance: 10000_00, CreatedAt: createdAt,
+		Forecasts: []models.Forecast{
+			{Data: models.ForecastData{Month: "2024-06", Revenue: 5000_00, Expense: -6000_00, Cashflow: -1000_00, ClosingBalance: 9000_00}},
+		},
+		Details: []models.ForecastDatabaseDetails{
+			{
+				Month:   "2024-06",
+				Revenue: []models.ForecastDetailRevenueExpense{sales},
+				Expense: []models.ForecastDetailRevenueExpense{salaries(map[string]int64{"Anna": -6000_00})},
+			},
+		},
+	}
+	to := &models.ForecastSnapshot{
+		ID: 2, Label: "Board Q2", OpeningBalance: 10000_00, CreatedAt: createdAt.AddDate(0, 3, 0),
+		Forecasts: []models.Forecast{
+			{Data: models.ForecastData{Month: "2024-06", Revenue: 5000_00, Expense: -19000_00, Cashflow: -14000_00, ClosingBalance: -4000_00}},
+			{Data: models.ForecastData{Month: "2024-07", Revenue: 0, Expense: -1000_00, Cashflow: -1000_00, ClosingBalance: -5000_00}},
+		},
+		Details: []models.ForecastDatabaseDetails{
+			{
+				Month:   "2024-06",
+				Revenue: []models.ForecastDetailRevenueExpense{sales},
+				Expense: []models.ForecastDetailRevenueExpense{salaries(map[string]int64{"Anna": -7000_00, "Yves": -12000_00})},
+			},
+			{
+				Month: "2024-07",
+				Expense: []models.ForecastDetailRevenueExpense{
+					{Name: "Mwst.", Children: []models.ForecastDetailRevenueExpense{{Name: "Mwst.", Amount: -1000_00, RelatedTable: "vat_settlement"}}},
+				},
+			},
+		},
+	}
+	mockDB.EXPECT().GetForecastSnapshot(userID, from.ID).Return(from, nil)
+	mockDB.EXPECT().GetForecastSnapshot(userID, to.ID).Return(to, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil).Times(2)
+
+	diff, err := service.DiffForecastSnapshots(context.Background(), userID, from.ID, to.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, "Board Q1", diff.From.Label)
+	require.Equal(t, "Board Q2", diff.To.Label)
+	require.Empty(t, diff.To.Forecasts)
+	require.Len(t, diff.Months, 2)
+
+	june := diff.Months[0]
+	require.Equal(t, "2024-06", june.Month)
+	require.Equal(t, models.ForecastValueDiff{From: -6000_00, To: -19000_00, Difference: -13000_00}, june.Expense)
+	require.Equal(t, int64(0), june.Revenue.Difference)
+	require.Equal(t, int64(-13000_00), june.ClosingBalance.Difference)
+	// The unchanged revenue is left out and the biggest driver comes first
+	require.Equal(t, []models.ForecastSnapshotItemDiff{
+		{Category: "Löhne", Name: "Yves", RelatedID: 4, RelatedTable: utils.SalariesTableName, Change: "added", From: 0, To: -12000_00, Difference: -12000_00},
+		{Category: "Löhne", Name: "Anna", RelatedID: 4, RelatedTable: utils.SalariesTableName, Change: "changed", From: -6000_00, To: -7000_00, Difference: -1000_00},
+	}, june.Items)
+
+	july := diff.Months[1]
+	require.Equal(t, "2024-07", july.Month)
+	require.Equal(t, models.ForecastValueDiff{From: 0, To: -1000_00, Difference: -1000_00}, july.Cashflow)
+	require.Len(t, july.Items, 1)
+	require.Equal(t, "Mwst.", july.Items[0].Category)
+	require.Equal(t, "added", july.Items[0].Change)
+}
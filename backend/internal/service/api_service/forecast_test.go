@@ -109,10 +109,13 @@ func TestCalculateForecast_SkipsDisabledTransactions(t *testing.T) {
 	mockDB.EXPECT().
 		ListFiatRates(baseCode).
 		Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().
+		ListOrganisationFiatRates(userID).
+		Return([]models.OrganisationFiatRate{}, nil)
 
 	mockDB.EXPECT().
-		ListForecastExclusions(userID, int64(1), utils.TransactionsTableName).
-		Return(map[string]bool{}, nil)
+		ListAllForecastExclusions(userID).
+		Return([]models.ForecastExclusionInfo{}, nil)
 
 	mockDB.EXPECT().
 		ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
@@ -122,10 +125,18 @@ func TestCalculateForecast_SkipsDisabledTransactions(t *testing.T) {
 		GetVatSetting(userID).
 		Return(nil, nil)
 
+	mockDB.EXPECT().
+		ListBankAccountsAtDate(userID, "2024-01-01").
+		Return([]models.BankAccount{}, nil)
+
 	mockDB.EXPECT().
 		ClearForecasts(userID).
 		Return(int64(0), nil)
 
+	mockDB.EXPECT().
+		ArchiveForecasts(userID).
+		Return(nil)
+
 	var capturedForecast models.CreateForecast
 	mockDB.EXPECT().
 		UpsertForecast(gomock.Any(), userID).
@@ -139,7 +150,7 @@ func TestCalculateForecast_SkipsDisabledTransactions(t *testing.T) {
 		Return(int64(0), nil)
 
 	mockDB.EXPECT().
-		ListForecasts(userID, int64(utils.GetTotalMonthsForMaxForecastYears())).
+		ListForecasts(userID, int64(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears))).
 		DoAndReturn(func(_ int64, _ int64) ([]models.Forecast, error) {
 			return []models.Forecast{
 				{
@@ -217,6 +228,9 @@ func TestCalculateForecast_SkipsDisabledSalariesOnly(t *testing.T) {
 	mockDB.EXPECT().
 		ListFiatRates(baseCode).
 		Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().
+		ListOrganisationFiatRates(userID).
+		Return([]models.OrganisationFiatRate{}, nil)
 
 	employee := models.Employee{
 		ID:   55,
@@ -276,17 +290,25 @@ func TestCalculateForecast_SkipsDisabledSalariesOnly(t *testing.T) {
 		Return([]models.Salary{activeSalary, disabledSalary}, int64(2), nil)
 
 	mockDB.EXPECT().
-		ListForecastExclusions(userID, activeSalary.ID, utils.SalariesTableName).
-		Return(map[string]bool{}, nil)
+		ListAllForecastExclusions(userID).
+		Return([]models.ForecastExclusionInfo{}, nil)
 
 	mockDB.EXPECT().
 		GetVatSetting(userID).
 		Return(nil, nil)
 
+	mockDB.EXPECT().
+		ListBankAccountsAtDate(userID, "2024-01-01").
+		Return([]models.BankAccount{}, nil)
+
 	mockDB.EXPECT().
 		ClearForecasts(userID).
 		Return(int64(0), nil)
 
+	mockDB.EXPECT().
+		ArchiveForecasts(userID).
+		Return(nil)
+
 	var capturedForecast models.CreateForecast
 	mockDB.EXPECT().
 		UpsertForecast(gomock.Any(), userID).
@@ -300,7 +322,7 @@ func TestCalculateForecast_SkipsDisabledSalariesOnly(t *testing.T) {
 		Return(int64(0), nil)
 
 	mockDB.EXPECT().
-		ListForecasts(userID, int64(utils.GetTotalMonthsForMaxForecastYears())).
+		ListForecasts(userID, int64(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears))).
 		DoAndReturn(func(_ int64, _ int64) ([]models.Forecast, error) {
 			return []models.Forecast{
 				{
@@ -379,6 +401,9 @@ func TestCalculateForecast_CountsBothSalaryCostsTwice(t *testing.T) {
 	mockDB.EXPECT().
 		ListFiatRates(baseCode).
 		Return([]models.FiatRate{}, nil)
+	mockDB.EXPECT().
+		ListOrganisationFiatRates(userID).
+		Return([]models.OrganisationFiatRate{}, nil)
 
 	employee := models.Employee{
 		ID:   55,
@@ -436,8 +461,8 @@ func TestCalculateForecast_CountsBothSalaryCostsTwice(t *testing.T) {
 	}
 
 	mockDB.EXPECT().
-		ListForecastExclusions(userID, activeSalary.ID, utils.SalariesTableName).
-		Return(map[string]bool{}, nil)
+		ListAllForecastExclusions(userID).
+		Return([]models.ForecastExclusionInfo{}, nil)
 
 	mockDB.EXPECT().
 		ListSalaryCosts(userID, activeSalary.ID, int64(1), int64(1000)).
@@ -455,18 +480,22 @@ func TestCalculateForecast_CountsBothSalaryCostsTwice(t *testing.T) {
 		}, nil).
 		AnyTimes()
 
-	mockDB.EXPECT().
-		ListForecastExclusions(userID, salaryCost.ID, utils.SalaryCostsTableName).
-		Return(map[string]bool{}, nil)
-
 	mockDB.EXPECT().
 		GetVatSetting(userID).
 		Return(nil, nil)
 
+	mockDB.EXPECT().
+		ListBankAccountsAtDate(userID, "2024-01-01").
+		Return([]models.BankAccount{}, nil)
+
 	mockDB.EXPECT().
 		ClearForecasts(userID).
 		Return(int64(0), nil)
 
+	mockDB.EXPECT().
+		ArchiveForecasts(userID).
+		Return(nil)
+
 	var capturedForecast models.CreateForecast
 	mockDB.EXPECT().
 		UpsertForecast(gomock.Any(), userID).
@@ -480,7 +509,7 @@ func TestCalculateForecast_CountsBothSalaryCostsTwice(t *testing.T) {
 		Return(int64(0), nil)
 
 	mockDB.EXPECT().
-		ListForecasts(userID, int64(utils.GetTotalMonthsForMaxForecastYears())).
+		ListForecasts(userID, int64(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears))).
 		DoAndReturn(func(_ int64, _ int64) ([]models.Forecast, error) {
 			return []models.Forecast{
 				{
@@ -547,6 +576,8 @@ func TestUpdateForecastExclusions_Success(t *testing.T) {
 			}, userID).
 			Return(int64(1), nil),
 	)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: 500}, nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
 
 	err := service.UpdateForecastExclusions(context.Background(), payload, userID)
 	require.NoError(t, err)
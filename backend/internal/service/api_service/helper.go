@@ -163,3 +163,32 @@ func lastDayOfMonth(t time.Time) int {
 	year, month := t.Year(), t.Month()
 	return time.Date(year, month+1, 0, 0, 0, 0, 0, t.Location()).Day()
 }
+
+// CalculateExtraPaymentDeductions returns the employee share of the percentage costs on an extra payment.
+// Costs on the salary apply their limits to the yearly base including the extra payments of that year, so
+// a capped cost only deducts from the part of the payment below the ceiling. Costs on other costs keep the
+// ratio they have on the regular salary.
+func (a *APIService) CalculateExtraPaymentDeductions(salary models.Salary, costs []models.SalaryCost, payment models.SalaryExtraPayment) uint64 {
+	if salary.Amount == 0 {
+		return 0
+	}
+	paymentDate := time.Time(payment.Date)
+	yearStart := time.Date(paymentDate.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearlyBase := salary.Amount*utils.CyclesPerYear(salary.Cycle) + salary.ExtraAmountBetween(yearStart, yearStart.AddDate(1, 0, -1))
+
+	var deductions uint64
+	for _, cost := range costs {
+		if cost.AmountType != "percentage" {
+			continue
+		}
+		if cost.DistributionType != models.SalaryCostDistributionEmployee && cost.DistributionType != models.SalaryCostDistributionBoth {
+			continue
+		}
+		if len(cost.BaseSalaryCostIDs) > 0 {
+			deductions += payment.Amount * cost.CalculatedAmount / salary.Amount
+			continue
+		}
+		deductions += cost.LimitBaseOfYear(payment.Amount, yearlyBase) * cost.Percentage(paymentDate) / 100_000
+	}
+	return min(deductions, payment.Amount)
+}
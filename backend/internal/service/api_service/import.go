@@ -0,0 +1,735 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/tabular"
+	"liquiswiss/pkg/utils"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// ErrImportCommitted marks attempts to commit an import a second time
+	ErrImportCommitted = errors.New("import has already been committed")
+	// ErrImportHasErrors marks commits of imports with rows that did not pass the preview
+	ErrImportHasErrors = errors.New("import contains rows with errors")
+)
+
+// The aliases are compared in lower case and accept the headers of the exports
+var transactionImportColumns = map[string][]string{
+	"name":        {"name", "bezeichnung"},
+	"category":    {"kategorie", "category"},
+	"type":        {"art", "typ", "type"},
+	"cycle":       {"zyklus", "cycle"},
+	"amount":      {"betrag", "amount"},
+	"currency":    {"währung", "waehrung", "currency"},
+	"vat":         {"mwst", "mwst.", "vat"},
+	"vatIncluded": {"mwst inkl.", "mwst inkl", "vat included", "vatincluded"},
+	"startDate":   {"start", "startdatum", "start date", "startdate"},
+	"endDate":     {"ende", "enddatum", "end date", "enddate"},
+	"employee":    {"mitarbeiter", "employee"},
+	"link":        {"link"},
+}
+
+var employeeImportColumns = map[string][]string{
+	"name":                {"mitarbeiter", "name", "employee"},
+	"fromDate":            {"von", "ab", "from", "from date", "fromdate"},
+	"toDate":              {"bis", "to", "to date", "todate"},
+	"cycle":               {"zyklus", "cycle"},
+	"hoursPerMonth":       {"stunden pro monat", "hours per month", "hourspermonth"},
+	"vacationDaysPerYear": {"ferientage", "vacation days", "vacationdaysperyear"},
+	"amount":              {"lohn", "salary", "amount"},
+	"currency":            {"währung", "waehrung", "currency"},
+	"isTermination":       {"austritt", "termination", "istermination"},
+}
+
+// importLookup resolves the names used in a file to the entities of the organisation
+type importLookup struct {
+	currency   string
+	categories map[string]models.Category
+	currencies map[string]models.Currency
+	vats       map[int64]models.Vat
+	employees  map[string]models.Employee
+}
+
+// importRecord gives access to the cells of a row by column name
+type importRecord struct {
+	cells   []string
+	columns map[string]int
+}
+
+func (r importRecord) get(column string) string {
+	index, ok := r.columns[column]
+	if !ok || index >= len(r.cells) {
+		return ""
+	}
+	return strings.TrimSpace(r.cells[index])
+}
+
+func (r importRecord) isEmpty() bool {
+	for _, cell := range r.cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// importRowErrors collects the errors of a row, keyed by field to skip follow-up validation errors
+type importRowErrors struct {
+	fields   map[string]bool
+	messages []string
+}
+
+func (e *importRowErrors) add(field string, message string) {
+	if e.fields == nil {
+		e.fields = make(map[string]bool)
+	}
+	if e.fields[field] {
+		return
+	}
+	e.fields[field] = true
+	e.messages = append(e.messages, fmt.Sprintf("%s: %s", field, message))
+}
+
+// addValidation appends the validator errors of all fields that did not fail to parse before
+func (e *importRowErrors) addValidation(err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		e.add("row", err.Error())
+		return
+	}
+	for _, fieldError := range validationErrors {
+		field := strings.ToLower(fieldError.Field()[:1]) + fieldError.Field()[1:]
+		e.add(field, importValidationMessage(fieldError.Tag()))
+	}
+}
+
+func (e *importRowErrors) list() []string {
+	if e.messages == nil {
+		return []string{}
+	}
+	return e.messages
+}
+
+func (a *APIService) ListImports(ctx context.Context, userID int64) ([]models.Import, error) {
+	imports, err := a.dbService.ListImports(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(imports, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return imports, nil
+}
+
+// GetImport removes the salaries from the rows of employee imports for members without payroll clearance
+func (a *APIService) GetImport(ctx context.Context, userID int64, importID int64) (*models.Import, error) {
+	fileImport, err := a.getImport(userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if fileImport.Entity == models.ImportEntityEmployees {
+		allowed, err := a.hasPayrollAccess(userID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			for i := range fileImport.Employees {
+				fileImport.Employees[i].Salary = nil
+				fileImport.Employees[i].Currency = nil
+			}
+		}
+	}
+	return fileImport, nil
+}
+
+func (a *APIService) getImport(userID int64, importID int64) (*models.Import, error) {
+	fileImport, err := a.dbService.GetImport(userID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(fileImport); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return fileImport, nil
+}
+
+// PreviewTransactionImport parses the file into transactions and validates every row like a single
+// create would. Nothing is written to the organisation until the import is committed.
+func (a *APIService) PreviewTransactionImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error) {
+	records, columns, err := readImportFile(data, transactionImportColumns, "name", "category", "amount", "startDate")
+	if err != nil {
+		return nil, err
+	}
+	lookup, err := a.loadImportLookup(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := utils.GetValidator()
+	rows := make([]models.ImportTransactionRow, 0, len(records)-1)
+	errorCount := int64(0)
+	for index, cells := range records[1:] {
+		record := importRecord{cells: cells, columns: columns}
+		if record.isEmpty() {
+			continue
+		}
+		rowErrors := importRowErrors{}
+		row := lookup.parseTransactionRow(record, &rowErrors)
+		row.Line = index + 2
+		if err := validator.Struct(row.Transaction); err != nil {
+			rowErrors.addValidation(err)
+		}
+		row.Errors = rowErrors.list()
+		if len(row.Errors) > 0 {
+			errorCount++
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("invalid file: no rows")
+	}
+
+	return a.createImport(ctx, userID, models.CreateImport{
+		Entity:       models.ImportEntityTransactions,
+		FileName:     fileName,
+		Transactions: rows,
+		RowCount:     int64(len(rows)),
+		ErrorCount:   errorCount,
+	})
+}
+
+// PreviewEmployeeImport parses the file into employees and their salaries, one salary per row.
+// Employees are matched by name, rows without a from date only create the employee.
+func (a *APIService) PreviewEmployeeImport(ctx context.Context, userID int64, fileName string, data []byte) (*models.Import, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	records, columns, err := readImportFile(data, employeeImportColumns, "name")
+	if err != nil {
+		return nil, err
+	}
+	lookup, err := a.loadImportLookup(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := utils.GetValidator()
+	// The salaries of an employee must not share a from date, neither in the file nor in the database
+	fromDates := make(map[string]map[string]bool)
+	rows := make([]models.ImportEmployeeRow, 0, len(records)-1)
+	errorCount := int64(0)
+	for index, cells := range records[1:] {
+		record := importRecord{cells: cells, columns: columns}
+		if record.isEmpty() {
+			continue
+		}
+		rowErrors := importRowErrors{}
+		row := models.ImportEmployeeRow{Line: index + 2, Name: record.get("name")}
+		if err := validator.Struct(models.CreateEmployee{Name: row.Name}); err != nil {
+			rowErrors.addValidation(err)
+		}
+		key := strings.ToLower(row.Name)
+		if employee, ok := lookup.employees[key]; ok {
+			employeeID := employee.ID
+			row.EmployeeID = &employeeID
+		}
+		if _, ok := fromDates[key]; !ok {
+			fromDates[key] = make(map[string]bool)
+			if row.EmployeeID != nil {
+				salaries, _, err := a.dbService.ListSalaries(userID, *row.EmployeeID, 1, 100000)
+				if err != nil {
+					logger.Logger.Error(err)
+					return nil, err
+				}
+				for _, salary := range salaries {
+					fromDates[key][salary.FromDate.ToString()] = true
+				}
+			}
+		}
+
+		salary, currency := lookup.parseSalary(record, &rowErrors)
+		if salary != nil {
+			row.Salary = salary
+			row.Currency = currency
+			if fromDates[key][salary.FromDate] {
+				rowErrors.add("fromDate", "a salary with this date already exists")
+			}
+			fromDates[key][salary.FromDate] = true
+			if err := validator.Struct(salary); err != nil {
+				rowErrors.addValidation(err)
+			}
+		}
+
+		row.Errors = rowErrors.list()
+		if len(row.Errors) > 0 {
+			errorCount++
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("invalid file: no rows")
+	}
+
+	return a.createImport(ctx, userID, models.CreateImport{
+		Entity:     models.ImportEntityEmployees,
+		FileName:   fileName,
+		Employees:  rows,
+		RowCount:   int64(len(rows)),
+		ErrorCount: errorCount,
+	})
+}
+
+func (a *APIService) createImport(ctx context.Context, userID int64, payload models.CreateImport) (*models.Import, error) {
+	validator := utils.GetValidator()
+	if err := validator.Struct(payload); err != nil {
+		return nil, err
+	}
+	importID, err := a.dbService.CreateImport(payload, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	fileImport, err := a.GetImport(ctx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChange(ctx, userID, "import", events.ActionCreated, importID)
+	return fileImport, nil
+}
+
+// CommitImport writes all rows of a previewed import in one database transaction, either every
+// row is created or none. Imports with errors have to be corrected and uploaded again.
+func (a *APIService) CommitImport(ctx context.Context, userID int64, importID int64) (*models.Import, error) {
+	fileImport, err := a.getImport(userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if fileImport.Entity == models.ImportEntityEmployees {
+		if err := a.requirePayrollAccess(userID); err != nil {
+			return nil, err
+		}
+	}
+	if fileImport.Status == models.ImportStatusCommitted {
+		return nil, ErrImportCommitted
+	}
+	if fileImport.ErrorCount > 0 {
+		return nil, ErrImportHasErrors
+	}
+
+	switch fileImport.Entity {
+	case models.ImportEntityTransactions:
+		payloads := make([]models.CreateTransaction, 0, len(fileImport.Transactions))
+		for _, row := range fileImport.Transactions {
+			payloads = append(payloads, row.Transaction)
+		}
+		transactionIDs, err := a.dbService.CommitTransactionImport(userID, importID, payloads)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		for _, transactionID := range transactionIDs {
+			a.notifyChange(ctx, userID, "transaction", events.ActionCreated, transactionID)
+		}
+	case models.ImportEntityEmployees:
+		results, err := a.dbService.CommitEmployeeImport(userID, importID, groupImportEmployees(fileImport.Employees))
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		refreshed := make(map[int64]bool)
+		for _, result := range results {
+			for _, salaryID := range append(result.SalaryIDs, result.AdjustedSalaryIDs...) {
+				if refreshed[salaryID] {
+					continue
+				}
+				refreshed[salaryID] = true
+				err = a.dbService.RefreshSalaryCostDetails(userID, salaryID)
+				if err != nil {
+					logger.Logger.Error(err)
+					return nil, err
+				}
+			}
+		}
+		for _, result := range results {
+			if result.Created {
+				a.notifyChange(ctx, userID, "employee", events.ActionCreated, result.EmployeeID)
+			}
+			for _, salaryID := range result.SalaryIDs {
+				a.notifyChangeWithParent(ctx, userID, "salary", events.ActionCreated, salaryID, result.EmployeeID)
+			}
+		}
+	}
+
+	// Recalculate Forecast
+	err = a.scheduleForecast(ctx, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	fileImport, err = a.GetImport(ctx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChange(ctx, userID, "import", events.ActionUpdated, importID)
+	return fileImport, nil
+}
+
+func (a *APIService) DeleteImport(ctx context.Context, userID int64, importID int64) error {
+	err := a.dbService.DeleteImport(userID, importID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChange(ctx, userID, "import", events.ActionDeleted, importID)
+	return nil
+}
+
+func (a *APIService) loadImportLookup(ctx context.Context, userID int64) (*importLookup, error) {
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	lookup := &importLookup{
+		currency:   *organisation.Currency.Code,
+		categories: make(map[string]models.Category),
+		currencies: make(map[string]models.Currency),
+		vats:       make(map[int64]models.Vat),
+		employees:  make(map[string]models.Employee),
+	}
+
+	categories, _, err := a.dbService.ListCategories(userID, 1, 100000)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	for _, category := range categories {
+		lookup.categories[strings.ToLower(category.Name)] = category
+	}
+	currencies, err := a.dbService.ListCurrencies(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	for _, currency := range currencies {
+		if currency.Code != nil {
+			lookup.currencies[strings.ToUpper(*currency.Code)] = currency
+		}
+	}
+	vats, err := a.dbService.ListVats(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	for _, vat := range vats {
+		lookup.vats[vat.Value] = vat
+	}
+	employees, _, err := a.dbService.ListEmployees(userID, 1, 100000, "name", "ASC", "", false)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	for _, employee := range employees {
+		lookup.employees[strings.ToLower(employee.Name)] = employee
+	}
+
+	return lookup, nil
+}
+
+// parseTransactionRow turns a record into a CreateTransaction, the validation is left to the caller
+func (l *importLookup) parseTransactionRow(record importRecord, rowErrors *importRowErrors) models.ImportTransactionRow {
+	transaction := models.CreateTransaction{Name: record.get("name")}
+
+	if link := record.get("link"); link != "" {
+		transaction.Link = &link
+	}
+
+	if value := record.get("amount"); value != "" {
+		amount, err := tabular.ParseAmount(value)
+		if err != nil {
+			rowErrors.add("amount", "is not a valid amount")
+		}
+		transaction.Amount = amount
+	}
+
+	if value := record.get("cycle"); value != "" {
+		cycle, ok := parseImportCycle(value)
+		if !ok {
+			rowErrors.add("cycle", fmt.Sprintf("unknown cycle %q", value))
+		} else if cycle != utils.CycleOnce {
+			transaction.Cycle = &cycle
+		}
+	}
+	switch strings.ToLower(record.get("type")) {
+	case "":
+		// Without a type a cycle makes the transaction repeating
+		transaction.Type = "single"
+		if transaction.Cycle != nil {
+			transaction.Type = "repeating"
+		}
+	case "einmalig", "single":
+		transaction.Type = "single"
+	case "wiederkehrend", "repeating":
+		transaction.Type = "repeating"
+	default:
+		rowErrors.add("type", fmt.Sprintf("unknown type %q", record.get("type")))
+	}
+	if transaction.Type == "single" {
+		transaction.Cycle = nil
+	}
+
+	if value := record.get("startDate"); value != "" {
+		startDate, err := tabular.ParseDate(value)
+		if err != nil {
+			rowErrors.add("startDate", "is not a valid date")
+		} else {
+			transaction.StartDate = startDate.Format(utils.InternalDateFormat)
+		}
+	}
+	if value := record.get("endDate"); value != "" && transaction.Type == "repeating" {
+		endDate, err := tabular.ParseDate(value)
+		if err != nil {
+			rowErrors.add("endDate", "is not a valid date")
+		} else {
+			formatted := endDate.Format(utils.InternalDateFormat)
+			transaction.EndDate = &formatted
+		}
+	}
+
+	row := models.ImportTransactionRow{}
+	if value := record.get("category"); value != "" {
+		if category, ok := l.categories[strings.ToLower(value)]; ok {
+			transaction.Category = category.ID
+			row.Category = &category.Name
+		} else {
+			rowErrors.add("category", fmt.Sprintf("unknown category %q", value))
+		}
+	}
+
+	currencyCode := strings.ToUpper(record.get("currency"))
+	if currencyCode == "" {
+		currencyCode = l.currency
+	}
+	if currency, ok := l.currencies[currencyCode]; ok && currency.ID != nil {
+		transaction.Currency = *currency.ID
+		row.Currency = currency.Code
+	} else {
+		rowErrors.add("currency", fmt.Sprintf("unknown currency %q", currencyCode))
+	}
+
+	if value := record.get("vat"); value != "" {
+		vat, included, err := l.parseVat(value, record.get("vatIncluded"))
+		if err != nil {
+			rowErrors.add("vat", err.Error())
+		} else {
+			transaction.Vat = &vat.ID
+			transaction.VatIncluded = included
+			row.Vat = &vat.FormattedValue
+		}
+	}
+
+	if value := record.get("employee"); value != "" {
+		if employee, ok := l.employees[strings.ToLower(value)]; ok {
+			transaction.Employee = &employee.ID
+			row.Employee = &employee.Name
+		} else {
+			rowErrors.add("employee", fmt.Sprintf("unknown employee %q", value))
+		}
+	}
+
+	row.Transaction = transaction
+	return row
+}
+
+// parseVat accepts the exported format like "8.1% inkl." as well as a plain rate with a separate inclusion column
+func (l *importLookup) parseVat(value string, includedValue string) (models.Vat, bool, error) {
+	normalized := strings.ToLower(value)
+	included := parseImportBool(includedValue)
+	switch {
+	case strings.Contains(normalized, "inkl"), strings.Contains(normalized, "incl"):
+		included = true
+	case strings.Contains(normalized, "exkl"), strings.Contains(normalized, "excl"):
+		included = false
+	}
+	rate, _, _ := strings.Cut(normalized, "%")
+	if fields := strings.Fields(rate); len(fields) > 0 {
+		rate = fields[0]
+	}
+	// The VAT value is stored in hundredths of a percent just like amounts in cents
+	rateValue, err := tabular.ParseAmount(rate)
+	if err != nil {
+		return models.Vat{}, false, fmt.Errorf("is not a valid rate")
+	}
+	vat, ok := l.vats[rateValue]
+	if !ok {
+		return models.Vat{}, false, fmt.Errorf("unknown rate %q", value)
+	}
+	return vat, included, nil
+}
+
+// parseSalary returns nil if the record only names the employee
+func (l *importLookup) parseSalary(record importRecord, rowErrors *importRowErrors) (*models.CreateSalary, *string) {
+	hasSalary := false
+	for _, column := range []string{"fromDate", "toDate", "amount", "hoursPerMonth", "vacationDaysPerYear", "isTermination"} {
+		if record.get(column) != "" {
+			hasSalary = true
+		}
+	}
+	if !hasSalary {
+		return nil, nil
+	}
+
+	salary := models.CreateSalary{Cycle: utils.CycleMonthly}
+	salary.IsTermination = parseImportBool(record.get("isTermination"))
+
+	if value := record.get("fromDate"); value != "" {
+		fromDate, err := tabular.ParseDate(value)
+		if err != nil {
+			rowErrors.add("fromDate", "is not a valid date")
+		} else {
+			salary.FromDate = fromDate.Format(utils.InternalDateFormat)
+		}
+	}
+	if value := record.get("toDate"); value != "" && !salary.IsTermination {
+		toDate, err := tabular.ParseDate(value)
+		if err != nil {
+			rowErrors.add("toDate", "is not a valid date")
+		} else {
+			formatted := toDate.Format(utils.InternalDateFormat)
+			salary.ToDate = &formatted
+		}
+	}
+	if value := record.get("cycle"); value != "" {
+		cycle, ok := parseImportCycle(value)
+		if !ok {
+			rowErrors.add("cycle", fmt.Sprintf("unknown cycle %q", value))
+		} else {
+			salary.Cycle = cycle
+		}
+	}
+	if value := record.get("amount"); value != "" {
+		amount, err := tabular.ParseAmount(value)
+		if err != nil || amount < 0 {
+			rowErrors.add("amount", "is not a valid amount")
+		} else {
+			salary.Amount = uint64(amount)
+		}
+	}
+	if value := record.get("hoursPerMonth"); value != "" {
+		hours, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			rowErrors.add("hoursPerMonth", "is not a valid number")
+		}
+		salary.HoursPerMonth = uint16(hours)
+	}
+	if value := record.get("vacationDaysPerYear"); value != "" {
+		days, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			rowErrors.add("vacationDaysPerYear", "is not a valid number")
+		}
+		salary.VacationDaysPerYear = uint16(days)
+	}
+
+	currencyCode := strings.ToUpper(record.get("currency"))
+	if currencyCode == "" {
+		currencyCode = l.currency
+	}
+	currency, ok := l.currencies[currencyCode]
+	if !ok || currency.ID == nil {
+		rowErrors.add("currencyID", fmt.Sprintf("unknown currency %q", currencyCode))
+		return &salary, nil
+	}
+	salary.CurrencyID = *currency.ID
+	return &salary, currency.Code
+}
+
+// groupImportEmployees merges the rows of the same employee while keeping the order of the file
+func groupImportEmployees(rows []models.ImportEmployeeRow) []models.ImportEmployee {
+	employees := []models.ImportEmployee{}
+	indexes := make(map[string]int)
+	for _, row := range rows {
+		key := strings.ToLower(row.Name)
+		index, ok := indexes[key]
+		if !ok {
+			index = len(employees)
+			indexes[key] = index
+			employees = append(employees, models.ImportEmployee{
+				EmployeeID: row.EmployeeID,
+				Name:       row.Name,
+				Salaries:   []models.CreateSalary{},
+			})
+		}
+		if row.Salary != nil {
+			employees[index].Salaries = append(employees[index].Salaries, *row.Salary)
+		}
+	}
+	return employees
+}
+
+// readImportFile returns the records of the file including the header along with the mapped columns
+func readImportFile(data []byte, aliases map[string][]string, required ...string) ([][]string, map[string]int, error) {
+	records, err := tabular.Read(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) < 2 {
+		return nil, nil, fmt.Errorf("invalid file: no rows")
+	}
+	columns := tabular.MapColumns(records[0], aliases)
+	for _, column := range required {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("invalid file: missing column %s", column)
+		}
+	}
+	return records, columns, nil
+}
+
+// parseImportCycle accepts the internal cycle names as well as the German ones of the exports
+func parseImportCycle(value string) (string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for cycle, name := range exportCycleNames {
+		if normalized == cycle || normalized == strings.ToLower(name) {
+			return cycle, true
+		}
+	}
+	return "", false
+}
+
+func parseImportBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "ja", "yes", "true", "1", "x":
+		return true
+	}
+	return false
+}
+
+func importValidationMessage(tag string) string {
+	switch tag {
+	case "required":
+		return "is required"
+	case "max":
+		return "is too long"
+	case "cycleRequiredIfRepeating":
+		return "repeating transactions need a cycle"
+	case "endDateGTEStartDate":
+		return "must not be before the start date"
+	case "fromDateGTEToDate":
+		return "must not be before the from date"
+	default:
+		return "is invalid"
+	}
+}
@@ -0,0 +1,211 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestPreviewTransactionImport_ValidatesAndMatchesRows(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	importID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	eurCode := "EUR"
+	chfID := int64(1)
+	eurID := int64(2)
+	chf := models.Currency{ID: &chfID, Code: &chfCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 400,
+		Currency:              chf,
+	}
+	// The second call resolves the organisation of the audit log entry
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil).Times(2)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).
+		Return(&models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: chf}, nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
+	mockDB.EXPECT().ListCategories(userID, int64(1), int64(100000)).
+		Return([]models.Category{{ID: 10, Name: "Miete"}, {ID: 11, Name: "Umsatz"}}, int64(2), nil)
+	mockDB.EXPECT().ListCurrencies(userID).
+		Return([]models.Currency{chf, {ID: &eurID, Code: &eurCode}}, nil)
+	mockDB.EXPECT().ListVats(userID).
+		Return([]models.Vat{{ID: 3, Value: 810, FormattedValue: "8.1%"}}, nil)
+	mockDB.EXPECT().ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
+		Return([]models.Employee{{ID: 5, Name: "Anna Muster"}}, int64(1), nil)
+
+	var created models.CreateImport
+	mockDB.EXPECT().CreateImport(gomock.Any(), userID).
+		DoAndReturn(func(payload models.CreateImport, _ int64) (int64, error) {
+			created = payload
+			return importID, nil
+		})
+	mockDB.EXPECT().GetImport(userID, importID).
+		DoAndReturn(func(_ int64, _ int64) (*models.Import, error) {
+			return &models.Import{
+				ID:           importID,
+				Entity:       created.Entity,
+				FileName:     created.FileName,
+				Status:       models.ImportStatusPreview,
+				RowCount:     created.RowCount,
+				ErrorCount:   created.ErrorCount,
+				CreatedAt:    time.Now(),
+				Transactions: created.Transactions,
+			}, nil
+		})
+
+	file := []byte("Name;Kategorie;Art;Zyklus;Betrag;Währung;MWST;Start;Ende;Mitarbeiter\n" +
+		"Büro;miete;Wiederkehrend;Monatlich;-1'500.00;;8.1% inkl.;01.02.2025;31.12.2025;\n" +
+		"Beratung;Umsatz;Einmalig;;2500,50;eur;8.1% exkl.;2025-03-15;;anna muster\n" +
+		"\n" +
+		"Lizenz;Software;Wiederkehrend;;-99.00;;;01.04.2025;01.03.2025;\n" +
+		";Miete;Einmalig;;abc;USD;7.7%;morgen;;Niemand\n")
+
+	fileImport, err := service.PreviewTransactionImport(context.Background(), userID, "plan.csv", file)
+	require.NoError(t, err)
+	require.Equal(t, models.ImportEntityTransactions, fileImport.Entity)
+	require.Equal(t, int64(4), fileImport.RowCount)
+	require.Equal(t, int64(2), fileImport.ErrorCount)
+
+	rows := fileImport.Transactions
+	require.Len(t, rows, 4)
+
+	monthly := utils.CycleMonthly
+	endDate := "2025-12-31"
+	vatID := int64(3)
+	require.Equal(t, 2, rows[0].Line)
+	require.Empty(t, rows[0].Errors)
+	require.Equal(t, models.CreateTransaction{
+		Name:        "Büro",
+		Amount:      -1500_00,
+		Cycle:       &monthly,
+		Type:        "repeating",
+		StartDate:   "2025-02-01",
+		EndDate:     &endDate,
+		Category:    10,
+		Currency:    chfID,
+		Vat:         &vatID,
+		VatIncluded: true,
+	}, rows[0].Transaction)
+	require.Equal(t, "Miete", *rows[0].Category)
+	require.Equal(t, "8.1%", *rows[0].Vat)
+
+	employeeID := int64(5)
+	require.Empty(t, rows[1].Errors)
+	require.Equal(t, models.CreateTransaction{
+		Name:      "Beratung",
+		Amount:    2500_50,
+		Type:      "single",
+		StartDate: "2025-03-15",
+		Category:  11,
+		Currency:  eurID,
+		Vat:       &vatID,
+		Employee:  &employeeID,
+	}, rows[1].Transaction)
+
+	// Empty lines are skipped
+	require.Equal(t, 4, rows[2].Line)
+	require.ElementsMatch(t, []string{
+		`category: unknown category "Software"`,
+		"type: repeating transactions need a cycle",
+		"endDate: must not be before the start date",
+	}, rows[2].Errors)
+
+	require.ElementsMatch(t, []string{
+		"name: is required",
+		"amount: is not a valid amount",
+		`currency: unknown currency "USD"`,
+		`vat: unknown rate "7.7%"`,
+		"startDate: is not a valid date",
+		`employee: unknown employee "Niemand"`,
+	}, rows[3].Errors)
+}
+
+func TestCommitImport_RejectsInvalidImports(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetImport(userID, int64(1)).Return(&models.Import{
+		ID:         1,
+		Entity:     models.ImportEntityTransactions,
+		Status:     models.ImportStatusPreview,
+		RowCount:   2,
+		ErrorCount: 1,
+	}, nil)
+	mockDB.EXPECT().GetImport(userID, int64(2)).Return(&models.Import{
+		ID:       2,
+		Entity:   models.ImportEntityTransactions,
+		Status:   models.ImportStatusCommitted,
+		RowCount: 2,
+	}, nil)
+
+	_, err := service.CommitImport(context.Background(), userID, 1)
+	require.ErrorIs(t, err, api_service.ErrImportHasErrors)
+
+	_, err = service.CommitImport(context.Background(), userID, 2)
+	require.ErrorIs(t, err, api_service.ErrImportCommitted)
+}
+
+func TestEmployeeImport_RequiresPayrollAccess(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chf := "CHF"
+	monthly := utils.CycleMonthly
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "editor", Confidential: true}, nil).Times(3)
+	mockDB.EXPECT().GetImport(userID, int64(1)).Return(&models.Import{
+		ID:       1,
+		Entity:   models.ImportEntityEmployees,
+		Status:   models.ImportStatusPreview,
+		RowCount: 1,
+		Employees: []models.ImportEmployeeRow{{
+			Line:     2,
+			Name:     "Anna Muster",
+			Salary:   &models.CreateSalary{Amount: 6000_00, Cycle: monthly, CurrencyID: 1, FromDate: "2025-01-01"},
+			Currency: &chf,
+		}},
+	}, nil).Times(2)
+
+	_, err := service.PreviewEmployeeImport(context.Background(), userID, "team.csv", []byte("Name;Lohn\nAnna Muster;6000\n"))
+	require.ErrorIs(t, err, api_service.ErrPayrollConfidential)
+
+	// The rows stay visible without the salaries
+	fileImport, err := service.GetImport(context.Background(), userID, 1)
+	require.NoError(t, err)
+	require.Len(t, fileImport.Employees, 1)
+	require.Equal(t, "Anna Muster", fileImport.Employees[0].Name)
+	require.Nil(t, fileImport.Employees[0].Salary)
+	require.Nil(t, fileImport.Employees[0].Currency)
+
+	_, err = service.CommitImport(context.Background(), userID, 1)
+	require.ErrorIs(t, err, api_service.ErrPayrollConfidential)
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"liquiswiss/config"
 	"liquiswiss/internal/events"
-	"liquiswiss/pkg/auth"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
 	"time"
@@ -379,8 +378,8 @@ func (a *APIService) AcceptInvitation(ctx context.Context, payload models.Accept
 	}
 
 	// Create default permissions based on role
-	canView, canEdit, canDelete := getPermissionsForRole(invitation.Role)
-	err = a.dbService.UpsertMemberPermission(userID, invitation.OrganisationID, canView, canEdit, canDelete)
+	permission := models.DefaultPermissionForRole(invitation.Role)
+	err = a.dbService.UpsertMemberPermission(userID, invitation.OrganisationID, permission.CanView, permission.CanEdit, permission.CanDelete)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, nil, nil, nil, nil, err
@@ -404,49 +403,9 @@ func (a *APIService) AcceptInvitation(ctx context.Context, payload models.Accept
 	a.notifyOrganisationChange(ctx, userID, invitation.OrganisationID, "invitation", events.ActionDeleted, invitation.ID)
 	a.notifyOrganisationChange(ctx, userID, invitation.OrganisationID, "member", events.ActionCreated, userID)
 
-	// Get user profile
-	user, err := a.dbService.GetProfile(userID)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	// Generate tokens
-	accessToken, accessExpirationTime, _, err := auth.GenerateAccessToken(*user)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	refreshToken, tokenId, refreshExpirationTime, err := auth.GenerateRefreshToken(*user)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	// Store the refresh token in the database
-	err = a.dbService.StoreRefreshTokenID(userID, tokenId, refreshExpirationTime, deviceName)
-	if err != nil {
-		logger.Logger.Error(err)
-		return nil, nil, nil, nil, nil, err
-	}
-
-	return user, &accessToken, &accessExpirationTime, &refreshToken, &refreshExpirationTime, nil
+	return a.issueLoginSession(ctx, userID, deviceName, "")
 }
 
 func (a *APIService) hasInvitingPermission(role string) bool {
 	return role == "owner" || role == "admin"
 }
-
-func getPermissionsForRole(role string) (canView, canEdit, canDelete bool) {
-	switch role {
-	case "admin":
-		return true, true, true
-	case "editor":
-		return true, true, false
-	case "read-only":
-		return true, false, false
-	default:
-		return true, false, false
-	}
-}
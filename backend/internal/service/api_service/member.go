@@ -2,7 +2,6 @@ package api_service
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"liquiswiss/internal/events"
 	"liquiswiss/pkg/logger"
@@ -25,13 +24,18 @@ func (a *APIService) ListOrganisationMembers(ctx context.Context, userID int64,
 
 	// Attach permissions to members
 	for i := range members {
-		permission, err := a.dbService.GetMemberPermission(members[i].UserID, organisationID)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		permissions, err := a.dbService.ListMemberPermissions(members[i].UserID, organisationID)
+		if err != nil {
 			logger.Logger.Error(err)
 			return nil, err
 		}
-		if permission != nil {
-			members[i].Permission = permission
+		members[i].EntityPermissions = []models.MemberPermission{}
+		for _, permission := range permissions {
+			if permission.EntityType == nil {
+				members[i].Permission = &permission
+				continue
+			}
+			members[i].EntityPermissions = append(members[i].EntityPermissions, permission)
 		}
 	}
 
@@ -46,16 +50,16 @@ func (a *APIService) UpdateOrganisationMember(ctx context.Context, payload model
 		return err
 	}
 
-	// Only owner can update members
-	if organisation.Role != "owner" {
-		err = errors.New("permission denied")
+	// Get the member
+	member, err := a.dbService.GetMember(organisationID, memberUserID)
+	if err != nil {
 		logger.Logger.Error(err)
 		return err
 	}
 
-	// Get the member
-	member, err := a.dbService.GetMember(organisationID, memberUserID)
-	if err != nil {
+	// Only owners can change roles, admins may manage the permissions of editors and read-only members
+	if !a.canManageMember(organisation.Role, member.Role, payload.Role != nil) {
+		err = errors.New("permission denied")
 		logger.Logger.Error(err)
 		return err
 	}
@@ -82,38 +86,52 @@ func (a *APIService) UpdateOrganisationMember(ctx context.Context, payload model
 			logger.Logger.Error(err)
 			return err
 		}
+		member.Role = *payload.Role
 	}
 
-	// Update permissions if provided
-	if payload.CanView != nil || payload.CanEdit != nil || payload.CanDelete != nil {
-		// Get current permissions or use defaults
-		currentPerm, err := a.dbService.GetMemberPermission(memberUserID, organisationID)
-		canView := true
-		canEdit := false
-		canDelete := false
-
-		if err == nil && currentPerm != nil {
-			canView = currentPerm.CanView
-			canEdit = currentPerm.CanEdit
-			canDelete = currentPerm.CanDelete
+	if payload.PayrollClearance != nil {
+		err = a.dbService.UpdateMemberPayrollClearance(organisationID, memberUserID, *payload.PayrollClearance)
+		if err != nil {
+			logger.Logger.Error(err)
+			return err
 		}
+	}
 
-		// Override with provided values
-		if payload.CanView != nil {
-			canView = *payload.CanView
-		}
-		if payload.CanEdit != nil {
-			canEdit = *payload.CanEdit
+	// A new role resets the global permission to its defaults unless it is set explicitly
+	if payload.Role != nil || payload.CanView != nil || payload.CanEdit != nil || payload.CanDelete != nil {
+		// Get current permissions or use defaults
+		permission := models.DefaultPermissionForRole(member.Role)
+		if payload.Role == nil {
+			currentPerm, err := a.dbService.GetMemberPermission(memberUserID, organisationID)
+			if err == nil && currentPerm != nil {
+				permission = models.EffectivePermission{CanView: currentPerm.CanView, CanEdit: currentPerm.CanEdit, CanDelete: currentPerm.CanDelete}
+			}
 		}
-		if payload.CanDelete != nil {
-			canDelete = *payload.CanDelete
+		applyPermissionFlags(&permission, payload.CanView, payload.CanEdit, payload.CanDelete)
+
+		err = a.dbService.UpsertMemberPermission(memberUserID, organisationID, permission.CanView, permission.CanEdit, permission.CanDelete)
+		if err != nil {
+			logger.Logger.Error(err)
+			return err
 		}
+	}
 
-		err = a.dbService.UpsertMemberPermission(memberUserID, organisationID, canView, canEdit, canDelete)
+	// Entity permissions start from what the member currently resolves to
+	if len(payload.EntityPermissions) > 0 {
+		permissions, err := a.dbService.ListMemberPermissions(memberUserID, organisationID)
 		if err != nil {
 			logger.Logger.Error(err)
 			return err
 		}
+		for _, entityPermission := range payload.EntityPermissions {
+			permission := models.ResolvePermission(member.Role, permissions, entityPermission.EntityType)
+			applyPermissionFlags(&permission, entityPermission.CanView, entityPermission.CanEdit, entityPermission.CanDelete)
+			err = a.dbService.UpsertMemberEntityPermission(memberUserID, organisationID, entityPermission.EntityType, permission.CanView, permission.CanEdit, permission.CanDelete)
+			if err != nil {
+				logger.Logger.Error(err)
+				return err
+			}
+		}
 	}
 
 	// Role/permission changed: drop the member's streams so they re-authenticate
@@ -122,6 +140,86 @@ func (a *APIService) UpdateOrganisationMember(ctx context.Context, payload model
 	return nil
 }
 
+// DeleteOrganisationMemberPermission removes the permission of an entity type, the member falls back to the global permission
+func (a *APIService) DeleteOrganisationMemberPermission(ctx context.Context, userID int64, organisationID int64, memberUserID int64, entityType string) error {
+	organisation, err := a.dbService.GetOrganisation(userID, organisationID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	member, err := a.dbService.GetMember(organisationID, memberUserID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if !a.canManageMember(organisation.Role, member.Role, false) {
+		err = errors.New("permission denied")
+		logger.Logger.Error(err)
+		return err
+	}
+
+	err = a.dbService.DeleteMemberEntityPermission(memberUserID, organisationID, entityType)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+
+	a.closeUserStreams(memberUserID)
+	a.notifyOrganisationChange(ctx, userID, organisationID, "member", events.ActionUpdated, memberUserID)
+	return nil
+}
+
+// UnlockOrganisationMember lifts the lockout of a member after too many failed logins before it expires
+func (a *APIService) UnlockOrganisationMember(ctx context.Context, userID int64, organisationID int64, memberUserID int64) error {
+	organisation, err := a.dbService.GetOrganisation(userID, organisationID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	member, err := a.dbService.GetMember(organisationID, memberUserID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if !a.canManageMember(organisation.Role, member.Role, false) {
+		err = errors.New("permission denied")
+		logger.Logger.Error(err)
+		return err
+	}
+
+	err = a.dbService.UnlockAuthAttempts(emailAttemptIdentifier(member.Email), userAttemptIdentifier(memberUserID))
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+
+	a.notifyOrganisationChange(ctx, userID, organisationID, "member", events.ActionUpdated, memberUserID)
+	return nil
+}
+
+func (a *APIService) canManageMember(role string, memberRole string, changesRole bool) bool {
+	switch role {
+	case "owner":
+		return true
+	case "admin":
+		return !changesRole && memberRole != "owner" && memberRole != "admin"
+	default:
+		return false
+	}
+}
+
+func applyPermissionFlags(permission *models.EffectivePermission, canView *bool, canEdit *bool, canDelete *bool) {
+	if canView != nil {
+		permission.CanView = *canView
+	}
+	if canEdit != nil {
+		permission.CanEdit = *canEdit
+	}
+	if canDelete != nil {
+		permission.CanDelete = *canDelete
+	}
+}
+
 func (a *APIService) RemoveOrganisationMember(ctx context.Context, userID int64, organisationID int64, memberUserID int64) error {
 	// Check if user belongs to the organisation
 	organisation, err := a.dbService.GetOrganisation(userID, organisationID)
@@ -10,6 +10,9 @@ import (
 	"slices"
 )
 
+// ErrForecastMonthlyYearsExceedHorizon is returned when more monthly years than forecast years are configured
+var ErrForecastMonthlyYearsExceedHorizon = errors.New("forecast monthly years must not exceed the forecast years")
+
 func (a *APIService) ListOrganisations(ctx context.Context, userID int64, page int64, limit int64) ([]models.Organisation, int64, error) {
 	organisations, totalCount, err := a.dbService.ListOrganisations(userID, page, limit)
 	if err != nil {
@@ -74,6 +77,34 @@ func (a *APIService) UpdateOrganisation(ctx context.Context, payload models.Upda
 		logger.Logger.Error(err)
 		return nil, err
 	}
+	forecastYears := existingOrganisation.ForecastYears
+	if payload.ForecastYears != nil {
+		forecastYears = *payload.ForecastYears
+	}
+	forecastMonthlyYears := existingOrganisation.ForecastMonthlyYears
+	if payload.ForecastMonthlyYears != nil {
+		forecastMonthlyYears = *payload.ForecastMonthlyYears
+	}
+	if forecastMonthlyYears > forecastYears {
+		return nil, ErrForecastMonthlyYearsExceedHorizon
+	}
+	// Requiring two-factor authentication without having it would lock the admin out
+	if payload.RequireTwoFactor != nil && *payload.RequireTwoFactor {
+		twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+		if !twoFactor.Enabled {
+			return nil, ErrTwoFactorNotEnabled
+		}
+	}
+	if payload.SSODomain != nil {
+		err = a.validateSSODomain(userID, organisationID, *payload.SSODomain)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err = a.dbService.UpdateOrganisation(payload, userID, organisationID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -89,7 +120,15 @@ func (a *APIService) UpdateOrganisation(ctx context.Context, payload models.Upda
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "organisation", events.ActionUpdated, organisationID)
+	if organisation.ForecastYears != existingOrganisation.ForecastYears ||
+		organisation.ForecastMonthlyYears != existingOrganisation.ForecastMonthlyYears ||
+		organisation.ForecastBucket != existingOrganisation.ForecastBucket {
+		err = a.recalculateForecastHorizon(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	a.notifyOrganisationChangeWithDiff(ctx, userID, organisationID, "organisation", events.ActionUpdated, organisationID, 0, existingOrganisation, organisation)
 	return organisation, err
 }
 
@@ -97,3 +136,33 @@ func (a *APIService) hasEditingPermission(role string) bool {
 	editingRoles := []string{"owner", "admin"}
 	return slices.Contains(editingRoles, role)
 }
+
+// recalculateForecastHorizon extends or shortens the salary costs to the new horizon and
+// stores the forecast in the new periods
+func (a *APIService) recalculateForecastHorizon(ctx context.Context, userID int64) error {
+	employees, _, err := a.dbService.ListEmployees(userID, 1, 100000, "name", "ASC", "", false)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	for _, employee := range employees {
+		salaries, _, err := a.dbService.ListSalaries(userID, employee.ID, 1, 100000)
+		if err != nil {
+			logger.Logger.Error(err)
+			return err
+		}
+		for _, salary := range salaries {
+			err = a.dbService.RefreshSalaryCostDetails(userID, salary.ID)
+			if err != nil {
+				logger.Logger.Error(err)
+				return err
+			}
+		}
+	}
+	err = a.scheduleForecast(ctx, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package api_service
+
+import (
+	"errors"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+// ErrPayrollConfidential is returned when a member without payroll clearance requests single salaries
+var ErrPayrollConfidential = errors.New("payroll data is confidential")
+
+// payrollDetailName replaces the salaries and salary costs in the forecast details of uncleared members
+const payrollDetailName = "Personalkosten"
+
+// salarySortKeys would reveal the order of the salaries and fall back to the name for uncleared members
+var salarySortKeys = map[string]bool{"hoursPerMonth": true, "salary": true, "vacationDaysPerYear": true}
+
+// hasPayrollAccess tells whether the user sees single salaries in the current organisation
+func (a *APIService) hasPayrollAccess(userID int64) (bool, error) {
+	access, err := a.dbService.GetCurrentUserPayrollAccess(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return false, err
+	}
+	return access.Allowed(), nil
+}
+
+func (a *APIService) requirePayrollAccess(userID int64) error {
+	allowed, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrPayrollConfidential
+	}
+	return nil
+}
+
+// redactEmployeeSalary removes everything that is taken from the current salary of the employee
+func redactEmployeeSalary(employee *models.Employee) {
+	employee.HoursPerMonth = nil
+	employee.SalaryAmount = nil
+	employee.Cycle = nil
+	employee.Currency = nil
+	employee.VacationDaysPerYear = nil
+	employee.SalaryID = nil
+}
+
+func (a *APIService) redactEmployeeForUser(userID int64, employee *models.Employee) error {
+	allowed, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		redactEmployeeSalary(employee)
+	}
+	return nil
+}
+
+// redactPayrollDetailsForUser aggregates the salaries of the forecast details for members without payroll clearance
+func (a *APIService) redactPayrollDetailsForUser(userID int64, details []models.ForecastDatabaseDetails) error {
+	allowed, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		redactPayrollDetails(details)
+	}
+	return nil
+}
+
+// redactPayrollDetails merges every salary and salary cost of a month into a single aggregated entry
+func redactPayrollDetails(details []models.ForecastDatabaseDetails) {
+	for i := range details {
+		redactPayrollDetail(&details[i])
+	}
+}
+
+func redactPayrollDetail(detail *models.ForecastDatabaseDetails) {
+	detail.Revenue = redactPayrollDetailNodes(detail.Revenue)
+	detail.Expense = redactPayrollDetailNodes(detail.Expense)
+}
+
+func redactPayrollDetailNodes(nodes []models.ForecastDetailRevenueExpense) []models.ForecastDetailRevenueExpense {
+	result := make([]models.ForecastDetailRevenueExpense, 0, len(nodes))
+	payrollIndex := -1
+	for _, node := range nodes {
+		kept := make([]models.ForecastDetailRevenueExpense, 0)
+		var payrollAmount int64
+		hasPayroll := false
+		for _, leaf := range forecastDetailLeaves(node) {
+			if leaf.RelatedTable != utils.SalariesTableName && leaf.RelatedTable != utils.SalaryCostsTableName {
+				kept = append(kept, leaf)
+				continue
+			}
+			hasPayroll = true
+			payrollAmount += leaf.Amount
+		}
+		if !hasPayroll {
+			result = append(result, node)
+			continue
+		}
+		if payrollIndex == -1 {
+			result = append(result, models.ForecastDetailRevenueExpense{Name: payrollDetailName})
+			payrollIndex = len(result) - 1
+		}
+		result[payrollIndex].Amount += payrollAmount
+		if len(kept) > 0 {
+			// Group nodes only carry a total when it was set, it must not include the removed salaries
+			if node.Amount != 0 {
+				node.Amount -= payrollAmount
+			}
+			node.Children = kept
+			result = append(result, node)
+		}
+	}
+	return result
+}
@@ -0,0 +1,399 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+var (
+	// ErrPayrollStatutoryYearUnknown is returned for years without federal values
+	ErrPayrollStatutoryYearUnknown = errors.New("no statutory payroll values for the year")
+	// ErrPayrollTemplateExists is returned when the organisation has a template with the name and year already
+	ErrPayrollTemplateExists = errors.New("payroll template exists already")
+	// ErrPayrollTemplateEmpty is returned when a template without items is applied to a salary
+	ErrPayrollTemplateEmpty = errors.New("payroll template has no items")
+)
+
+// statutoryPayrollYear holds the federal values of a year, rates are per side in thousandths of a
+// percent and amounts are yearly in cents
+type statutoryPayrollYear struct {
+	ahvRate      uint64
+	alvRate      uint64
+	alvCeiling   uint64
+	uvgCeiling   uint64
+	bvgThreshold uint64
+	bvgCeiling   uint64
+	bvgDeduction uint64
+	bvgMinimum   uint64
+}
+
+// statutoryPayrollYears needs the federal values of the next year before January 1st
+var statutoryPayrollYears = map[uint16]statutoryPayrollYear{
+	2024: {
+		ahvRate:      5_300,
+		alvRate:      1_100,
+		alvCeiling:   148_200_00,
+		uvgCeiling:   148_200_00,
+		bvgThreshold: 22_050_00,
+		bvgCeiling:   88_200_00,
+		bvgDeduction: 25_725_00,
+		bvgMinimum:   3_675_00,
+	},
+	2025: {
+		ahvRate:      5_300,
+		alvRate:      1_100,
+		alvCeiling:   148_200_00,
+		uvgCeiling:   148_200_00,
+		bvgThreshold: 22_680_00,
+		bvgCeiling:   90_720_00,
+		bvgDeduction: 26_460_00,
+		bvgMinimum:   3_780_00,
+	},
+	2026: {
+		ahvRate:      5_300,
+		alvRate:      1_100,
+		alvCeiling:   148_200_00,
+		uvgCeiling:   148_200_00,
+		bvgThreshold: 22_680_00,
+		bvgCeiling:   90_720_00,
+		bvgDeduction: 26_460_00,
+		bvgMinimum:   3_780_00,
+	},
+}
+
+// bvgAgeRates are the legal minimum savings contributions per side (7%, 10%, 15% and 18% in total)
+var bvgAgeRates = []models.SalaryCostAgeRate{
+	{FromAge: 25, Amount: 3_500},
+	{FromAge: 35, Amount: 5_000},
+	{FromAge: 45, Amount: 7_500},
+	{FromAge: 55, Amount: 9_000},
+}
+
+// statutoryPayrollFederal tells which values of an item a new version takes from the federal values,
+// the UVG, KTG and FAK rates depend on the insurer and canton and stay as the organisation set them
+var statutoryPayrollFederal = map[string]struct{ rate, limits bool }{
+	models.PayrollStatutoryAHV:    {rate: true},
+	models.PayrollStatutoryALV:    {rate: true, limits: true},
+	models.PayrollStatutoryBVG:    {rate: true, limits: true},
+	models.PayrollStatutoryUVGBU:  {limits: true},
+	models.PayrollStatutoryUVGNBU: {limits: true},
+}
+
+func (a *APIService) ListStatutoryPayrollItems(ctx context.Context, year uint16) ([]models.CreatePayrollTemplateItem, error) {
+	return statutoryPayrollItems(year)
+}
+
+func statutoryPayrollItems(year uint16) ([]models.CreatePayrollTemplateItem, error) {
+	values, ok := statutoryPayrollYears[year]
+	if !ok {
+		return nil, ErrPayrollStatutoryYearUnknown
+	}
+
+	item := func(key string, label string, distributionType string, amount uint64, limits models.SalaryCostLimits) models.CreatePayrollTemplateItem {
+		return models.CreatePayrollTemplateItem{
+			StatutoryKey:     utils.StringAsPointer(key),
+			Label:            label,
+			Cycle:            utils.CycleMonthly,
+			AmountType:       "percentage",
+			Amount:           amount,
+			DistributionType: distributionType,
+			RelativeOffset:   1,
+			SalaryCostLimits: limits,
+		}
+	}
+	ageRates := make([]models.SalaryCostAgeRate, len(bvgAgeRates))
+	copy(ageRates, bvgAgeRates)
+
+	return []models.CreatePayrollTemplateItem{
+		item(models.PayrollStatutoryAHV, "AHV/IV/EO", models.SalaryCostDistributionBoth, values.ahvRate, models.SalaryCostLimits{}),
+		item(models.PayrollStatutoryALV, "ALV", models.SalaryCostDistributionBoth, values.alvRate, models.SalaryCostLimits{
+			BaseCeiling: uint64Pointer(values.alvCeiling),
+		}),
+		// Without a birth date of the employee the rate of the youngest age group applies
+		item(models.PayrollStatutoryBVG, "BVG", models.SalaryCostDistributionBoth, bvgAgeRates[0].Amount, models.SalaryCostLimits{
+			BaseThreshold: uint64Pointer(values.bvgThreshold),
+			BaseCeiling:   uint64Pointer(values.bvgCeiling),
+			BaseDeduction: uint64Pointer(values.bvgDeduction),
+			BaseMinimum:   uint64Pointer(values.bvgMinimum),
+			AgeRates:      ageRates,
+		}),
+		item(models.PayrollStatutoryUVGBU, "UVG BU", models.SalaryCostDistributionEmployer, 0, models.SalaryCostLimits{
+			BaseCeiling: uint64Pointer(values.uvgCeiling),
+		}),
+		item(models.PayrollStatutoryUVGNBU, "UVG NBU", models.SalaryCostDistributionEmployee, 0, models.SalaryCostLimits{
+			BaseCeiling: uint64Pointer(values.uvgCeiling),
+		}),
+		item(models.PayrollStatutoryKTG, "KTG", models.SalaryCostDistributionBoth, 0, models.SalaryCostLimits{}),
+		item(models.PayrollStatutoryFAK, "FAK", models.SalaryCostDistributionEmployer, 0, models.SalaryCostLimits{}),
+	}, nil
+}
+
+func uint64Pointer(value uint64) *uint64 {
+	return &value
+}
+
+func (a *APIService) ListPayrollTemplates(ctx context.Context, userID int64) ([]models.PayrollTemplate, error) {
+	templates, err := a.dbService.ListPayrollTemplates(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (a *APIService) GetPayrollTemplate(ctx context.Context, userID int64, templateID int64) (*models.PayrollTemplate, error) {
+	template, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return template, nil
+}
+
+func (a *APIService) CreatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID int64) (*models.PayrollTemplate, error) {
+	if err := a.validatePayrollTemplate(payload, userID, nil); err != nil {
+		return nil, err
+	}
+	templateID, err := a.dbService.CreatePayrollTemplate(payload, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	template, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "payroll_template", events.ActionCreated, templateID, 0, nil, template)
+	return template, nil
+}
+
+// CreateStatutoryPayrollTemplate creates a template with the federal values of the year, the
+// organisation fills in the rates of its UVG, KTG and FAK insurers afterwards
+func (a *APIService) CreateStatutoryPayrollTemplate(ctx context.Context, payload models.CreateStatutoryPayrollTemplate, userID int64) (*models.PayrollTemplate, error) {
+	items, err := statutoryPayrollItems(payload.Year)
+	if err != nil {
+		return nil, err
+	}
+	return a.CreatePayrollTemplate(ctx, models.CreatePayrollTemplate{
+		Name:  payload.Name,
+		Year:  payload.Year,
+		Items: items,
+	}, userID)
+}
+
+// CreatePayrollTemplateVersion copies the template to another year. Statutory items take the federal
+// values of that year, everything the organisation added or set itself is kept.
+func (a *APIService) CreatePayrollTemplateVersion(ctx context.Context, payload models.CreatePayrollTemplateVersion, userID int64, templateID int64) (*models.PayrollTemplate, error) {
+	template, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	statutoryItems, err := statutoryPayrollItems(payload.Year)
+	if err != nil {
+		return nil, err
+	}
+	statutoryByKey := make(map[string]models.CreatePayrollTemplateItem, len(statutoryItems))
+	for _, item := range statutoryItems {
+		statutoryByKey[*item.StatutoryKey] = item
+	}
+
+	items := make([]models.CreatePayrollTemplateItem, 0, len(template.Items))
+	for _, item := range template.Items {
+		next := payrollTemplateItemPayload(item)
+		if item.StatutoryKey != nil {
+			statutory, ok := statutoryByKey[*item.StatutoryKey]
+			federal := statutoryPayrollFederal[*item.StatutoryKey]
+			if ok && federal.rate {
+				next.Amount = statutory.Amount
+			}
+			if ok && federal.limits {
+				next.SalaryCostLimits = statutory.SalaryCostLimits
+			}
+		}
+		items = append(items, next)
+	}
+
+	return a.CreatePayrollTemplate(ctx, models.CreatePayrollTemplate{
+		Name:  template.Name,
+		Year:  payload.Year,
+		Items: items,
+	}, userID)
+}
+
+func (a *APIService) UpdatePayrollTemplate(ctx context.Context, payload models.CreatePayrollTemplate, userID int64, templateID int64) (*models.PayrollTemplate, error) {
+	existingTemplate, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if err := a.validatePayrollTemplate(payload, userID, &templateID); err != nil {
+		return nil, err
+	}
+	err = a.dbService.UpdatePayrollTemplate(payload, userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	template, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "payroll_template", events.ActionUpdated, templateID, 0, existingTemplate, template)
+	return template, nil
+}
+
+func (a *APIService) DeletePayrollTemplate(ctx context.Context, userID int64, templateID int64) error {
+	existingTemplate, err := a.dbService.GetPayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeletePayrollTemplate(userID, templateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "payroll_template", events.ActionDeleted, templateID, 0, existingTemplate, nil)
+	return nil
+}
+
+// ApplyPayrollTemplate creates a salary cost per item of the template, the labels are matched by name
+// and created if the organisation doesn't have them yet
+func (a *APIService) ApplyPayrollTemplate(ctx context.Context, payload models.ApplyPayrollTemplate, userID int64, salaryID int64) ([]models.SalaryCost, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	salary, err := a.dbService.GetSalary(userID, salaryID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if salary.IsTermination {
+		return nil, fmt.Errorf("cannot attach costs to a termination salary")
+	}
+	template, err := a.dbService.GetPayrollTemplate(userID, payload.TemplateID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if len(template.Items) == 0 {
+		return nil, ErrPayrollTemplateEmpty
+	}
+
+	labelIDs, err := a.payrollTemplateLabelIDs(userID, template.Items)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	if payload.Replace {
+		err = a.dbService.DeleteSalaryCostsBySalaryID(salaryID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+	}
+
+	for _, item := range template.Items {
+		labelID := labelIDs[item.Label]
+		_, err := a.dbService.CreateSalaryCost(models.CreateSalaryCost{
+			Cycle:             item.Cycle,
+			AmountType:        item.AmountType,
+			Amount:            item.Amount,
+			DistributionType:  item.DistributionType,
+			RelativeOffset:    item.RelativeOffset,
+			LabelID:           &labelID,
+			BaseSalaryCostIDs: []int64{},
+			SalaryCostLimits:  item.SalaryCostLimits,
+		}, userID, salaryID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+	}
+
+	err = a.dbService.RefreshSalaryCostDetails(userID, salaryID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	// Recalculate Forecast
+	err = a.scheduleForecast(ctx, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithParent(ctx, userID, "salary_cost", events.ActionUpdated, 0, salaryID)
+
+	salaryCosts, _, err := a.listSalaryCosts(ctx, userID, salaryID, 1, 1000, true)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return salaryCosts, nil
+}
+
+func (a *APIService) payrollTemplateLabelIDs(userID int64, items []models.PayrollTemplateItem) (map[string]int64, error) {
+	labels, _, err := a.dbService.ListSalaryCostLabels(userID, 1, 10000)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := make(map[string]int64, len(labels))
+	for _, label := range labels {
+		labelIDs[label.Name] = label.ID
+	}
+	for _, item := range items {
+		if _, exists := labelIDs[item.Label]; exists {
+			continue
+		}
+		labelID, err := a.dbService.CreateSalaryCostLabel(models.CreateSalaryCostLabel{Name: item.Label}, userID)
+		if err != nil {
+			return nil, err
+		}
+		labelIDs[item.Label] = labelID
+	}
+	return labelIDs, nil
+}
+
+func (a *APIService) validatePayrollTemplate(payload models.CreatePayrollTemplate, userID int64, currentTemplateID *int64) error {
+	for _, item := range payload.Items {
+		if err := validateSalaryCostLimits(item.AmountType, item.SalaryCostLimits); err != nil {
+			return err
+		}
+	}
+	templates, err := a.dbService.ListPayrollTemplates(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	for _, template := range templates {
+		if currentTemplateID != nil && template.ID == *currentTemplateID {
+			continue
+		}
+		if template.Name == payload.Name && template.Year == payload.Year {
+			return ErrPayrollTemplateExists
+		}
+	}
+	return nil
+}
+
+func payrollTemplateItemPayload(item models.PayrollTemplateItem) models.CreatePayrollTemplateItem {
+	return models.CreatePayrollTemplateItem{
+		StatutoryKey:     item.StatutoryKey,
+		Label:            item.Label,
+		Cycle:            item.Cycle,
+		AmountType:       item.AmountType,
+		Amount:           item.Amount,
+		DistributionType: item.DistributionType,
+		RelativeOffset:   item.RelativeOffset,
+		SalaryCostLimits: item.SalaryCostLimits,
+	}
+}
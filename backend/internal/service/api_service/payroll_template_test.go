@@ -0,0 +1,195 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func statutoryItem(items []models.CreatePayrollTemplateItem, key string) models.CreatePayrollTemplateItem {
+	for _, item := range items {
+		if item.StatutoryKey != nil && *item.StatutoryKey == key {
+			return item
+		}
+	}
+	return models.CreatePayrollTemplateItem{}
+}
+
+func TestStatutoryPayrollItems_LimitTheBase(t *testing.T) {
+	service := api_service.NewAPIService(nil, nil)
+
+	items, err := service.ListStatutoryPayrollItems(context.Background(), 2025)
+	require.NoError(t, err)
+
+	bvg := statutoryItem(items, models.PayrollStatutoryBVG).SalaryCostLimits
+	// Below the entry threshold
+	require.Equal(t, uint64(0), bvg.LimitYearlyBase(20_000_00))
+	// Coordinated salary raised to the minimum
+	require.Equal(t, uint64(3_780_00), bvg.LimitYearlyBase(25_000_00))
+	require.Equal(t, uint64(33_540_00), bvg.LimitYearlyBase(60_000_00))
+	// Capped at the upper limit
+	require.Equal(t, uint64(64_260_00), bvg.LimitYearlyBase(200_000_00))
+	require.Equal(t, uint64(5_355_00), bvg.LimitBase(20_000_00, 12))
+
+	alv := statutoryItem(items, models.PayrollStatutoryALV).SalaryCostLimits
+	require.Equal(t, uint64(12_350_00), alv.LimitBase(15_000_00, 12))
+	require.Equal(t, uint64(10_000_00), alv.LimitBase(10_000_00, 12))
+
+	_, err = service.ListStatutoryPayrollItems(context.Background(), 1999)
+	require.ErrorIs(t, err, api_service.ErrPayrollStatutoryYearUnknown)
+}
+
+func TestSalaryCostPercentage_FollowsTheAgeRates(t *testing.T) {
+	birthDate := types.AsDate(time.Date(1990, 11, 30, 0, 0, 0, 0, time.UTC))
+	cost := models.SalaryCost{
+		Amount: 3_500,
+		SalaryCostLimits: models.SalaryCostLimits{AgeRates: []models.SalaryCostAgeRate{
+			{FromAge: 35, Amount: 5_000},
+			{FromAge: 25, Amount: 3_500},
+		}},
+	}
+
+	// Without a birth date the amount applies
+	require.Equal(t, uint64(3_500), cost.Percentage(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	cost.EmployeeBirthDate = &birthDate
+	require.Equal(t, uint64(0), cost.Percentage(time.Date(2014, 12, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, uint64(3_500), cost.Percentage(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)))
+	// The age counts by calendar year, the birthday itself doesn't matter
+	require.Equal(t, uint64(5_000), cost.Percentage(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCreatePayrollTemplateVersion_TakesFederalValues(t *testing.T) {
+	utils.InitValidator()
+	logger.Logger = zap.NewNop().Sugar()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	items2024, err := service.ListStatutoryPayrollItems(context.Background(), 2024)
+	require.NoError(t, err)
+	bvg := statutoryItem(items2024, models.PayrollStatutoryBVG)
+	uvg := statutoryItem(items2024, models.PayrollStatutoryUVGBU)
+	ktgCeiling := uint64(300_000_00)
+
+	template := models.PayrollTemplate{
+		ID:   3,
+		Name: "Standard",
+		Year: 2024,
+		Items: []models.PayrollTemplateItem{
+			{StatutoryKey: bvg.StatutoryKey, Label: "BVG", Cycle: "monthly", AmountType: "percentage", Amount: 4_000, DistributionType: "both", RelativeOffset: 1, SalaryCostLimits: bvg.SalaryCostLimits},
+			{StatutoryKey: uvg.StatutoryKey, Label: "UVG BU", Cycle: "monthly", AmountType: "percentage", Amount: 120, DistributionType: "employer", RelativeOffset: 1, SalaryCostLimits: uvg.SalaryCostLimits},
+			{StatutoryKey: utils.StringAsPointer(models.PayrollStatutoryKTG), Label: "KTG", Cycle: "monthly", AmountType: "percentage", Amount: 450, DistributionType: "both", RelativeOffset: 1, SalaryCostLimits: models.SalaryCostLimits{BaseCeiling: &ktgCeiling}},
+			{Label: "Verpflegung", Cycle: "monthly", AmountType: "fixed", Amount: 200_00, DistributionType: "employer", RelativeOffset: 1},
+		},
+	}
+
+	mockDB.EXPECT().GetPayrollTemplate(userID, template.ID).Return(&template, nil)
+	mockDB.EXPECT().ListPayrollTemplates(userID).Return([]models.PayrollTemplate{template}, nil)
+
+	var created models.CreatePayrollTemplate
+	mockDB.EXPECT().CreatePayrollTemplate(gomock.Any(), userID).DoAndReturn(func(payload models.CreatePayrollTemplate, _ int64) (int64, error) {
+		created = payload
+		return 4, nil
+	})
+	mockDB.EXPECT().GetPayrollTemplate(userID, int64(4)).Return(&models.PayrollTemplate{ID: 4, Name: "Standard", Year: 2025}, nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID}, nil)
+
+	_, err = service.CreatePayrollTemplateVersion(context.Background(), models.CreatePayrollTemplateVersion{Year: 2025}, userID, template.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, "Standard", created.Name)
+	require.Equal(t, uint16(2025), created.Year)
+	require.Len(t, created.Items, 4)
+	// The BVG takes the rate and limits of 2025
+	require.Equal(t, uint64(3_500), created.Items[0].Amount)
+	require.Equal(t, uint64(26_460_00), *created.Items[0].BaseDeduction)
+	require.Len(t, created.Items[0].AgeRates, 4)
+	// The UVG keeps the rate of the insurer
+	require.Equal(t, uint64(120), created.Items[1].Amount)
+	require.Equal(t, uint64(148_200_00), *created.Items[1].BaseCeiling)
+	// Values the organisation set itself stay
+	require.Equal(t, uint64(450), created.Items[2].Amount)
+	require.Equal(t, ktgCeiling, *created.Items[2].BaseCeiling)
+	require.Nil(t, created.Items[3].StatutoryKey)
+	require.Equal(t, uint64(200_00), created.Items[3].Amount)
+}
+
+func TestCreatePayrollTemplate_RejectsDuplicateNameAndYear(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().ListPayrollTemplates(userID).Return([]models.PayrollTemplate{{ID: 1, Name: "Standard", Year: 2025}}, nil)
+
+	_, err := service.CreateStatutoryPayrollTemplate(context.Background(), models.CreateStatutoryPayrollTemplate{Name: "Standard", Year: 2025}, userID)
+	require.ErrorIs(t, err, api_service.ErrPayrollTemplateExists)
+}
+
+func TestApplyPayrollTemplate_CreatesMissingLabels(t *testing.T) {
+	utils.InitValidator()
+	logger.Logger = zap.NewNop().Sugar()
+
+	userID := int64(42)
+	salaryID := int64(9)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	// Keeps the recalculation out of the test
+	service.EnableForecastScheduler(time.Hour)
+
+	ceiling := uint64(148_200_00)
+	template := models.PayrollTemplate{
+		ID:   3,
+		Name: "Standard",
+		Year: 2025,
+		Items: []models.PayrollTemplateItem{
+			{Label: "AHV/IV/EO", Cycle: "monthly", AmountType: "percentage", Amount: 5_300, DistributionType: "both", RelativeOffset: 1},
+			{Label: "ALV", Cycle: "monthly", AmountType: "percentage", Amount: 1_100, DistributionType: "both", RelativeOffset: 1, SalaryCostLimits: models.SalaryCostLimits{BaseCeiling: &ceiling}},
+		},
+	}
+
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetSalary(userID, salaryID).Return(&models.Salary{ID: salaryID}, nil)
+	mockDB.EXPECT().GetPayrollTemplate(userID, template.ID).Return(&template, nil)
+	mockDB.EXPECT().ListSalaryCostLabels(userID, int64(1), int64(10000)).Return([]models.SalaryCostLabel{{ID: 5, Name: "AHV/IV/EO"}}, int64(1), nil)
+	mockDB.EXPECT().CreateSalaryCostLabel(models.CreateSalaryCostLabel{Name: "ALV"}, userID).Return(int64(6), nil)
+	mockDB.EXPECT().DeleteSalaryCostsBySalaryID(salaryID).Return(nil)
+
+	created := make([]models.CreateSalaryCost, 0)
+	mockDB.EXPECT().CreateSalaryCost(gomock.Any(), userID, salaryID).DoAndReturn(func(payload models.CreateSalaryCost, _ int64, _ int64) (int64, error) {
+		created = append(created, payload)
+		return int64(len(created)), nil
+	}).Times(2)
+	mockDB.EXPECT().RefreshSalaryCostDetails(userID, salaryID).Return(nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID}, nil).Times(2)
+	mockDB.EXPECT().ListSalaryCosts(userID, salaryID, int64(1), int64(1000)).Return([]models.SalaryCost{}, int64(0), nil)
+
+	_, err := service.ApplyPayrollTemplate(context.Background(), models.ApplyPayrollTemplate{TemplateID: template.ID, Replace: true}, userID, salaryID)
+	require.NoError(t, err)
+
+	require.Len(t, created, 2)
+	require.Equal(t, int64(5), *created[0].LabelID)
+	require.Equal(t, int64(6), *created[1].LabelID)
+	require.Equal(t, ceiling, *created[1].BaseCeiling)
+}
@@ -0,0 +1,149 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func TestListForecastDetails_AggregatesPayrollWithoutClearance(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().ListForecastDetails(userID, int64(1)).Return([]models.ForecastDatabaseDetails{
+		{
+			Month: "2025-01",
+			Expense: []models.ForecastDetailRevenueExpense{
+				{Name: "Löhne", Children: []models.ForecastDetailRevenueExpense{
+					{Name: "Anna", Amount: -5000_00, RelatedID: 1, RelatedTable: utils.SalariesTableName},
+					{Name: "Ben", Amount: -6000_00, RelatedID: 2, RelatedTable: utils.SalariesTableName},
+				}},
+				{Name: "Lohnkosten", Children: []models.ForecastDetailRevenueExpense{
+					{Name: "AHV", Amount: -1000_00, RelatedID: 3, RelatedTable: utils.SalaryCostsTableName},
+				}},
+				{Name: "Miete", Children: []models.ForecastDetailRevenueExpense{
+					{Name: "Büro", Amount: -300_00, RelatedID: 4, RelatedTable: utils.TransactionsTableName},
+				}},
+			},
+		},
+	}, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "read-only", Confidential: true}, nil)
+
+	details, err := service.ListForecastDetails(context.Background(), userID, 1)
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	require.Equal(t, []models.ForecastDetailRevenueExpense{
+		{Name: "Personalkosten", Amount: -12000_00},
+		{Name: "Miete", Children: []models.ForecastDetailRevenueExpense{
+			{Name: "Büro", Amount: -300_00, RelatedID: 4, RelatedTable: utils.TransactionsTableName},
+		}},
+	}, details[0].Expense)
+}
+
+func TestListForecastDetails_SubtractsPayrollFromMixedNodes(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().ListForecastDetails(userID, int64(1)).Return([]models.ForecastDatabaseDetails{
+		{
+			Month: "2025-01",
+			Expense: []models.ForecastDetailRevenueExpense{
+				{Name: "Personal", Amount: -5500_00, Children: []models.ForecastDetailRevenueExpense{
+					{Name: "Anna", Amount: -5000_00, RelatedID: 1, RelatedTable: utils.SalariesTableName},
+					{Name: "Weiterbildung", Amount: -500_00, RelatedID: 2, RelatedTable: utils.TransactionsTableName},
+				}},
+			},
+		},
+	}, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "read-only", Confidential: true}, nil)
+
+	details, err := service.ListForecastDetails(context.Background(), userID, 1)
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	require.Equal(t, []models.ForecastDetailRevenueExpense{
+		{Name: "Personalkosten", Amount: -5000_00},
+		{Name: "Personal", Amount: -500_00, Children: []models.ForecastDetailRevenueExpense{
+			{Name: "Weiterbildung", Amount: -500_00, RelatedID: 2, RelatedTable: utils.TransactionsTableName},
+		}},
+	}, details[0].Expense)
+}
+
+func TestListSalaries_RejectsMembersWithoutClearance(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "editor", Confidential: true}, nil)
+
+	_, _, err := service.ListSalaries(context.Background(), userID, 7, 1, 100)
+	require.ErrorIs(t, err, api_service.ErrPayrollConfidential)
+}
+
+func TestListEmployees_HidesSalaryWithoutClearance(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	salaryAmount := uint64(5000_00)
+	salaryID := int64(9)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "read-only", Confidential: true}, nil)
+	// Sorting by salary would reveal the order of the salaries
+	mockDB.EXPECT().ListEmployees(userID, int64(1), int64(10), "name", "DESC", "", false).Return([]models.Employee{
+		{ID: 1, Name: "Anna", SalaryAmount: &salaryAmount, SalaryID: &salaryID},
+	}, int64(1), nil)
+
+	employees, total, err := service.ListEmployees(context.Background(), userID, 1, 10, "salary", "DESC", "", false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "Anna", employees[0].Name)
+	require.Nil(t, employees[0].SalaryAmount)
+	require.Nil(t, employees[0].SalaryID)
+}
+
+func TestListEmployees_KeepsSalaryWithClearance(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	salaryAmount := uint64(5000_00)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "read-only", Confidential: true, Clearance: true}, nil)
+	mockDB.EXPECT().ListEmployees(userID, int64(1), int64(10), "salary", "DESC", "", false).Return([]models.Employee{
+		{ID: 1, Name: "Anna", SalaryAmount: &salaryAmount},
+	}, int64(1), nil)
+
+	employees, _, err := service.ListEmployees(context.Background(), userID, 1, 10, "salary", "DESC", "", false)
+	require.NoError(t, err)
+	require.Equal(t, &salaryAmount, employees[0].SalaryAmount)
+}
@@ -2,14 +2,26 @@ package api_service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"liquiswiss/internal/events"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/models"
 	"liquiswiss/pkg/types"
 	"liquiswiss/pkg/utils"
+	"time"
 )
 
+var ErrInvalidSalaryExtraPayments = errors.New("invalid salary extra payments")
+
 func (a *APIService) ListSalaries(ctx context.Context, userID int64, employeeID int64, page int64, limit int64) ([]models.Salary, int64, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, 0, err
+	}
+	return a.listSalaries(ctx, userID, employeeID, page, limit)
+}
+
+func (a *APIService) listSalaries(ctx context.Context, userID int64, employeeID int64, page int64, limit int64) ([]models.Salary, int64, error) {
 	salaries, totalCount, err := a.dbService.ListSalaries(userID, employeeID, page, limit)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -32,6 +44,13 @@ func (a *APIService) ListSalaries(ctx context.Context, userID int64, employeeID
 }
 
 func (a *APIService) GetSalary(ctx context.Context, userID int64, salaryID int64) (*models.Salary, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	return a.getSalary(ctx, userID, salaryID)
+}
+
+func (a *APIService) getSalary(ctx context.Context, userID int64, salaryID int64) (*models.Salary, error) {
 	salary, err := a.dbService.GetSalary(userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -50,6 +69,12 @@ func (a *APIService) GetSalary(ctx context.Context, userID int64, salaryID int64
 }
 
 func (a *APIService) CreateSalary(ctx context.Context, payload models.CreateSalary, userID int64, employeeID int64) (*models.Salary, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	if err := validateSalaryExtraPayments(payload.Cycle, payload.FromDate, payload.ExtraSalaries, payload.Bonuses); err != nil {
+		return nil, err
+	}
 	salaryID, previousSalaryID, nextSalaryID, err := a.dbService.CreateSalary(payload, userID, employeeID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -75,7 +100,7 @@ func (a *APIService) CreateSalary(ctx context.Context, payload models.CreateSala
 			return nil, err
 		}
 	}
-	salary, err := a.GetSalary(ctx, userID, salaryID)
+	salary, err := a.getSalary(ctx, userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -86,17 +111,20 @@ func (a *APIService) CreateSalary(ctx context.Context, payload models.CreateSala
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "salary", events.ActionCreated, salaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary", events.ActionCreated, salaryID, 0, nil, salary)
 	return salary, nil
 }
 
 func (a *APIService) UpdateSalary(ctx context.Context, payload models.UpdateSalary, userID int64, salaryID int64) (*models.Salary, error) {
-	existingSalary, err := a.GetSalary(ctx, userID, salaryID)
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	existingSalary, err := a.getSalary(ctx, userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -120,6 +148,21 @@ func (a *APIService) UpdateSalary(ctx context.Context, payload models.UpdateSala
 		fromDate := existingSalary.FromDate.ToString()
 		payload.FromDate = &fromDate
 	}
+	cycle := existingSalary.Cycle
+	if payload.Cycle != nil {
+		cycle = *payload.Cycle
+	}
+	extraSalaries := existingSalary.ExtraSalaries
+	if payload.ExtraSalaries != nil {
+		extraSalaries = *payload.ExtraSalaries
+	}
+	bonuses := existingSalary.Bonuses
+	if payload.Bonuses != nil {
+		bonuses = *payload.Bonuses
+	}
+	if err := validateSalaryExtraPayments(cycle, *payload.FromDate, extraSalaries, bonuses); err != nil {
+		return nil, err
+	}
 	previousSalaryID, nextSalaryID, err := a.dbService.UpdateSalary(payload, existingSalary.EmployeeID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -145,7 +188,7 @@ func (a *APIService) UpdateSalary(ctx context.Context, payload models.UpdateSala
 			return nil, err
 		}
 	}
-	salary, err := a.GetSalary(ctx, userID, salaryID)
+	salary, err := a.getSalary(ctx, userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -156,17 +199,20 @@ func (a *APIService) UpdateSalary(ctx context.Context, payload models.UpdateSala
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "salary", events.ActionUpdated, salaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary", events.ActionUpdated, salaryID, 0, existingSalary, salary)
 	return salary, nil
 }
 
 func (a *APIService) DeleteSalary(ctx context.Context, userID int64, salaryID int64) error {
-	existingSalary, err := a.GetSalary(ctx, userID, salaryID)
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return err
+	}
+	existingSalary, err := a.getSalary(ctx, userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -197,23 +243,24 @@ func (a *APIService) DeleteSalary(ctx context.Context, userID int64, salaryID in
 		}
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "salary", events.ActionDeleted, salaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary", events.ActionDeleted, salaryID, 0, existingSalary, nil)
 	return nil
 }
 
 func (a *APIService) applySalaryCalculations(ctx context.Context, userID int64, salary *models.Salary) (*models.Salary, error) {
 	// Determine whether separate salary costs exist.
-	salaryCosts, _, err := a.ListSalaryCosts(ctx, userID, salary.ID, 1, 1000, true)
+	salaryCosts, _, err := a.listSalaryCosts(ctx, userID, salary.ID, 1, 1000, true)
 	if err != nil {
 		return nil, err
 	}
 	salary.HasSeparateCostsDefined = len(salaryCosts) > 0
 
+	salary.ExtraPayments = make([]models.SalaryExtraPayment, 0)
 	if salary.IsDisabled {
 		salary.EmployeeDeductions = 0
 		salary.EmployerCosts = 0
@@ -225,6 +272,8 @@ func (a *APIService) applySalaryCalculations(ctx context.Context, userID int64,
 		salary.Amount = 0
 		salary.HoursPerMonth = 0
 		salary.VacationDaysPerYear = 0
+		salary.ExtraSalaries = 0
+		salary.Bonuses = make([]models.SalaryBonus, 0)
 		salary.EmployeeDeductions = 0
 		salary.EmployerCosts = 0
 		salary.HasSeparateCostsDefined = false
@@ -244,6 +293,9 @@ func (a *APIService) applySalaryCalculations(ctx context.Context, userID int64,
 		salaryCosts,
 	)
 	salary.EmployerCosts = employerCosts
+	// The extra payments of the coming twelve months
+	dbDate := time.Time(salary.DBDate)
+	salary.ExtraPayments = salary.ExtraPaymentsBetween(dbDate, dbDate.AddDate(0, 11, 0))
 	nextExecutionDate := a.CalculateSalaryExecutionDate(salary.FromDate, salary.ToDate, &salary.Cycle, salary.DBDate, 1, true)
 	if nextExecutionDate != nil {
 		nextSalaryExecutionDateAsDate := types.AsDate(*nextExecutionDate)
@@ -251,3 +303,21 @@ func (a *APIService) applySalaryCalculations(ctx context.Context, userID int64,
 	}
 	return salary, nil
 }
+
+// validateSalaryExtraPayments only allows 13th/14th month salaries on monthly salaries, bonuses can't be
+// paid before the salary starts
+func validateSalaryExtraPayments(cycle string, fromDate string, extraSalaries uint8, bonuses []models.SalaryBonus) error {
+	if extraSalaries > 0 && cycle != utils.CycleMonthly {
+		return fmt.Errorf("%w: ein 13./14. Monatslohn ist nur bei monatlichen Löhnen möglich", ErrInvalidSalaryExtraPayments)
+	}
+	parsedFromDate, err := time.Parse(utils.InternalDateFormat, fromDate)
+	if err != nil {
+		return err
+	}
+	for _, bonus := range bonuses {
+		if time.Time(bonus.Date).Before(parsedFromDate) {
+			return fmt.Errorf("%w: der Bonus %q liegt vor dem Beginn des Lohns", ErrInvalidSalaryExtraPayments, bonus.Name)
+		}
+	}
+	return nil
+}
@@ -12,6 +12,13 @@ import (
 )
 
 func (a *APIService) ListSalaryCosts(ctx context.Context, userID int64, salaryID int64, page int64, limit int64, skipPrevious bool) ([]models.SalaryCost, int64, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, 0, err
+	}
+	return a.listSalaryCosts(ctx, userID, salaryID, page, limit, skipPrevious)
+}
+
+func (a *APIService) listSalaryCosts(ctx context.Context, userID int64, salaryID int64, page int64, limit int64, skipPrevious bool) ([]models.SalaryCost, int64, error) {
 	salaryCosts, totalCount, err := a.dbService.ListSalaryCosts(userID, salaryID, page, limit)
 	if err != nil {
 		return nil, 0, err
@@ -32,6 +39,13 @@ func (a *APIService) ListSalaryCosts(ctx context.Context, userID int64, salaryID
 }
 
 func (a *APIService) GetSalaryCost(ctx context.Context, userID int64, salaryCostID int64, skipPrevious bool) (*models.SalaryCost, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	return a.getSalaryCost(ctx, userID, salaryCostID, skipPrevious)
+}
+
+func (a *APIService) getSalaryCost(ctx context.Context, userID int64, salaryCostID int64, skipPrevious bool) (*models.SalaryCost, error) {
 	salaryCost, err := a.dbService.GetSalaryCost(userID, salaryCostID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -50,6 +64,9 @@ func (a *APIService) GetSalaryCost(ctx context.Context, userID int64, salaryCost
 }
 
 func (a *APIService) CreateSalaryCost(ctx context.Context, payload models.CreateSalaryCost, userID int64, salaryID int64) (*models.SalaryCost, error) {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
 	salary, err := a.dbService.GetSalary(userID, salaryID)
 	if err != nil {
 		logger.Logger.Error(err)
@@ -66,6 +83,10 @@ func (a *APIService) CreateSalaryCost(ctx context.Context, payload models.Create
 		}
 	}
 
+	if err := validateSalaryCostLimits(payload.AmountType, payload.SalaryCostLimits); err != nil {
+		return nil, err
+	}
+
 	if err := a.validateSalaryCostBase(payload, userID, salaryID, nil); err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -86,7 +107,7 @@ func (a *APIService) CreateSalaryCost(ctx context.Context, payload models.Create
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	salaryCost, err := a.GetSalaryCost(ctx, userID, salaryCostID, true)
+	salaryCost, err := a.getSalaryCost(ctx, userID, salaryCostID, true)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -97,17 +118,20 @@ func (a *APIService) CreateSalaryCost(ctx context.Context, payload models.Create
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChangeWithParent(ctx, userID, "salary_cost", events.ActionCreated, salaryCostID, salaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost", events.ActionCreated, salaryCostID, salaryID, nil, salaryCost)
 	return salaryCost, nil
 }
 
 func (a *APIService) UpdateSalaryCost(ctx context.Context, payload models.CreateSalaryCost, userID int64, salaryCostID int64) (*models.SalaryCost, error) {
-	existingSalaryCost, err := a.GetSalaryCost(ctx, userID, salaryCostID, true)
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return nil, err
+	}
+	existingSalaryCost, err := a.getSalaryCost(ctx, userID, salaryCostID, true)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -120,6 +144,10 @@ func (a *APIService) UpdateSalaryCost(ctx context.Context, payload models.Create
 		}
 	}
 
+	if err := validateSalaryCostLimits(payload.AmountType, payload.SalaryCostLimits); err != nil {
+		return nil, err
+	}
+
 	if err := a.validateSalaryCostBase(payload, userID, existingSalaryCost.SalaryID, &salaryCostID); err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -139,7 +167,7 @@ func (a *APIService) UpdateSalaryCost(ctx context.Context, payload models.Create
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	salaryCost, err := a.GetSalaryCost(ctx, userID, salaryCostID, true)
+	salaryCost, err := a.getSalaryCost(ctx, userID, salaryCostID, true)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -150,17 +178,20 @@ func (a *APIService) UpdateSalaryCost(ctx context.Context, payload models.Create
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChangeWithParent(ctx, userID, "salary_cost", events.ActionUpdated, salaryCostID, salaryCost.SalaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost", events.ActionUpdated, salaryCostID, salaryCost.SalaryID, existingSalaryCost, salaryCost)
 	return salaryCost, nil
 }
 
 func (a *APIService) DeleteSalaryCost(ctx context.Context, userID int64, salaryCostID int64) error {
-	existingSalaryCost, err := a.GetSalaryCost(ctx, userID, salaryCostID, true)
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return err
+	}
+	existingSalaryCost, err := a.getSalaryCost(ctx, userID, salaryCostID, true)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -171,18 +202,21 @@ func (a *APIService) DeleteSalaryCost(ctx context.Context, userID int64, salaryC
 		return err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChangeWithParent(ctx, userID, "salary_cost", events.ActionDeleted, salaryCostID, existingSalaryCost.SalaryID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost", events.ActionDeleted, salaryCostID, existingSalaryCost.SalaryID, existingSalaryCost, nil)
 	return nil
 }
 
 func (a *APIService) CopySalaryCosts(ctx context.Context, payload models.CopySalaryCosts, userID int64, salaryID int64) error {
+	if err := a.requirePayrollAccess(userID); err != nil {
+		return err
+	}
 	if payload.SourceSalaryID != nil && len(payload.IDs) == 0 {
-		sourceCosts, _, err := a.ListSalaryCosts(ctx, userID, *payload.SourceSalaryID, 1, 1000, true)
+		sourceCosts, _, err := a.listSalaryCosts(ctx, userID, *payload.SourceSalaryID, 1, 1000, true)
 		if err != nil {
 			logger.Logger.Error(err)
 			return err
@@ -220,7 +254,7 @@ func (a *APIService) CopySalaryCosts(ctx context.Context, payload models.CopySal
 		return err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -229,6 +263,17 @@ func (a *APIService) CopySalaryCosts(ctx context.Context, payload models.CopySal
 	return nil
 }
 
+// validateSalaryCostLimits only allows limits and age rates on percentages, a fixed amount has no base
+func validateSalaryCostLimits(amountType string, limits models.SalaryCostLimits) error {
+	if amountType != "percentage" && !limits.IsEmpty() {
+		return fmt.Errorf("grenzwerte und altersabhängige Sätze können nur für prozentuale Lohnkosten gesetzt werden")
+	}
+	if limits.BaseThreshold != nil && limits.BaseCeiling != nil && *limits.BaseCeiling < *limits.BaseThreshold {
+		return fmt.Errorf("die obergrenze muss über der eintrittsschwelle liegen")
+	}
+	return nil
+}
+
 func (a *APIService) validateSalaryCostBase(payload models.CreateSalaryCost, userID, salaryID int64, currentCostID *int64) error {
 	if len(payload.BaseSalaryCostIDs) == 0 {
 		return nil
@@ -376,8 +421,9 @@ func (a *APIService) applyCostCalculation(salaryCost *models.SalaryCost, skipPre
 		dtAsDate := types.AsDate(dt)
 		salaryCost.CalculatedNextExecutionDate = &dtAsDate
 		salaryCost.CalculatedNextCost = nextDetail.Amount
+		// The regular amount per period, the extra payments are only part of the next cost
 		if nextDetail.Divider > 0 {
-			salaryCost.CalculatedAmount = nextDetail.Amount / uint64(nextDetail.Divider)
+			salaryCost.CalculatedAmount = (nextDetail.Amount - nextDetail.ExtraPaymentAmount) / uint64(nextDetail.Divider)
 		}
 	}
 
@@ -52,12 +52,12 @@ func (a *APIService) CreateSalaryCostLabel(ctx context.Context, payload models.C
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "salary_cost_label", events.ActionCreated, salaryCostLabelID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost_label", events.ActionCreated, salaryCostLabelID, 0, nil, salaryCostLabel)
 	return salaryCostLabel, nil
 }
 
 func (a *APIService) UpdateSalaryCostLabel(ctx context.Context, payload models.CreateSalaryCostLabel, userID int64, salaryCostLabelID int64) (*models.SalaryCostLabel, error) {
-	_, err := a.dbService.GetSalaryCostLabel(userID, salaryCostLabelID)
+	existingSalaryCostLabel, err := a.dbService.GetSalaryCostLabel(userID, salaryCostLabelID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -77,7 +77,7 @@ func (a *APIService) UpdateSalaryCostLabel(ctx context.Context, payload models.C
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "salary_cost_label", events.ActionUpdated, salaryCostLabelID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost_label", events.ActionUpdated, salaryCostLabelID, 0, existingSalaryCostLabel, salaryCostLabel)
 	return salaryCostLabel, nil
 }
 
@@ -92,6 +92,6 @@ func (a *APIService) DeleteSalaryCostLabel(ctx context.Context, userID int64, sa
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "salary_cost_label", events.ActionDeleted, existingSalaryCostLabel.ID)
+	a.notifyChangeWithDiff(ctx, userID, "salary_cost_label", events.ActionDeleted, existingSalaryCostLabel.ID, 0, existingSalaryCostLabel, nil)
 	return nil
 }
@@ -0,0 +1,174 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func asDate(year int, month time.Month, day int) types.AsDate {
+	return types.AsDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func extraSalary(fromDate types.AsDate, payout string) models.Salary {
+	return models.Salary{
+		ID:                 1,
+		Amount:             6000_00,
+		Cycle:              utils.CycleMonthly,
+		ExtraSalaries:      1,
+		ExtraSalaryPayout:  payout,
+		FromDate:           fromDate,
+		EmploymentFromDate: fromDate,
+	}
+}
+
+func TestSalaryExtraPayments_PaidInDecember(t *testing.T) {
+	salary := extraSalary(asDate(2024, time.January, 25), models.SalaryExtraPayoutDecember)
+
+	payments := salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 2)
+	require.Equal(t, "2025-12-25", payments[0].Date.ToString())
+	require.Equal(t, uint64(6000_00), payments[0].Amount)
+	require.Equal(t, "13. Monatslohn", payments[0].Name)
+	require.Equal(t, "2026-12-25", payments[1].Date.ToString())
+
+	salary.ExtraSalaries = 2
+	require.Equal(t, uint64(12000_00), salary.ExtraAmountBetween(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, uint64(0), salary.WithoutExtraPayments().ExtraAmountBetween(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSalaryExtraPayments_ProratedInEntryAndExitYear(t *testing.T) {
+	// Entry in April pays 9 of 12 months
+	salary := extraSalary(asDate(2025, time.April, 1), models.SalaryExtraPayoutDecember)
+	payments := salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 1)
+	require.Equal(t, uint64(4500_00), payments[0].Amount)
+
+	// Exit at the end of June pays the first half year with the last salary
+	exitDate := asDate(2026, time.June, 30)
+	salary.PeriodToDate = &exitDate
+	salary.EndsEmployment = true
+	payments = salary.ExtraPaymentsBetween(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 1)
+	require.Equal(t, "2026-06-01", payments[0].Date.ToString())
+	require.Equal(t, uint64(3000_00), payments[0].Amount)
+
+	// A raise in September leaves December to the new salary
+	salary.EndsEmployment = false
+	periodToDate := asDate(2026, time.August, 31)
+	salary.PeriodToDate = &periodToDate
+	require.Empty(t, salary.ExtraPaymentsBetween(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	raise := extraSalary(asDate(2026, time.September, 1), models.SalaryExtraPayoutDecember)
+	raise.Amount = 7200_00
+	raise.EmploymentFromDate = asDate(2025, time.April, 1)
+	payments = raise.ExtraPaymentsBetween(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 1)
+	require.Equal(t, uint64(7200_00), payments[0].Amount)
+}
+
+func TestSalaryExtraPayments_SplitPayouts(t *testing.T) {
+	salary := extraSalary(asDate(2025, time.March, 1), models.SalaryExtraPayoutJuneDecember)
+	payments := salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 2)
+	// March until June
+	require.Equal(t, uint64(2000_00), payments[0].Amount)
+	require.Equal(t, uint64(3000_00), payments[1].Amount)
+
+	salary.ExtraSalaryPayout = models.SalaryExtraPayoutMonthly
+	payments = salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 10)
+	require.Equal(t, uint64(500_00), payments[0].Amount)
+}
+
+func TestSalaryExtraPayments_Bonuses(t *testing.T) {
+	salary := extraSalary(asDate(2025, time.January, 1), models.SalaryExtraPayoutDecember)
+	salary.ExtraSalaries = 0
+	salary.Bonuses = []models.SalaryBonus{
+		{Name: "Bonus", Date: asDate(2025, time.March, 31), Amount: 5000_00},
+		{Name: "Jubiläum", Date: asDate(2026, time.January, 15), Amount: 1000_00},
+	}
+
+	payments := salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 1)
+	require.Equal(t, models.SalaryExtraPaymentBonus, payments[0].Type)
+	require.Equal(t, uint64(5000_00), payments[0].Amount)
+
+	// Not paid after the employment has ended
+	exitDate := asDate(2025, time.December, 31)
+	salary.PeriodToDate = &exitDate
+	salary.EndsEmployment = true
+	require.Empty(t, salary.ExtraPaymentsBetween(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSalaryCostLimits_LimitBaseOfYear(t *testing.T) {
+	ceiling := uint64(148_200_00)
+	limits := models.SalaryCostLimits{BaseCeiling: &ceiling}
+
+	// 13 salaries of 12'350 are above the ALV ceiling, each one pays its share of it
+	yearlyBase := uint64(13 * 12_350_00)
+	require.Equal(t, uint64(11_400_00), limits.LimitBaseOfYear(12_350_00, yearlyBase))
+	// Without limits the base stays
+	require.Equal(t, uint64(12_350_00), models.SalaryCostLimits{}.LimitBaseOfYear(12_350_00, yearlyBase))
+}
+
+func TestCalculateExtraPaymentDeductions_AppliesCostLimits(t *testing.T) {
+	service := &api_service.APIService{}
+	salary := extraSalary(asDate(2025, time.January, 1), models.SalaryExtraPayoutDecember)
+	salary.Amount = 12_350_00
+	payments := salary.ExtraPaymentsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, payments, 1)
+
+	ceiling := uint64(148_200_00)
+	costs := []models.SalaryCost{
+		// AHV without limits
+		{AmountType: "percentage", Amount: 5_300, DistributionType: models.SalaryCostDistributionBoth, Cycle: utils.CycleMonthly},
+		// ALV is capped, the 13 salaries are above the ceiling
+		{AmountType: "percentage", Amount: 1_100, DistributionType: models.SalaryCostDistributionBoth, Cycle: utils.CycleMonthly, SalaryCostLimits: models.SalaryCostLimits{BaseCeiling: &ceiling}},
+		// Employer only
+		{AmountType: "percentage", Amount: 2_000, DistributionType: models.SalaryCostDistributionEmployer, Cycle: utils.CycleMonthly},
+	}
+
+	// 654.55 AHV on the whole payment, 125.40 ALV on its share of the ceiling (11'400)
+	require.Equal(t, uint64(654_55+125_40), service.CalculateExtraPaymentDeductions(salary, costs, payments[0]))
+}
+
+func TestCreateSalary_RejectsExtraSalariesOnOtherCycles(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil).Times(2)
+
+	_, err := service.CreateSalary(context.Background(), models.CreateSalary{
+		Amount:        78000_00,
+		Cycle:         utils.CycleYearly,
+		ExtraSalaries: 1,
+		CurrencyID:    1,
+		FromDate:      "2025-01-01",
+	}, userID, 1)
+	require.ErrorIs(t, err, api_service.ErrInvalidSalaryExtraPayments)
+
+	_, err = service.CreateSalary(context.Background(), models.CreateSalary{
+		Amount:     6000_00,
+		Cycle:      utils.CycleMonthly,
+		CurrencyID: 1,
+		FromDate:   "2025-01-01",
+		Bonuses:    []models.SalaryBonus{{Name: "Bonus", Date: asDate(2024, time.December, 31), Amount: 1000_00}},
+	}, userID, 1)
+	require.ErrorIs(t, err, api_service.ErrInvalidSalaryExtraPayments)
+}
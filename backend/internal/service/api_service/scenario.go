@@ -0,0 +1,446 @@
+package api_service
+
+import (
+	"context"
+	"fmt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+// ListScenarios hides the amounts of salary items from members without payroll clearance
+func (a *APIService) ListScenarios(ctx context.Context, userID int64) ([]models.Scenario, error) {
+	scenarios, err := a.dbService.ListScenarios(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Var(scenarios, "dive"); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	payrollAccess, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !payrollAccess {
+		for i := range scenarios {
+			redactScenarioItems(scenarios[i].Items)
+		}
+	}
+	return scenarios, nil
+}
+
+// GetScenario hides the amounts of salary items from members without payroll clearance
+func (a *APIService) GetScenario(ctx context.Context, userID int64, scenarioID int64) (*models.Scenario, error) {
+	scenario, err := a.getScenario(ctx, userID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+	payrollAccess, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !payrollAccess {
+		redactScenarioItems(scenario.Items)
+	}
+	return scenario, nil
+}
+
+func (a *APIService) getScenario(ctx context.Context, userID int64, scenarioID int64) (*models.Scenario, error) {
+	scenario, err := a.dbService.GetScenario(userID, scenarioID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(scenario); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return scenario, nil
+}
+
+func (a *APIService) CreateScenario(ctx context.Context, payload models.CreateScenario, userID int64) (*models.Scenario, error) {
+	scenarioID, err := a.dbService.CreateScenario(payload, userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	scenario, err := a.GetScenario(ctx, userID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "scenario", events.ActionCreated, scenarioID, 0, nil, scenario)
+	return scenario, nil
+}
+
+func (a *APIService) UpdateScenario(ctx context.Context, payload models.UpdateScenario, userID int64, scenarioID int64) (*models.Scenario, error) {
+	existingScenario, err := a.dbService.GetScenario(userID, scenarioID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.dbService.UpdateScenario(payload, userID, scenarioID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	scenario, err := a.GetScenario(ctx, userID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "scenario", events.ActionUpdated, scenarioID, 0, existingScenario, scenario)
+	return scenario, nil
+}
+
+func (a *APIService) DeleteScenario(ctx context.Context, userID int64, scenarioID int64) error {
+	err := a.dbService.DeleteScenario(userID, scenarioID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChange(ctx, userID, "scenario", events.ActionDeleted, scenarioID)
+	return nil
+}
+
+func (a *APIService) CreateScenarioItem(ctx context.Context, payload models.CreateScenarioItem, userID int64, scenarioID int64) (*models.ScenarioItem, error) {
+	if _, err := a.dbService.GetScenario(userID, scenarioID); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if isPayrollTable(payload.RelatedTable) {
+		if err := a.requirePayrollAccess(userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.validateScenarioItem(payload, userID); err != nil {
+		return nil, err
+	}
+
+	scenarioItemID, err := a.dbService.CreateScenarioItem(payload, userID, scenarioID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	scenarioItem, err := a.dbService.GetScenarioItem(userID, scenarioID, scenarioItemID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	validator := utils.GetValidator()
+	if err := validator.Struct(scenarioItem); err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "scenario_item", events.ActionCreated, scenarioItemID, scenarioID, nil, scenarioItem)
+	return scenarioItem, nil
+}
+
+func (a *APIService) DeleteScenarioItem(ctx context.Context, userID int64, scenarioID int64, scenarioItemID int64) error {
+	err := a.dbService.DeleteScenarioItem(userID, scenarioID, scenarioItemID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithParent(ctx, userID, "scenario_item", events.ActionDeleted, scenarioItemID, scenarioID)
+	return nil
+}
+
+// CalculateScenarioForecast runs the forecast once for the base plan and once with the
+// scenario applied. Nothing is persisted, so scenarios never touch the real forecast.
+func (a *APIService) CalculateScenarioForecast(ctx context.Context, userID int64, scenarioID int64) (*models.ScenarioForecast, error) {
+	scenario, err := a.getScenario(ctx, userID, scenarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseResult, err := a.buildForecast(ctx, userID, nil)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	scenarioResult, err := a.buildForecast(ctx, userID, newScenarioOverlay(scenario.Items))
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	forecasts, forecastDetails := scenarioResult.toForecasts()
+	baseForecasts, _ := baseResult.toForecasts()
+	payrollAccess, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !payrollAccess {
+		redactPayrollDetails(forecastDetails)
+		redactScenarioItems(scenario.Items)
+	}
+
+	return &models.ScenarioForecast{
+		Scenario:        *scenario,
+		Base:            baseForecasts,
+		Forecast:        forecasts,
+		ForecastDetails: forecastDetails,
+		Warnings:        scenarioResult.warnings,
+	}, nil
+}
+
+// isPayrollTable tells whether scenario items on the table reveal salaries
+func isPayrollTable(relatedTable string) bool {
+	return relatedTable == utils.SalariesTableName || relatedTable == utils.SalaryCostsTableName
+}
+
+// redactScenarioItems removes the amounts of the salary items, the rest of the scenario stays visible
+func redactScenarioItems(items []models.ScenarioItem) {
+	for i := range items {
+		if isPayrollTable(items[i].RelatedTable) {
+			items[i].Amount = nil
+		}
+	}
+}
+
+// validateScenarioItem checks the operation specific fields and makes sure every
+// referenced entity belongs to the user's organisation
+func (a *APIService) validateScenarioItem(payload models.CreateScenarioItem, userID int64) error {
+	if payload.Operation == "add" {
+		if payload.Type != nil && *payload.Type == "single" && payload.EndDate != nil {
+			return fmt.Errorf("single items cannot have an end date")
+		}
+		if payload.RelatedTable == utils.TransactionsTableName {
+			if payload.Category == nil {
+				return fmt.Errorf("invalid category: required")
+			}
+			if *payload.Amount == 0 {
+				return fmt.Errorf("invalid amount: must not be zero")
+			}
+		} else if *payload.Amount <= 0 {
+			return fmt.Errorf("invalid amount: must be positive")
+		}
+		if payload.Category != nil {
+			if _, err := a.dbService.GetCategory(userID, *payload.Category); err != nil {
+				return fmt.Errorf("invalid category: not found")
+			}
+		}
+		if _, err := a.dbService.GetCurrency(*payload.Currency); err != nil {
+			return fmt.Errorf("invalid currency: not found")
+		}
+		return nil
+	}
+
+	switch payload.RelatedTable {
+	case utils.TransactionsTableName:
+		if _, err := a.dbService.GetTransaction(userID, *payload.RelatedID); err != nil {
+			return fmt.Errorf("invalid transaction: not found")
+		}
+	case utils.SalariesTableName:
+		if _, err := a.dbService.GetSalary(userID, *payload.RelatedID); err != nil {
+			return fmt.Errorf("invalid salary: not found")
+		}
+	case utils.SalaryCostsTableName:
+		if _, err := a.dbService.GetSalaryCost(userID, *payload.RelatedID); err != nil {
+			return fmt.Errorf("invalid salary cost: not found")
+		}
+	}
+	if payload.Operation == "override" && payload.RelatedTable != utils.TransactionsTableName && *payload.Amount < 0 {
+		return fmt.Errorf("invalid amount: must not be negative")
+	}
+	return nil
+}
+
+// scenarioOverlay indexes the items of a scenario for the forecast calculation.
+// All methods are nil-safe so the base plan can simply pass no overlay.
+type scenarioOverlay struct {
+	removed   map[string]map[int64]bool
+	overrides map[string]map[int64]int64
+	additions []models.ScenarioItem
+}
+
+func newScenarioOverlay(items []models.ScenarioItem) *scenarioOverlay {
+	overlay := &scenarioOverlay{
+		removed:   make(map[string]map[int64]bool),
+		overrides: make(map[string]map[int64]int64),
+		additions: make([]models.ScenarioItem, 0),
+	}
+	for _, item := range items {
+		switch item.Operation {
+		case "add":
+			overlay.additions = append(overlay.additions, item)
+		case "remove":
+			if item.RelatedID == nil {
+				continue
+			}
+			if overlay.removed[item.RelatedTable] == nil {
+				overlay.removed[item.RelatedTable] = make(map[int64]bool)
+			}
+			overlay.removed[item.RelatedTable][*item.RelatedID] = true
+		case "override":
+			if item.RelatedID == nil || item.Amount == nil {
+				continue
+			}
+			// Salaries and salary costs are paid out, a negative amount can't be projected
+			if item.RelatedTable != utils.TransactionsTableName && *item.Amount < 0 {
+				continue
+			}
+			if overlay.overrides[item.RelatedTable] == nil {
+				overlay.overrides[item.RelatedTable] = make(map[int64]int64)
+			}
+			overlay.overrides[item.RelatedTable][*item.RelatedID] = *item.Amount
+		}
+	}
+	return overlay
+}
+
+func (o *scenarioOverlay) isRemoved(relatedTable string, relatedID int64) bool {
+	if o == nil {
+		return false
+	}
+	return o.removed[relatedTable][relatedID]
+}
+
+// override returns the amount per occurrence that replaces the base amount.
+// Salaries are overridden by their net payout, salary costs by their final cost.
+func (o *scenarioOverlay) override(relatedTable string, relatedID int64) (int64, bool) {
+	if o == nil {
+		return 0, false
+	}
+	amount, ok := o.overrides[relatedTable][relatedID]
+	return amount, ok
+}
+
+func (o *scenarioOverlay) applyToTransactions(transactions []models.Transaction) []models.Transaction {
+	if o == nil {
+		return transactions
+	}
+	result := make([]models.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if o.isRemoved(utils.TransactionsTableName, transaction.ID) {
+			continue
+		}
+		if amount, ok := o.override(utils.TransactionsTableName, transaction.ID); ok {
+			// Keep the VAT proportional to the new amount so the settlement follows the override
+			if transaction.Amount != 0 {
+				transaction.VatAmount = transaction.VatAmount * amount / transaction.Amount
+			}
+			transaction.Amount = amount
+		}
+		result = append(result, transaction)
+	}
+	return result
+}
+
+// addScenarioAdditions projects the items that only exist within a scenario. They carry
+// no VAT and cannot be excluded per month, so they are added as they are.
+func addScenarioAdditions(
+	forecastMap map[string]map[string]int64, forecastDetailMap map[string]*models.ForecastDetails,
+	additions []models.ScenarioItem, fiatRates *models.FiatRateTable, warnings *forecastWarnings,
+	today time.Time, lastDayOfMaxEndDate time.Time,
+) {
+	for _, item := range additions {
+		if item.Amount == nil || item.StartDate == nil || item.Type == nil || item.Currency == nil || item.Currency.Code == nil {
+			continue
+		}
+
+		name := "<Kein Name>"
+		if item.Name != nil {
+			name = *item.Name
+		}
+
+		warnings.check(utils.ScenarioItemsTableName, item.ID, name, *item.Currency.Code)
+		fiatRate := fiatRates.Rate(*item.Currency.Code, today)
+		amount := models.CalculateAmountWithFiatRate(*item.Amount, fiatRate)
+
+		var categories []string
+		switch item.RelatedTable {
+		case utils.TransactionsTableName:
+			categoryName := "<Kein Label>"
+			if item.Category != nil {
+				categoryName = item.Category.Name
+			}
+			categories = []string{categoryName, name}
+		case utils.SalariesTableName:
+			// Salaries and salary costs are always expenses
+			amount = -amount
+			categories = []string{"Löhne", name}
+		case utils.SalaryCostsTableName:
+			amount = -amount
+			categories = []string{"Lohnkosten", name}
+		default:
+			continue
+		}
+		isRevenue := amount > 0
+
+		for _, current := range scenarioItemOccurrences(item, lastDayOfMaxEndDate) {
+			if current.Before(today) {
+				continue
+			}
+			monthKey := getYearMonth(current)
+			if forecastMap[monthKey] == nil {
+				initForecastMapKey(forecastMap, monthKey)
+			}
+			if isRevenue {
+				forecastMap[monthKey]["revenue"] += amount
+			} else {
+				forecastMap[monthKey]["expense"] += amount
+			}
+			addForecastDetail(
+				forecastDetailMap, monthKey, amount, isRevenue, false,
+				item.ID, utils.ScenarioItemsTableName, categories...,
+			)
+		}
+	}
+}
+
+func scenarioItemOccurrences(item models.ScenarioItem, lastDayOfMaxEndDate time.Time) []time.Time {
+	startDate := time.Time(*item.StartDate)
+	if *item.Type == "single" || item.Cycle == nil {
+		return []time.Time{startDate}
+	}
+
+	endDate := lastDayOfMaxEndDate
+	if item.EndDate != nil && time.Time(*item.EndDate).Before(endDate) {
+		endDate = time.Time(*item.EndDate)
+	}
+
+	occurrences := make([]time.Time, 0)
+	for current := startDate; !current.After(endDate); current = addOffset(*item.Cycle, startDate, current, 1) {
+		occurrences = append(occurrences, current)
+	}
+	return occurrences
+}
+
+// toForecasts returns the calculated periods in the same shape as the persisted
+// forecast, including empty months, starting with the current month
+func (r *forecastResult) toForecasts() ([]models.Forecast, []models.ForecastDatabaseDetails) {
+	periods := r.horizon.periods()
+	forecasts := make([]models.Forecast, 0, len(periods))
+	forecastDetails := make([]models.ForecastDatabaseDetails, 0, len(periods))
+
+	for _, period := range periods {
+		revenue := r.months[period.month]["revenue"]
+		expense := r.months[period.month]["expense"]
+		forecasts = append(forecasts, models.Forecast{
+			Data: models.ForecastData{
+				Month:    period.month,
+				Period:   period.period,
+				EndMonth: period.endMonth,
+				Revenue:  revenue,
+				Expense:  expense,
+				Cashflow: revenue + expense,
+			},
+		})
+
+		revenueList, expenseList := flattenForecastDetails(r.details[period.month])
+		forecastDetails = append(forecastDetails, models.ForecastDatabaseDetails{
+			Month:   period.month,
+			Revenue: revenueList,
+			Expense: expenseList,
+		})
+	}
+	applyRunningBalance(forecasts, r.openingBalance)
+
+	return forecasts, forecastDetails
+}
@@ -0,0 +1,248 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func TestCalculateScenarioForecast_AppliesOverlayWithoutPersisting(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(77)
+	fixedToday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	baseCode := "CHF"
+	eurCode := "EUR"
+	localeCode := "de-CH"
+	currencyID := int64(1)
+	orgCurrency := models.Currency{
+		ID:         &currencyID,
+		Code:       &baseCode,
+		LocaleCode: &localeCode,
+	}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 700,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{
+		ID:       user.CurrentOrganisationID,
+		Name:     "Org",
+		Currency: orgCurrency,
+	}
+
+	february := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	transactions := []models.Transaction{
+		{
+			ID:          1,
+			Name:        "Client X",
+			Amount:      100_00,
+			VatIncluded: true,
+			Type:        "single",
+			StartDate:   types.AsDate(february),
+			Category:    models.Category{Name: "Sales"},
+			Currency:    orgCurrency,
+		},
+		{
+			ID:          2,
+			Name:        "Client Y",
+			Amount:      150_00,
+			VatIncluded: true,
+			Type:        "single",
+			StartDate:   types.AsDate(march),
+			Category:    models.Category{Name: "Sales"},
+			Currency:    orgCurrency,
+		},
+	}
+
+	overrideAmount := int64(300_00)
+	hireAmount := int64(40_00)
+	hireName := "New Hire"
+	repeating := "repeating"
+	monthly := utils.CycleMonthly
+	hireStart := types.AsDate(march)
+	hireEnd := types.AsDate(april)
+	scenario := models.Scenario{
+		ID:   5,
+		Name: "Lose Y, hire one",
+		Items: []models.ScenarioItem{
+			{ID: 10, Operation: "override", RelatedTable: utils.TransactionsTableName, RelatedID: &transactions[0].ID, Amount: &overrideAmount},
+			{ID: 11, Operation: "remove", RelatedTable: utils.TransactionsTableName, RelatedID: &transactions[1].ID},
+			{
+				ID: 12, Operation: "add", RelatedTable: utils.SalariesTableName, Name: &hireName, Amount: &hireAmount,
+				Type: &repeating, Cycle: &monthly, StartDate: &hireStart, EndDate: &hireEnd, Currency: &orgCurrency,
+			},
+		},
+	}
+
+	mockDB.EXPECT().GetScenario(userID, scenario.ID).Return(&scenario, nil)
+
+	// The base plan and the scenario are both calculated from the same data
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil).Times(2)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil).Times(2)
+	mockDB.EXPECT().
+		ListTransactions(userID, int64(1), int64(100000), "name", "ASC", "", true, false).
+		DoAndReturn(func(_, _, _ int64, _, _, _ string, _, _ bool) ([]models.Transaction, int64, error) {
+			result := make([]models.Transaction, len(transactions))
+			copy(result, transactions)
+			return result, int64(len(result)), nil
+		}).
+		Times(2)
+	mockDB.EXPECT().
+		ListFiatRates(baseCode).
+		Return([]models.FiatRate{{Base: baseCode, Target: eurCode, Rate: 0.8}}, nil).
+		Times(2)
+	mockDB.EXPECT().
+		ListOrganisationFiatRates(userID).
+		Return([]models.OrganisationFiatRate{}, nil).
+		Times(2)
+	mockDB.EXPECT().ListAllForecastExclusions(userID).Return([]models.ForecastExclusionInfo{}, nil).Times(2)
+	mockDB.EXPECT().
+		ListEmployees(userID, int64(1), int64(100000), "name", "ASC", "", false).
+		Return([]models.Employee{}, int64(0), nil).
+		Times(2)
+	mockDB.EXPECT().GetVatSetting(userID).Return(nil, nil).Times(2)
+	mockDB.EXPECT().
+		ListBankAccountsAtDate(userID, "2024-01-01").
+		Return([]models.BankAccount{
+			{ID: 1, Name: "Main", Amount: 1000_00, Currency: models.Currency{Code: &baseCode}},
+			{ID: 2, Name: "Savings", Amount: 500_00, Currency: models.Currency{Code: &eurCode}},
+		}, nil).
+		Times(2)
+
+	// No ClearForecasts/UpsertForecast expectations: scenarios must never be persisted
+	result, err := service.CalculateScenarioForecast(context.Background(), userID, scenario.ID)
+	require.NoError(t, err)
+
+	totalMonths := int(utils.GetTotalMonthsForForecastYears(utils.DefaultForecastYears))
+	require.Len(t, result.Base, totalMonths)
+	require.Len(t, result.Forecast, totalMonths)
+	require.Len(t, result.ForecastDetails, totalMonths)
+	require.Equal(t, "2024-01", result.Forecast[0].Data.Month)
+
+	// Base plan stays untouched
+	require.EqualValues(t, 100_00, result.Base[1].Data.Revenue)
+	require.EqualValues(t, 150_00, result.Base[2].Data.Revenue)
+	require.EqualValues(t, 0, result.Base[3].Data.Expense)
+
+	// Scenario overrides Client X, drops Client Y and adds the hire for March and April
+	require.Equal(t, "2024-02", result.Forecast[1].Data.Month)
+	require.EqualValues(t, 300_00, result.Forecast[1].Data.Revenue)
+	require.EqualValues(t, 0, result.Forecast[2].Data.Revenue)
+	require.EqualValues(t, -40_00, result.Forecast[2].Data.Expense)
+	require.EqualValues(t, -40_00, result.Forecast[3].Data.Cashflow)
+	require.EqualValues(t, 0, result.Forecast[4].Data.Expense)
+
+	// Both plans start from today's bank balances converted into CHF and carry the cashflow forward
+	require.EqualValues(t, 1625_00, result.Base[0].Data.OpeningBalance)
+	require.EqualValues(t, 1625_00, result.Forecast[0].Data.OpeningBalance)
+	require.EqualValues(t, 1625_00+300_00, result.Forecast[1].Data.ClosingBalance)
+	require.EqualValues(t, 1625_00+300_00-40_00, result.Forecast[2].Data.ClosingBalance)
+	for i := 1; i < totalMonths; i++ {
+		require.Equal(t, result.Base[i-1].Data.ClosingBalance, result.Base[i].Data.OpeningBalance)
+		require.Equal(t, result.Forecast[i-1].Data.ClosingBalance, result.Forecast[i].Data.OpeningBalance)
+	}
+
+	marchExpenses := result.ForecastDetails[2].Expense
+	require.Len(t, marchExpenses, 1)
+	require.Equal(t, "Löhne", marchExpenses[0].Name)
+	require.Len(t, marchExpenses[0].Children, 1)
+	require.Equal(t, hireName, marchExpenses[0].Children[0].Name)
+	require.Equal(t, utils.ScenarioItemsTableName, marchExpenses[0].Children[0].RelatedTable)
+	require.EqualValues(t, 12, marchExpenses[0].Children[0].RelatedID)
+}
+
+func TestCreateScenarioItem_RejectsNegativeSalaryOverride(t *testing.T) {
+	userID := int64(77)
+	salaryID := int64(3)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetScenario(userID, int64(5)).Return(&models.Scenario{ID: 5}, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetSalary(userID, salaryID).Return(&models.Salary{ID: salaryID}, nil)
+
+	amount := int64(-1)
+	_, err := service.CreateScenarioItem(context.Background(), models.CreateScenarioItem{
+		Operation:    "override",
+		RelatedTable: utils.SalariesTableName,
+		RelatedID:    &salaryID,
+		Amount:       &amount,
+	}, userID, 5)
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func TestScenarioItems_HideSalariesFromUnclearedMembers(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(77)
+	salaryID := int64(3)
+	transactionID := int64(4)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	salaryAmount := int64(9000_00)
+	transactionAmount := int64(500_00)
+	scenario := func() *models.Scenario {
+		return &models.Scenario{ID: 5, Name: "Plan", Items: []models.ScenarioItem{
+			{ID: 1, Operation: "override", RelatedTable: utils.SalariesTableName, RelatedID: &salaryID, Amount: &salaryAmount},
+			{ID: 2, Operation: "override", RelatedTable: utils.TransactionsTableName, RelatedID: &transactionID, Amount: &transactionAmount},
+		}}
+	}
+	uncleared := &models.PayrollAccess{Role: "editor", Confidential: true}
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(uncleared, nil).Times(3)
+	mockDB.EXPECT().GetScenario(userID, int64(5)).DoAndReturn(func(_, _ int64) (*models.Scenario, error) {
+		return scenario(), nil
+	}).Times(2)
+	mockDB.EXPECT().ListScenarios(userID).Return([]models.Scenario{*scenario()}, nil)
+
+	result, err := service.GetScenario(context.Background(), userID, 5)
+	require.NoError(t, err)
+	require.Nil(t, result.Items[0].Amount)
+	require.Equal(t, transactionAmount, *result.Items[1].Amount)
+
+	scenarios, err := service.ListScenarios(context.Background(), userID)
+	require.NoError(t, err)
+	require.Nil(t, scenarios[0].Items[0].Amount)
+
+	amount := int64(8000_00)
+	_, err = service.CreateScenarioItem(context.Background(), models.CreateScenarioItem{
+		Operation:    "override",
+		RelatedTable: utils.SalariesTableName,
+		RelatedID:    &salaryID,
+		Amount:       &amount,
+	}, userID, 5)
+	require.ErrorIs(t, err, api_service.ErrPayrollConfidential)
+}
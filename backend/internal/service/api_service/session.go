@@ -0,0 +1,53 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+)
+
+// ErrCurrentSessionUnknown is returned for access tokens issued before sessions were tracked,
+// without the current session all other sessions can't be told apart
+var ErrCurrentSessionUnknown = errors.New("current session unknown")
+
+// ListSessions returns the active web sessions of the user, the one of the request is marked as current
+func (a *APIService) ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]models.Session, error) {
+	sessions, err := a.dbService.ListSessions(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = currentSessionID != "" && sessions[i].TokenID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs out another device of the user, the current session ends with a logout instead
+func (a *APIService) RevokeSession(ctx context.Context, userID int64, sessionID int64, currentSessionID string) error {
+	err := a.dbService.DeleteSession(userID, sessionID, currentSessionID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	// Same as logout, the streams of the remaining sessions reconnect on their own
+	a.closeUserStreams(userID)
+	return nil
+}
+
+// RevokeOtherSessions logs out every device of the user except the current one
+func (a *APIService) RevokeOtherSessions(ctx context.Context, userID int64, currentSessionID string) error {
+	if currentSessionID == "" {
+		return ErrCurrentSessionUnknown
+	}
+	revoked, err := a.dbService.DeleteOtherSessions(userID, currentSessionID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if revoked > 0 {
+		a.closeUserStreams(userID)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/events"
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+)
+
+func TestListSessions_MarksCurrentSession(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().ListSessions(userID).Return([]models.Session{
+		{ID: 1, TokenID: "other"},
+		{ID: 2, TokenID: "current"},
+	}, nil)
+
+	sessions, err := service.ListSessions(context.Background(), userID, "current")
+	require.NoError(t, err)
+	require.False(t, sessions[0].Current)
+	require.True(t, sessions[1].Current)
+}
+
+func TestRevokeOtherSessions_ClosesEventStreams(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	hub := events.NewHub()
+	service.SetEventHub(hub)
+	sub := hub.Subscribe(userID)
+
+	// Without the current session every session would be revoked
+	err := service.RevokeOtherSessions(context.Background(), userID, "")
+	require.ErrorIs(t, err, api_service.ErrCurrentSessionUnknown)
+
+	mockDB.EXPECT().DeleteOtherSessions(userID, "current").Return(int64(2), nil)
+	err = service.RevokeOtherSessions(context.Background(), userID, "current")
+	require.NoError(t, err)
+
+	select {
+	case <-sub.Done:
+	default:
+		t.Fatal("expected the event stream to be closed")
+	}
+}
@@ -0,0 +1,220 @@
+package api_service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrSSOEmailNotVerified is returned when the provider does not confirm the email of the user
+	ErrSSOEmailNotVerified = errors.New("sso email not verified")
+	// ErrSSODomainNotAllowed is returned when the SSO domain is not the email domain of the acting user
+	ErrSSODomainNotAllowed = errors.New("sso domain not allowed")
+	// ErrSSODomainTaken is returned when another organisation uses the SSO domain already
+	ErrSSODomainTaken = errors.New("sso domain taken")
+)
+
+// publicEmailDomains can't be claimed as SSO domain, every user of the provider would join
+var publicEmailDomains = []string{
+	"gmail.com", "googlemail.com", "outlook.com", "hotmail.com", "live.com", "icloud.com",
+	"yahoo.com", "gmx.ch", "gmx.net", "gmx.de", "bluewin.ch", "protonmail.com", "proton.me",
+}
+
+// LoginSSO signs in the user of a verified SSO identity. Unknown identities are linked to the user
+// with the same email or a new user is created, both join the organisation of their email domain.
+func (a *APIService) LoginSSO(ctx context.Context, identity models.SSOIdentity, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, nil, nil, nil, nil, ErrSSOEmailNotVerified
+	}
+
+	userID, err := a.dbService.GetUserIDBySSOIdentity(identity.Provider, identity.Subject)
+	switch {
+	case err == nil:
+		err = a.dbService.TouchUserIdentity(identity.Provider, identity.Subject, identity.Email)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, nil, nil, nil, nil, err
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		userID, err = a.linkSSOIdentity(ctx, identity)
+		if err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+	default:
+		logger.Logger.Error(err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	// The provider replaces the password but not the second factor of the user
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, nil, nil, nil, nil, err
+	}
+	if twoFactor.Enabled {
+		challengeToken, expiresAt, err := auth.GenerateTwoFactorChallengeToken(userID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, nil, nil, nil, nil, err
+		}
+		return nil, nil, nil, nil, nil, &TwoFactorChallenge{Challenge: models.LoginChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+			ExpiresAt:         expiresAt,
+		}}
+	}
+
+	return a.issueLoginSession(ctx, userID, deviceName, existingRefreshToken)
+}
+
+// linkSSOIdentity stores the identity for the user with the same email or a newly provisioned user
+func (a *APIService) linkSSOIdentity(ctx context.Context, identity models.SSOIdentity) (int64, error) {
+	provisioned := false
+	userID, err := a.dbService.GetUserIDByEmail(identity.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		userID, err = a.provisionSSOUser(identity.Email)
+		provisioned = true
+	}
+	if err != nil {
+		logger.Logger.Error(err)
+		return 0, err
+	}
+
+	err = a.dbService.CreateUserIdentity(userID, identity.Provider, identity.Subject, identity.Email)
+	if err != nil {
+		logger.Logger.Error(err)
+		return 0, err
+	}
+
+	err = a.joinSSOOrganisation(ctx, userID, identity.Email, provisioned)
+	if err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// provisionSSOUser creates a user like FinishRegistration, the random password can only be replaced
+// through the password reset
+func (a *APIService) provisionSSOUser(email string) (int64, error) {
+	encryptedPassword, err := bcrypt.GenerateFromPassword([]byte(rand.Text()), 12)
+	if err != nil {
+		return 0, err
+	}
+
+	userID, err := a.dbService.CreateUser(email, string(encryptedPassword))
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = a.dbService.CreateUserSetting(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Every new user gets an organisation assigned automatically
+	organisationID, err := a.dbService.CreateOrganisation("Meine Organisation")
+	if err != nil {
+		return 0, err
+	}
+	err = a.dbService.AssignUserToOrganisation(userID, organisationID, "owner", true)
+	if err != nil {
+		return 0, err
+	}
+	err = a.dbService.SetUserCurrentOrganisation(userID, organisationID)
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// joinSSOOrganisation adds the user to the organisation that claimed the domain of the email,
+// new users start in that organisation
+func (a *APIService) joinSSOOrganisation(ctx context.Context, userID int64, email string, setCurrent bool) error {
+	organisation, err := a.dbService.GetOrganisationBySSODomain(emailDomain(email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		logger.Logger.Error(err)
+		return err
+	}
+
+	isMember, err := a.dbService.CheckUserInOrganisation(userID, organisation.ID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if isMember {
+		return nil
+	}
+
+	err = a.dbService.AssignUserToOrganisation(userID, organisation.ID, organisation.DefaultRole, false)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	permission := models.DefaultPermissionForRole(organisation.DefaultRole)
+	err = a.dbService.UpsertMemberPermission(userID, organisation.ID, permission.CanView, permission.CanEdit, permission.CanDelete)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if setCurrent {
+		err = a.dbService.SetUserCurrentOrganisation(userID, organisation.ID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return err
+		}
+	}
+
+	a.notifyOrganisationChange(ctx, userID, organisation.ID, "member", events.ActionCreated, userID)
+	return nil
+}
+
+// validateSSODomain only lets users claim the domain of their own email address
+func (a *APIService) validateSSODomain(userID int64, organisationID int64, domain string) error {
+	domain = strings.ToLower(domain)
+	if domain == "" {
+		return nil
+	}
+	if slices.Contains(publicEmailDomains, domain) {
+		return ErrSSODomainNotAllowed
+	}
+	user, err := a.dbService.GetProfile(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if emailDomain(user.Email) != domain {
+		return ErrSSODomainNotAllowed
+	}
+
+	organisation, err := a.dbService.GetOrganisationBySSODomain(domain)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		logger.Logger.Error(err)
+		return err
+	}
+	if organisation.ID != organisationID {
+		return ErrSSODomainTaken
+	}
+	return nil
+}
+
+func emailDomain(email string) string {
+	_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	return domain
+}
@@ -0,0 +1,110 @@
+package api_service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+)
+
+func TestLoginSSO_RejectsUnverifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	_, _, _, _, _, err := service.LoginSSO(context.Background(), models.SSOIdentity{
+		Provider: "entra",
+		Subject:  "subject",
+		Email:    "anna@firma.ch",
+	}, "test-device", "")
+	require.ErrorIs(t, err, api_service.ErrSSOEmailNotVerified)
+}
+
+func TestLoginSSO_ProvisionsUserAndJoinsDomainOrganisation(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+	userID := int64(42)
+	personalOrganisationID := int64(3)
+	domainOrganisationID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	identity := models.SSOIdentity{Provider: "entra", Subject: "subject", Email: "anna@firma.ch", EmailVerified: true}
+
+	mockDB.EXPECT().GetUserIDBySSOIdentity("entra", "subject").Return(int64(0), sql.ErrNoRows)
+	mockDB.EXPECT().GetUserIDByEmail("anna@firma.ch").Return(int64(0), sql.ErrNoRows)
+	mockDB.EXPECT().CreateUser("anna@firma.ch", gomock.Any()).Return(userID, nil)
+	mockDB.EXPECT().CreateUserSetting(userID).Return(int64(1), nil)
+	mockDB.EXPECT().CreateOrganisation("Meine Organisation").Return(personalOrganisationID, nil)
+	mockDB.EXPECT().AssignUserToOrganisation(userID, personalOrganisationID, "owner", true).Return(nil)
+	mockDB.EXPECT().SetUserCurrentOrganisation(userID, personalOrganisationID).Return(nil)
+	mockDB.EXPECT().CreateUserIdentity(userID, "entra", "subject", "anna@firma.ch").Return(nil)
+	mockDB.EXPECT().GetOrganisationBySSODomain("firma.ch").Return(&models.SSOOrganisation{ID: domainOrganisationID, DefaultRole: "editor"}, nil)
+	mockDB.EXPECT().CheckUserInOrganisation(userID, domainOrganisationID).Return(false, nil)
+	mockDB.EXPECT().AssignUserToOrganisation(userID, domainOrganisationID, "editor", false).Return(nil)
+	mockDB.EXPECT().UpsertMemberPermission(userID, domainOrganisationID, true, true, false).Return(nil)
+	mockDB.EXPECT().SetUserCurrentOrganisation(userID, domainOrganisationID).Return(nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
+	mockDB.EXPECT().GetUserTwoFactor(userID).Return(&models.UserTwoFactor{}, nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, Email: "anna@firma.ch"}, nil)
+	mockDB.EXPECT().StoreRefreshTokenID(userID, gomock.Any(), gomock.Any(), "test-device", "").Return(nil)
+
+	user, accessToken, _, refreshToken, _, err := service.LoginSSO(context.Background(), identity, "test-device", "")
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+	require.NotNil(t, accessToken)
+	require.NotNil(t, refreshToken)
+}
+
+func TestLoginSSO_KnownIdentityRequiresSecondFactor(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	identity := models.SSOIdentity{Provider: "entra", Subject: "subject", Email: "anna@firma.ch", EmailVerified: true}
+
+	mockDB.EXPECT().GetUserIDBySSOIdentity("entra", "subject").Return(userID, nil)
+	mockDB.EXPECT().TouchUserIdentity("entra", "subject", "anna@firma.ch").Return(nil)
+	mockDB.EXPECT().GetUserTwoFactor(userID).Return(&models.UserTwoFactor{Enabled: true}, nil)
+
+	_, _, _, _, _, err := service.LoginSSO(context.Background(), identity, "test-device", "")
+	var challenge *api_service.TwoFactorChallenge
+	require.True(t, errors.As(err, &challenge))
+	require.NotEmpty(t, challenge.Challenge.ChallengeToken)
+}
+
+func TestUpdateOrganisation_RejectsForeignSSODomain(t *testing.T) {
+	userID := int64(42)
+	organisationID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	mockDB.EXPECT().GetOrganisation(userID, organisationID).Return(&models.Organisation{
+		ID: organisationID, Role: "owner", ForecastYears: 3, ForecastMonthlyYears: 1,
+	}, nil).Times(2)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, Email: "anna@firma.ch"}, nil)
+
+	domain := "other.ch"
+	_, err := service.UpdateOrganisation(context.Background(), models.UpdateOrganisation{SSODomain: &domain}, userID, organisationID)
+	require.ErrorIs(t, err, api_service.ErrSSODomainNotAllowed)
+
+	domain = "gmail.com"
+	_, err = service.UpdateOrganisation(context.Background(), models.UpdateOrganisation{SSODomain: &domain}, userID, organisationID)
+	require.ErrorIs(t, err, api_service.ErrSSODomainNotAllowed)
+}
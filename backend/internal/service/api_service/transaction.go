@@ -70,12 +70,12 @@ func (a *APIService) CreateTransaction(ctx context.Context, payload models.Creat
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "transaction", events.ActionCreated, transactionID)
+	a.notifyChangeWithDiff(ctx, userID, "transaction", events.ActionCreated, transactionID, 0, nil, transaction)
 	return transaction, nil
 }
 
@@ -132,17 +132,17 @@ func (a *APIService) UpdateTransaction(ctx context.Context, payload models.Updat
 		return nil, err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "transaction", events.ActionUpdated, transactionID)
+	a.notifyChangeWithDiff(ctx, userID, "transaction", events.ActionUpdated, transactionID, 0, existingTransaction, transaction)
 	return transaction, nil
 }
 
 func (a *APIService) DeleteTransaction(ctx context.Context, userID int64, transactionID int64) error {
-	_, err := a.dbService.GetTransaction(userID, transactionID)
+	existingTransaction, err := a.dbService.GetTransaction(userID, transactionID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -153,11 +153,11 @@ func (a *APIService) DeleteTransaction(ctx context.Context, userID int64, transa
 		return err
 	}
 	// Recalculate Forecast
-	_, err = a.CalculateForecast(ctx, userID)
+	err = a.scheduleForecast(ctx, userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "transaction", events.ActionDeleted, transactionID)
+	a.notifyChangeWithDiff(ctx, userID, "transaction", events.ActionDeleted, transactionID, 0, existingTransaction, nil)
 	return nil
 }
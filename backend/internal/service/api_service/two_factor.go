@@ -0,0 +1,242 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+var (
+	// ErrInvalidTwoFactorCode covers wrong, reused and expired codes as well as invalid challenge tokens
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupMissing   = errors.New("two-factor setup has not been started")
+)
+
+// TwoFactorChallenge is returned by Login instead of a session when the user has two-factor
+// authentication enabled. The challenge token is exchanged in LoginTwoFactor.
+type TwoFactorChallenge struct {
+	Challenge models.LoginChallenge
+}
+
+func (c *TwoFactorChallenge) Error() string {
+	return "two-factor authentication required"
+}
+
+// LoginTwoFactor is the second step of a login, it verifies a TOTP or recovery code and issues the session
+func (a *APIService) LoginTwoFactor(ctx context.Context, payload models.LoginTwoFactor, deviceName string, existingRefreshToken string) (*models.User, *string, *time.Time, *string, *time.Time, error) {
+	userID, err := auth.VerifyTwoFactorChallengeToken(payload.ChallengeToken)
+	if err != nil {
+		return nil, nil, nil, nil, nil, ErrInvalidTwoFactorCode
+	}
+	// A six digit code is quickly guessed, the limiter counts per user since the challenge can be repeated
+	attemptIdentifier := userAttemptIdentifier(userID)
+	err = a.checkAuthAttempts(ctx, authScopeTwoFactor, attemptIdentifier)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, nil, nil, nil, nil, err
+	}
+	if !twoFactor.Enabled {
+		return nil, nil, nil, nil, nil, ErrInvalidTwoFactorCode
+	}
+	err = a.verifyTwoFactorCode(userID, twoFactor, payload.Code, true)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) && a.recordAuthFailure(ctx, authScopeTwoFactor, attemptIdentifier) {
+			// Whoever tries knows the password already
+			if user, err := a.dbService.GetProfile(userID); err == nil {
+				a.notifyAccountLocked(user.Email)
+			}
+		}
+		return nil, nil, nil, nil, nil, err
+	}
+	a.resetAuthAttempts(authScopeTwoFactor, attemptIdentifier)
+	return a.issueLoginSession(ctx, userID, deviceName, existingRefreshToken)
+}
+
+func (a *APIService) GetTwoFactorStatus(ctx context.Context, userID int64) (*models.TwoFactorStatus, error) {
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	required, err := a.dbService.CheckUserTwoFactorRequired(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	status := models.TwoFactorStatus{
+		Enabled:  twoFactor.Enabled,
+		Required: required,
+	}
+	if twoFactor.Enabled {
+		status.RecoveryCodesRemaining, err = a.dbService.CountUserRecoveryCodes(userID)
+		if err != nil {
+			logger.Logger.Error(err)
+			return nil, err
+		}
+	}
+	return &status, nil
+}
+
+// SetupTwoFactor generates a new secret, it is only used for logins once EnableTwoFactor confirmed it
+func (a *APIService) SetupTwoFactor(ctx context.Context, userID int64) (*models.TwoFactorSetup, error) {
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if twoFactor.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	user, err := a.dbService.GetProfile(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.dbService.UpdateUserTwoFactor(userID, &secret, false)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return &models.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(secret, utils.TwoFactorIssuer, user.Email),
+	}, nil
+}
+
+// EnableTwoFactor confirms the secret of the setup with a code of the authenticator app
+func (a *APIService) EnableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error) {
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if twoFactor.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if twoFactor.Secret == nil {
+		return nil, ErrTwoFactorSetupMissing
+	}
+	step, ok := auth.ValidateTOTPCode(*twoFactor.Secret, payload.Code, time.Now(), 0)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	err = a.dbService.UpdateUserTwoFactor(userID, twoFactor.Secret, true)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	_, err = a.dbService.UpdateUserTwoFactorLastStep(userID, step)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return a.replaceRecoveryCodes(userID)
+}
+
+// DisableTwoFactor removes the secret and all recovery codes, it needs a valid code to do so
+func (a *APIService) DisableTwoFactor(ctx context.Context, payload models.TwoFactorCode, userID int64) error {
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if !twoFactor.Enabled {
+		return ErrTwoFactorNotEnabled
+	}
+	err = a.verifyTwoFactorCode(userID, twoFactor, payload.Code, true)
+	if err != nil {
+		return err
+	}
+	err = a.dbService.UpdateUserTwoFactor(userID, nil, false)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.ReplaceUserRecoveryCodes(userID, nil)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// RegenerateTwoFactorRecoveryCodes invalidates the remaining recovery codes and returns new ones
+func (a *APIService) RegenerateTwoFactorRecoveryCodes(ctx context.Context, payload models.TwoFactorCode, userID int64) (*models.TwoFactorRecoveryCodes, error) {
+	twoFactor, err := a.dbService.GetUserTwoFactor(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	if !twoFactor.Enabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	// A recovery code would be replaced right away anyway, so only the app counts here
+	err = a.verifyTwoFactorCode(userID, twoFactor, payload.Code, false)
+	if err != nil {
+		return nil, err
+	}
+	return a.replaceRecoveryCodes(userID)
+}
+
+// verifyTwoFactorCode accepts a TOTP code of a step that was not used yet and, if allowed, an unused recovery code
+func (a *APIService) verifyTwoFactorCode(userID int64, twoFactor *models.UserTwoFactor, code string, allowRecoveryCode bool) error {
+	if twoFactor.Secret != nil {
+		if step, ok := auth.ValidateTOTPCode(*twoFactor.Secret, code, time.Now(), twoFactor.LastStep); ok {
+			// A concurrent login may have used the same step in the meantime
+			accepted, err := a.dbService.UpdateUserTwoFactorLastStep(userID, step)
+			if err != nil {
+				logger.Logger.Error(err)
+				return err
+			}
+			if !accepted {
+				return ErrInvalidTwoFactorCode
+			}
+			return nil
+		}
+	}
+	if !allowRecoveryCode {
+		return ErrInvalidTwoFactorCode
+	}
+	used, err := a.dbService.UseUserRecoveryCode(userID, auth.HashRecoveryCode(code))
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (a *APIService) replaceRecoveryCodes(userID int64) (*models.TwoFactorRecoveryCodes, error) {
+	codes, err := auth.GenerateRecoveryCodes(utils.TwoFactorRecoveryCodeCount)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	codeHashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		codeHashes = append(codeHashes, auth.HashRecoveryCode(code))
+	}
+	err = a.dbService.ReplaceUserRecoveryCodes(userID, codeHashes)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return &models.TwoFactorRecoveryCodes{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,107 @@
+package api_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/auth"
+	"liquiswiss/pkg/models"
+)
+
+func TestLogin_ReturnsChallengeWhenTwoFactorEnabled(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	mockDB.EXPECT().GetAuthBlock("login", "email:test@example.com", "").Return(time.Duration(0), nil)
+	mockDB.EXPECT().GetUserPasswordByEMail("test@example.com").Return(&models.Login{ID: userID, Password: string(hash)}, nil)
+	mockDB.EXPECT().ResetAuthAttempts("login", "email:test@example.com").Return(nil)
+	mockDB.EXPECT().GetUserTwoFactor(userID).Return(&models.UserTwoFactor{Secret: &secret, Enabled: true}, nil)
+
+	user, accessToken, _, refreshToken, _, err := service.Login(context.Background(), models.Login{Email: "test@example.com", Password: "secret-password"}, "test", "")
+	require.Nil(t, user)
+	require.Nil(t, accessToken)
+	require.Nil(t, refreshToken)
+	var challenge *api_service.TwoFactorChallenge
+	require.True(t, errors.As(err, &challenge))
+	require.True(t, challenge.Challenge.TwoFactorRequired)
+
+	challengedUserID, err := auth.VerifyTwoFactorChallengeToken(challenge.Challenge.ChallengeToken)
+	require.NoError(t, err)
+	require.Equal(t, userID, challengedUserID)
+}
+
+func TestLoginTwoFactor_RejectsUsedStepAndUnknownRecoveryCode(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	step := auth.TOTPStep(time.Now())
+	code, err := auth.GenerateTOTPCode(secret, step)
+	require.NoError(t, err)
+	challengeToken, _, err := auth.GenerateTwoFactorChallengeToken(userID)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().GetAuthBlock("two-factor", "user:42", "").Return(time.Duration(0), nil)
+	// The code of the current step was used for the last login already
+	mockDB.EXPECT().GetUserTwoFactor(userID).Return(&models.UserTwoFactor{Secret: &secret, Enabled: true, LastStep: step + 1}, nil)
+	mockDB.EXPECT().UseUserRecoveryCode(userID, auth.HashRecoveryCode(code)).Return(false, nil)
+	mockDB.EXPECT().RecordAuthFailure("two-factor", "user:42", gomock.Any()).Return(int64(1), false, nil)
+
+	_, _, _, _, _, err = service.LoginTwoFactor(context.Background(), models.LoginTwoFactor{ChallengeToken: challengeToken, Code: code}, "test", "")
+	require.ErrorIs(t, err, api_service.ErrInvalidTwoFactorCode)
+}
+
+func TestEnableTwoFactor_ConfirmsSetupAndIssuesRecoveryCodes(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	code, err := auth.GenerateTOTPCode(secret, auth.TOTPStep(time.Now()))
+	require.NoError(t, err)
+
+	mockDB.EXPECT().GetUserTwoFactor(userID).Return(&models.UserTwoFactor{Secret: &secret}, nil)
+	mockDB.EXPECT().UpdateUserTwoFactor(userID, &secret, true).Return(nil)
+	mockDB.EXPECT().UpdateUserTwoFactorLastStep(userID, gomock.Any()).Return(true, nil)
+	var storedHashes []string
+	mockDB.EXPECT().ReplaceUserRecoveryCodes(userID, gomock.Any()).DoAndReturn(func(_ int64, codeHashes []string) error {
+		storedHashes = codeHashes
+		return nil
+	})
+
+	recoveryCodes, err := service.EnableTwoFactor(context.Background(), models.TwoFactorCode{Code: code}, userID)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes.RecoveryCodes, 10)
+	require.Len(t, storedHashes, 10)
+	require.Equal(t, auth.HashRecoveryCode(recoveryCodes.RecoveryCodes[0]), storedHashes[0])
+	require.NotContains(t, storedHashes, recoveryCodes.RecoveryCodes[0])
+}
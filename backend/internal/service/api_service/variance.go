@@ -0,0 +1,209 @@
+package api_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"sort"
+	"time"
+)
+
+// ErrInvalidVarianceRange is returned for malformed months or a range the report cannot cover
+var ErrInvalidVarianceRange = errors.New("invalid variance range")
+
+const (
+	// maxVarianceMonths limits the months of a single variance report
+	maxVarianceMonths = 36
+	// unassignedCategoryName groups actuals that are not linked to a planned transaction
+	unassignedCategoryName = "Nicht zugeordnet"
+)
+
+// GetVarianceReport compares the recorded plan with the actuals for the months from to to (YYYY-MM).
+// Without a range the last twelve months up to the current one are reported.
+func (a *APIService) GetVarianceReport(ctx context.Context, userID int64, from string, to string) (*models.VarianceReport, error) {
+	today := utils.GetTodayAsUTC()
+	toMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		parsed, err := time.Parse("2006-01", to)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid to month %s", ErrInvalidVarianceRange, to)
+		}
+		toMonth = parsed
+	}
+	fromMonth := toMonth.AddDate(0, -11, 0)
+	if from != "" {
+		parsed, err := time.Parse("2006-01", from)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid from month %s", ErrInvalidVarianceRange, from)
+		}
+		fromMonth = parsed
+	}
+	if fromMonth.After(toMonth) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidVarianceRange)
+	}
+	if !fromMonth.AddDate(0, maxVarianceMonths, 0).After(toMonth) {
+		return nil, fmt.Errorf("%w: at most %d months are supported", ErrInvalidVarianceRange, maxVarianceMonths)
+	}
+
+	organisation, err := a.GetCurrentOrganisation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	baseCurrency := *organisation.Currency.Code
+	// Actuals are converted with the rate of their booking date, so past months don't change
+	fiatRates, err := a.historicalFiatRates(ctx, baseCurrency, fromMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := a.dbService.ListForecastHistory(userID, getYearMonth(fromMonth), getYearMonth(toMonth))
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	payrollAccess, err := a.hasPayrollAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !payrollAccess {
+		for i := range history {
+			redactPayrollDetail(&history[i].Details)
+		}
+	}
+	lastDay := utils.GetLastDayOfMonth(toMonth)
+	actuals, err := a.dbService.ListActuals(userID, fromMonth.Format(utils.InternalDateFormat), lastDay.Format(utils.InternalDateFormat))
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+
+	return buildVarianceReport(fromMonth, toMonth, history, actuals, fiatRates), nil
+}
+
+// varianceMonthBuilder collects planned and actual amounts per category and item of one month
+type varianceMonthBuilder struct {
+	month      models.VarianceMonth
+	categories map[string]*models.VarianceCategory
+	items      map[string]map[string]*models.VarianceItem
+}
+
+func buildVarianceReport(fromMonth time.Time, toMonth time.Time, history []models.ForecastHistory, actuals []models.Actual, fiatRates *models.FiatRateTable) *models.VarianceReport {
+	builders := make(map[string]*varianceMonthBuilder)
+	monthKeys := make([]string, 0)
+	for current := fromMonth; !current.After(toMonth); current = current.AddDate(0, 1, 0) {
+		monthKey := getYearMonth(current)
+		monthKeys = append(monthKeys, monthKey)
+		builders[monthKey] = &varianceMonthBuilder{
+			month:      models.VarianceMonth{Month: monthKey},
+			categories: make(map[string]*models.VarianceCategory),
+			items:      make(map[string]map[string]*models.VarianceItem),
+		}
+	}
+
+	for _, entry := range history {
+		builder := builders[entry.Month]
+		if builder == nil {
+			continue
+		}
+		builder.month.HasPlan = true
+		builder.month.PlannedRevenue = entry.Revenue
+		builder.month.PlannedExpense = entry.Expense
+		for _, category := range append(entry.Details.Revenue, entry.Details.Expense...) {
+			for _, leaf := range forecastDetailLeaves(category) {
+				builder.item(category.Name, leaf.Name, leaf.RelatedID, leaf.RelatedTable).Planned += leaf.Amount
+			}
+		}
+	}
+
+	for _, actual := range actuals {
+		builder := builders[getYearMonth(time.Time(actual.BookingDate))]
+		if builder == nil {
+			continue
+		}
+		fiatRate := fiatRates.Rate(*actual.Currency.Code, time.Time(actual.BookingDate))
+		amount := models.CalculateAmountWithFiatRate(actual.Amount, fiatRate)
+		if amount > 0 {
+			builder.month.ActualRevenue += amount
+		} else {
+			builder.month.ActualExpense += amount
+		}
+
+		if actual.Transaction == nil {
+			builder.item(unassignedCategoryName, actual.Name, 0, "").Actual += amount
+			continue
+		}
+		categoryName := unassignedCategoryName
+		if actual.Transaction.Category != nil {
+			categoryName = actual.Transaction.Category.Name
+		}
+		builder.item(categoryName, actual.Transaction.Name, actual.Transaction.ID, utils.TransactionsTableName).Actual += amount
+	}
+
+	report := &models.VarianceReport{
+		From:   getYearMonth(fromMonth),
+		To:     getYearMonth(toMonth),
+		Months: make([]models.VarianceMonth, 0, len(monthKeys)),
+	}
+	for _, monthKey := range monthKeys {
+		report.Months = append(report.Months, builders[monthKey].build())
+	}
+	return report
+}
+
+func (b *varianceMonthBuilder) item(categoryName string, name string, relatedID int64, relatedTable string) *models.VarianceItem {
+	if b.categories[categoryName] == nil {
+		b.categories[categoryName] = &models.VarianceCategory{Name: categoryName}
+		b.items[categoryName] = make(map[string]*models.VarianceItem)
+	}
+	key := "name:" + name
+	if relatedID != 0 {
+		key = fmt.Sprintf("%s:%d", relatedTable, relatedID)
+	}
+	if b.items[categoryName][key] == nil {
+		b.items[categoryName][key] = &models.VarianceItem{Name: name, RelatedID: relatedID, RelatedTable: relatedTable}
+	}
+	return b.items[categoryName][key]
+}
+
+func (b *varianceMonthBuilder) build() models.VarianceMonth {
+	month := b.month
+	month.RevenueVariance = month.ActualRevenue - month.PlannedRevenue
+	month.ExpenseVariance = month.ActualExpense - month.PlannedExpense
+	month.CashflowVariance = month.RevenueVariance + month.ExpenseVariance
+	month.Categories = make([]models.VarianceCategory, 0, len(b.categories))
+
+	for categoryName, category := range b.categories {
+		category.Items = make([]models.VarianceItem, 0, len(b.items[categoryName]))
+		for _, item := range b.items[categoryName] {
+			item.Variance = item.Actual - item.Planned
+			category.Planned += item.Planned
+			category.Actual += item.Actual
+			category.Items = append(category.Items, *item)
+		}
+		category.Variance = category.Actual - category.Planned
+		sort.Slice(category.Items, func(i, j int) bool {
+			return category.Items[i].Name < category.Items[j].Name
+		})
+		month.Categories = append(month.Categories, *category)
+	}
+	sort.Slice(month.Categories, func(i, j int) bool {
+		return month.Categories[i].Name < month.Categories[j].Name
+	})
+
+	return month
+}
+
+// forecastDetailLeaves returns the items below a category of the forecast detail tree
+func forecastDetailLeaves(node models.ForecastDetailRevenueExpense) []models.ForecastDetailRevenueExpense {
+	if len(node.Children) == 0 {
+		return []models.ForecastDetailRevenueExpense{node}
+	}
+	leaves := make([]models.ForecastDetailRevenueExpense, 0)
+	for _, child := range node.Children {
+		leaves = append(leaves, forecastDetailLeaves(child)...)
+	}
+	return leaves
+}
@@ -0,0 +1,219 @@
+package api_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+)
+
+func TestGetVarianceReport_ComparesPlanWithActuals(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	fixedToday := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	eurCode := "EUR"
+	orgCurrency := models.Currency{Code: &chfCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 400,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil)
+	mockDB.EXPECT().
+		ListFiatRates(chfCode).
+		Return([]models.FiatRate{{Base: chfCode, Target: eurCode, Rate: 0.5}}, nil)
+	mockDB.EXPECT().
+		ListFiatRateHistory(chfCode, gomock.Any()).
+		Return([]models.FiatRateHistory{}, nil)
+
+	mockDB.EXPECT().
+		ListForecastHistory(userID, "2024-01", "2024-02").
+		Return([]models.ForecastHistory{
+			{
+				Month:   "2024-01",
+				Revenue: 1000_00,
+				Expense: -300_00,
+				Details: models.ForecastDatabaseDetails{
+					Revenue: []models.ForecastDetailRevenueExpense{
+						{Name: "Sales", Children: []models.ForecastDetailRevenueExpense{
+							{Name: "Client X", Amount: 1000_00, RelatedID: 1, RelatedTable: utils.TransactionsTableName},
+						}},
+					},
+					Expense: []models.ForecastDetailRevenueExpense{
+						{Name: "Rent", Children: []models.ForecastDetailRevenueExpense{
+							{Name: "Office", Amount: -300_00, RelatedID: 2, RelatedTable: utils.TransactionsTableName},
+						}},
+					},
+				},
+			},
+		}, nil)
+
+	salesCategory := models.Category{ID: 1, Name: "Sales"}
+	mockDB.EXPECT().
+		ListActuals(userID, "2024-01-01", "2024-02-29").
+		Return([]models.Actual{
+			{
+				ID:          1,
+				BookingDate: types.AsDate(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+				Amount:      900_00,
+				Name:        "Client X AG",
+				Status:      utils.ActualStatusDeviation,
+				Transaction: &models.ActualTransaction{ID: 1, Name: "Client X", Category: &salesCategory},
+				Currency:    orgCurrency,
+			},
+			{
+				ID:          2,
+				BookingDate: types.AsDate(time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)),
+				Amount:      -25_00,
+				Name:        "Coffee",
+				Status:      utils.ActualStatusUnmatched,
+				Currency:    models.Currency{Code: &eurCode},
+			},
+		}, nil)
+
+	report, err := service.GetVarianceReport(context.Background(), userID, "2024-01", "2024-02")
+	require.NoError(t, err)
+	require.Equal(t, "2024-01", report.From)
+	require.Equal(t, "2024-02", report.To)
+	require.Len(t, report.Months, 2)
+
+	january := report.Months[0]
+	require.True(t, january.HasPlan)
+	require.EqualValues(t, 900_00, january.ActualRevenue)
+	require.EqualValues(t, -50_00, january.ActualExpense)
+	require.EqualValues(t, -100_00, january.RevenueVariance)
+	require.EqualValues(t, 250_00, january.ExpenseVariance)
+	require.EqualValues(t, 150_00, january.CashflowVariance)
+
+	// Categories are sorted by name, unlinked actuals are grouped separately
+	require.Len(t, january.Categories, 3)
+	require.Equal(t, "Nicht zugeordnet", january.Categories[0].Name)
+	require.EqualValues(t, -50_00, january.Categories[0].Actual)
+	require.Equal(t, "Rent", january.Categories[1].Name)
+	require.EqualValues(t, 300_00, january.Categories[1].Variance)
+	require.Equal(t, "Sales", january.Categories[2].Name)
+	require.Len(t, january.Categories[2].Items, 1)
+	require.EqualValues(t, 1000_00, january.Categories[2].Items[0].Planned)
+	require.EqualValues(t, 900_00, january.Categories[2].Items[0].Actual)
+	require.EqualValues(t, -100_00, january.Categories[2].Items[0].Variance)
+
+	// Months without a recorded plan are reported as such
+	require.False(t, report.Months[1].HasPlan)
+	require.Empty(t, report.Months[1].Categories)
+}
+
+func TestGetVarianceReport_RejectsInvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := api_service.NewAPIService(mocks.NewMockIDatabaseAdapter(ctrl), nil)
+
+	_, err := service.GetVarianceReport(context.Background(), 1, "2024-05", "2024-01")
+	require.ErrorIs(t, err, api_service.ErrInvalidVarianceRange)
+
+	_, err = service.GetVarianceReport(context.Background(), 1, "2020-01", "2024-01")
+	require.ErrorIs(t, err, api_service.ErrInvalidVarianceRange)
+
+	_, err = service.GetVarianceReport(context.Background(), 1, "2024-1", "")
+	require.ErrorIs(t, err, api_service.ErrInvalidVarianceRange)
+}
+
+func TestGetVarianceReport_ConvertsActualsWithRateOfBookingDate(t *testing.T) {
+	utils.InitValidator()
+
+	userID := int64(42)
+	fixedToday := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	originalClock := utils.DefaultClock
+	utils.DefaultClock = &stubClock{fixed: fixedToday}
+	defer func() {
+		utils.DefaultClock = originalClock
+	}()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+
+	chfCode := "CHF"
+	eurCode := "EUR"
+	orgCurrency := models.Currency{Code: &chfCode}
+	user := models.User{
+		ID:                    userID,
+		Name:                  "Test User",
+		Email:                 "test@example.com",
+		CurrentOrganisationID: 400,
+		Currency:              orgCurrency,
+	}
+	organisation := models.Organisation{ID: user.CurrentOrganisationID, Name: "Org", Currency: orgCurrency}
+	mockDB.EXPECT().GetProfile(userID).Return(&user, nil)
+	mockDB.EXPECT().GetCurrentUserPayrollAccess(userID).Return(&models.PayrollAccess{Role: "owner"}, nil)
+	mockDB.EXPECT().GetOrganisation(userID, user.CurrentOrganisationID).Return(&organisation, nil)
+	mockDB.EXPECT().
+		ListFiatRates(chfCode).
+		Return([]models.FiatRate{{Base: chfCode, Target: eurCode, Rate: 0.4}}, nil)
+	mockDB.EXPECT().
+		ListFiatRateHistory(chfCode, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)).
+		Return([]models.FiatRateHistory{
+			{Base: chfCode, Target: eurCode, Rate: 0.8, Date: types.AsDate(time.Date(2023, time.December, 29, 0, 0, 0, 0, time.UTC))},
+			{Base: chfCode, Target: eurCode, Rate: 0.5, Date: types.AsDate(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC))},
+		}, nil)
+	mockDB.EXPECT().
+		ListForecastHistory(userID, "2024-01", "2024-02").
+		Return([]models.ForecastHistory{}, nil)
+	mockDB.EXPECT().
+		ListActuals(userID, "2024-01-01", "2024-02-29").
+		Return([]models.Actual{
+			{
+				ID:          1,
+				BookingDate: types.AsDate(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+				Amount:      -40_00,
+				Name:        "Hosting",
+				Status:      utils.ActualStatusUnmatched,
+				Currency:    models.Currency{Code: &eurCode},
+			},
+			{
+				ID:          2,
+				BookingDate: types.AsDate(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)),
+				Amount:      -40_00,
+				Name:        "Hosting",
+				Status:      utils.ActualStatusUnmatched,
+				Currency:    models.Currency{Code: &eurCode},
+			},
+		}, nil)
+
+	report, err := service.GetVarianceReport(context.Background(), userID, "2024-01", "2024-02")
+	require.NoError(t, err)
+	require.Len(t, report.Months, 2)
+
+	// Each month keeps the rate of its booking date instead of today's rate
+	require.EqualValues(t, -50_00, report.Months[0].ActualExpense)
+	require.EqualValues(t, -80_00, report.Months[1].ActualExpense)
+}
@@ -52,12 +52,12 @@ func (a *APIService) CreateVat(ctx context.Context, payload models.CreateVat, us
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "vat", events.ActionCreated, vatID)
+	a.notifyChangeWithDiff(ctx, userID, "vat", events.ActionCreated, vatID, 0, nil, vat)
 	return vat, nil
 }
 
 func (a *APIService) UpdateVat(ctx context.Context, payload models.UpdateVat, userID int64, vatID int64) (*models.Vat, error) {
-	_, err := a.dbService.GetVat(userID, vatID)
+	existingVat, err := a.dbService.GetVat(userID, vatID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -77,12 +77,12 @@ func (a *APIService) UpdateVat(ctx context.Context, payload models.UpdateVat, us
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "vat", events.ActionUpdated, vatID)
+	a.notifyChangeWithDiff(ctx, userID, "vat", events.ActionUpdated, vatID, 0, existingVat, vat)
 	return vat, nil
 }
 
 func (a *APIService) DeleteVat(ctx context.Context, userID int64, vatID int64) error {
-	_, err := a.dbService.GetVat(userID, vatID)
+	existingVat, err := a.dbService.GetVat(userID, vatID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -92,6 +92,6 @@ func (a *APIService) DeleteVat(ctx context.Context, userID int64, vatID int64) e
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "vat", events.ActionDeleted, vatID)
+	a.notifyChangeWithDiff(ctx, userID, "vat", events.ActionDeleted, vatID, 0, existingVat, nil)
 	return nil
 }
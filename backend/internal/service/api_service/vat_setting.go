@@ -58,12 +58,12 @@ func (a *APIService) CreateVatSetting(ctx context.Context, payload models.Create
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "vat_setting", events.ActionCreated, vatSetting.ID)
+	a.notifyChangeWithDiff(ctx, userID, "vat_setting", events.ActionCreated, vatSetting.ID, 0, nil, vatSetting)
 	return vatSetting, nil
 }
 
 func (a *APIService) UpdateVatSetting(ctx context.Context, payload models.UpdateVatSetting, userID int64) (*models.VatSetting, error) {
-	_, err := a.dbService.GetVatSetting(userID)
+	existingSetting, err := a.dbService.GetVatSetting(userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return nil, err
@@ -83,12 +83,12 @@ func (a *APIService) UpdateVatSetting(ctx context.Context, payload models.Update
 		logger.Logger.Error(err)
 		return nil, err
 	}
-	a.notifyChange(ctx, userID, "vat_setting", events.ActionUpdated, vatSetting.ID)
+	a.notifyChangeWithDiff(ctx, userID, "vat_setting", events.ActionUpdated, vatSetting.ID, 0, existingSetting, vatSetting)
 	return vatSetting, nil
 }
 
 func (a *APIService) DeleteVatSetting(ctx context.Context, userID int64) error {
-	_, err := a.dbService.GetVatSetting(userID)
+	existingSetting, err := a.dbService.GetVatSetting(userID)
 	if err != nil {
 		logger.Logger.Error(err)
 		return err
@@ -98,6 +98,6 @@ func (a *APIService) DeleteVatSetting(ctx context.Context, userID int64) error {
 		logger.Logger.Error(err)
 		return err
 	}
-	a.notifyChange(ctx, userID, "vat_setting", events.ActionDeleted, 0)
+	a.notifyChangeWithDiff(ctx, userID, "vat_setting", events.ActionDeleted, 0, 0, existingSetting, nil)
 	return nil
 }
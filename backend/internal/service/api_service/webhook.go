@@ -0,0 +1,156 @@
+package api_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"liquiswiss/internal/events"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"time"
+)
+
+// webhookSecretPrefix marks the signing secrets of webhooks
+const webhookSecretPrefix = "whsec_"
+
+// EnableWebhooks queues every change event for the webhooks of the organisation. Off by
+// default so that tests don't need to expect the queueing.
+func (a *APIService) EnableWebhooks() {
+	a.webhooksEnabled = true
+}
+
+func (a *APIService) ListWebhooks(ctx context.Context, userID int64) ([]models.Webhook, error) {
+	webhooks, err := a.dbService.ListWebhooks(userID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook returns the signing secret, it's only shown again after rotating it
+func (a *APIService) CreateWebhook(ctx context.Context, payload models.CreateWebhook, userID int64) (*models.WebhookWithSecret, error) {
+	secret := newWebhookSecret()
+	webhookID, err := a.dbService.CreateWebhook(payload, userID, secret)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	webhook, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "webhook", events.ActionCreated, webhookID, 0, nil, webhook)
+	return &models.WebhookWithSecret{Webhook: *webhook, Secret: secret}, nil
+}
+
+func (a *APIService) UpdateWebhook(ctx context.Context, payload models.UpdateWebhook, userID int64, webhookID int64) (*models.Webhook, error) {
+	before, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	err = a.dbService.UpdateWebhook(payload, userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	webhook, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "webhook", events.ActionUpdated, webhookID, 0, before, webhook)
+	return webhook, nil
+}
+
+// RotateWebhookSecret replaces the signing secret, deliveries still pending are signed with the new one
+func (a *APIService) RotateWebhookSecret(ctx context.Context, userID int64, webhookID int64) (*models.WebhookWithSecret, error) {
+	secret := newWebhookSecret()
+	err := a.dbService.UpdateWebhookSecret(userID, webhookID, secret)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	webhook, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	a.notifyChange(ctx, userID, "webhook", events.ActionUpdated, webhookID)
+	return &models.WebhookWithSecret{Webhook: *webhook, Secret: secret}, nil
+}
+
+func (a *APIService) DeleteWebhook(ctx context.Context, userID int64, webhookID int64) error {
+	before, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	err = a.dbService.DeleteWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return err
+	}
+	a.notifyChangeWithDiff(ctx, userID, "webhook", events.ActionDeleted, webhookID, 0, before, nil)
+	return nil
+}
+
+func (a *APIService) ListWebhookDeliveries(ctx context.Context, userID int64, webhookID int64, page int64, limit int64) ([]models.WebhookDelivery, int64, error) {
+	// Distinguishes an unknown webhook from one without deliveries
+	_, err := a.dbService.GetWebhook(userID, webhookID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, 0, err
+	}
+	deliveries, totalCount, err := a.dbService.ListWebhookDeliveries(userID, webhookID, page, limit)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, 0, err
+	}
+	return deliveries, totalCount, nil
+}
+
+// ReplayWebhookDelivery queues the event of a delivery once more, the log keeps both deliveries
+func (a *APIService) ReplayWebhookDelivery(ctx context.Context, userID int64, webhookID int64, deliveryID int64) (*models.WebhookDelivery, error) {
+	replayID, err := a.dbService.ReplayWebhookDelivery(userID, webhookID, deliveryID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	delivery, err := a.dbService.GetWebhookDelivery(userID, webhookID, replayID)
+	if err != nil {
+		logger.Logger.Error(err)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// enqueueWebhooks queues the change for the subscribed webhooks of the organisation. Failures
+// are only logged, the mutation itself already happened.
+func (a *APIService) enqueueWebhooks(organisationID int64, entity string, action string, id int64, parentID int64) {
+	if !a.webhooksEnabled {
+		return
+	}
+	payload, err := json.Marshal(models.WebhookEvent{
+		Entity:         entity,
+		Action:         action,
+		ID:             id,
+		ParentID:       parentID,
+		OrganisationID: organisationID,
+		OccurredAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Logger.Error(err)
+		return
+	}
+	_, err = a.dbService.EnqueueWebhookDeliveries(organisationID, entity, action, payload)
+	if err != nil {
+		logger.Logger.Warnf("webhooks: could not queue %s %s for organisation %d: %v", entity, action, organisationID, err)
+	}
+}
+
+func newWebhookSecret() string {
+	return webhookSecretPrefix + rand.Text()
+}
@@ -0,0 +1,65 @@
+package api_service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/models"
+)
+
+func TestCreateWebhook_ReturnsTheSigningSecret(t *testing.T) {
+	userID := int64(42)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	payload := models.CreateWebhook{Name: "ERP", URL: "https://erp.example.com/hooks", Entities: []string{"transaction"}}
+
+	var storedSecret string
+	mockDB.EXPECT().CreateWebhook(payload, userID, gomock.Any()).DoAndReturn(func(payload models.CreateWebhook, userID int64, secret string) (int64, error) {
+		storedSecret = secret
+		return 5, nil
+	})
+	mockDB.EXPECT().GetWebhook(userID, int64(5)).Return(&models.Webhook{ID: 5, Name: "ERP", Entities: []string{"transaction"}}, nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: 7}, nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
+
+	webhook, err := service.CreateWebhook(context.Background(), payload, userID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(webhook.Secret, "whsec_"))
+	require.Equal(t, storedSecret, webhook.Secret)
+}
+
+func TestWebhooks_QueueChangeEventsOnceEnabled(t *testing.T) {
+	userID := int64(42)
+	organisationID := int64(7)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := api_service.NewAPIService(mockDB, nil)
+	service.EnableWebhooks()
+
+	mockDB.EXPECT().DeleteScenario(userID, int64(3)).Return(nil)
+	mockDB.EXPECT().GetProfile(userID).Return(&models.User{ID: userID, CurrentOrganisationID: organisationID}, nil)
+	mockDB.EXPECT().CreateAuditLog(gomock.Any()).Return(int64(1), nil)
+	mockDB.EXPECT().EnqueueWebhookDeliveries(organisationID, "scenario", "deleted", gomock.Any()).
+		DoAndReturn(func(organisationID int64, entity string, action string, payload []byte) (int64, error) {
+			var event models.WebhookEvent
+			require.NoError(t, json.Unmarshal(payload, &event))
+			require.Equal(t, int64(3), event.ID)
+			require.Equal(t, organisationID, event.OrganisationID)
+			return 1, nil
+		})
+
+	err := service.DeleteScenario(context.Background(), userID, 3)
+	require.NoError(t, err)
+}
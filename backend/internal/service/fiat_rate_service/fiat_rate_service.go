@@ -0,0 +1,117 @@
+//go:generate mockgen -package=mocks -destination ../../mocks/fiat_rate_service.go liquiswiss/internal/service/fiat_rate_service IFiatRateService
+package fiat_rate_service
+
+import (
+	"context"
+	"liquiswiss/internal/adapter/fx_adapter"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"time"
+)
+
+// fetchTimeout bounds a single fetch of the provider
+const fetchTimeout = time.Minute
+
+type IFiatRateService interface {
+	FetchFiatRates()
+	RequiresInitialFetch() (bool, error)
+}
+
+// FiatRateService stores the rates of the configured provider. The provider only delivers
+// one base currency, the rates between all currencies of the database are derived from it.
+type FiatRateService struct {
+	apiService api_service.IAPIService
+	provider   fx_adapter.IRateProvider
+}
+
+func NewFiatRateService(s *api_service.IAPIService, provider fx_adapter.IRateProvider) IFiatRateService {
+	return &FiatRateService{
+		apiService: *s,
+		provider:   provider,
+	}
+}
+
+func (f *FiatRateService) RequiresInitialFetch() (bool, error) {
+	totalCurrenciesInRates, err := f.apiService.CountUniqueCurrenciesInFiatRates(context.Background())
+	if err != nil {
+		return false, err
+	}
+	totalCurrencies, err := f.apiService.CountCurrencies(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return totalCurrenciesInRates < totalCurrencies, nil
+}
+
+func (f *FiatRateService) FetchFiatRates() {
+	source := f.provider.Source()
+	if f.skipFetch(source) {
+		return
+	}
+
+	logger.Logger.Infof("Fetching fiat rates from %s", source)
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	snapshot, err := f.provider.Fetch(ctx)
+	if err != nil {
+		logger.Logger.Errorf("Failed to fetch fiat rates from %s: %v", source, err)
+		f.recordFetch(models.CreateFiatRateFetch{Source: source, Status: models.FiatRateFetchStatusFailed}, err)
+		return
+	}
+
+	currencies, err := f.apiService.ListCurrencies(context.Background(), 0)
+	if err != nil {
+		logger.Logger.Errorf("Failed to load currencies: %v", err)
+		return
+	}
+	codes := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		codes = append(codes, *currency.Code)
+	}
+
+	stored := int64(0)
+	for _, rate := range fx_adapter.CrossRates(snapshot, codes) {
+		err := f.apiService.UpsertFiatRate(context.Background(), rate)
+		if err != nil {
+			logger.Logger.Errorf("Failed to upsert fiat rate for base %s to target %s: %v", rate.Base, rate.Target, err)
+			continue
+		}
+		stored++
+	}
+	logger.Logger.Infof("Stored %d fiat rates from %s (base %s, %s)", stored, source, snapshot.Base, snapshot.Date.Format(utils.InternalDateFormat))
+
+	f.recordFetch(models.CreateFiatRateFetch{
+		Source:    source,
+		Base:      &snapshot.Base,
+		RateDate:  &snapshot.Date,
+		RateCount: stored,
+		Status:    models.FiatRateFetchStatusSucceeded,
+	}, nil)
+}
+
+// skipFetch keeps the bundled rates from overwriting real ones and saves the Fixer.io quota outside of production
+func (f *FiatRateService) skipFetch(source string) bool {
+	if source != fx_adapter.SourceFallback && (source != fx_adapter.SourceFixer || utils.IsProduction()) {
+		return false
+	}
+	fiatRates, err := f.apiService.ListFiatRates(context.Background(), "CHF")
+	if err != nil || len(fiatRates) == 0 {
+		return false
+	}
+	logger.Logger.Debugf("Skipping the %s fetch because fiat rates are loaded already", source)
+	return true
+}
+
+func (f *FiatRateService) recordFetch(payload models.CreateFiatRateFetch, fetchErr error) {
+	if fetchErr != nil {
+		message := fetchErr.Error()
+		payload.Error = &message
+	}
+	err := f.apiService.RecordFiatRateFetch(context.Background(), payload)
+	if err != nil {
+		logger.Logger.Errorf("Failed to record the fiat rate fetch: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package fiat_rate_service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/adapter/fx_adapter"
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/api_service"
+	"liquiswiss/internal/service/fiat_rate_service"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func init() {
+	logger.NewZapLogger(false)
+}
+
+const ecbFeed = `<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube><Cube time="2024-05-03"><Cube currency="CHF" rate="0.9750"/><Cube currency="USD" rate="1.0760"/></Cube></Cube>
+</gesmes:Envelope>`
+
+func currencies(codes ...string) []models.Currency {
+	result := make([]models.Currency, 0, len(codes))
+	for _, code := range codes {
+		result = append(result, models.Currency{Code: &code})
+	}
+	return result
+}
+
+func TestFetchFiatRates_StoresCrossRatesAndRecordsFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(ecbFeed))
+	}))
+	defer server.Close()
+
+	mockAPI := mocks.NewMockIAPIService(ctrl)
+	var apiService api_service.IAPIService = mockAPI
+	service := fiat_rate_service.NewFiatRateService(&apiService, fx_adapter.NewECBProvider(server.URL, server.Client()))
+
+	stored := make(map[string]models.CreateFiatRate)
+	mockAPI.EXPECT().ListCurrencies(gomock.Any(), int64(0)).Return(currencies("CHF", "EUR", "USD"), nil)
+	mockAPI.EXPECT().UpsertFiatRate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payload models.CreateFiatRate) error {
+		stored[payload.Base+payload.Target] = payload
+		return nil
+	}).Times(9)
+	mockAPI.EXPECT().RecordFiatRateFetch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payload models.CreateFiatRateFetch) error {
+		require.Equal(t, fx_adapter.SourceECB, payload.Source)
+		require.Equal(t, models.FiatRateFetchStatusSucceeded, payload.Status)
+		require.Equal(t, "EUR", *payload.Base)
+		require.Equal(t, "2024-05-03", payload.RateDate.Format(utils.InternalDateFormat))
+		require.Equal(t, int64(9), payload.RateCount)
+		require.Nil(t, payload.Error)
+		return nil
+	})
+
+	service.FetchFiatRates()
+
+	require.Equal(t, 1, requests)
+	require.Equal(t, 1.025641, stored["CHFEUR"].Rate)
+	require.Equal(t, 1.10359, stored["CHFUSD"].Rate)
+	require.Equal(t, 0.975, stored["EURCHF"].Rate)
+	require.Equal(t, fx_adapter.SourceECB, stored["USDCHF"].Source)
+}
+
+func TestFetchFiatRates_RecordsFailedFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	mockAPI := mocks.NewMockIAPIService(ctrl)
+	var apiService api_service.IAPIService = mockAPI
+	service := fiat_rate_service.NewFiatRateService(&apiService, fx_adapter.NewECBProvider(server.URL, server.Client()))
+
+	mockAPI.EXPECT().RecordFiatRateFetch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payload models.CreateFiatRateFetch) error {
+		require.Equal(t, models.FiatRateFetchStatusFailed, payload.Status)
+		require.Nil(t, payload.Base)
+		require.Equal(t, "unexpected status 502", *payload.Error)
+		return nil
+	})
+
+	service.FetchFiatRates()
+}
+
+func TestFetchFiatRates_FallbackKeepsExistingRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockIAPIService(ctrl)
+	var apiService api_service.IAPIService = mockAPI
+	service := fiat_rate_service.NewFiatRateService(&apiService, fx_adapter.NewFallbackProvider())
+
+	mockAPI.EXPECT().ListFiatRates(gomock.Any(), "CHF").Return([]models.FiatRate{{Base: "CHF", Target: "EUR", Rate: 1.05}}, nil)
+
+	service.FetchFiatRates()
+}
+
+func TestRequiresInitialFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPI := mocks.NewMockIAPIService(ctrl)
+	var apiService api_service.IAPIService = mockAPI
+	service := fiat_rate_service.NewFiatRateService(&apiService, fx_adapter.NewFallbackProvider())
+
+	mockAPI.EXPECT().CountUniqueCurrenciesInFiatRates(gomock.Any()).Return(int64(2), nil)
+	mockAPI.EXPECT().CountCurrencies(gomock.Any()).Return(int64(0), errors.New("unavailable"))
+
+	_, err := service.RequiresInitialFetch()
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package webhook_service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a webhook URL resolves to an address of the internal network
+var ErrPrivateAddress = errors.New("webhook target resolves to a private address")
+
+// TargetAllowlist names the private addresses webhooks may reach anyway, e.g. a receiver in the same network
+type TargetAllowlist struct {
+	prefixes []netip.Prefix
+}
+
+// ParseTargetAllowlist reads CIDR ranges ("10.20.0.0/16") and single addresses ("10.20.0.5"). Host names
+// are rejected, the check applies to the address that is dialed, so a changed DNS record can't widen it.
+func ParseTargetAllowlist(targets []string) (TargetAllowlist, error) {
+	allowlist := TargetAllowlist{}
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		if strings.Contains(target, "/") {
+			prefix, err := netip.ParsePrefix(target)
+			if err != nil {
+				return TargetAllowlist{}, fmt.Errorf("invalid webhook target %q: %w", target, err)
+			}
+			allowlist.prefixes = append(allowlist.prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(target)
+		if err != nil {
+			return TargetAllowlist{}, fmt.Errorf("invalid webhook target %q: only addresses and CIDR ranges are allowed", target)
+		}
+		allowlist.prefixes = append(allowlist.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return allowlist, nil
+}
+
+func (l TargetAllowlist) allowsAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range l.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHTTPClient only connects to public addresses and the targets of the allowlist, a webhook must
+// not reach services next to the backend. Redirects are not followed for the same reason.
+func NewHTTPClient(allowlist TargetAllowlist) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) && !allowlist.allowsAddress(addrPort.Addr()) {
+				return ErrPrivateAddress
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast()
+}
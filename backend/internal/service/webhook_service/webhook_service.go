@@ -0,0 +1,195 @@
+//go:generate mockgen -package=mocks -destination ../../mocks/webhook_service.go liquiswiss/internal/service/webhook_service IWebhookService
+package webhook_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"liquiswiss/internal/adapter/db_adapter"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-LiquiSwiss-Signature"
+	TimestampHeader = "X-LiquiSwiss-Timestamp"
+	DeliveryHeader  = "X-LiquiSwiss-Delivery"
+	EventHeader     = "X-LiquiSwiss-Event"
+
+	// claimBatchSize bounds the deliveries attempted per poll
+	claimBatchSize = 20
+	// maxErrorLength fits the last_error column
+	maxErrorLength = 1000
+)
+
+type IWebhookService interface {
+	Start(ctx context.Context)
+	DeliverDue(ctx context.Context) (int, error)
+	PurgeDeliveries()
+}
+
+// WebhookService delivers the queued webhook events. Deliveries are claimed in the database,
+// so any number of instances can run it side by side.
+type WebhookService struct {
+	dbService  db_adapter.IDatabaseAdapter
+	httpClient *http.Client
+}
+
+// NewWebhookService uses the given client for the deliveries, NewHTTPClient without allowlist if nil
+func NewWebhookService(dbService db_adapter.IDatabaseAdapter, httpClient *http.Client) IWebhookService {
+	if httpClient == nil {
+		httpClient = NewHTTPClient(TargetAllowlist{})
+	}
+	return &WebhookService{
+		dbService:  dbService,
+		httpClient: httpClient,
+	}
+}
+
+// Start polls for due deliveries until the context is done
+func (w *WebhookService) Start(ctx context.Context) {
+	ticker := time.NewTicker(utils.WebhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A full batch likely means more deliveries are due already
+			for {
+				delivered, err := w.DeliverDue(ctx)
+				if err != nil {
+					logger.Logger.Errorf("webhooks: %v", err)
+					break
+				}
+				if delivered < claimBatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DeliverDue attempts a batch of due deliveries and returns how many were attempted
+func (w *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	// The lease outlasts every attempt of the batch, expired leases are picked up again
+	lease := claimBatchSize*utils.WebhookTimeout + time.Minute
+	jobs, err := w.dbService.ClaimWebhookDeliveries(rand.Text(), claimBatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		result := w.attempt(ctx, job)
+		err := w.dbService.CompleteWebhookDelivery(job.ID, result)
+		if err != nil {
+			logger.Logger.Errorf("webhooks: could not store the result of delivery %d: %v", job.ID, err)
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// PurgeDeliveries removes finished deliveries after utils.WebhookDeliveryRetention
+func (w *WebhookService) PurgeDeliveries() {
+	deleted, err := w.dbService.DeleteWebhookDeliveriesBefore(time.Now().Add(-utils.WebhookDeliveryRetention))
+	if err != nil {
+		logger.Logger.Errorf("webhooks: could not purge deliveries: %v", err)
+		return
+	}
+	logger.Logger.Infof("webhooks: purged %d deliveries", deleted)
+}
+
+func (w *WebhookService) attempt(ctx context.Context, job models.WebhookDeliveryJob) models.WebhookDeliveryResult {
+	if !job.Enabled {
+		return failedResult(nil, "webhook disabled")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, utils.WebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return failedResult(nil, err.Error())
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LiquiSwiss-Webhooks/1.0")
+	req.Header.Set(SignatureHeader, Sign(job.Secret, timestamp, job.Payload))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(job.ID, 10))
+	req.Header.Set(EventHeader, job.Entity+"."+job.Action)
+
+	res, err := w.httpClient.Do(req)
+	if err != nil {
+		return retryResult(job, nil, err.Error())
+	}
+	defer res.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return models.WebhookDeliveryResult{Status: models.WebhookDeliveryStatusSucceeded, ResponseStatus: &res.StatusCode}
+	}
+	return retryResult(job, &res.StatusCode, fmt.Sprintf("unexpected status %d", res.StatusCode))
+}
+
+// Sign returns the signature header value: HMAC-SHA256 of "<timestamp>.<body>" with the secret of the webhook
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RetryDelay backs off exponentially with the number of failed attempts
+func RetryDelay(attempts int64) time.Duration {
+	delay := utils.WebhookRetryBase
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= utils.WebhookRetryMax {
+			return utils.WebhookRetryMax
+		}
+	}
+	return delay
+}
+
+// retryResult schedules the next attempt unless the delivery used up all its attempts
+func retryResult(job models.WebhookDeliveryJob, responseStatus *int, message string) models.WebhookDeliveryResult {
+	attempts := job.Attempts + 1
+	if attempts >= utils.WebhookMaxAttempts {
+		return failedResult(responseStatus, message)
+	}
+	return models.WebhookDeliveryResult{
+		Status:         models.WebhookDeliveryStatusPending,
+		ResponseStatus: responseStatus,
+		Error:          truncateError(message),
+		RetryIn:        RetryDelay(attempts),
+	}
+}
+
+func failedResult(responseStatus *int, message string) models.WebhookDeliveryResult {
+	return models.WebhookDeliveryResult{
+		Status:         models.WebhookDeliveryStatusFailed,
+		ResponseStatus: responseStatus,
+		Error:          truncateError(message),
+	}
+}
+
+func truncateError(message string) *string {
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return &message
+}
@@ -0,0 +1,171 @@
+package webhook_service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"liquiswiss/internal/mocks"
+	"liquiswiss/internal/service/webhook_service"
+	"liquiswiss/pkg/logger"
+	"liquiswiss/pkg/models"
+	"liquiswiss/pkg/utils"
+)
+
+func init() {
+	logger.NewZapLogger(false)
+}
+
+func TestDeliverDue_SignsThePayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload := json.RawMessage(`{"entity":"transaction","action":"created","id":5,"organisationId":7}`)
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := webhook_service.NewWebhookService(mockDB, server.Client())
+	job := models.WebhookDeliveryJob{ID: 11, Entity: "transaction", Action: "created", Payload: payload, URL: server.URL, Secret: "whsec_test", Enabled: true}
+
+	mockDB.EXPECT().ClaimWebhookDeliveries(gomock.Any(), int64(20), gomock.Any()).Return([]models.WebhookDeliveryJob{job}, nil)
+	mockDB.EXPECT().CompleteWebhookDelivery(int64(11), gomock.Any()).DoAndReturn(func(deliveryID int64, result models.WebhookDeliveryResult) error {
+		require.Equal(t, models.WebhookDeliveryStatusSucceeded, result.Status)
+		require.Equal(t, http.StatusNoContent, *result.ResponseStatus)
+		return nil
+	})
+
+	delivered, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+	require.JSONEq(t, string(payload), string(body))
+	timestamp := received.Header.Get(webhook_service.TimestampHeader)
+	require.Equal(t, webhook_service.Sign("whsec_test", timestamp, body), received.Header.Get(webhook_service.SignatureHeader))
+	require.Equal(t, "11", received.Header.Get(webhook_service.DeliveryHeader))
+	require.Equal(t, "transaction.created", received.Header.Get(webhook_service.EventHeader))
+}
+
+func TestDeliverDue_RetriesWithBackoffUntilExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := webhook_service.NewWebhookService(mockDB, server.Client())
+	firstAttempt := models.WebhookDeliveryJob{ID: 1, Payload: json.RawMessage(`{}`), URL: server.URL, Enabled: true}
+	lastAttempt := models.WebhookDeliveryJob{ID: 2, Payload: json.RawMessage(`{}`), URL: server.URL, Enabled: true, Attempts: utils.WebhookMaxAttempts - 1}
+
+	mockDB.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WebhookDeliveryJob{firstAttempt, lastAttempt}, nil)
+	mockDB.EXPECT().CompleteWebhookDelivery(int64(1), gomock.Any()).DoAndReturn(func(deliveryID int64, result models.WebhookDeliveryResult) error {
+		require.Equal(t, models.WebhookDeliveryStatusPending, result.Status)
+		require.Equal(t, utils.WebhookRetryBase, result.RetryIn)
+		require.Equal(t, http.StatusBadGateway, *result.ResponseStatus)
+		return nil
+	})
+	mockDB.EXPECT().CompleteWebhookDelivery(int64(2), gomock.Any()).DoAndReturn(func(deliveryID int64, result models.WebhookDeliveryResult) error {
+		require.Equal(t, models.WebhookDeliveryStatusFailed, result.Status)
+		return nil
+	})
+
+	_, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDeliverDue_FailsDisabledWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := webhook_service.NewWebhookService(mockDB, nil)
+
+	mockDB.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WebhookDeliveryJob{{ID: 3, URL: "https://example.com"}}, nil)
+	mockDB.EXPECT().CompleteWebhookDelivery(int64(3), gomock.Any()).DoAndReturn(func(deliveryID int64, result models.WebhookDeliveryResult) error {
+		require.Equal(t, models.WebhookDeliveryStatusFailed, result.Status)
+		require.Nil(t, result.ResponseStatus)
+		return nil
+	})
+
+	_, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestDeliverDue_RefusesPrivateAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the guarded client must not reach a loopback address")
+	}))
+	defer server.Close()
+
+	mockDB := mocks.NewMockIDatabaseAdapter(ctrl)
+	service := webhook_service.NewWebhookService(mockDB, nil)
+
+	mockDB.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WebhookDeliveryJob{{ID: 4, Payload: json.RawMessage(`{}`), URL: server.URL, Enabled: true}}, nil)
+	mockDB.EXPECT().CompleteWebhookDelivery(int64(4), gomock.Any()).DoAndReturn(func(deliveryID int64, result models.WebhookDeliveryResult) error {
+		require.Equal(t, models.WebhookDeliveryStatusPending, result.Status)
+		require.Contains(t, *result.Error, webhook_service.ErrPrivateAddress.Error())
+		return nil
+	})
+
+	_, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestNewHTTPClient_ReachesAllowedPrivateTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	localhostURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	get := func(allowlist webhook_service.TargetAllowlist, url string) error {
+		response, err := webhook_service.NewHTTPClient(allowlist).Get(url)
+		if err != nil {
+			return err
+		}
+		return response.Body.Close()
+	}
+
+	otherRange, err := webhook_service.ParseTargetAllowlist([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	require.ErrorIs(t, get(otherRange, server.URL), webhook_service.ErrPrivateAddress)
+	// Host names are checked by the address they resolve to
+	require.ErrorIs(t, get(otherRange, localhostURL), webhook_service.ErrPrivateAddress)
+
+	loopbackRange, err := webhook_service.ParseTargetAllowlist([]string{" 127.0.0.0/8 "})
+	require.NoError(t, err)
+	require.NoError(t, get(loopbackRange, server.URL))
+	require.NoError(t, get(loopbackRange, localhostURL))
+
+	_, err = webhook_service.ParseTargetAllowlist([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+	// A host name would be trusted with whatever its DNS returns
+	_, err = webhook_service.ParseTargetAllowlist([]string{"hooks.internal"})
+	require.Error(t, err)
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, utils.WebhookRetryBase, webhook_service.RetryDelay(1))
+	require.Equal(t, 4*utils.WebhookRetryBase, webhook_service.RetryDelay(3))
+	require.Equal(t, utils.WebhookRetryMax, webhook_service.RetryDelay(30))
+	require.Less(t, webhook_service.RetryDelay(utils.WebhookMaxAttempts-1), utils.WebhookRetryMax+time.Second)
+}
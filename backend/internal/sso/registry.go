@@ -0,0 +1,41 @@
+package sso
+
+import (
+	"net/http"
+	"strings"
+
+	"liquiswiss/config"
+	"liquiswiss/pkg/models"
+)
+
+// Registry holds the configured providers in the order of SSO_PROVIDERS
+type Registry struct {
+	providers []*Provider
+}
+
+// NewRegistry creates the providers of the config, their callback lives on the API host
+func NewRegistry(cfg config.Config, httpClient *http.Client) *Registry {
+	registry := &Registry{}
+	for _, providerCfg := range cfg.SSOProviders {
+		redirectURL := strings.TrimSuffix(cfg.APIHost, "/") + "/api/auth/sso/" + providerCfg.ID + "/callback"
+		registry.providers = append(registry.providers, NewProvider(providerCfg, redirectURL, httpClient))
+	}
+	return registry
+}
+
+func (r *Registry) Get(id string) (*Provider, error) {
+	for _, provider := range r.providers {
+		if provider.ID() == id {
+			return provider, nil
+		}
+	}
+	return nil, ErrUnknownProvider
+}
+
+func (r *Registry) List() []models.SSOProvider {
+	providers := make([]models.SSOProvider, 0, len(r.providers))
+	for _, provider := range r.providers {
+		providers = append(providers, models.SSOProvider{ID: provider.ID(), Name: provider.Name()})
+	}
+	return providers
+}
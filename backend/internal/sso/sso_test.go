@@ -0,0 +1,108 @@
+package sso_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/config"
+	"liquiswiss/internal/sso"
+	"liquiswiss/internal/sso/ssotest"
+)
+
+func newTestProvider(t *testing.T) (*ssotest.IDP, *sso.Provider) {
+	idp := ssotest.NewIDP()
+	t.Cleanup(idp.Close)
+	provider := sso.NewProvider(config.SSOProvider{
+		ID:       "test",
+		Name:     "Test",
+		Issuer:   idp.Issuer(),
+		ClientID: ssotest.ClientID,
+	}, "http://localhost:8087/api/auth/sso/test/callback", idp.Server.Client())
+	return idp, provider
+}
+
+func TestExchangeReturnsVerifiedIdentity(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+	idp, provider := newTestProvider(t)
+	state, err := sso.NewLoginState("test", "/forecasts")
+	require.NoError(t, err)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), state)
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, state.State, parsed.Query().Get("state"))
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+	identity, err := provider.Exchange(context.Background(), idp.Authorize(state.Nonce), state)
+	require.NoError(t, err)
+	assert.Equal(t, "test", identity.Provider)
+	assert.Equal(t, "user-1", identity.Subject)
+	assert.Equal(t, "sso@liquiswiss.ch", identity.Email)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestExchangeRejectsWrongNonce(t *testing.T) {
+	idp, provider := newTestProvider(t)
+	state, err := sso.NewLoginState("test", "/")
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(context.Background(), idp.Authorize("other-nonce"), state)
+	assert.True(t, errors.Is(err, sso.ErrInvalidIDToken))
+}
+
+func TestExchangeRejectsWrongAudience(t *testing.T) {
+	idp, provider := newTestProvider(t)
+	idp.SetClaims(jwt.MapClaims{"sub": "user-1", "email": "sso@liquiswiss.ch", "email_verified": true, "aud": "other-client"})
+	state, err := sso.NewLoginState("test", "/")
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(context.Background(), idp.Authorize(state.Nonce), state)
+	assert.True(t, errors.Is(err, sso.ErrInvalidIDToken))
+}
+
+func TestExchangeMarksUnverifiedEmail(t *testing.T) {
+	idp, provider := newTestProvider(t)
+	idp.SetClaims(jwt.MapClaims{"sub": "user-1", "email": "sso@liquiswiss.ch", "email_verified": "false"})
+	state, err := sso.NewLoginState("test", "/")
+	require.NoError(t, err)
+
+	identity, err := provider.Exchange(context.Background(), idp.Authorize(state.Nonce), state)
+	require.NoError(t, err)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestLoginStateRoundTrip(t *testing.T) {
+	t.Setenv("JWT_KEY", "test-key")
+	state, err := sso.NewLoginState("test", "//evil.example")
+	require.NoError(t, err)
+	assert.Equal(t, "/", state.Redirect)
+
+	cookie, _, err := state.Encode()
+	require.NoError(t, err)
+	decoded, err := sso.DecodeLoginState(cookie, "test", state.State)
+	require.NoError(t, err)
+	assert.Equal(t, state, *decoded)
+
+	_, err = sso.DecodeLoginState(cookie, "test", "other-state")
+	assert.ErrorIs(t, err, sso.ErrInvalidState)
+	_, err = sso.DecodeLoginState(cookie, "other", state.State)
+	assert.ErrorIs(t, err, sso.ErrInvalidState)
+}
+
+func TestRegistryListsConfiguredProviders(t *testing.T) {
+	registry := sso.NewRegistry(config.Config{
+		APIHost:      "http://localhost:8087",
+		SSOProviders: []config.SSOProvider{{ID: "entra", Name: "Microsoft"}},
+	}, http.DefaultClient)
+	assert.Len(t, registry.List(), 1)
+	_, err := registry.Get("google")
+	assert.ErrorIs(t, err, sso.ErrUnknownProvider)
+}
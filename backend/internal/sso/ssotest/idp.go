@@ -0,0 +1,151 @@
+// Package ssotest provides a local OpenID Connect identity provider for tests and local development
+package ssotest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ClientID = "liquiswiss-test"
+	keyID    = "test-key"
+)
+
+// IDP issues an ID token with Claims for every authorization code. Claims can be changed
+// between logins, nonce, issuer, audience and the timestamps are filled in unless set.
+type IDP struct {
+	Server *httptest.Server
+
+	mu     sync.Mutex
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+	nonces map[string]string
+}
+
+func NewIDP() *IDP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	idp := &IDP{
+		key: key,
+		claims: jwt.MapClaims{
+			"sub":            "user-1",
+			"email":          "sso@liquiswiss.ch",
+			"email_verified": true,
+			"name":           "SSO User",
+		},
+		nonces: map[string]string{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /keys", idp.keys)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+func (i *IDP) Close() {
+	i.Server.Close()
+}
+
+func (i *IDP) Issuer() string {
+	return i.Server.URL
+}
+
+// SetClaims replaces the claims of the next ID tokens
+func (i *IDP) SetClaims(claims jwt.MapClaims) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.claims = claims
+}
+
+// Authorize behaves like a user that signs in and returns the code for the callback
+func (i *IDP) Authorize(nonce string) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	code := rand.Text()
+	i.nonces[code] = nonce
+	return code
+}
+
+func (i *IDP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.Issuer(),
+		"authorization_endpoint": i.Issuer() + "/authorize",
+		"token_endpoint":         i.Issuer() + "/token",
+		"jwks_uri":               i.Issuer() + "/keys",
+	})
+}
+
+func (i *IDP) keys(w http.ResponseWriter, r *http.Request) {
+	publicKey := i.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize signs the user in immediately and redirects back with the code
+func (i *IDP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	code := i.Authorize(query.Get("nonce"))
+	http.Redirect(w, r, query.Get("redirect_uri")+"?code="+code+"&state="+query.Get("state"), http.StatusFound)
+}
+
+func (i *IDP) token(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	code := r.PostFormValue("code")
+	nonce, ok := i.nonces[code]
+	if !ok || r.PostFormValue("code_verifier") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	delete(i.nonces, code)
+
+	claims := jwt.MapClaims{
+		"iss":   i.Issuer(),
+		"aud":   ClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": nonce,
+	}
+	for name, value := range i.claims {
+		claims[name] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(i.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
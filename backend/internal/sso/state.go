@@ -0,0 +1,112 @@
+package sso
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"liquiswiss/config"
+)
+
+const (
+	// StateCookieName carries the LoginState from the start of the login to the callback
+	StateCookieName = "liquiswiss_sso_state"
+	// StateValidity is the time the user has to sign in at the provider
+	StateValidity = 10 * time.Minute
+
+	stateAudience = "sso-state"
+)
+
+var ErrInvalidState = errors.New("invalid sso state")
+
+// LoginState binds the callback to the browser that started the login. State protects
+// against CSRF, Nonce against replayed ID tokens and Verifier is the PKCE secret.
+type LoginState struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	// Redirect is the path of the web app the user returns to
+	Redirect string `json:"redirect"`
+}
+
+type stateClaims struct {
+	LoginState
+	jwt.RegisteredClaims
+}
+
+func NewLoginState(provider string, redirect string) (LoginState, error) {
+	state, err := randomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+	return LoginState{
+		Provider: provider,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+		Redirect: SafeRedirect(redirect),
+	}, nil
+}
+
+// Encode signs the state for the cookie, it is only readable by the backend because of HttpOnly
+func (s LoginState) Encode() (string, time.Time, error) {
+	expiresAt := time.Now().Add(StateValidity)
+	claims := stateClaims{
+		LoginState: s,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			Audience:  jwt.ClaimStrings{stateAudience},
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.GetConfig().JWTKey)
+	return token, expiresAt, err
+}
+
+// DecodeLoginState verifies the cookie and that the callback belongs to it
+func DecodeLoginState(token string, provider string, state string) (*LoginState, error) {
+	var claims stateClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (any, error) {
+		return config.GetConfig().JWTKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(stateAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	if claims.Provider != provider || claims.State == "" || claims.State != state {
+		return nil, ErrInvalidState
+	}
+	return &claims.LoginState, nil
+}
+
+// SafeRedirect only allows paths of the web app, anything else would be an open redirect
+func SafeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, `\`) {
+		return "/"
+	}
+	return redirect
+}
+
+func randomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
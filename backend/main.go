@@ -3,6 +3,7 @@ package main
 // LiquiSwiss backend application entry point
 
 import (
+	"context"
 	"embed"
 	"flag"
 	"github.com/joho/godotenv"
@@ -12,12 +13,14 @@ import (
 	"liquiswiss/config"
 	"liquiswiss/internal/adapter/db_adapter"
 	"liquiswiss/internal/adapter/email_adapter"
+	"liquiswiss/internal/adapter/fx_adapter"
 	"liquiswiss/internal/api"
 	"liquiswiss/internal/db"
 	"liquiswiss/internal/events"
 	"liquiswiss/internal/middleware"
 	"liquiswiss/internal/service/api_service"
-	"liquiswiss/internal/service/fixer_io_service"
+	"liquiswiss/internal/service/fiat_rate_service"
+	"liquiswiss/internal/service/webhook_service"
 	"liquiswiss/pkg/logger"
 	"liquiswiss/pkg/utils"
 	"net/http"
@@ -104,33 +107,62 @@ func runApp() {
 	dbService := db_adapter.NewDatabaseAdapter(conn)
 
 	apiService := api_service.NewAPIService(dbService, emailService)
-	fixerIOService := fixer_io_service.NewFixerIOService(&apiService)
+	rateProvider, err := fx_adapter.NewRateProvider(cfg, nil)
+	if err != nil {
+		logger.Logger.Error(err)
+		os.Exit(1)
+	}
+	fiatRateService := fiat_rate_service.NewFiatRateService(&apiService, rateProvider)
 	middleware.InjectUserService(dbService)
 	apiHandler := api.NewAPI(dbService, apiService, emailService)
 
 	// Real-time event hub (SSE): service layer publishes, /api/events streams
-	eventHub := events.NewHub()
+	eventHub, err := newEventHub(cfg, dbService)
+	if err != nil {
+		logger.Logger.Error(err)
+		os.Exit(1)
+	}
+	go eventHub.Run(context.Background())
 	apiService.SetEventHub(eventHub)
 	apiHandler.EventHub = eventHub
 
+	// Changes recalculate the forecast in the background, debounced per organisation
+	apiService.EnableForecastScheduler(cfg.ForecastDebounce)
+
+	// Changes are queued for the webhooks of the organisation and delivered in the background
+	apiService.EnableWebhooks()
+	// Private targets are refused unless WEBHOOK_ALLOWED_TARGETS lists them
+	webhookTargets, err := webhook_service.ParseTargetAllowlist(cfg.WebhookAllowedTargets)
+	if err != nil {
+		logger.Logger.Error(err)
+		os.Exit(1)
+	}
+	webhookService := webhook_service.NewWebhookService(dbService, webhook_service.NewHTTPClient(webhookTargets))
+	go webhookService.Start(context.Background())
+
 	// Cronjob
 	c := cron.New()
-	_, err = c.AddFunc("@every 12h", fixerIOService.FetchFiatRates)
+	_, err = c.AddFunc("@every 12h", fiatRateService.FetchFiatRates)
+	if err != nil {
+		logger.Logger.Errorf("Failed to set fiat rates cronjob: %v", err)
+		return
+	}
+	_, err = c.AddFunc("@every 24h", webhookService.PurgeDeliveries)
 	if err != nil {
-		logger.Logger.Errorf("Failed to set fixer.io cronjob: %v", err)
+		logger.Logger.Errorf("Failed to set webhook delivery cleanup cronjob: %v", err)
 		return
 	}
 	c.Start()
 
 	go func() {
-		requiresInitialFetch, err := fixerIOService.RequiresInitialFetch()
+		requiresInitialFetch, err := fiatRateService.RequiresInitialFetch()
 		if err != nil {
 			logger.Logger.Error("Error checking if initial fetch is required", err)
 			return
 		}
 		if requiresInitialFetch {
 			logger.Logger.Info("Count of fiat rate currencies doesn't match currencies, triggering initial fiat rates fetch")
-			fixerIOService.FetchFiatRates()
+			fiatRateService.FetchFiatRates()
 		} else {
 			logger.Logger.Info("No initial fetch required for fiat rates")
 		}
@@ -150,6 +182,19 @@ func runApp() {
 	}
 }
 
+// newEventHub shares the events with the other instances when EVENT_BROKER names a broker
+func newEventHub(cfg config.Config, dbService db_adapter.IDatabaseAdapter) (*events.Hub, error) {
+	switch cfg.EventBroker {
+	case "", "memory":
+		return events.NewHub(), nil
+	case "mysql":
+		logger.Logger.Infof("Sharing events through the database, polling every %s", cfg.EventOutboxPollInterval)
+		return events.NewHubWithBroker(events.NewOutboxBroker(dbService, cfg.EventOutboxPollInterval, utils.EventOutboxRetention)), nil
+	default:
+		return nil, errors.Errorf("unknown EVENT_BROKER %q", cfg.EventBroker)
+	}
+}
+
 func runStaticMigrations() error {
 	logger.Logger.Info("Running static migrations...")
 
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"liquiswiss/pkg/utils"
+)
+
+// apiTokenPrefixLength is the part of the token that stays visible in the token list
+const apiTokenPrefixLength = 12
+
+// GenerateAPIToken returns a new personal API token along with its visible prefix
+func GenerateAPIToken() (string, string) {
+	token := utils.APITokenPrefix + rand.Text()
+	return token, token[:apiTokenPrefixLength]
+}
+
+// HashAPIToken is what gets stored, the tokens are random enough for a plain SHA-256
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -14,14 +14,23 @@ import (
 
 type Claims struct {
 	UserID int64 `json:"userId"`
+	// SessionID is the ID of the refresh token an access token was issued for, revoking the
+	// session invalidates the access token right away
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // GenerateAccessToken generates a new JWT token
 func GenerateAccessToken(user models.User) (string, time.Time, *Claims, error) {
+	return GenerateSessionAccessToken(user, "")
+}
+
+// GenerateSessionAccessToken generates a new JWT token bound to the session of a refresh token
+func GenerateSessionAccessToken(user models.User, sessionID string) (string, time.Time, *Claims, error) {
 	expirationTime := time.Now().Add(utils.AccessTokenValidity)
 	claims := &Claims{
-		UserID: user.ID,
+		UserID:    user.ID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
@@ -76,6 +85,41 @@ func GenerateAudienceAccessToken(userID int64, clientID string, audience string)
 	return tokenString, expirationTime, err
 }
 
+// twoFactorAudience marks tokens that only prove the password of a login, they are never a session
+const twoFactorAudience = "two-factor"
+
+// GenerateTwoFactorChallengeToken is handed out after the password check and exchanged for
+// the session once the second factor is verified
+func GenerateTwoFactorChallengeToken(userID int64) (string, time.Time, error) {
+	expirationTime := time.Now().Add(utils.TwoFactorChallengeValidity)
+	claims := &Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			Audience:  jwt.ClaimStrings{twoFactorAudience},
+		},
+	}
+
+	cfg := config.GetConfig()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(cfg.JWTKey)
+
+	return tokenString, expirationTime, err
+}
+
+// VerifyTwoFactorChallengeToken returns the user of a valid challenge token
+func VerifyTwoFactorChallengeToken(tokenString string) (int64, error) {
+	claims, err := VerifyToken(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != twoFactorAudience {
+		return 0, fmt.Errorf("invalid token")
+	}
+	return claims.UserID, nil
+}
+
 // VerifyToken verifies the given token and returns the user ID
 func VerifyToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
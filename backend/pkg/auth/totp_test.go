@@ -0,0 +1,55 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/auth"
+)
+
+// Secret "12345678901234567890" of the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode_MatchesRFC6238(t *testing.T) {
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range vectors {
+		code, err := auth.GenerateTOTPCode(rfcSecret, auth.TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidateTOTPCode_AcceptsDriftButNoReplay(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := auth.TOTPStep(now)
+	previous, err := auth.GenerateTOTPCode(rfcSecret, step-1)
+	require.NoError(t, err)
+
+	matched, ok := auth.ValidateTOTPCode(rfcSecret, previous, now, 0)
+	require.True(t, ok)
+	require.Equal(t, step-1, matched)
+
+	_, ok = auth.ValidateTOTPCode(rfcSecret, previous, now, step-1)
+	require.False(t, ok)
+
+	tooOld, err := auth.GenerateTOTPCode(rfcSecret, step-2)
+	require.NoError(t, err)
+	_, ok = auth.ValidateTOTPCode(rfcSecret, tooOld, now, 0)
+	require.False(t, ok)
+}
+
+func TestHashRecoveryCode_IgnoresCaseAndSpaces(t *testing.T) {
+	codes, err := auth.GenerateRecoveryCodes(2)
+	require.NoError(t, err)
+	require.Len(t, codes, 2)
+	require.NotEqual(t, codes[0], codes[1])
+	require.Equal(t, auth.HashRecoveryCode(codes[0]), auth.HashRecoveryCode(" "+codes[0]+" "))
+	require.Equal(t, auth.HashRecoveryCode("abcde-12345"), auth.HashRecoveryCode("ABCDE-12345"))
+}
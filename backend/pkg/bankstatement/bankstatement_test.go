@@ -0,0 +1,168 @@
+package bankstatement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"liquiswiss/pkg/bankstatement"
+)
+
+const camt053Sample = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.04">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct>
+        <Id><IBAN>CH9300762011623852957</IBAN></Id>
+        <Ccy>CHF</Ccy>
+      </Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="CHF">10000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-01-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="CHF">11950.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-01-31</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="CHF">2500.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2024-01-15</Dt></BookgDt>
+        <ValDt><Dt>2024-01-15</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties><Dbtr><Nm>Client X AG</Nm></Dbtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="CHF">550.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><Dt>2024-01-20</Dt></BookgDt>
+        <AcctSvcrRef>REF-2</AcctSvcrRef>
+        <AddtlNtryInf>Office Rent January</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+const mt940Sample = `:20:STATEMENT1
+:25:CH9300762011623852957
+:28C:1/1
+:60F:C231231CHF10000,00
+:61:2401150115C2500,50NTRFNONREF//REF-1
+:86:?20Client X AG?21Invoice 42
+:61:2401200120D550,NTRFNONREF//REF-2
+:86:Office Rent
+January
+:62F:C240131CHF11950,50
+-`
+
+func TestParse_Camt053(t *testing.T) {
+	statement, err := bankstatement.Parse("", []byte(camt053Sample))
+	require.NoError(t, err)
+
+	require.Equal(t, bankstatement.FormatCamt053, statement.Format)
+	require.Equal(t, "CH9300762011623852957", statement.IBAN)
+	require.Equal(t, "CHF", statement.Currency)
+	require.EqualValues(t, 10000_00, *statement.OpeningBalance)
+	require.EqualValues(t, 11950_50, *statement.ClosingBalance)
+	require.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), *statement.ClosingDate)
+
+	require.Len(t, statement.Entries, 2)
+	require.EqualValues(t, 2500_50, statement.Entries[0].Amount)
+	require.Equal(t, "Client X AG", statement.Entries[0].Name)
+	require.Equal(t, "REF-1", statement.Entries[0].Reference)
+	require.Equal(t, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), statement.Entries[0].BookingDate)
+	require.EqualValues(t, -550_00, statement.Entries[1].Amount)
+	require.Equal(t, "Office Rent January", statement.Entries[1].Name)
+	require.Nil(t, statement.Entries[1].ValueDate)
+}
+
+func TestParse_MT940(t *testing.T) {
+	statement, err := bankstatement.Parse("", []byte(mt940Sample))
+	require.NoError(t, err)
+
+	require.Equal(t, bankstatement.FormatMT940, statement.Format)
+	require.Equal(t, "CH9300762011623852957", statement.IBAN)
+	require.Equal(t, "CHF", statement.Currency)
+	require.EqualValues(t, 10000_00, *statement.OpeningBalance)
+	require.EqualValues(t, 11950_50, *statement.ClosingBalance)
+	require.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), *statement.ClosingDate)
+
+	require.Len(t, statement.Entries, 2)
+	require.EqualValues(t, 2500_50, statement.Entries[0].Amount)
+	require.Equal(t, "Client X AG Invoice 42", statement.Entries[0].Name)
+	require.Equal(t, "REF-1", statement.Entries[0].Reference)
+	require.EqualValues(t, -550_00, statement.Entries[1].Amount)
+	require.Equal(t, "Office Rent January", statement.Entries[1].Name)
+	require.Equal(t, "CHF", statement.Entries[1].Currency)
+}
+
+func TestParse_MT940BookingDates(t *testing.T) {
+	data := ":20:STATEMENT1\n:25:CH9300762011623852957\n:60F:C231231CHF0,00\n" +
+		":61:2402290229C10,NTRFNONREF\n" +
+		// Booked at the end of the year before the value date
+		":61:2401021230C10,NTRFNONREF\n" +
+		":62F:C240229CHF20,00\n-"
+	statement, err := bankstatement.ParseMT940([]byte(data))
+	require.NoError(t, err)
+
+	require.Len(t, statement.Entries, 2)
+	require.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), statement.Entries[0].BookingDate)
+	require.Equal(t, time.Date(2023, time.December, 30, 0, 0, 0, 0, time.UTC), statement.Entries[1].BookingDate)
+}
+
+func TestParse_MT940MissingAccount(t *testing.T) {
+	for _, account := range []string{"", "   "} {
+		_, err := bankstatement.ParseMT940([]byte(":20:STATEMENT1\n:25:" + account + "\n:60F:C231231CHF0,00\n-"))
+		require.ErrorContains(t, err, "missing account")
+	}
+}
+
+func TestParse_CSV(t *testing.T) {
+	data := "\xef\xbb\xbfDatum;Buchungstext;Gutschrift;Belastung;Saldo\n" +
+		"15.01.2024;Client X AG;2'500.50;;12'500.50\n" +
+		"20.01.2024;Office Rent;;-550.00;11'950.50\n" +
+		";Total;2'500.50;550.00;\n"
+
+	statement, err := bankstatement.Parse("", []byte(data))
+	require.NoError(t, err)
+
+	require.Equal(t, bankstatement.FormatCSV, statement.Format)
+	require.Nil(t, statement.OpeningBalance)
+	require.EqualValues(t, 11950_50, *statement.ClosingBalance)
+	require.Equal(t, time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC), *statement.ClosingDate)
+
+	require.Len(t, statement.Entries, 2)
+	require.EqualValues(t, 2500_50, statement.Entries[0].Amount)
+	require.Equal(t, "Client X AG", statement.Entries[0].Name)
+	require.EqualValues(t, -550_00, statement.Entries[1].Amount)
+}
+
+func TestParse_CSVAmountFormats(t *testing.T) {
+	data := "date,amount,name\n" +
+		"2024-01-01,1234.5,A\n" +
+		"2024-01-01,\"-1.234,56\",B\n" +
+		"2024-01-01,-0.05,C\n" +
+		"2024-01-01,1.000,D\n"
+
+	statement, err := bankstatement.ParseCSV([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 4)
+	require.EqualValues(t, 1234_50, statement.Entries[0].Amount)
+	require.EqualValues(t, -1234_56, statement.Entries[1].Amount)
+	require.EqualValues(t, -5, statement.Entries[2].Amount)
+	require.EqualValues(t, 1000_00, statement.Entries[3].Amount)
+}
+
+func TestParse_CSVMissingAmountColumn(t *testing.T) {
+	_, err := bankstatement.ParseCSV([]byte("date;name\n2024-01-01;A\n"))
+	require.Error(t, err)
+}
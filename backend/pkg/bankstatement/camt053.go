@@ -0,0 +1,178 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"liquiswiss/pkg/tabular"
+	"strings"
+)
+
+// The structs only cover the parts of camt.053 we need. Tags without a namespace
+// match any version of the schema (001.02 up to 001.08)
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Account struct {
+		IBAN     string `xml:"Id>IBAN"`
+		Currency string `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) value() string {
+	if d.Date != "" {
+		return d.Date
+	}
+	return d.DateTime
+}
+
+type camtBalance struct {
+	Code                 string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount               camtAmount `xml:"Amt"`
+	CreditDebitIndicator string     `xml:"CdtDbtInd"`
+	Date                 camtDate   `xml:"Dt"`
+}
+
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (p camtParty) value() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PartyName
+}
+
+type camtEntry struct {
+	Amount               camtAmount `xml:"Amt"`
+	CreditDebitIndicator string     `xml:"CdtDbtInd"`
+	BookingDate          camtDate   `xml:"BookgDt"`
+	ValueDate            camtDate   `xml:"ValDt"`
+	Reference            string     `xml:"AcctSvcrRef"`
+	AdditionalInfo       string     `xml:"AddtlNtryInf"`
+	Details              []struct {
+		Debtor         camtParty `xml:"RltdPties>Dbtr"`
+		Creditor       camtParty `xml:"RltdPties>Cdtr"`
+		Unstructured   []string  `xml:"RmtInf>Ustrd"`
+		AdditionalInfo string    `xml:"AddtlTxInf"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// name returns the counterparty, falling back to the remittance and booking texts
+func (e camtEntry) name(isCredit bool) string {
+	for _, detail := range e.Details {
+		counterparty := detail.Creditor.value()
+		if isCredit {
+			counterparty = detail.Debtor.value()
+		}
+		if counterparty != "" {
+			return strings.TrimSpace(counterparty)
+		}
+	}
+	for _, detail := range e.Details {
+		if len(detail.Unstructured) > 0 {
+			return strings.TrimSpace(strings.Join(detail.Unstructured, " "))
+		}
+		if detail.AdditionalInfo != "" {
+			return strings.TrimSpace(detail.AdditionalInfo)
+		}
+	}
+	return strings.TrimSpace(e.AdditionalInfo)
+}
+
+// ParseCamt053 parses an ISO 20022 camt.053 bank to customer statement
+func ParseCamt053(data []byte) (*Statement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 file: %w", err)
+	}
+	if len(document.Statements) == 0 {
+		return nil, fmt.Errorf("invalid camt.053 file: no statement found")
+	}
+
+	statement := &Statement{Entries: []Entry{}}
+	for _, camtStatement := range document.Statements {
+		statement.IBAN = camtStatement.Account.IBAN
+		statement.Currency = camtStatement.Account.Currency
+
+		for _, balance := range camtStatement.Balances {
+			amount, err := signedAmount(balance.Amount.Value, balance.CreditDebitIndicator)
+			if err != nil {
+				return nil, err
+			}
+			if statement.Currency == "" {
+				statement.Currency = balance.Amount.Currency
+			}
+			switch balance.Code {
+			case "OPBD", "PRCD":
+				// Only the first statement in the file opens the period
+				if statement.OpeningBalance == nil {
+					statement.OpeningBalance = &amount
+				}
+			case "CLBD":
+				date, err := tabular.ParseDate(balance.Date.value())
+				if err != nil {
+					return nil, err
+				}
+				statement.ClosingBalance = &amount
+				statement.ClosingDate = &date
+			}
+		}
+
+		for _, camtEntry := range camtStatement.Entries {
+			isCredit := camtEntry.CreditDebitIndicator == "CRDT"
+			amount, err := signedAmount(camtEntry.Amount.Value, camtEntry.CreditDebitIndicator)
+			if err != nil {
+				return nil, err
+			}
+			bookingDate, err := tabular.ParseDate(camtEntry.BookingDate.value())
+			if err != nil {
+				return nil, err
+			}
+			entry := Entry{
+				BookingDate: bookingDate,
+				Amount:      amount,
+				Currency:    camtEntry.Amount.Currency,
+				Name:        camtEntry.name(isCredit),
+				Reference:   strings.TrimSpace(camtEntry.Reference),
+			}
+			if camtEntry.ValueDate.value() != "" {
+				valueDate, err := tabular.ParseDate(camtEntry.ValueDate.value())
+				if err != nil {
+					return nil, err
+				}
+				entry.ValueDate = &valueDate
+			}
+			statement.Entries = append(statement.Entries, entry)
+		}
+	}
+
+	return statement, nil
+}
+
+// signedAmount applies the credit/debit indicator to an unsigned camt amount
+func signedAmount(value string, creditDebitIndicator string) (int64, error) {
+	amount, err := tabular.ParseAmount(value)
+	if err != nil {
+		return 0, err
+	}
+	if creditDebitIndicator == "DBIT" {
+		amount = -amount
+	}
+	return amount, nil
+}
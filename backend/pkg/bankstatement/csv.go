@@ -0,0 +1,127 @@
+package bankstatement
+
+import (
+	"fmt"
+	"liquiswiss/pkg/tabular"
+	"strings"
+)
+
+// Accepted column headers (lower case) for the generic CSV format
+var csvColumnAliases = map[string][]string{
+	"date":      {"date", "booking date", "bookingdate", "datum", "buchungsdatum"},
+	"valueDate": {"value date", "valuedate", "valuta", "valutadatum"},
+	"amount":    {"amount", "betrag"},
+	"credit":    {"credit", "gutschrift"},
+	"debit":     {"debit", "belastung", "lastschrift"},
+	"name":      {"name", "description", "text", "buchungstext", "beschreibung", "avisierungstext"},
+	"reference": {"reference", "referenz"},
+	"currency":  {"currency", "währung", "waehrung"},
+	"balance":   {"balance", "saldo"},
+}
+
+// ParseCSV parses a CSV export with a header row. Either an amount column or separate
+// credit and debit columns are required, an optional balance column provides the closing balance
+func ParseCSV(data []byte) (*Statement, error) {
+	records, err := tabular.ReadCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid CSV file: missing header row")
+	}
+
+	columns := tabular.MapColumns(records[0], csvColumnAliases)
+	if _, ok := columns["date"]; !ok {
+		return nil, fmt.Errorf("invalid CSV file: missing date column")
+	}
+	_, hasAmount := columns["amount"]
+	_, hasCredit := columns["credit"]
+	_, hasDebit := columns["debit"]
+	if !hasAmount && !hasCredit && !hasDebit {
+		return nil, fmt.Errorf("invalid CSV file: missing amount column")
+	}
+
+	statement := &Statement{Entries: []Entry{}}
+	for i, record := range records[1:] {
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		// Skip empty lines and footers without a date
+		if value("date") == "" {
+			continue
+		}
+		bookingDate, err := tabular.ParseDate(value("date"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+
+		var amount int64
+		if hasAmount {
+			amount, err = tabular.ParseAmount(value("amount"))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+		} else {
+			credit, debit := int64(0), int64(0)
+			if value("credit") != "" {
+				if credit, err = tabular.ParseAmount(value("credit")); err != nil {
+					return nil, fmt.Errorf("row %d: %w", i+2, err)
+				}
+			}
+			if value("debit") != "" {
+				if debit, err = tabular.ParseAmount(value("debit")); err != nil {
+					return nil, fmt.Errorf("row %d: %w", i+2, err)
+				}
+			}
+			// Debits might be exported with or without a sign
+			amount = abs(credit) - abs(debit)
+		}
+
+		entry := Entry{
+			BookingDate: bookingDate,
+			Amount:      amount,
+			Currency:    strings.ToUpper(value("currency")),
+			Name:        value("name"),
+			Reference:   value("reference"),
+		}
+		if value("valueDate") != "" {
+			valueDate, err := tabular.ParseDate(value("valueDate"))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+			entry.ValueDate = &valueDate
+		}
+		if statement.Currency == "" {
+			statement.Currency = entry.Currency
+		}
+
+		// The balance after the latest booking closes the statement
+		if value("balance") != "" {
+			balance, err := tabular.ParseAmount(value("balance"))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+			if statement.ClosingDate == nil || !bookingDate.Before(*statement.ClosingDate) {
+				closingDate := bookingDate
+				statement.ClosingBalance = &balance
+				statement.ClosingDate = &closingDate
+			}
+		}
+
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	return statement, nil
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
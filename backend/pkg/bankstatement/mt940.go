@@ -0,0 +1,200 @@
+package bankstatement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"liquiswiss/pkg/tabular"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// :61:YYMMDD[MMDD](C|D|RC|RD)[funds code]amount transaction type, references
+	mt940StatementLinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])([A-Z])?(\d+,\d*)(.*)$`)
+	// :60F:/:62F: (C|D)YYMMDDCURamount
+	mt940BalancePattern = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)`)
+	// Structured :86: subfields like ?20 or ?32
+	mt940SubfieldPattern = regexp.MustCompile(`\?\d{2}`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 parses a SWIFT MT940 customer statement
+func ParseMT940(data []byte) (*Statement, error) {
+	fields := splitMT940Fields(data)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid MT940 file: no fields found")
+	}
+
+	statement := &Statement{Entries: []Entry{}}
+	for i, field := range fields {
+		switch field.tag {
+		case "25":
+			// Account identification, usually the IBAN optionally followed by the currency
+			account := strings.Fields(strings.ReplaceAll(field.value, "/", " "))
+			if len(account) == 0 {
+				return nil, fmt.Errorf("invalid MT940 file: missing account")
+			}
+			statement.IBAN = account[0]
+		case "60F", "60M":
+			if statement.OpeningBalance != nil {
+				continue
+			}
+			amount, _, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, err
+			}
+			statement.OpeningBalance = &amount
+			statement.Currency = currency
+		case "62F", "62M":
+			amount, date, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, err
+			}
+			statement.ClosingBalance = &amount
+			statement.ClosingDate = &date
+			statement.Currency = currency
+		case "61":
+			entry, err := parseMT940StatementLine(field.value)
+			if err != nil {
+				return nil, err
+			}
+			// The information to the account owner follows its statement line
+			if i+1 < len(fields) && fields[i+1].tag == "86" {
+				information := mt940SubfieldPattern.ReplaceAllString(fields[i+1].value, " ")
+				entry.Name = strings.Join(strings.Fields(information), " ")
+			}
+			statement.Entries = append(statement.Entries, *entry)
+		}
+	}
+
+	for i := range statement.Entries {
+		statement.Entries[i].Currency = statement.Currency
+	}
+
+	return statement, nil
+}
+
+// splitMT940Fields splits the message into its tagged fields, joining continuation lines
+func splitMT940Fields(data []byte) []mt940Field {
+	fields := []mt940Field{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, ":") {
+			end := strings.Index(line[1:], ":")
+			if end > 0 {
+				fields = append(fields, mt940Field{tag: line[1 : end+1], value: line[end+2:]})
+				continue
+			}
+		}
+		// Block delimiters like {4: or -} carry no data
+		if line == "-" || line == "-}" || strings.HasPrefix(line, "{") {
+			continue
+		}
+		if len(fields) > 0 {
+			last := &fields[len(fields)-1]
+			last.value += "\n" + line
+		}
+	}
+	return fields
+}
+
+func parseMT940Balance(value string) (int64, time.Time, string, error) {
+	matches := mt940BalancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return 0, time.Time{}, "", fmt.Errorf("invalid MT940 balance: %s", value)
+	}
+	date, err := time.Parse("060102", matches[2])
+	if err != nil {
+		return 0, time.Time{}, "", fmt.Errorf("invalid MT940 balance date: %s", value)
+	}
+	amount, err := tabular.ParseAmount(matches[4])
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+	if matches[1] == "D" {
+		amount = -amount
+	}
+	return amount, date, matches[3], nil
+}
+
+func parseMT940StatementLine(value string) (*Entry, error) {
+	lines := strings.SplitN(value, "\n", 2)
+	matches := mt940StatementLinePattern.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if matches == nil {
+		return nil, fmt.Errorf("invalid MT940 statement line: %s", value)
+	}
+
+	valueDate, err := time.Parse("060102", matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid MT940 value date: %s", value)
+	}
+	bookingDate := valueDate
+	if matches[2] != "" {
+		bookingDate, err = parseMT940BookingDate(matches[2], valueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MT940 booking date: %s", value)
+		}
+	}
+
+	amount, err := tabular.ParseAmount(matches[5])
+	if err != nil {
+		return nil, err
+	}
+	// Debits and reversed credits reduce the balance
+	if matches[3] == "D" || matches[3] == "RC" {
+		amount = -amount
+	}
+
+	entry := &Entry{
+		BookingDate: bookingDate,
+		ValueDate:   &valueDate,
+		Amount:      amount,
+	}
+	// The bank's reference follows the customer reference after a double slash
+	if index := strings.Index(matches[6], "//"); index >= 0 {
+		entry.Reference = strings.TrimSpace(matches[6][index+2:])
+	}
+	return entry, nil
+}
+
+// parseMT940BookingDate reads an MMDD booking date. It has no year, it may fall into the year before or
+// after the value date. The date is built per year, so Feb 29 only exists in leap years.
+func parseMT940BookingDate(value string, valueDate time.Time) (time.Time, error) {
+	month, err := strconv.Atoi(value[:2])
+	if err != nil {
+		return time.Time{}, err
+	}
+	day, err := strconv.Atoi(value[2:])
+	if err != nil {
+		return time.Time{}, err
+	}
+	bookingDate := time.Time{}
+	for _, year := range []int{valueDate.Year(), valueDate.Year() - 1, valueDate.Year() + 1} {
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if date.Month() != time.Month(month) || date.Day() != day {
+			continue
+		}
+		if bookingDate.IsZero() || absDuration(date.Sub(valueDate)) < absDuration(bookingDate.Sub(valueDate)) {
+			bookingDate = date
+		}
+	}
+	if bookingDate.IsZero() {
+		return time.Time{}, fmt.Errorf("invalid date %s", value)
+	}
+	return bookingDate, nil
+}
+
+func absDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
+}
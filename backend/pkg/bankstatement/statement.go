@@ -0,0 +1,78 @@
+// Package bankstatement parses bank statement files (ISO 20022 camt.053, SWIFT MT940 and CSV)
+// into a common representation with amounts in Rappen/cents
+package bankstatement
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+const (
+	FormatCamt053 = "camt053"
+	FormatMT940   = "mt940"
+	FormatCSV     = "csv"
+)
+
+type Statement struct {
+	Format         string
+	IBAN           string
+	Currency       string
+	OpeningBalance *int64
+	ClosingBalance *int64
+	ClosingDate    *time.Time
+	Entries        []Entry
+}
+
+// Entry is a single booking, credits are positive and debits negative
+type Entry struct {
+	BookingDate time.Time
+	ValueDate   *time.Time
+	Amount      int64
+	Currency    string
+	// Name is the counterparty or booking text used to match planned transactions
+	Name string
+	// Reference is the bank's unique reference of the booking, if provided
+	Reference string
+}
+
+// DetectFormat guesses the format from the file content
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return FormatCamt053
+	case bytes.Contains(trimmed, []byte(":20:")) && bytes.Contains(trimmed, []byte(":61:")):
+		return FormatMT940
+	case bytes.Contains(trimmed, []byte(":20:")) && bytes.Contains(trimmed, []byte(":62F:")):
+		return FormatMT940
+	default:
+		return FormatCSV
+	}
+}
+
+// Parse parses the data in the given format, an empty format is detected from the content
+func Parse(format string, data []byte) (*Statement, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+
+	var statement *Statement
+	var err error
+	switch format {
+	case FormatCamt053:
+		statement, err = ParseCamt053(data)
+	case FormatMT940:
+		statement, err = ParseMT940(data)
+	case FormatCSV:
+		statement, err = ParseCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported statement format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statement.Format = format
+	return statement, nil
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// writeCSV writes all tables one after another, separated by an empty line and the table title.
+// Amounts are written without digit grouping so spreadsheet programs recognise them as numbers.
+func writeCSV(w io.Writer, report *Report) error {
+	// The byte order mark makes Excel read the file as UTF-8
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = report.Locale.CSVDelimiter
+
+	for i, table := range report.Tables {
+		if len(report.Tables) > 1 {
+			if i > 0 {
+				if err := writer.Write([]string{}); err != nil {
+					return err
+				}
+			}
+			if err := writer.Write([]string{table.Title}); err != nil {
+				return err
+			}
+		}
+
+		header := make([]string, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			header = append(header, column.Header)
+		}
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+
+		for _, row := range table.Rows {
+			record := make([]string, len(table.Columns))
+			for c, column := range table.Columns {
+				if c >= len(row.Cells) {
+					break
+				}
+				record[c] = formatCell(row.Cells[c], column.Kind, report.Locale, false)
+			}
+			if len(record) > 0 && table.Columns[0].Kind == KindText {
+				record[0] = indent(record[0], row.Level)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,93 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"liquiswiss/pkg/export"
+)
+
+func sampleReport(localeCode string) *export.Report {
+	return &export.Report{
+		Title:        "Liquiditätsplanung",
+		Organisation: "Müller AG",
+		Currency:     "CHF",
+		Locale:       export.LocaleFor(localeCode),
+		CreatedAt:    time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+		Tables: []export.Table{
+			{
+				Title: "Prognose",
+				Columns: []export.Column{
+					{Header: "Position", Kind: export.KindText},
+					{Header: "2025-01", Kind: export.KindAmount},
+					{Header: "Fällig", Kind: export.KindDate},
+				},
+				Rows: []export.Row{
+					{Cells: []any{"Einnahmen", int64(123456789), nil}, Emphasis: true},
+					{Cells: []any{"Verkauf", int64(-5), time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)}, Level: 1},
+				},
+			},
+		},
+	}
+}
+
+func TestLocale_FormatsAmountsAndPercentages(t *testing.T) {
+	swiss := export.LocaleFor("de-CH")
+	require.Equal(t, "1'234'567.89", swiss.FormatAmount(123456789, true))
+	require.Equal(t, "-0.05", swiss.FormatAmount(-5, true))
+	require.Equal(t, "5.325%", swiss.FormatPercent(5325))
+	require.Equal(t, "8%", swiss.FormatPercent(8000))
+
+	german := export.LocaleFor("de-DE")
+	require.Equal(t, "1.234.567,89", german.FormatAmount(123456789, true))
+	require.Equal(t, "1234567,89", german.FormatAmount(123456789, false))
+
+	// Unknown locales fall back to the locale of the default currency
+	require.Equal(t, export.DefaultLocaleCode, export.LocaleFor("xx").Code)
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buffer bytes.Buffer
+	err := export.Write(&buffer, export.FormatCSV, sampleReport("de-CH"))
+	require.NoError(t, err)
+
+	expected := "\xef\xbb\xbf" +
+		"Position;2025-01;Fällig\n" +
+		"Einnahmen;1234567.89;\n" +
+		"\"  Verkauf\";-0.05;15.01.2025\n"
+	require.Equal(t, expected, buffer.String())
+}
+
+func TestWrite_XLSX(t *testing.T) {
+	var buffer bytes.Buffer
+	err := export.Write(&buffer, export.FormatXLSX, sampleReport("de-CH"))
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buffer)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Equal(t, []string{"Prognose"}, f.GetSheetList())
+	value, err := f.GetCellValue("Prognose", "A3", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	require.Equal(t, "Verkauf", value)
+	value, err = f.GetCellValue("Prognose", "B2", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	require.Equal(t, "1234567.89", value)
+}
+
+func TestWrite_PDF(t *testing.T) {
+	var buffer bytes.Buffer
+	err := export.Write(&buffer, export.FormatPDF, sampleReport("fr-CH"))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buffer.Bytes(), []byte("%PDF-")))
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	err := export.Write(&bytes.Buffer{}, "docx", sampleReport("de-CH"))
+	require.ErrorIs(t, err, export.ErrUnsupportedFormat)
+}
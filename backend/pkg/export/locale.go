@@ -0,0 +1,108 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Locale holds the number and date conventions of a currency locale like "de-CH"
+type Locale struct {
+	Code               string
+	DecimalSeparator   string
+	ThousandsSeparator string
+	// CSVDelimiter is the separator spreadsheet programs expect in this locale
+	CSVDelimiter rune
+	DateLayout   string
+}
+
+var locales = map[string]Locale{
+	"de-CH": {DecimalSeparator: ".", ThousandsSeparator: "'", CSVDelimiter: ';', DateLayout: "02.01.2006"},
+	"it-CH": {DecimalSeparator: ".", ThousandsSeparator: "'", CSVDelimiter: ';', DateLayout: "02.01.2006"},
+	"fr-CH": {DecimalSeparator: ",", ThousandsSeparator: " ", CSVDelimiter: ';', DateLayout: "02.01.2006"},
+	"de":    {DecimalSeparator: ",", ThousandsSeparator: ".", CSVDelimiter: ';', DateLayout: "02.01.2006"},
+	"fr":    {DecimalSeparator: ",", ThousandsSeparator: " ", CSVDelimiter: ';', DateLayout: "02/01/2006"},
+	"it":    {DecimalSeparator: ",", ThousandsSeparator: ".", CSVDelimiter: ';', DateLayout: "02/01/2006"},
+	"en-US": {DecimalSeparator: ".", ThousandsSeparator: ",", CSVDelimiter: ',', DateLayout: "01/02/2006"},
+	"en":    {DecimalSeparator: ".", ThousandsSeparator: ",", CSVDelimiter: ',', DateLayout: "02/01/2006"},
+}
+
+// DefaultLocaleCode is used for unknown locales, as it is the locale of the default currency CHF
+const DefaultLocaleCode = "de-CH"
+
+// LocaleFor returns the conventions of the locale, falling back to its language and then to de-CH
+func LocaleFor(code string) Locale {
+	if locale, ok := locales[code]; ok {
+		locale.Code = code
+		return locale
+	}
+	language, _, _ := strings.Cut(code, "-")
+	if locale, ok := locales[language]; ok {
+		locale.Code = code
+		return locale
+	}
+	locale := locales[DefaultLocaleCode]
+	locale.Code = DefaultLocaleCode
+	return locale
+}
+
+// FormatAmount formats Rappen/cents with two decimals, e.g. 123456789 as 1'234'567.89 for de-CH
+func (l Locale) FormatAmount(cents int64, grouped bool) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	units := strconv.FormatInt(cents/100, 10)
+	if grouped {
+		units = groupDigits(units, l.ThousandsSeparator)
+	}
+	fraction := strconv.FormatInt(cents%100+100, 10)[1:]
+	return sign + units + l.DecimalSeparator + fraction
+}
+
+// FormatNumber formats an integer, optionally with digit grouping
+func (l Locale) FormatNumber(number int64, grouped bool) string {
+	sign := ""
+	if number < 0 {
+		sign = "-"
+		number = -number
+	}
+	digits := strconv.FormatInt(number, 10)
+	if grouped {
+		digits = groupDigits(digits, l.ThousandsSeparator)
+	}
+	return sign + digits
+}
+
+func groupDigits(digits string, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var builder strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		builder.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if builder.Len() > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(digits[i : i+3])
+	}
+	return builder.String()
+}
+
+// FormatPercent formats thousandths of a percent, e.g. 5325 as 5.325% for de-CH
+func (l Locale) FormatPercent(thousandths int64) string {
+	sign := ""
+	if thousandths < 0 {
+		sign = "-"
+		thousandths = -thousandths
+	}
+	fraction := strings.TrimRight(strconv.FormatInt(thousandths%1000+1000, 10)[1:], "0")
+	units := strconv.FormatInt(thousandths/1000, 10)
+	if fraction == "" {
+		return sign + units + "%"
+	}
+	return sign + units + l.DecimalSeparator + fraction + "%"
+}
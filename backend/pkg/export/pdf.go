@@ -0,0 +1,145 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin       = 10.0
+	pdfBottomMargin = 15.0
+	pdfIndent       = 3.0
+)
+
+// writePDF renders a printable landscape report with one section per table
+func writePDF(w io.Writer, report *Report) error {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfBottomMargin)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.CellFormat(0, 4, tr(report.Title+" - "+report.Organisation), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, tr(report.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	subtitle := fmt.Sprintf("%s, Beträge in %s, erstellt am %s",
+		report.Organisation, report.Currency, report.CreatedAt.Format(report.Locale.DateLayout))
+	pdf.CellFormat(0, 5, tr(subtitle), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pdfMargin
+	for _, table := range report.Tables {
+		fontSize := 9.0
+		switch {
+		case len(table.Columns) > 10:
+			fontSize = 6.5
+		case len(table.Columns) > 7:
+			fontSize = 7.5
+		}
+		rowHeight := fontSize * 0.55
+		widths := pdfColumnWidths(table.Columns, contentWidth)
+
+		writeHeader := func() {
+			pdf.SetFont("Helvetica", "B", fontSize)
+			pdf.SetFillColor(235, 235, 235)
+			for c, column := range table.Columns {
+				pdf.CellFormat(widths[c], rowHeight+1, pdfFit(pdf, tr, column.Header, widths[c]), "B", 0, pdfAlign(column.Kind), true, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+
+		if pdf.GetY()+3*rowHeight+8 > pageHeight-pdfBottomMargin {
+			pdf.AddPage()
+		}
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 7, tr(table.Title), "", 1, "L", false, 0, "")
+		writeHeader()
+
+		for _, row := range table.Rows {
+			if pdf.GetY()+rowHeight > pageHeight-pdfBottomMargin {
+				pdf.AddPage()
+				writeHeader()
+			}
+			style := ""
+			border := ""
+			if row.Emphasis {
+				style = "B"
+				border = "T"
+			}
+			pdf.SetFont("Helvetica", style, fontSize)
+			for c, column := range table.Columns {
+				var value any
+				if c < len(row.Cells) {
+					value = row.Cells[c]
+				}
+				text := formatCell(value, column.Kind, report.Locale, true)
+				width := widths[c]
+				if c == 0 && row.Level > 0 {
+					offset := float64(row.Level) * pdfIndent
+					pdf.CellFormat(offset, rowHeight, "", border, 0, "L", false, 0, "")
+					width -= offset
+				}
+				pdf.CellFormat(width, rowHeight, pdfFit(pdf, tr, text, width), border, 0, pdfAlign(column.Kind), false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+		pdf.Ln(4)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+// pdfColumnWidths gives the first text column three shares, other text columns two and all others one
+func pdfColumnWidths(columns []Column, contentWidth float64) []float64 {
+	weights := make([]float64, len(columns))
+	total := 0.0
+	for c, column := range columns {
+		weights[c] = 1
+		if column.Kind == KindText {
+			weights[c] = 2
+			if c == 0 {
+				weights[c] = 3
+			}
+		}
+		total += weights[c]
+	}
+	widths := make([]float64, len(columns))
+	for c := range columns {
+		widths[c] = contentWidth * weights[c] / total
+	}
+	return widths
+}
+
+func pdfAlign(kind Kind) string {
+	if kind == KindAmount || kind == KindNumber {
+		return "R"
+	}
+	return "L"
+}
+
+// pdfFit translates the text into the font encoding and shortens it with an ellipsis until it fits into the cell
+func pdfFit(pdf *fpdf.Fpdf, tr func(string) string, text string, width float64) string {
+	available := width - 2*pdf.GetCellMargin()
+	if pdf.GetStringWidth(tr(text)) <= available {
+		return tr(text)
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(tr(string(runes)+"…")) > available {
+		runes = runes[:len(runes)-1]
+	}
+	return tr(string(runes) + "…")
+}
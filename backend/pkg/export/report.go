@@ -0,0 +1,148 @@
+// Package export renders tabular reports as CSV, XLSX or printable PDF.
+// Amounts are passed in Rappen/cents and formatted according to the report locale.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatPDF  = "pdf"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+type Kind int
+
+const (
+	// KindText cells hold a string
+	KindText Kind = iota
+	// KindAmount cells hold an int64 in Rappen/cents
+	KindAmount
+	// KindNumber cells hold an integer
+	KindNumber
+	// KindDate cells hold a time.Time
+	KindDate
+)
+
+type Column struct {
+	Header string
+	Kind   Kind
+}
+
+// Row holds one cell per column, nil cells stay empty
+type Row struct {
+	Cells []any
+	// Level indents the first cell to show a hierarchy
+	Level int
+	// Emphasis marks totals and headings
+	Emphasis bool
+}
+
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    []Row
+}
+
+type Report struct {
+	Title        string
+	Organisation string
+	// Currency is the code all converted amounts are shown in
+	Currency  string
+	Locale    Locale
+	CreatedAt time.Time
+	Tables    []Table
+}
+
+// Write renders the report in the given format
+func Write(w io.Writer, format string, report *Report) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, report)
+	case FormatXLSX:
+		return writeXLSX(w, report)
+	case FormatPDF:
+		return writePDF(w, report)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileName returns a file name like "forecast_2025-01-31.xlsx"
+func FileName(name string, format string, date time.Time) string {
+	return fmt.Sprintf("%s_%s.%s", name, date.Format("2006-01-02"), format)
+}
+
+// IsSupportedFormat reports whether the format can be written
+func IsSupportedFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX || format == FormatPDF
+}
+
+// formatCell returns the cell as text, amounts with or without digit grouping
+func formatCell(value any, kind Kind, locale Locale, grouped bool) string {
+	if value == nil {
+		return ""
+	}
+	switch kind {
+	case KindAmount:
+		amount, ok := toInt64(value)
+		if !ok {
+			return fmt.Sprint(value)
+		}
+		return locale.FormatAmount(amount, grouped)
+	case KindNumber:
+		number, ok := toInt64(value)
+		if !ok {
+			return fmt.Sprint(value)
+		}
+		return locale.FormatNumber(number, grouped)
+	case KindDate:
+		date, ok := value.(time.Time)
+		if !ok {
+			return fmt.Sprint(value)
+		}
+		return date.Format(locale.DateLayout)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func indent(text string, level int) string {
+	return strings.Repeat("  ", level) + text
+}
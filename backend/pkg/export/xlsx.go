@@ -0,0 +1,167 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type xlsxStyleKey struct {
+	kind     Kind
+	level    int
+	emphasis bool
+}
+
+// writeXLSX writes every table into its own sheet, amounts and dates as real spreadsheet values
+func writeXLSX(w io.Writer, report *Report) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetDocProps(&excelize.DocProperties{
+		Title:   report.Title,
+		Subject: report.Organisation,
+		Creator: "LiquiSwiss",
+	}); err != nil {
+		return err
+	}
+
+	dateFormat := strings.NewReplacer("2006", "yyyy", "01", "mm", "02", "dd").Replace(report.Locale.DateLayout)
+	amountFormat := "#,##0.00"
+	styles := make(map[xlsxStyleKey]int)
+	style := func(key xlsxStyleKey) (int, error) {
+		if id, ok := styles[key]; ok {
+			return id, nil
+		}
+		definition := &excelize.Style{Font: &excelize.Font{Bold: key.emphasis}}
+		switch key.kind {
+		case KindAmount:
+			definition.CustomNumFmt = &amountFormat
+		case KindDate:
+			definition.CustomNumFmt = &dateFormat
+		case KindText:
+			definition.Alignment = &excelize.Alignment{Indent: key.level}
+		}
+		id, err := f.NewStyle(definition)
+		if err != nil {
+			return 0, err
+		}
+		styles[key] = id
+		return id, nil
+	}
+
+	usedNames := make(map[string]bool)
+	for i, table := range report.Tables {
+		sheet := sheetName(table.Title, usedNames)
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+
+		for c, column := range table.Columns {
+			cell, err := excelize.CoordinatesToCellName(c+1, 1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheet, cell, column.Header); err != nil {
+				return err
+			}
+			headerStyle, err := style(xlsxStyleKey{kind: KindText, emphasis: true})
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellStyle(sheet, cell, cell, headerStyle); err != nil {
+				return err
+			}
+
+			width := 14.0
+			if column.Kind == KindText {
+				width = 30
+			}
+			columnName, err := excelize.ColumnNumberToName(c + 1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetColWidth(sheet, columnName, columnName, width); err != nil {
+				return err
+			}
+		}
+
+		for r, row := range table.Rows {
+			for c, column := range table.Columns {
+				if c >= len(row.Cells) || row.Cells[c] == nil {
+					continue
+				}
+				cell, err := excelize.CoordinatesToCellName(c+1, r+2)
+				if err != nil {
+					return err
+				}
+				if err := f.SetCellValue(sheet, cell, xlsxValue(row.Cells[c], column.Kind)); err != nil {
+					return err
+				}
+				level := 0
+				if c == 0 {
+					level = row.Level
+				}
+				cellStyle, err := style(xlsxStyleKey{kind: column.Kind, level: level, emphasis: row.Emphasis})
+				if err != nil {
+					return err
+				}
+				if err := f.SetCellStyle(sheet, cell, cell, cellStyle); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := f.SetPanes(sheet, &excelize.Panes{
+			Freeze:      true,
+			YSplit:      1,
+			TopLeftCell: "A2",
+			ActivePane:  "bottomLeft",
+		}); err != nil {
+			return err
+		}
+	}
+
+	return f.Write(w)
+}
+
+func xlsxValue(value any, kind Kind) any {
+	if kind == KindAmount {
+		if amount, ok := toInt64(value); ok {
+			return float64(amount) / 100
+		}
+	}
+	return value
+}
+
+// sheetName removes the characters Excel does not allow and keeps the name unique and within 31 characters
+func sheetName(title string, usedNames map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, title)
+	if name == "" {
+		name = "Export"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	unique := name
+	for i := 2; usedNames[unique]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		runes := []rune(name)
+		if len(runes)+len(suffix) > 31 {
+			runes = runes[:31-len(suffix)]
+		}
+		unique = string(runes) + suffix
+	}
+	usedNames[unique] = true
+	return unique
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"liquiswiss/pkg/types"
+	"time"
+)
+
+// Actual is a realised booking, either imported from a bank statement or entered manually.
+// Matched actuals point to the planned transaction occurrence they settle.
+type Actual struct {
+	ID                    int64              `db:"id" json:"id"`
+	BookingDate           types.AsDate       `db:"booking_date" json:"bookingDate"`
+	Amount                int64              `db:"amount" json:"amount"`
+	Name                  string             `db:"name" json:"name"`
+	Reference             *string            `db:"reference" json:"reference"`
+	Status                string             `db:"status" json:"status"`
+	PlannedDate           *types.AsDate      `db:"planned_date" json:"plannedDate"`
+	PlannedAmount         *int64             `db:"planned_amount" json:"plannedAmount"`
+	Transaction           *ActualTransaction `json:"transaction"`
+	BankAccountID         *int64             `db:"bank_account_id" json:"bankAccountID"`
+	BankStatementImportID *int64             `db:"bank_statement_import_id" json:"bankStatementImportID"`
+	Currency              Currency           `json:"currency"`
+}
+
+type ActualTransaction struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	Category *Category `json:"category"`
+}
+
+type CreateActual struct {
+	BookingDate         string  `json:"bookingDate" validate:"required,datetime=2006-01-02"`
+	Amount              int64   `json:"amount" validate:"required"`
+	Name                string  `json:"name" validate:"required,max=255"`
+	Reference           *string `json:"reference" validate:"omitempty,max=255"`
+	Status              string  `json:"status" validate:"required,oneof=matched deviation unmatched"`
+	PlannedDate         *string `json:"plannedDate" validate:"omitempty,datetime=2006-01-02"`
+	PlannedAmount       *int64  `json:"plannedAmount"`
+	Transaction         *int64  `json:"transaction" validate:"omitempty,gt=0"`
+	BankAccount         *int64  `json:"bankAccount" validate:"omitempty,gt=0"`
+	BankStatementImport *int64  `json:"bankStatementImport" validate:"omitempty,gt=0"`
+	Currency            int64   `json:"currency" validate:"required,gt=0"`
+}
+
+// CreateManualActual records a realised amount by hand. Linking a transaction requires the planned
+// date of the occurrence it settles, the status is derived from the planned amount.
+type CreateManualActual struct {
+	BookingDate string  `json:"bookingDate" validate:"required,datetime=2006-01-02"`
+	Amount      int64   `json:"amount" validate:"required"`
+	Name        string  `json:"name" validate:"required,max=255"`
+	Reference   *string `json:"reference" validate:"omitempty,max=255"`
+	Transaction *int64  `json:"transaction" validate:"omitempty,gt=0"`
+	PlannedDate *string `json:"plannedDate" validate:"omitempty,datetime=2006-01-02"`
+	Currency    int64   `json:"currency" validate:"required,gt=0"`
+}
+
+type UpdateActual struct {
+	BookingDate *string `json:"bookingDate" validate:"omitempty,datetime=2006-01-02"`
+	Amount      *int64  `json:"amount" validate:"omitempty"`
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	Reference   *string `json:"reference" validate:"omitempty,max=255"`
+	// Zero removes the link to the transaction
+	Transaction *int64  `json:"transaction" validate:"omitempty,gte=0"`
+	PlannedDate *string `json:"plannedDate" validate:"omitempty,datetime=2006-01-02"`
+	Currency    *int64  `json:"currency" validate:"omitempty,gt=0"`
+}
+
+// ActualsImport summarises a CSV upload of actuals
+type ActualsImport struct {
+	Matched    int64    `json:"matched"`
+	Deviations int64    `json:"deviations"`
+	Unmatched  int64    `json:"unmatched"`
+	Skipped    int64    `json:"skipped"`
+	Actuals    []Actual `json:"actuals"`
+}
+
+type BankStatementImport struct {
+	ID             int64         `db:"id" json:"id"`
+	FileName       string        `db:"file_name" json:"fileName"`
+	Format         string        `db:"format" json:"format"`
+	OpeningBalance *int64        `db:"opening_balance" json:"openingBalance"`
+	ClosingBalance *int64        `db:"closing_balance" json:"closingBalance"`
+	ClosingDate    *types.AsDate `db:"closing_date" json:"closingDate"`
+	CreatedAt      time.Time     `db:"created_at" json:"createdAt"`
+	BankAccountID  int64         `db:"bank_account_id" json:"bankAccountID"`
+
+	// Calculated Values
+	Matched    int64 `json:"matched"`
+	Deviations int64 `json:"deviations"`
+	Unmatched  int64 `json:"unmatched"`
+	// Skipped counts bookings that were already imported before
+	Skipped int64    `json:"skipped"`
+	Actuals []Actual `json:"actuals,omitempty"`
+}
+
+type CreateBankStatementImport struct {
+	FileName       string  `validate:"required,max=255"`
+	Format         string  `validate:"required,oneof=camt053 mt940 csv"`
+	OpeningBalance *int64  `validate:"omitempty"`
+	ClosingBalance *int64  `validate:"omitempty"`
+	ClosingDate    *string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+// TransactionOccurrence identifies a single planned execution of a transaction
+type TransactionOccurrence struct {
+	TransactionID int64
+	Date          string
+}
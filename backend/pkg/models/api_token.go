@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// APIToken is a personal access token of a user for scripts, bound to one organisation
+type APIToken struct {
+	ID               int64  `db:"id" json:"id"`
+	Name             string `db:"name" json:"name"`
+	Prefix           string `db:"token_prefix" json:"prefix"`
+	Scope            string `db:"scope" json:"scope"`
+	OrganisationID   int64  `db:"organisation_id" json:"organisationID"`
+	OrganisationName string `db:"organisation_name" json:"organisationName"`
+	// LastUsedAt is updated at most once per minute
+	LastUsedAt *time.Time `db:"last_used_at" json:"lastUsedAt"`
+	LastUsedIP *string    `db:"last_used_ip" json:"lastUsedIP"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expiresAt"`
+}
+
+// CreatedAPIToken carries the secret, it is only shown once after creating the token
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+type CreateAPIToken struct {
+	Name  string `json:"name" validate:"required,min=3,max=100"`
+	Scope string `json:"scope" validate:"required,oneof=editor read-only"`
+	// OrganisationID defaults to the current organisation
+	OrganisationID *int64 `json:"organisationID" validate:"omitempty,min=1"`
+	ExpiresInDays  int    `json:"expiresInDays" validate:"required,min=1,max=365"`
+}
+
+// APITokenAuth is what the auth middleware needs to know about a presented token
+type APITokenAuth struct {
+	ID             int64  `db:"id"`
+	UserID         int64  `db:"user_id"`
+	OrganisationID int64  `db:"organisation_id"`
+	Scope          string `db:"scope"`
+}
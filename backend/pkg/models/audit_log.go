@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	AuditSourceWeb = "web"
+	AuditSourceMCP = "mcp"
+	// AuditSourceAPIToken marks changes of scripts using a personal API token
+	AuditSourceAPIToken = "api-token"
+)
+
+// AuditLog records a single mutation of an organisation
+type AuditLog struct {
+	ID     int64   `db:"id" json:"id"`
+	UserID *int64  `db:"user_id" json:"userID"`
+	User   *string `db:"user_name" json:"user"`
+	// Source tells whether the change was made in the web app or by an MCP client, the latter along with its OAuth client id
+	Source        string             `db:"source" json:"source"`
+	OAuthClientID *string            `db:"oauth_client_id" json:"oauthClientID"`
+	Entity        string             `db:"entity" json:"entity"`
+	EntityID      int64              `db:"entity_id" json:"entityID"`
+	ParentID      *int64             `db:"parent_id" json:"parentID"`
+	Action        string             `db:"action" json:"action"`
+	Changes       []AuditFieldChange `db:"changes" json:"changes"`
+	CreatedAt     time.Time          `db:"created_at" json:"createdAt"`
+}
+
+// AuditFieldChange is a field that differs between the entity before and after the mutation
+type AuditFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type CreateAuditLog struct {
+	OrganisationID int64
+	UserID         int64
+	Source         string
+	OAuthClientID  *string
+	Entity         string
+	EntityID       int64
+	ParentID       *int64
+	Action         string
+	Changes        []AuditFieldChange
+}
+
+// AuditLogFilter narrows the audit trail, From and To are inclusive dates (YYYY-MM-DD)
+type AuditLogFilter struct {
+	Entity *string
+	UserID *int64
+	From   *string
+	To     *string
+}
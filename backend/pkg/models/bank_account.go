@@ -1,5 +1,7 @@
 package models
 
+import "liquiswiss/pkg/types"
+
 type BankAccount struct {
 	ID       int64    `db:"id" json:"id"`
 	Name     string   `db:"name" json:"name"`
@@ -18,3 +20,16 @@ type UpdateBankAccount struct {
 	Amount   *int64  `json:"amount" validate:"omitempty"`
 	Currency *int64  `json:"currency" validate:"omitempty"`
 }
+
+// BankAccountBalance is a dated snapshot of a bank account's balance in the account's currency
+type BankAccountBalance struct {
+	ID            int64        `db:"id" json:"id"`
+	Date          types.AsDate `db:"balance_date" json:"date"`
+	Amount        int64        `db:"amount" json:"amount"`
+	BankAccountID int64        `db:"bank_account_id" json:"bankAccountID"`
+}
+
+type CreateBankAccountBalance struct {
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount *int64 `json:"amount" validate:"required"`
+}
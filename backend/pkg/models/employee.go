@@ -7,6 +7,7 @@ import (
 type Employee struct {
 	ID                  int64         `db:"id" json:"id"`
 	Name                string        `db:"name" json:"name"`
+	BirthDate           *types.AsDate `db:"birth_date" json:"birthDate"`
 	HoursPerMonth       *uint16       `db:"-" json:"hoursPerMonth"`
 	SalaryAmount        *uint64       `db:"-" json:"salaryAmount"`
 	Cycle               *string       `db:"-" json:"cycle"`
@@ -21,9 +22,11 @@ type Employee struct {
 }
 
 type CreateEmployee struct {
-	Name string `json:"name" validate:"required,max=100"`
+	Name      string  `json:"name" validate:"required,max=100"`
+	BirthDate *string `json:"birthDate" validate:"omitempty,datetime=2006-01-02"`
 }
 
 type UpdateEmployee struct {
-	Name *string `json:"name" validate:"omitempty,max=100"`
+	Name      *string `json:"name" validate:"omitempty,max=100"`
+	BirthDate *string `json:"birthDate" validate:"omitempty,datetime=2006-01-02"`
 }
@@ -0,0 +1,9 @@
+package models
+
+import "encoding/json"
+
+// EventOutboxEntry is a message of the event hub shared through the database
+type EventOutboxEntry struct {
+	ID      int64           `db:"id"`
+	Payload json.RawMessage `db:"payload"`
+}
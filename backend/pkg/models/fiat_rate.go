@@ -0,0 +1,178 @@
+package models
+
+import (
+	"liquiswiss/pkg/types"
+	"sort"
+	"time"
+)
+
+// FiatRateHistory is the rate of a currency pair on one day
+type FiatRateHistory struct {
+	Base   string       `json:"base"`
+	Target string       `json:"target"`
+	Rate   float64      `json:"rate"`
+	Source string       `json:"source"`
+	Date   types.AsDate `json:"date"`
+}
+
+const (
+	FiatRateFetchStatusSucceeded = "succeeded"
+	FiatRateFetchStatusFailed    = "failed"
+
+	// FiatRateSourceFallback marks the bundled rates, they are a snapshot and never current
+	FiatRateSourceFallback = "fallback"
+
+	FiatRateWarningMissing  = "missing"
+	FiatRateWarningStale    = "stale"
+	FiatRateWarningFallback = "fallback"
+
+	FiatRateHealthOK      = "ok"
+	FiatRateHealthStale   = "stale"
+	FiatRateHealthFailing = "failing"
+	FiatRateHealthUnknown = "unknown"
+)
+
+// CreateFiatRateFetch records one fetch of the rate provider
+type CreateFiatRateFetch struct {
+	Source    string
+	Base      *string
+	RateDate  *time.Time
+	RateCount int64
+	Status    string
+	Error     *string
+}
+
+// FiatRateFetch is one logged fetch of the rate provider
+type FiatRateFetch struct {
+	ID        int64         `json:"id"`
+	Source    string        `json:"source"`
+	Base      *string       `json:"base"`
+	RateDate  *types.AsDate `json:"rateDate"`
+	RateCount int64         `json:"rateCount"`
+	Status    string        `json:"status"`
+	Error     *string       `json:"error"`
+	FetchedAt time.Time     `json:"fetchedAt"`
+}
+
+// FiatRateHealth summarises the latest fetches for monitoring
+type FiatRateHealth struct {
+	// Status is stale once the last successful rates are older than MaxRateAgeDays, failing
+	// while the fetches since then fail and unknown without any fetch
+	Status         string          `json:"status"`
+	MaxRateAgeDays int64           `json:"maxRateAgeDays"`
+	LastSuccess    *FiatRateFetch  `json:"lastSuccess"`
+	FailedSince    int64           `json:"failedSince"`
+	RecentFetches  []FiatRateFetch `json:"recentFetches"`
+}
+
+// FiatRateWarning tells why a conversion can't be relied on
+type FiatRateWarning struct {
+	Kind     string        `json:"kind"`
+	Currency string        `json:"currency"`
+	Source   *string       `json:"source"`
+	RateDate *types.AsDate `json:"rateDate"`
+}
+
+// ForecastWarning is a rate warning of an item the forecast converts
+type ForecastWarning struct {
+	RelatedID    int64  `json:"relatedID"`
+	RelatedTable string `json:"relatedTable"`
+	Name         string `json:"name"`
+	FiatRateWarning
+}
+
+// OrganisationFiatRate is a planning rate of an organisation for its base currency
+type OrganisationFiatRate struct {
+	ID        int64     `json:"id"`
+	Base      string    `json:"base"`
+	Target    string    `json:"target"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UpsertOrganisationFiatRate struct {
+	Rate float64 `json:"rate" validate:"required,gt=0"`
+}
+
+// FiatRateTable converts into the base currency with the rate valid on a date
+type FiatRateTable struct {
+	base      string
+	current   map[string]FiatRate
+	history   map[string][]FiatRateHistory
+	overrides map[string]float64
+}
+
+// NewFiatRateTable expects the history sorted by date. Overrides of another base are ignored.
+func NewFiatRateTable(base string, current []FiatRate, history []FiatRateHistory, overrides []OrganisationFiatRate) *FiatRateTable {
+	table := &FiatRateTable{
+		base:      base,
+		current:   make(map[string]FiatRate),
+		history:   make(map[string][]FiatRateHistory),
+		overrides: make(map[string]float64),
+	}
+	for _, rate := range current {
+		if rate.Base == base {
+			table.current[rate.Target] = rate
+		}
+	}
+	for _, rate := range history {
+		if rate.Base == base {
+			table.history[rate.Target] = append(table.history[rate.Target], rate)
+		}
+	}
+	for _, rate := range overrides {
+		if rate.Base == base {
+			table.overrides[rate.Target] = rate.Rate
+		}
+	}
+	return table
+}
+
+// Rate returns the planning rate of the organisation, otherwise the latest rate known on the date.
+// Dates before the history use the current rate, unknown currencies 1.0, see Warning.
+func (t *FiatRateTable) Rate(target string, date time.Time) float64 {
+	if target == t.base {
+		return 1.0
+	}
+	if rate, ok := t.overrides[target]; ok {
+		return rate
+	}
+	history := t.history[target]
+	// Index of the first entry after the date
+	index := sort.Search(len(history), func(i int) bool {
+		return time.Time(history[i].Date).After(date)
+	})
+	if index > 0 {
+		return history[index-1].Rate
+	}
+	if rate, ok := t.current[target]; ok {
+		return rate.Rate
+	}
+	return 1.0
+}
+
+// Warning tells why the current rate of the target can't be relied on today, nil if it can.
+// Planning rates of the organisation are set deliberately and never warned about.
+func (t *FiatRateTable) Warning(target string, today time.Time, maxAge time.Duration) *FiatRateWarning {
+	if target == t.base {
+		return nil
+	}
+	if _, ok := t.overrides[target]; ok {
+		return nil
+	}
+	rate, ok := t.current[target]
+	if !ok {
+		return &FiatRateWarning{Kind: FiatRateWarningMissing, Currency: target}
+	}
+
+	warning := &FiatRateWarning{Currency: target, Source: &rate.Source, RateDate: &rate.RateDate}
+	switch {
+	case rate.Source == FiatRateSourceFallback:
+		warning.Kind = FiatRateWarningFallback
+	case today.Sub(time.Time(rate.RateDate)) > maxAge:
+		warning.Kind = FiatRateWarningStale
+	default:
+		return nil
+	}
+	return warning
+}
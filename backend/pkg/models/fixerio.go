@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"liquiswiss/pkg/types"
+	"time"
+)
 
 type FixerIOError struct {
 	Code int    `json:"code"`
@@ -18,15 +21,21 @@ type FixerIOResponse struct {
 }
 
 type FiatRate struct {
-	ID        int64     `json:"id"`
-	Base      string    `json:"base"`
-	Target    string    `json:"target"`
-	Rate      float64   `json:"rate"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        int64        `json:"id"`
+	Base      string       `json:"base"`
+	Target    string       `json:"target"`
+	Rate      float64      `json:"rate"`
+	Source    string       `json:"source"`
+	RateDate  types.AsDate `json:"rateDate"`
+	UpdatedAt time.Time    `json:"updatedAt"`
 }
 
 type CreateFiatRate struct {
 	Base   string  `json:"base"`
 	Target string  `json:"target"`
 	Rate   float64 `json:"rate"`
+	// Source is the provider the rate comes from
+	Source string `json:"source"`
+	// Date is the day the rate is valid for in the history, today if nil
+	Date *time.Time `json:"date,omitempty"`
 }
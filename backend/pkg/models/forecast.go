@@ -2,11 +2,23 @@ package models
 
 import "time"
 
+const (
+	ForecastPeriodMonth   = "month"
+	ForecastPeriodQuarter = "quarter"
+	ForecastPeriodYear    = "year"
+)
+
 type ForecastData struct {
-	Month    string `db:"month" json:"month"`
+	Month string `db:"month" json:"month"`
+	// Period is month for monthly rows, aggregated rows cover the months from Month to EndMonth
+	Period   string `db:"period" json:"period"`
+	EndMonth string `db:"end_month" json:"endMonth"`
 	Revenue  int64  `db:"revenue" json:"revenue"`
 	Expense  int64  `db:"expense" json:"expense"`
 	Cashflow int64  `db:"cashflow" json:"cashflow"`
+	// Calculated Values: the projected liquidity at the start and end of the period
+	OpeningBalance int64 `json:"openingBalance"`
+	ClosingBalance int64 `json:"closingBalance"`
 }
 
 type Forecast struct {
@@ -47,6 +59,8 @@ type ForecastDatabaseDetails struct {
 
 type CreateForecast struct {
 	Month    string `json:"month" validate:"required,max=7"`
+	Period   string `json:"period" validate:"required,oneof=month quarter year"`
+	EndMonth string `json:"endMonth" validate:"required,max=7"`
 	Revenue  int64  `json:"revenue" validate:"required"`
 	Expense  int64  `json:"expense" validate:"required"`
 	Cashflow int64  `json:"cashflow" validate:"required"`
@@ -89,3 +103,19 @@ type ForecastExclusionInfo struct {
 	Name         string `json:"name"`
 	Amount       int64  `json:"amount"`
 }
+
+const (
+	ForecastStatusIdle    = "idle"
+	ForecastStatusPending = "pending"
+	ForecastStatusRunning = "running"
+	ForecastStatusFailed  = "failed"
+)
+
+// ForecastStatus tells whether the stored forecast reflects the latest changes of the organisation
+type ForecastStatus struct {
+	Status      string     `json:"status"`
+	RequestedAt *time.Time `json:"requestedAt"`
+	StartedAt   *time.Time `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+	Error       *string    `json:"error"`
+}
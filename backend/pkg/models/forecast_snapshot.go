@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// ForecastSnapshot is an immutable copy of a forecast run including the detail tree of every month
+type ForecastSnapshot struct {
+	ID             int64                     `db:"id" json:"id"`
+	Label          string                    `db:"label" json:"label"`
+	Description    *string                   `db:"description" json:"description"`
+	OpeningBalance int64                     `db:"opening_balance" json:"openingBalance"`
+	CreatedAt      time.Time                 `db:"created_at" json:"createdAt"`
+	CreatedBy      *string                   `db:"created_by" json:"createdBy"`
+	Forecasts      []Forecast                `json:"forecasts"`
+	Details        []ForecastDatabaseDetails `json:"details"`
+}
+
+type CreateForecastSnapshot struct {
+	Label       string  `json:"label" validate:"required,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+// ForecastSnapshotDiff compares two snapshots month by month, all differences are to minus from
+type ForecastSnapshotDiff struct {
+	From   ForecastSnapshot            `json:"from"`
+	To     ForecastSnapshot            `json:"to"`
+	Months []ForecastSnapshotMonthDiff `json:"months"`
+}
+
+type ForecastSnapshotMonthDiff struct {
+	Month          string            `json:"month"`
+	Revenue        ForecastValueDiff `json:"revenue"`
+	Expense        ForecastValueDiff `json:"expense"`
+	Cashflow       ForecastValueDiff `json:"cashflow"`
+	ClosingBalance ForecastValueDiff `json:"closingBalance"`
+	// Items only lists the items that changed, the biggest difference first
+	Items []ForecastSnapshotItemDiff `json:"items"`
+}
+
+type ForecastValueDiff struct {
+	From       int64 `json:"from"`
+	To         int64 `json:"to"`
+	Difference int64 `json:"difference"`
+}
+
+type ForecastSnapshotItemDiff struct {
+	Category     string `json:"category"`
+	Name         string `json:"name"`
+	RelatedID    int64  `json:"relatedID"`
+	RelatedTable string `json:"relatedTable"`
+	// Change is either "added", "removed" or "changed"
+	Change     string `json:"change"`
+	From       int64  `json:"from"`
+	To         int64  `json:"to"`
+	Difference int64  `json:"difference"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+const (
+	ImportEntityTransactions = "transactions"
+	ImportEntityEmployees    = "employees"
+
+	ImportStatusPreview   = "preview"
+	ImportStatusCommitted = "committed"
+)
+
+// Import is an uploaded file parsed into rows, it is only written to the organisation once committed
+type Import struct {
+	ID          int64      `db:"id" json:"id"`
+	Entity      string     `db:"entity" json:"entity"`
+	FileName    string     `db:"file_name" json:"fileName"`
+	Status      string     `db:"status" json:"status"`
+	RowCount    int64      `db:"row_count" json:"rowCount"`
+	ErrorCount  int64      `db:"error_count" json:"errorCount"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	CommittedAt *time.Time `db:"committed_at" json:"committedAt"`
+	CreatedBy   *string    `db:"created_by" json:"createdBy"`
+
+	Transactions []ImportTransactionRow `json:"transactions,omitempty"`
+	Employees    []ImportEmployeeRow    `json:"employees,omitempty"`
+}
+
+// ImportTransactionRow is a single line of a transaction import along with the names it was matched to
+// Line is the position of the row in the file with the header being line 1
+type ImportTransactionRow struct {
+	Line        int               `json:"line"`
+	Transaction CreateTransaction `json:"transaction"`
+	Category    *string           `json:"category"`
+	Currency    *string           `json:"currency"`
+	Vat         *string           `json:"vat"`
+	Employee    *string           `json:"employee"`
+	Errors      []string          `json:"errors"`
+}
+
+// ImportEmployeeRow is a single line of an employee import, lines without a salary only create the employee
+type ImportEmployeeRow struct {
+	Line       int           `json:"line"`
+	Name       string        `json:"name"`
+	EmployeeID *int64        `json:"employeeID"`
+	Salary     *CreateSalary `json:"salary"`
+	Currency   *string       `json:"currency"`
+	Errors     []string      `json:"errors"`
+}
+
+// ImportEmployee groups the salaries of an import by employee, a missing EmployeeID creates the employee
+type ImportEmployee struct {
+	EmployeeID *int64
+	Name       string
+	Salaries   []CreateSalary
+}
+
+// ImportEmployeeResult returns the IDs that were created for an ImportEmployee along with the
+// existing salaries whose end date had to be adjusted
+type ImportEmployeeResult struct {
+	EmployeeID        int64
+	Created           bool
+	SalaryIDs         []int64
+	AdjustedSalaryIDs []int64
+}
+
+type CreateImport struct {
+	Entity       string `validate:"required,oneof=transactions employees"`
+	FileName     string `validate:"required,max=255"`
+	Transactions []ImportTransactionRow
+	Employees    []ImportEmployeeRow
+	RowCount     int64
+	ErrorCount   int64
+}
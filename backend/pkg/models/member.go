@@ -3,12 +3,18 @@ package models
 import "time"
 
 type OrganisationMember struct {
-	UserID     int64             `db:"user_id" json:"userId"`
-	Name       string            `db:"name" json:"name"`
-	Email      string            `db:"email" json:"email"`
-	Role       string            `db:"role" json:"role"`
-	IsDefault  bool              `db:"is_default" json:"isDefault"`
-	Permission *MemberPermission `json:"permission,omitempty"`
+	UserID    int64  `db:"user_id" json:"userId"`
+	Name      string `db:"name" json:"name"`
+	Email     string `db:"email" json:"email"`
+	Role      string `db:"role" json:"role"`
+	IsDefault bool   `db:"is_default" json:"isDefault"`
+	// PayrollClearance lets the member see single salaries in a payroll confidential organisation
+	PayrollClearance bool `db:"payroll_clearance" json:"payrollClearance"`
+	// LockedUntil is set while the login of the member is locked after failed attempts
+	LockedUntil *time.Time        `db:"locked_until" json:"lockedUntil"`
+	Permission  *MemberPermission `json:"permission,omitempty"`
+	// EntityPermissions override Permission for single entity types
+	EntityPermissions []MemberPermission `json:"entityPermissions"`
 }
 
 type MemberPermission struct {
@@ -24,8 +30,102 @@ type MemberPermission struct {
 }
 
 type UpdateMember struct {
-	Role      *string `json:"role" validate:"omitempty,oneof=admin editor read-only"`
-	CanView   *bool   `json:"canView"`
-	CanEdit   *bool   `json:"canEdit"`
-	CanDelete *bool   `json:"canDelete"`
+	Role             *string `json:"role" validate:"omitempty,oneof=admin editor read-only"`
+	CanView          *bool   `json:"canView"`
+	CanEdit          *bool   `json:"canEdit"`
+	CanDelete        *bool   `json:"canDelete"`
+	PayrollClearance *bool   `json:"payrollClearance"`
+	// EntityPermissions are upserted, missing flags keep the value the member currently resolves to
+	EntityPermissions []UpdateMemberEntityPermission `json:"entityPermissions" validate:"omitempty,dive"`
+}
+
+type UpdateMemberEntityPermission struct {
+	EntityType string `json:"entityType" validate:"required,oneof=transaction employee salary bank_account category vat forecast scenario actual"`
+	CanView    *bool  `json:"canView"`
+	CanEdit    *bool  `json:"canEdit"`
+	CanDelete  *bool  `json:"canDelete"`
+}
+
+// Entity types a member permission can be scoped to, a permission without entity type applies to all of them
+const (
+	PermissionEntityTransaction = "transaction"
+	PermissionEntityEmployee    = "employee"
+	// PermissionEntitySalary covers salaries, salary costs and salary cost labels
+	PermissionEntitySalary      = "salary"
+	PermissionEntityBankAccount = "bank_account"
+	PermissionEntityCategory    = "category"
+	PermissionEntityVat         = "vat"
+	// PermissionEntityForecast covers forecast exclusions and snapshots
+	PermissionEntityForecast = "forecast"
+	PermissionEntityScenario = "scenario"
+	PermissionEntityActual   = "actual"
+)
+
+const (
+	PermissionActionView   = "view"
+	PermissionActionEdit   = "edit"
+	PermissionActionDelete = "delete"
+)
+
+// EffectivePermission is what a member may do with an entity type
+type EffectivePermission struct {
+	CanView   bool `json:"canView"`
+	CanEdit   bool `json:"canEdit"`
+	CanDelete bool `json:"canDelete"`
+}
+
+func (p EffectivePermission) Allows(action string) bool {
+	switch action {
+	case PermissionActionView:
+		return p.CanView
+	case PermissionActionEdit:
+		return p.CanEdit
+	case PermissionActionDelete:
+		return p.CanDelete
+	default:
+		return false
+	}
+}
+
+// Intersect only allows what both permissions allow
+func (p EffectivePermission) Intersect(other EffectivePermission) EffectivePermission {
+	return EffectivePermission{
+		CanView:   p.CanView && other.CanView,
+		CanEdit:   p.CanEdit && other.CanEdit,
+		CanDelete: p.CanDelete && other.CanDelete,
+	}
+}
+
+// DefaultPermissionForRole applies to members without a stored permission
+func DefaultPermissionForRole(role string) EffectivePermission {
+	switch role {
+	case "owner", "admin":
+		return EffectivePermission{CanView: true, CanEdit: true, CanDelete: true}
+	case "editor":
+		return EffectivePermission{CanView: true, CanEdit: true}
+	default:
+		return EffectivePermission{CanView: true}
+	}
+}
+
+// ResolvePermission looks for the permission of the entity type, then the global one and falls
+// back to the role default. Owners and admins always have full access so they cannot lock themselves out.
+func ResolvePermission(role string, permissions []MemberPermission, entityType string) EffectivePermission {
+	if role == "owner" || role == "admin" {
+		return DefaultPermissionForRole(role)
+	}
+	var global *MemberPermission
+	for i, permission := range permissions {
+		if permission.EntityType == nil {
+			global = &permissions[i]
+			continue
+		}
+		if *permission.EntityType == entityType {
+			return EffectivePermission{CanView: permission.CanView, CanEdit: permission.CanEdit, CanDelete: permission.CanDelete}
+		}
+	}
+	if global != nil {
+		return EffectivePermission{CanView: global.CanView, CanEdit: global.CanEdit, CanDelete: global.CanDelete}
+	}
+	return DefaultPermissionForRole(role)
 }
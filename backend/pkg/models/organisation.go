@@ -7,6 +7,18 @@ type Organisation struct {
 	MemberCount int64    `db:"member_count" json:"memberCount"`
 	Role        string   `db:"role" json:"role"`
 	IsDefault   bool     `db:"is_default" json:"isDefault"`
+	// The forecast covers ForecastYears, after ForecastMonthlyYears the months are aggregated into ForecastBucket
+	ForecastYears        int    `db:"forecast_years" json:"forecastYears"`
+	ForecastMonthlyYears int    `db:"forecast_monthly_years" json:"forecastMonthlyYears"`
+	ForecastBucket       string `db:"forecast_bucket" json:"forecastBucket"`
+	// PayrollConfidential hides single salaries from members without PayrollClearance
+	PayrollConfidential bool `db:"payroll_confidential" json:"payrollConfidential"`
+	PayrollClearance    bool `db:"payroll_clearance" json:"payrollClearance"`
+	// RequireTwoFactor blocks the organisation for members without two-factor authentication
+	RequireTwoFactor bool `db:"require_two_factor" json:"requireTwoFactor"`
+	// Users of SSODomain join the organisation with SSODefaultRole on their first SSO login
+	SSODomain      *string `db:"sso_domain" json:"ssoDomain"`
+	SSODefaultRole string  `db:"sso_default_role" json:"ssoDefaultRole"`
 }
 
 type CreateOrganisation struct {
@@ -15,6 +27,29 @@ type CreateOrganisation struct {
 }
 
 type UpdateOrganisation struct {
-	Name       *string `json:"name" validate:"omitempty,min=3,max=100"`
-	CurrencyID *int64  `json:"currencyID" validate:"omitempty"`
+	Name                 *string `json:"name" validate:"omitempty,min=3,max=100"`
+	CurrencyID           *int64  `json:"currencyID" validate:"omitempty"`
+	ForecastYears        *int    `json:"forecastYears" validate:"omitempty,min=1,max=10"`
+	ForecastMonthlyYears *int    `json:"forecastMonthlyYears" validate:"omitempty,min=1,max=10"`
+	ForecastBucket       *string `json:"forecastBucket" validate:"omitempty,oneof=quarter year"`
+	PayrollConfidential  *bool   `json:"payrollConfidential"`
+	RequireTwoFactor     *bool   `json:"requireTwoFactor"`
+	// An empty SSODomain removes the domain
+	SSODomain      *string `json:"ssoDomain" validate:"omitempty,fqdn"`
+	SSODefaultRole *string `json:"ssoDefaultRole" validate:"omitempty,oneof=editor read-only"`
+}
+
+// PayrollAccess decides whether the current user of an organisation sees single salaries
+type PayrollAccess struct {
+	Role         string `db:"role"`
+	Confidential bool   `db:"payroll_confidential"`
+	Clearance    bool   `db:"payroll_clearance"`
+}
+
+// Allowed is true unless the organisation is payroll confidential and the user is neither owner, admin nor cleared
+func (p PayrollAccess) Allowed() bool {
+	if !p.Confidential {
+		return true
+	}
+	return p.Role == "owner" || p.Role == "admin" || p.Clearance
 }
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Statutory keys of the Swiss social insurances, items with a key take the federal values of the
+// year when a new version of the template is created
+const (
+	PayrollStatutoryAHV    = "ahv_iv_eo"
+	PayrollStatutoryALV    = "alv"
+	PayrollStatutoryBVG    = "bvg"
+	PayrollStatutoryUVGBU  = "uvg_bu"
+	PayrollStatutoryUVGNBU = "uvg_nbu"
+	PayrollStatutoryKTG    = "ktg"
+	PayrollStatutoryFAK    = "fak"
+)
+
+type PayrollTemplate struct {
+	ID        int64                 `db:"id" json:"id"`
+	Name      string                `db:"name" json:"name"`
+	Year      uint16                `db:"year" json:"year"`
+	Items     []PayrollTemplateItem `db:"-" json:"items"`
+	CreatedAt time.Time             `db:"created_at" json:"createdAt"`
+}
+
+type PayrollTemplateItem struct {
+	ID               int64   `db:"id" json:"id"`
+	StatutoryKey     *string `db:"statutory_key" json:"statutoryKey"`
+	Label            string  `db:"label" json:"label"`
+	Cycle            string  `db:"cycle" json:"cycle"`
+	AmountType       string  `db:"amount_type" json:"amountType"`
+	Amount           uint64  `db:"amount" json:"amount"`
+	DistributionType string  `db:"distribution_type" json:"distributionType"`
+	RelativeOffset   int64   `db:"relative_offset" json:"relativeOffset"`
+	SortOrder        int     `db:"sort_order" json:"sortOrder"`
+	SalaryCostLimits
+}
+
+type CreatePayrollTemplate struct {
+	Name  string                      `json:"name" validate:"required,max=255"`
+	Year  uint16                      `json:"year" validate:"gte=2000,lte=2100"`
+	Items []CreatePayrollTemplateItem `json:"items" validate:"dive"`
+}
+
+type CreatePayrollTemplateItem struct {
+	StatutoryKey     *string `json:"statutoryKey" validate:"omitempty,max=20"`
+	Label            string  `json:"label" validate:"required,max=255"`
+	Cycle            string  `json:"cycle" validate:"allowedCycles"`
+	AmountType       string  `json:"amountType" validate:"allowedCostAmountTypes"`
+	Amount           uint64  `json:"amount" validate:"gte=0"`
+	DistributionType string  `json:"distributionType" validate:"allowedCostDistributionTypes"`
+	RelativeOffset   int64   `json:"relativeOffset" validate:"gt=0"`
+	SalaryCostLimits
+}
+
+// CreateStatutoryPayrollTemplate creates a template with the federal values of the year
+type CreateStatutoryPayrollTemplate struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Year uint16 `json:"year" validate:"gte=2000,lte=2100"`
+}
+
+// CreatePayrollTemplateVersion copies a template to another year
+type CreatePayrollTemplateVersion struct {
+	Year uint16 `json:"year" validate:"gte=2000,lte=2100"`
+}
+
+type ApplyPayrollTemplate struct {
+	TemplateID int64 `json:"templateID" validate:"required,gt=0"`
+	// Replace removes the existing costs of the salary first
+	Replace bool `json:"replace"`
+}
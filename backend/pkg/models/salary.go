@@ -1,6 +1,23 @@
 package models
 
-import "liquiswiss/pkg/types"
+import (
+	"liquiswiss/pkg/types"
+	"liquiswiss/pkg/utils"
+	"sort"
+	"time"
+)
+
+// When the 13th/14th month salaries are paid
+const (
+	SalaryExtraPayoutDecember     = "december"
+	SalaryExtraPayoutJuneDecember = "june_december"
+	SalaryExtraPayoutMonthly      = "monthly"
+)
+
+const (
+	SalaryExtraPaymentExtraSalary = "extra_salary"
+	SalaryExtraPaymentBonus       = "bonus"
+)
 
 type Salary struct {
 	ID                      int64         `db:"id" json:"id"`
@@ -8,6 +25,9 @@ type Salary struct {
 	HoursPerMonth           uint16        `db:"hours_per_month" json:"hoursPerMonth"`
 	Amount                  uint64        `db:"amount" json:"amount"`
 	Cycle                   string        `db:"cycle" json:"cycle"`
+	ExtraSalaries           uint8         `db:"extra_salaries" json:"extraSalaries"`
+	ExtraSalaryPayout       string        `db:"extra_salary_payout" json:"extraSalaryPayout"`
+	Bonuses                 []SalaryBonus `db:"bonuses" json:"bonuses"`
 	Currency                Currency      `db:"currency_id" json:"currency"`
 	VacationDaysPerYear     uint16        `db:"vacation_days_per_year" json:"vacationDaysPerYear"`
 	FromDate                types.AsDate  `db:"from_date" json:"fromDate"`
@@ -18,31 +38,198 @@ type Salary struct {
 
 	// Hidden values
 	DBDate types.AsDate `db:"db_date" json:"-"`
+	// Start of the employment this salary belongs to
+	EmploymentFromDate types.AsDate `db:"employment_from_date" json:"-"`
+	// Last day before the next salary starts, otherwise the to date
+	PeriodToDate *types.AsDate `db:"period_to_date" json:"-"`
+	// No salary follows or the next one is a termination
+	EndsEmployment bool `db:"ends_employment" json:"-"`
 
 	// Calculated Values
-	NextExecutionDate  *types.AsDate `db:"-" json:"nextExecutionDate"`
-	EmployeeDeductions uint64        `db:"-" json:"employeeDeductions"`
-	EmployerCosts      uint64        `db:"-" json:"employerCosts"`
+	NextExecutionDate  *types.AsDate        `db:"-" json:"nextExecutionDate"`
+	EmployeeDeductions uint64               `db:"-" json:"employeeDeductions"`
+	EmployerCosts      uint64               `db:"-" json:"employerCosts"`
+	ExtraPayments      []SalaryExtraPayment `db:"-" json:"extraPayments"`
+}
+
+type SalaryBonus struct {
+	Name   string       `json:"name" validate:"required,max=255"`
+	Date   types.AsDate `json:"date"`
+	Amount uint64       `json:"amount" validate:"gt=0"`
+}
+
+// SalaryExtraPayment is a 13th/14th month salary or a bonus paid on top of the regular salary
+type SalaryExtraPayment struct {
+	Type   string       `json:"type"`
+	Name   string       `json:"name"`
+	Date   types.AsDate `json:"date"`
+	Amount uint64       `json:"amount"`
+}
+
+// ExtraPaymentsBetween lists the extra payments of the months from until to. The 13th/14th month salaries
+// are based on the salary of the payout month and prorated to the months of employment within the year.
+// The share accrued so far is paid out when the employment ends.
+func (s Salary) ExtraPaymentsBetween(from, to time.Time) []SalaryExtraPayment {
+	payments := make([]SalaryExtraPayment, 0)
+	if s.IsTermination || s.IsDisabled {
+		return payments
+	}
+
+	firstMonth := firstDayOfMonth(from)
+	lastMonth := firstDayOfMonth(to)
+	salaryFromMonth := firstDayOfMonth(time.Time(s.FromDate))
+	if firstMonth.Before(salaryFromMonth) {
+		firstMonth = salaryFromMonth
+	}
+
+	if s.ExtraSalaries > 0 && s.Amount > 0 {
+		monthlyAmount := s.Amount * utils.CyclesPerYear(s.Cycle) / 12
+		employmentFromMonth := firstDayOfMonth(time.Time(s.EmploymentFromDate))
+		for month := firstMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+			if !s.paysMonth(month) {
+				continue
+			}
+			isEndOfEmployment := s.endsEmploymentIn(month)
+			accrualStart, isPayoutMonth := extraSalaryAccrualStart(s.ExtraSalaryPayout, month)
+			if !isPayoutMonth && !isEndOfEmployment {
+				continue
+			}
+			if accrualStart.Before(employmentFromMonth) {
+				accrualStart = employmentFromMonth
+			}
+			if accrualStart.After(month) {
+				continue
+			}
+			months := uint64(month.Year()-accrualStart.Year())*12 + uint64(month.Month()) - uint64(accrualStart.Month()) + 1
+			amount := monthlyAmount * uint64(s.ExtraSalaries) * months / 12
+			if amount == 0 {
+				continue
+			}
+			name := "13. Monatslohn"
+			if s.ExtraSalaries > 1 {
+				name = "13./14. Monatslohn"
+			}
+			payments = append(payments, SalaryExtraPayment{
+				Type:   SalaryExtraPaymentExtraSalary,
+				Name:   name,
+				Date:   types.AsDate(s.payoutDate(month)),
+				Amount: amount,
+			})
+		}
+	}
+
+	for _, bonus := range s.Bonuses {
+		bonusMonth := firstDayOfMonth(time.Time(bonus.Date))
+		if bonusMonth.Before(firstMonth) || bonusMonth.After(lastMonth) {
+			continue
+		}
+		// Bonuses after the end of the employment are not paid anymore
+		if s.EndsEmployment && s.PeriodToDate != nil && bonusMonth.After(time.Time(*s.PeriodToDate)) {
+			continue
+		}
+		payments = append(payments, SalaryExtraPayment{
+			Type:   SalaryExtraPaymentBonus,
+			Name:   bonus.Name,
+			Date:   bonus.Date,
+			Amount: bonus.Amount,
+		})
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		return time.Time(payments[i].Date).Before(time.Time(payments[j].Date))
+	})
+	return payments
+}
+
+// ExtraAmountBetween sums the extra payments of the months from until to
+func (s Salary) ExtraAmountBetween(from, to time.Time) uint64 {
+	var total uint64
+	for _, payment := range s.ExtraPaymentsBetween(from, to) {
+		total += payment.Amount
+	}
+	return total
+}
+
+// WithoutExtraPayments returns the salary as if it had neither extra salaries nor bonuses
+func (s Salary) WithoutExtraPayments() Salary {
+	s.ExtraSalaries = 0
+	s.Bonuses = nil
+	return s
+}
+
+// paysMonth tells whether the extra payments of the month belong to this salary. A salary that starts
+// within a month takes over the payments of that month from the previous one.
+func (s Salary) paysMonth(month time.Time) bool {
+	if month.Before(firstDayOfMonth(time.Time(s.FromDate))) {
+		return false
+	}
+	if s.PeriodToDate == nil {
+		return true
+	}
+	if s.endsEmploymentIn(month) {
+		return true
+	}
+	return !utils.GetLastDayOfMonth(month).After(time.Time(*s.PeriodToDate))
+}
+
+func (s Salary) endsEmploymentIn(month time.Time) bool {
+	return s.EndsEmployment && s.PeriodToDate != nil && firstDayOfMonth(time.Time(*s.PeriodToDate)).Equal(month)
+}
+
+// payoutDate uses the payday of the salary within the month but not later than the end of the employment
+func (s Salary) payoutDate(month time.Time) time.Time {
+	lastDay := utils.GetLastDayOfMonth(month).Day()
+	date := time.Date(month.Year(), month.Month(), min(time.Time(s.FromDate).Day(), lastDay), 0, 0, 0, 0, time.UTC)
+	if s.endsEmploymentIn(month) && time.Time(*s.PeriodToDate).Before(date) {
+		return time.Time(*s.PeriodToDate)
+	}
+	return date
+}
+
+// extraSalaryAccrualStart returns the first month that is paid out with the given month and whether the
+// month is a regular payout month
+func extraSalaryAccrualStart(payout string, month time.Time) (time.Time, bool) {
+	switch payout {
+	case SalaryExtraPayoutMonthly:
+		return month, true
+	case SalaryExtraPayoutJuneDecember:
+		if month.Month() <= time.June {
+			return time.Date(month.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), month.Month() == time.June
+		}
+		return time.Date(month.Year(), time.July, 1, 0, 0, 0, 0, time.UTC), month.Month() == time.December
+	default:
+		return time.Date(month.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), month.Month() == time.December
+	}
+}
+
+func firstDayOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
 }
 
 type CreateSalary struct {
-	HoursPerMonth       uint16  `json:"hoursPerMonth" validate:"gte=0"`
-	Amount              uint64  `json:"amount" validate:"gte=0"`
-	Cycle               string  `json:"cycle" validate:"allowedCycles"`
-	CurrencyID          int64   `json:"currencyID" validate:"required,gte=0"`
-	VacationDaysPerYear uint16  `json:"vacationDaysPerYear" validate:"gte=0"`
-	FromDate            string  `json:"fromDate" validate:"required"`
-	ToDate              *string `json:"toDate" validate:"omitempty,fromDateGTEToDate"`
-	IsTermination       bool    `json:"isTermination"`
+	HoursPerMonth       uint16        `json:"hoursPerMonth" validate:"gte=0"`
+	Amount              uint64        `json:"amount" validate:"gte=0"`
+	Cycle               string        `json:"cycle" validate:"allowedCycles"`
+	ExtraSalaries       uint8         `json:"extraSalaries" validate:"lte=2"`
+	ExtraSalaryPayout   string        `json:"extraSalaryPayout" validate:"omitempty,allowedExtraSalaryPayouts"`
+	Bonuses             []SalaryBonus `json:"bonuses" validate:"omitempty,dive"`
+	CurrencyID          int64         `json:"currencyID" validate:"required,gte=0"`
+	VacationDaysPerYear uint16        `json:"vacationDaysPerYear" validate:"gte=0"`
+	FromDate            string        `json:"fromDate" validate:"required"`
+	ToDate              *string       `json:"toDate" validate:"omitempty,fromDateGTEToDate"`
+	IsTermination       bool          `json:"isTermination"`
 }
 
 type UpdateSalary struct {
-	HoursPerMonth       *uint16 `json:"hoursPerMonth" validate:"omitempty,gte=0"`
-	Amount              *uint64 `json:"amount" validate:"omitempty,gte=0"`
-	Cycle               *string `json:"cycle" validate:"omitempty,allowedCycles"`
-	CurrencyID          *int64  `json:"currencyID" validate:"omitempty,gte=0"`
-	VacationDaysPerYear *uint16 `json:"vacationDaysPerYear" validate:"omitempty,gte=0"`
-	FromDate            *string `json:"fromDate" validate:"omitempty"`
-	ToDate              *string `json:"toDate" validate:"omitempty,fromDateGTEToDate"`
-	IsDisabled          *bool   `json:"isDisabled" validate:"omitempty"`
+	HoursPerMonth       *uint16        `json:"hoursPerMonth" validate:"omitempty,gte=0"`
+	Amount              *uint64        `json:"amount" validate:"omitempty,gte=0"`
+	Cycle               *string        `json:"cycle" validate:"omitempty,allowedCycles"`
+	ExtraSalaries       *uint8         `json:"extraSalaries" validate:"omitempty,lte=2"`
+	ExtraSalaryPayout   *string        `json:"extraSalaryPayout" validate:"omitempty,allowedExtraSalaryPayouts"`
+	Bonuses             *[]SalaryBonus `json:"bonuses" validate:"omitempty,dive"`
+	CurrencyID          *int64         `json:"currencyID" validate:"omitempty,gte=0"`
+	VacationDaysPerYear *uint16        `json:"vacationDaysPerYear" validate:"omitempty,gte=0"`
+	FromDate            *string        `json:"fromDate" validate:"omitempty"`
+	ToDate              *string        `json:"toDate" validate:"omitempty,fromDateGTEToDate"`
+	IsDisabled          *bool          `json:"isDisabled" validate:"omitempty"`
 }
@@ -1,6 +1,10 @@
 package models
 
-import "liquiswiss/pkg/types"
+import (
+	"liquiswiss/pkg/types"
+	"sort"
+	"time"
+)
 
 type SalaryCost struct {
 	ID                int64            `db:"id" json:"id"`
@@ -13,13 +17,15 @@ type SalaryCost struct {
 	TargetDate        *types.AsDate    `db:"target_date" json:"targetDate"`
 	BaseSalaryCostIDs []int64          `db:"-" json:"baseSalaryCostIDs"`
 	SalaryID          int64            `db:"salary_id" json:"salaryID"`
+	SalaryCostLimits
 
 	// Hidden values
-	SalaryCycle    string        `db:"salary_cycle" json:"-"`
-	SalaryAmount   uint64        `db:"salary_amount" json:"-"`
-	SalaryFromDate types.AsDate  `db:"salary_from_date" json:"-"`
-	SalaryToDate   *types.AsDate `db:"salary_to_date" json:"-"`
-	DBDate         types.AsDate  `db:"db_date" json:"-"`
+	SalaryCycle       string        `db:"salary_cycle" json:"-"`
+	SalaryAmount      uint64        `db:"salary_amount" json:"-"`
+	SalaryFromDate    types.AsDate  `db:"salary_from_date" json:"-"`
+	SalaryToDate      *types.AsDate `db:"salary_to_date" json:"-"`
+	EmployeeBirthDate *types.AsDate `db:"employee_birth_date" json:"-"`
+	DBDate            types.AsDate  `db:"db_date" json:"-"`
 
 	// Calculated values
 	CalculatedAmount                uint64             `db:"-" json:"calculatedAmount"`
@@ -51,9 +57,101 @@ type CreateSalaryCost struct {
 	TargetDate        *string `db:"target_date" json:"targetDate"`
 	LabelID           *int64  `db:"label_id" json:"labelID"`
 	BaseSalaryCostIDs []int64 `db:"-" json:"baseSalaryCostIDs" validate:"omitempty,dive,gt=0"`
+	SalaryCostLimits
 }
 
 type CopySalaryCosts struct {
 	IDs            []int64 `db:"-" json:"ids" validate:"omitempty,dive,gt=0"`
 	SourceSalaryID *int64  `db:"-" json:"sourceSalaryID" validate:"omitempty,gt=0"`
 }
+
+// SalaryCostLimits shape the base of percentage costs, the base amounts are yearly.
+// Below the threshold nothing is due (BVG entry threshold), above the ceiling the base is capped
+// (ALV, UVG), the deduction is subtracted afterwards (BVG coordination deduction) and what remains
+// is raised to the minimum (BVG minimum coordinated salary). The age rates replace the amount once
+// the birth date of the employee is known (BVG savings contributions).
+type SalaryCostLimits struct {
+	BaseThreshold *uint64             `db:"base_threshold" json:"baseThreshold"`
+	BaseCeiling   *uint64             `db:"base_ceiling" json:"baseCeiling"`
+	BaseDeduction *uint64             `db:"base_deduction" json:"baseDeduction"`
+	BaseMinimum   *uint64             `db:"base_minimum" json:"baseMinimum"`
+	AgeRates      []SalaryCostAgeRate `db:"age_rates" json:"ageRates" validate:"omitempty,dive"`
+}
+
+type SalaryCostAgeRate struct {
+	FromAge uint16 `json:"fromAge" validate:"lte=100"`
+	Amount  uint64 `json:"amount" validate:"lte=100000"`
+}
+
+func (l SalaryCostLimits) IsEmpty() bool {
+	return l.BaseThreshold == nil && l.BaseCeiling == nil && l.BaseDeduction == nil && l.BaseMinimum == nil && len(l.AgeRates) == 0
+}
+
+func (l SalaryCostLimits) hasBaseLimits() bool {
+	return l.BaseThreshold != nil || l.BaseCeiling != nil || l.BaseDeduction != nil || l.BaseMinimum != nil
+}
+
+// LimitYearlyBase applies the threshold, ceiling, deduction and minimum to a yearly base
+func (l SalaryCostLimits) LimitYearlyBase(yearlyBase uint64) uint64 {
+	if yearlyBase == 0 {
+		return 0
+	}
+	if l.BaseThreshold != nil && yearlyBase < *l.BaseThreshold {
+		return 0
+	}
+	if l.BaseCeiling != nil && yearlyBase > *l.BaseCeiling {
+		yearlyBase = *l.BaseCeiling
+	}
+	if l.BaseDeduction != nil {
+		if yearlyBase > *l.BaseDeduction {
+			yearlyBase -= *l.BaseDeduction
+		} else {
+			yearlyBase = 0
+		}
+	}
+	if l.BaseMinimum != nil && yearlyBase < *l.BaseMinimum {
+		yearlyBase = *l.BaseMinimum
+	}
+	return yearlyBase
+}
+
+// LimitBase applies the limits to the base of a single period, periodsPerYear annualises it
+func (l SalaryCostLimits) LimitBase(base uint64, periodsPerYear uint64) uint64 {
+	if !l.hasBaseLimits() || periodsPerYear == 0 {
+		return base
+	}
+	return l.LimitYearlyBase(base*periodsPerYear) / periodsPerYear
+}
+
+// LimitBaseOfYear applies the limits to a base that is part of the given yearly base, the limited yearly
+// base is shared proportionally. Used when extra payments make single periods differ from each other.
+func (l SalaryCostLimits) LimitBaseOfYear(base uint64, yearlyBase uint64) uint64 {
+	if !l.hasBaseLimits() || yearlyBase == 0 {
+		return base
+	}
+	return base * l.LimitYearlyBase(yearlyBase) / yearlyBase
+}
+
+// Percentage returns the percentage due on the date. Like the BVG the age is the difference of the
+// calendar years, ages below the first age rate pay nothing.
+func (c SalaryCost) Percentage(date time.Time) uint64 {
+	if len(c.AgeRates) == 0 || c.EmployeeBirthDate == nil {
+		return c.Amount
+	}
+	age := date.Year() - time.Time(*c.EmployeeBirthDate).Year()
+
+	rates := make([]SalaryCostAgeRate, len(c.AgeRates))
+	copy(rates, c.AgeRates)
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].FromAge < rates[j].FromAge
+	})
+
+	var amount uint64
+	for _, rate := range rates {
+		if int(rate.FromAge) > age {
+			break
+		}
+		amount = rate.Amount
+	}
+	return amount
+}
@@ -1,18 +1,20 @@
 package models
 
 type SalaryCostDetail struct {
-	ID           int64  `db:"id" json:"id"`
-	Month        string `db:"month" json:"month"`
-	Amount       uint64 `db:"amount" json:"amount"`
-	Divider      uint   `db:"divider" json:"Divider"`
-	IsExtraMonth bool   `db:"is_extra_month" json:"isExtraMonth"`
-	CostID       int64  `db:"cost_id" json:"costID"`
+	ID                 int64  `db:"id" json:"id"`
+	Month              string `db:"month" json:"month"`
+	Amount             uint64 `db:"amount" json:"amount"`
+	ExtraPaymentAmount uint64 `db:"extra_payment_amount" json:"extraPaymentAmount"`
+	Divider            uint   `db:"divider" json:"Divider"`
+	IsExtraMonth       bool   `db:"is_extra_month" json:"isExtraMonth"`
+	CostID             int64  `db:"cost_id" json:"costID"`
 }
 
 type CreateSalaryCostDetail struct {
-	Month        string `db:"month" json:"month"`
-	Amount       uint64 `db:"amount" json:"amount"`
-	Divider      uint   `db:"divider" json:"divider"`
-	IsExtraMonth bool   `db:"is_extra_month" json:"isExtraMonth"`
-	CostID       int64  `db:"cost_id" json:"costID"`
+	Month              string `db:"month" json:"month"`
+	Amount             uint64 `db:"amount" json:"amount"`
+	ExtraPaymentAmount uint64 `db:"extra_payment_amount" json:"extraPaymentAmount"`
+	Divider            uint   `db:"divider" json:"divider"`
+	IsExtraMonth       bool   `db:"is_extra_month" json:"isExtraMonth"`
+	CostID             int64  `db:"cost_id" json:"costID"`
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"liquiswiss/pkg/types"
+	"time"
+)
+
+type Scenario struct {
+	ID          int64          `db:"id" json:"id"`
+	Name        string         `db:"name" json:"name"`
+	Description *string        `db:"description" json:"description"`
+	CreatedAt   time.Time      `db:"created_at" json:"createdAt"`
+	Items       []ScenarioItem `json:"items"`
+}
+
+// ScenarioItem overlays a single change onto the base plan. Removals and
+// overrides point to an existing item via RelatedTable/RelatedID, additions
+// carry everything needed to project a new item on their own.
+type ScenarioItem struct {
+	ID           int64         `db:"id" json:"id"`
+	Operation    string        `db:"operation" json:"operation"`
+	RelatedTable string        `db:"related_table" json:"relatedTable"`
+	RelatedID    *int64        `db:"related_id" json:"relatedID"`
+	Name         *string       `db:"name" json:"name"`
+	Amount       *int64        `db:"amount" json:"amount"`
+	Cycle        *string       `db:"cycle" json:"cycle"`
+	Type         *string       `db:"type" json:"type"`
+	StartDate    *types.AsDate `db:"start_date" json:"startDate"`
+	EndDate      *types.AsDate `db:"end_date" json:"endDate"`
+	Category     *Category     `json:"category"`
+	Currency     *Currency     `json:"currency"`
+	ScenarioID   int64         `db:"scenario_id" json:"scenarioID"`
+}
+
+type CreateScenario struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+type UpdateScenario struct {
+	Name        *string `json:"name" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+type CreateScenarioItem struct {
+	Operation    string  `json:"operation" validate:"required,oneof='add' 'remove' 'override'"`
+	RelatedTable string  `json:"relatedTable" validate:"required,oneof='transactions' 'salaries' 'salary_costs'"`
+	RelatedID    *int64  `json:"relatedID" validate:"required_unless=Operation add,omitempty,gt=0"`
+	Name         *string `json:"name" validate:"required_if=Operation add,omitempty,max=255"`
+	Amount       *int64  `json:"amount" validate:"required_unless=Operation remove"`
+	Cycle        *string `json:"cycle" validate:"omitempty,allowedCycles"`
+	Type         *string `json:"type" validate:"required_if=Operation add,omitempty,oneof='single' 'repeating',cycleRequiredIfRepeating"`
+	StartDate    *string `json:"startDate" validate:"required_if=Operation add"`
+	EndDate      *string `json:"endDate" validate:"omitempty,endDateGTEStartDate"`
+	Category     *int64  `json:"category" validate:"omitempty"`
+	Currency     *int64  `json:"currency" validate:"required_if=Operation add"`
+}
+
+// ScenarioForecast returns a scenario next to the base plan for the same months
+type ScenarioForecast struct {
+	Scenario        Scenario                  `json:"scenario"`
+	Base            []Forecast                `json:"base"`
+	Forecast        []Forecast                `json:"forecast"`
+	ForecastDetails []ForecastDatabaseDetails `json:"forecastDetails"`
+	Warnings        []ForecastWarning         `json:"warnings"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Session is a web login of a user, backed by its refresh token
+type Session struct {
+	ID         int64      `db:"id" json:"id"`
+	TokenID    string     `db:"token_id" json:"-"`
+	DeviceName *string    `db:"device_name" json:"deviceName"`
+	IPAddress  *string    `db:"ip_address" json:"ipAddress"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"lastUsedAt"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expiresAt"`
+	// Current marks the session the request was made with
+	Current bool `json:"current"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// SSOProvider is shown on the login page
+type SSOProvider struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SSOIdentity is the user as confirmed by the ID token of a provider
+type SSOIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// SSOOrganisation is the organisation users of an email domain join on their first SSO login
+type SSOOrganisation struct {
+	ID          int64  `db:"id"`
+	DefaultRole string `db:"sso_default_role"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// UserTwoFactor is the stored TOTP state of a user, the secret is set during the setup already
+type UserTwoFactor struct {
+	Secret   *string `db:"totp_secret"`
+	Enabled  bool    `db:"totp_enabled"`
+	LastStep int64   `db:"totp_last_step"`
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool  `json:"enabled"`
+	RecoveryCodesRemaining int64 `json:"recoveryCodesRemaining"`
+	// Required is set when one of the user's organisations requires two-factor authentication
+	Required bool `json:"required"`
+}
+
+// TwoFactorSetup is shown once, ProvisioningURI is rendered as QR code for the authenticator app
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// TwoFactorRecoveryCodes are only returned right after they have been generated
+type TwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// TwoFactorCode is either a TOTP code or one of the recovery codes
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+// LoginChallenge is the answer to a correct password of a user with two-factor authentication
+type LoginChallenge struct {
+	TwoFactorRequired bool      `json:"twoFactorRequired"`
+	ChallengeToken    string    `json:"challengeToken"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+type LoginTwoFactor struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required,max=20"`
+}
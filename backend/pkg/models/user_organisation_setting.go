@@ -31,7 +31,7 @@ type UserOrganisationSetting struct {
 }
 
 type UpdateUserOrganisationSetting struct {
-	ForecastMonths          *int             `json:"forecastMonths" validate:"omitempty,min=1,max=60"`
+	ForecastMonths          *int             `json:"forecastMonths" validate:"omitempty,min=1,max=121"`
 	ForecastPerformance     *int             `json:"forecastPerformance" validate:"omitempty,min=0,max=200"`
 	ForecastRevenueDetails  *bool            `json:"forecastRevenueDetails" validate:"omitempty"`
 	ForecastExpenseDetails  *bool            `json:"forecastExpenseDetails" validate:"omitempty"`
@@ -28,17 +28,3 @@ func CalculatePagination(currentPage int64, limit int64, totalCount int64) Pagin
 func CalculateAmountWithFiatRate(amount int64, rate float64) int64 {
 	return int64(math.Round(float64(amount) / rate))
 }
-
-func GetFiatRateFromCurrency(fiatRates []FiatRate, base, target string) float64 {
-	if base == target {
-		return 1.0
-	}
-
-	for _, rate := range fiatRates {
-		if rate.Base == base && rate.Target == target {
-			return rate.Rate
-		}
-	}
-
-	return 1.0
-}
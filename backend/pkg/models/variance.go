@@ -0,0 +1,49 @@
+package models
+
+// VarianceReport compares the plan of record of past months with the realised actuals,
+// all amounts in the organisation currency. Variance is always actual minus planned.
+type VarianceReport struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Months []VarianceMonth `json:"months"`
+}
+
+type VarianceMonth struct {
+	Month string `json:"month"`
+	// HasPlan is false for months without a recorded forecast
+	HasPlan          bool               `json:"hasPlan"`
+	PlannedRevenue   int64              `json:"plannedRevenue"`
+	PlannedExpense   int64              `json:"plannedExpense"`
+	ActualRevenue    int64              `json:"actualRevenue"`
+	ActualExpense    int64              `json:"actualExpense"`
+	RevenueVariance  int64              `json:"revenueVariance"`
+	ExpenseVariance  int64              `json:"expenseVariance"`
+	CashflowVariance int64              `json:"cashflowVariance"`
+	Categories       []VarianceCategory `json:"categories"`
+}
+
+type VarianceCategory struct {
+	Name     string         `json:"name"`
+	Planned  int64          `json:"planned"`
+	Actual   int64          `json:"actual"`
+	Variance int64          `json:"variance"`
+	Items    []VarianceItem `json:"items"`
+}
+
+type VarianceItem struct {
+	Name         string `json:"name"`
+	RelatedID    int64  `json:"relatedID"`
+	RelatedTable string `json:"relatedTable"`
+	Planned      int64  `json:"planned"`
+	Actual       int64  `json:"actual"`
+	Variance     int64  `json:"variance"`
+}
+
+// ForecastHistory is the plan of a month as it stood when the month began
+type ForecastHistory struct {
+	Month    string                  `json:"month"`
+	Revenue  int64                   `json:"revenue"`
+	Expense  int64                   `json:"expense"`
+	Cashflow int64                   `json:"cashflow"`
+	Details  ForecastDatabaseDetails `json:"details"`
+}
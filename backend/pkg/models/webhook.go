@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// Webhook receives the change events of an organisation as signed HTTP POST
+type Webhook struct {
+	ID   int64  `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+	URL  string `db:"url" json:"url"`
+	// Entities filters the events, empty means all entities
+	Entities  []string  `db:"entities" json:"entities"`
+	Enabled   bool      `db:"enabled" json:"enabled"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// WebhookWithSecret is only answered when creating the webhook or rotating its secret
+type WebhookWithSecret struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+type CreateWebhook struct {
+	Name     string   `json:"name" validate:"required,min=3,max=100"`
+	URL      string   `json:"url" validate:"required,http_url,max=2048"`
+	Entities []string `json:"entities" validate:"max=50,dive,required,max=50"`
+}
+
+type UpdateWebhook struct {
+	Name     *string   `json:"name" validate:"omitempty,min=3,max=100"`
+	URL      *string   `json:"url" validate:"omitempty,http_url,max=2048"`
+	Entities *[]string `json:"entities" validate:"omitempty,max=50,dive,required,max=50"`
+	Enabled  *bool     `json:"enabled"`
+}
+
+// WebhookDelivery is an entry of the delivery log
+type WebhookDelivery struct {
+	ID             int64           `db:"id" json:"id"`
+	WebhookID      int64           `db:"webhook_id" json:"webhookID"`
+	Entity         string          `db:"entity" json:"entity"`
+	Action         string          `db:"action" json:"action"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Status         string          `db:"status" json:"status"`
+	Attempts       int64           `db:"attempts" json:"attempts"`
+	NextAttemptAt  *time.Time      `db:"next_attempt_at" json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time      `db:"last_attempt_at" json:"lastAttemptAt"`
+	ResponseStatus *int            `db:"response_status" json:"responseStatus"`
+	LastError      *string         `db:"last_error" json:"lastError"`
+	ReplayOf       *int64          `db:"replay_of" json:"replayOf"`
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+}
+
+// WebhookEvent is the body of a delivery
+type WebhookEvent struct {
+	Entity         string    `json:"entity"`
+	Action         string    `json:"action"`
+	ID             int64     `json:"id,omitempty"`
+	ParentID       int64     `json:"parentId,omitempty"`
+	OrganisationID int64     `json:"organisationId"`
+	OccurredAt     time.Time `json:"occurredAt"`
+}
+
+// WebhookDeliveryJob is a claimed delivery along with the target of its webhook
+type WebhookDeliveryJob struct {
+	ID       int64           `db:"id"`
+	Entity   string          `db:"entity"`
+	Action   string          `db:"action"`
+	Payload  json.RawMessage `db:"payload"`
+	Attempts int64           `db:"attempts"`
+	URL      string          `db:"url"`
+	Secret   string          `db:"secret"`
+	Enabled  bool            `db:"enabled"`
+}
+
+// WebhookDeliveryResult is the outcome of an attempt, Retry schedules the next one
+type WebhookDeliveryResult struct {
+	Status         string
+	ResponseStatus *int
+	Error          *string
+	RetryIn        time.Duration
+}
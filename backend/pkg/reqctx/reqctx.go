@@ -7,7 +7,12 @@ import "context"
 
 type contextKey int
 
-const clientIDKey contextKey = iota
+const (
+	clientIDKey contextKey = iota
+	oauthClientIDKey
+	clientIPKey
+	apiTokenIDKey
+)
 
 // maxClientIDLength bounds the accepted client id (browser tabs send UUIDs)
 const maxClientIDLength = 64
@@ -29,3 +34,52 @@ func ClientID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// WithOAuthClientID marks the request as made by the given OAuth client (MCP)
+func WithOAuthClientID(ctx context.Context, oauthClientID string) context.Context {
+	if oauthClientID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, oauthClientIDKey, oauthClientID)
+}
+
+// OAuthClientID returns the OAuth client id carried by the context, or "" for
+// web sessions and background jobs.
+func OAuthClientID(ctx context.Context) string {
+	if id, ok := ctx.Value(oauthClientIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// WithClientIP carries the caller's IP address, it is stored with new sessions
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	if clientIP == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, clientIPKey, clientIP)
+}
+
+// ClientIP returns the IP address carried by the context, or "" when absent
+func ClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// WithAPITokenID marks the request as made with the given personal API token
+func WithAPITokenID(ctx context.Context, apiTokenID int64) context.Context {
+	if apiTokenID == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, apiTokenIDKey, apiTokenID)
+}
+
+// APITokenID returns the personal API token of the request, or 0 for sessions and OAuth clients
+func APITokenID(ctx context.Context) int64 {
+	if id, ok := ctx.Value(apiTokenIDKey).(int64); ok {
+		return id
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+// Package tabular reads CSV and XLSX files into rows of text and parses the
+// amounts and dates commonly found in Swiss spreadsheets
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Read returns all rows of a CSV file or of the first sheet of an XLSX file, including the header row
+func Read(data []byte) ([][]string, error) {
+	if IsXLSX(data) {
+		return readXLSX(data)
+	}
+	return ReadCSV(data)
+}
+
+// IsXLSX reports whether the data is an Office Open XML file (a ZIP archive)
+func IsXLSX(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// ReadCSV reads a CSV file with the separator detected from the header row
+func ReadCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = DetectSeparator(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	return records, nil
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("invalid XLSX file: no sheet")
+	}
+	// Formatted values keep dates readable, ParseAmount copes with the digit grouping
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	return rows, nil
+}
+
+// DetectSeparator picks the most frequent of comma, semicolon and tab in the header row
+func DetectSeparator(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	separator := ','
+	maxCount := bytes.Count(header, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(header, []byte(string(candidate))); count > maxCount {
+			separator = candidate
+			maxCount = count
+		}
+	}
+	return separator
+}
+
+// MapColumns returns the index of every column whose header matches one of its aliases (lower case)
+func MapColumns(header []string, aliases map[string][]string) map[string]int {
+	columns := make(map[string]int)
+	for index, title := range header {
+		normalized := strings.ToLower(strings.TrimSpace(title))
+		for column, columnAliases := range aliases {
+			if _, exists := columns[column]; exists {
+				continue
+			}
+			for _, alias := range columnAliases {
+				if normalized == alias {
+					columns[column] = index
+				}
+			}
+		}
+	}
+	return columns
+}
+
+// ParseAmount converts a decimal amount like "1'234.50" or "1234,5" into Rappen/cents
+func ParseAmount(value string) (int64, error) {
+	cleaned := strings.NewReplacer("'", "", "’", "", " ", "", " ", "").Replace(strings.TrimSpace(value))
+	if cleaned == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	negative := false
+	switch {
+	case strings.HasPrefix(cleaned, "-"):
+		negative = true
+		cleaned = cleaned[1:]
+	case strings.HasPrefix(cleaned, "+"):
+		cleaned = cleaned[1:]
+	}
+
+	// The last separator is the decimal separator, any other one groups thousands
+	decimalIndex := strings.LastIndexAny(cleaned, ".,")
+	integerPart := cleaned
+	fractionPart := ""
+	if decimalIndex >= 0 && len(cleaned)-decimalIndex-1 <= 2 {
+		integerPart = cleaned[:decimalIndex]
+		fractionPart = cleaned[decimalIndex+1:]
+	}
+	integerPart = strings.NewReplacer(".", "", ",", "").Replace(integerPart)
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	for len(fractionPart) < 2 {
+		fractionPart += "0"
+	}
+
+	units, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", value)
+	}
+	cents, err := strconv.ParseInt(fractionPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", value)
+	}
+
+	amount := units*100 + cents
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ParseDate accepts the date layouts commonly found in Swiss exports
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	// Date times like 2024-01-31T00:00:00+01:00 only need the date part
+	if len(value) > 10 && value[4] == '-' {
+		value = value[:10]
+	}
+	for _, layout := range []string{"2006-01-02", "02.01.2006", "02.01.06", "02/01/2006", "20060102"} {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", value)
+}
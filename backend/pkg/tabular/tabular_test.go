@@ -0,0 +1,79 @@
+package tabular_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"liquiswiss/pkg/tabular"
+)
+
+func TestReadCSV_DetectsSeparatorAndStripsBOM(t *testing.T) {
+	data := []byte("\xef\xbb\xbfName;Betrag;Start\nRent;-1'500.00;01.02.2025\nClient;2500,5;2025-03-15\n")
+
+	records, err := tabular.Read(data)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Name", "Betrag", "Start"},
+		{"Rent", "-1'500.00", "01.02.2025"},
+		{"Client", "2500,5", "2025-03-15"},
+	}, records)
+}
+
+func TestRead_XLSXUsesFirstSheet(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+	require.NoError(t, f.SetSheetRow(sheet, "A1", &[]any{"Name", "Betrag"}))
+	require.NoError(t, f.SetSheetRow(sheet, "A2", &[]any{"Rent", -1500.5}))
+	var buffer bytes.Buffer
+	require.NoError(t, f.Write(&buffer))
+
+	require.True(t, tabular.IsXLSX(buffer.Bytes()))
+	records, err := tabular.Read(buffer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Name", "Betrag"}, {"Rent", "-1500.5"}}, records)
+}
+
+func TestMapColumns_MatchesAliasesCaseInsensitive(t *testing.T) {
+	columns := tabular.MapColumns(
+		[]string{" Name ", "BETRAG", "Unknown", "Amount"},
+		map[string][]string{"name": {"name"}, "amount": {"betrag", "amount"}, "date": {"datum"}},
+	)
+	// The first matching column wins
+	require.Equal(t, map[string]int{"name": 0, "amount": 1}, columns)
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := map[string]int64{
+		"1'234.50": 1234_50,
+		"1234,5":   1234_50,
+		"-80":      -80_00,
+		"+1.234":   1234_00,
+		"1.234,56": 1234_56,
+		"8.1":      8_10,
+	}
+	for value, expected := range cases {
+		amount, err := tabular.ParseAmount(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, amount, value)
+	}
+
+	_, err := tabular.ParseAmount("abc")
+	require.Error(t, err)
+}
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2025-01-31", "31.01.2025", "31.01.25", "2025-01-31T00:00:00+01:00", "20250131"} {
+		date, err := tabular.ParseDate(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, date, value)
+	}
+
+	_, err := tabular.ParseDate("31/13/2025")
+	require.Error(t, err)
+}
@@ -11,6 +11,30 @@ const (
 
 	RegistrationCodeValidity = 1 * time.Hour
 
+	// TwoFactorChallengeValidity is the time between the password and the second factor of a login
+	TwoFactorChallengeValidity = 5 * time.Minute
+	// TwoFactorRecoveryCodeCount is the number of recovery codes issued when enabling two-factor authentication
+	TwoFactorRecoveryCodeCount = 10
+	TwoFactorIssuer            = "LiquiSwiss"
+
+	// APITokenPrefix marks personal API tokens in the Authorization header, JWTs never start with it
+	APITokenPrefix = "lsw_"
+	// APITokenTouchInterval limits how often the last use of an API token is written
+	APITokenTouchInterval = 1 * time.Minute
+
+	// AuthAttemptWindow is how long failed logins and code checks are remembered
+	AuthAttemptWindow = 1 * time.Hour
+	// AuthBackoffAfter failures of an account pass without delay, each further one doubles
+	// the wait starting at AuthBackoffBase up to AuthBackoffMax
+	AuthBackoffAfter = 3
+	AuthBackoffBase  = 2 * time.Second
+	AuthBackoffMax   = 5 * time.Minute
+	// AuthLockoutAfter failures lock the account for AuthLockoutDuration, its owner gets an email
+	AuthLockoutAfter    = 10
+	AuthLockoutDuration = 30 * time.Minute
+	// AuthIPAttemptFactor raises the limits of IP addresses, offices share one behind NAT
+	AuthIPAttemptFactor = 5
+
 	// Default anti-spam window between forgot-password requests for the same email.
 	// Override via RESET_PASSWORD_DELAY_MINUTES env var.
 	ResetPasswordDelay = 1 * time.Hour
@@ -24,12 +48,50 @@ const (
 
 	InvitationValidity = 7 * 24 * time.Hour // 7 days validity
 
-	MaxForecastYears = 3
+	// WebhookTimeout bounds a single delivery attempt
+	WebhookTimeout = 10 * time.Second
+	// WebhookMaxAttempts failed attempts mark a delivery as failed, the retries back off
+	// exponentially from WebhookRetryBase up to WebhookRetryMax
+	WebhookMaxAttempts = 8
+	WebhookRetryBase   = 30 * time.Second
+	WebhookRetryMax    = 6 * time.Hour
+	// WebhookPollInterval is how often pending deliveries are picked up
+	WebhookPollInterval = 5 * time.Second
+	// WebhookDeliveryRetention is how long the delivery log is kept
+	WebhookDeliveryRetention = 30 * 24 * time.Hour
+
+	// Default poll interval of the MySQL event broker, override via EVENT_OUTBOX_POLL_MS env var
+	EventOutboxPollInterval = 500 * time.Millisecond
+	// EventOutboxRetention is how long the messages of the MySQL event broker are kept
+	EventOutboxRetention = time.Hour
+
+	// Default quiet period before a changed forecast is recalculated, bursts of edits share one run.
+	// Override via FORECAST_DEBOUNCE_MS env var.
+	ForecastDebounce = 2 * time.Second
+
+	// Default age after which a rate counts as stale, ECB and SNB skip weekends and holidays.
+	// Override via FX_MAX_RATE_AGE_DAYS env var.
+	FXMaxRateAge = 5 * 24 * time.Hour
+
+	// DefaultForecastYears is the horizon of organisations that did not configure one
+	DefaultForecastYears = 3
+	// MaxForecastYears is the longest horizon an organisation can configure
+	MaxForecastYears = 10
 
 	AccessTokenName  = "liq-access-token"
 	RefreshTokenName = "liq-refresh-token"
+	// TwoFactorChallengeName carries the challenge of an SSO login to the second step, never the URL
+	TwoFactorChallengeName = "liq-two-factor-challenge"
 
 	TransactionsTableName = "transactions"
 	SalariesTableName     = "salaries"
 	SalaryCostsTableName  = "salary_costs"
+	// Salary cost exclusions are stored per label
+	SalaryCostLabelsTableName = "salary_cost_labels"
+	ScenarioItemsTableName    = "scenario_items"
+	BankAccountsTableName     = "bank_accounts"
+
+	ActualStatusMatched   = "matched"
+	ActualStatusDeviation = "deviation"
+	ActualStatusUnmatched = "unmatched"
 )
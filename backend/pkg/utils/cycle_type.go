@@ -7,3 +7,17 @@ const (
 	CycleBiannually string = "biannually"
 	CycleYearly     string = "yearly"
 )
+
+// CyclesPerYear returns how often a cycle repeats within a year, once counts as one
+func CyclesPerYear(cycle string) uint64 {
+	switch cycle {
+	case CycleMonthly:
+		return 12
+	case CycleQuarterly:
+		return 4
+	case CycleBiannually:
+		return 2
+	default:
+		return 1
+	}
+}
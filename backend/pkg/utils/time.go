@@ -87,5 +87,10 @@ func GetNextAvailableDate(fromDate, limitDate time.Time, cycle string) time.Time
 }
 
 func GetTotalMonthsForMaxForecastYears() float64 {
-	return float64(MaxForecastYears*12 + 1)
+	return GetTotalMonthsForForecastYears(MaxForecastYears)
+}
+
+// GetTotalMonthsForForecastYears includes the current month, so 3 years cover 37 months
+func GetTotalMonthsForForecastYears(years int) float64 {
+	return float64(years*12 + 1)
 }
@@ -18,6 +18,7 @@ func InitValidator() {
 	validate.RegisterAlias("allowedCostCycles", `oneof='once' 'monthly' 'quarterly' 'biannually' 'yearly'`)
 	validate.RegisterAlias("allowedCostAmountTypes", `oneof='fixed' 'percentage'`)
 	validate.RegisterAlias("allowedCostDistributionTypes", `oneof='employee' 'employer' 'both'`)
+	validate.RegisterAlias("allowedExtraSalaryPayouts", `oneof='december' 'june_december' 'monthly'`)
 }
 
 func GetValidator() *validator.Validate {
@@ -1,4 +1,4 @@
-import type { ForecastDetailResponse, ForecastResponse } from '~/models/forecast'
+import type { ForecastDetailResponse, ForecastResponse, ForecastWarningResponse } from '~/models/forecast'
 
 type ForecastExclusionChange = {
   key: string
@@ -12,6 +12,7 @@ type ForecastExclusionChange = {
 export default function useForecasts() {
   const forecasts = useState<ForecastResponse[]>('forecasts', () => [])
   const forecastDetails = useState<ForecastDetailResponse[]>('forecastDetails', () => [])
+  const forecastWarnings = useState<ForecastWarningResponse[]>('forecastWarnings', () => [])
   const forecastExclusionChanges = useState<Record<string, ForecastExclusionChange>>('forecastExclusionChanges', () => ({}))
 
   const createDraftKey = (month: string, relatedID: number, relatedTable: string) => {
@@ -150,6 +151,17 @@ export default function useForecasts() {
     }
   }
 
+  const listForecastWarnings = async () => {
+    try {
+      forecastWarnings.value = await $fetch<ForecastWarningResponse[]>('/api/forecasts/warnings', {
+        method: 'GET',
+      })
+    }
+    catch {
+      return Promise.reject('Fehler beim Prüfen der Wechselkurse')
+    }
+  }
+
   const setForecasts = (data: ForecastResponse[] | null, append: boolean) => {
     if (data) {
       if (append) {
@@ -213,6 +225,7 @@ export default function useForecasts() {
   return {
     forecasts,
     forecastDetails,
+    forecastWarnings,
     forecastExclusionChanges,
     useFetchListForecast,
     listForecasts,
@@ -220,6 +233,7 @@ export default function useForecasts() {
     listForecastDetails,
     setForecasts,
     calculateForecast,
+    listForecastWarnings,
     excludeForecast,
     includeForecast,
     toggleForecastExclusionChange,
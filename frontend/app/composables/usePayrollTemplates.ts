@@ -0,0 +1,82 @@
+import type {
+  PayrollTemplateApplyFormData,
+  PayrollTemplateResponse,
+  SalaryCostResponse,
+} from '~/models/employee'
+
+export default function usePayrollTemplates() {
+  const payrollTemplates = useState<PayrollTemplateResponse[]>('payrollTemplates', () => [])
+
+  const listPayrollTemplates = async () => {
+    try {
+      payrollTemplates.value = await $fetch<PayrollTemplateResponse[]>('/api/employees/salary/costs/templates', {
+        method: 'GET',
+      })
+      return payrollTemplates.value
+    }
+    catch {
+      return Promise.reject('Fehler beim Laden der Lohnkostenvorlagen')
+    }
+  }
+
+  const createStatutoryPayrollTemplate = async (name: string, year: number) => {
+    try {
+      const template = await $fetch<PayrollTemplateResponse>('/api/employees/salary/costs/templates/statutory', {
+        method: 'POST',
+        body: { name, year },
+      })
+      await listPayrollTemplates()
+      return template
+    }
+    catch {
+      return Promise.reject('Fehler beim Erstellen der Lohnkostenvorlage')
+    }
+  }
+
+  const createPayrollTemplateVersion = async (templateID: number, year: number) => {
+    try {
+      const template = await $fetch<PayrollTemplateResponse>(`/api/employees/salary/costs/templates/${templateID}/versions`, {
+        method: 'POST',
+        body: { year },
+      })
+      await listPayrollTemplates()
+      return template
+    }
+    catch {
+      return Promise.reject(`Fehler beim Erstellen der Vorlage für ${year}`)
+    }
+  }
+
+  const deletePayrollTemplate = async (templateID: number) => {
+    try {
+      await $fetch(`/api/employees/salary/costs/templates/${templateID}`, {
+        method: 'DELETE',
+      })
+      await listPayrollTemplates()
+    }
+    catch {
+      return Promise.reject('Fehler beim Löschen der Lohnkostenvorlage')
+    }
+  }
+
+  const applyPayrollTemplate = async (salaryID: number, payload: PayrollTemplateApplyFormData) => {
+    try {
+      return await $fetch<SalaryCostResponse[]>(`/api/employees/salary/${salaryID}/costs/template`, {
+        method: 'POST',
+        body: payload,
+      })
+    }
+    catch {
+      return Promise.reject('Fehler beim Anwenden der Lohnkostenvorlage')
+    }
+  }
+
+  return {
+    payrollTemplates,
+    listPayrollTemplates,
+    createStatutoryPayrollTemplate,
+    createPayrollTemplateVersion,
+    deletePayrollTemplate,
+    applyPayrollTemplate,
+  }
+}
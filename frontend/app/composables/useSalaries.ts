@@ -1,4 +1,16 @@
-import type { ListSalaryResponse, SalaryPATCHFormData, SalaryPUTFormData, SalaryResponse } from '~/models/employee'
+import type {
+  ListSalaryResponse,
+  SalaryBonusFormData,
+  SalaryPATCHFormData,
+  SalaryPUTFormData,
+  SalaryResponse,
+} from '~/models/employee'
+
+const toBonusPayload = (bonuses?: SalaryBonusFormData[]) => bonuses?.map(bonus => ({
+  name: bonus.name,
+  date: DateToApiFormat(bonus.date),
+  amount: AmountToInteger(bonus.amount),
+}))
 import { DefaultListResponse } from '~/models/default-data'
 
 export default function useSalaries() {
@@ -57,6 +69,7 @@ export default function useSalaries() {
           ...payload,
           amount: payload.amount ? AmountToInteger(payload.amount) : undefined,
           fromDate: payload.fromDate ? DateToApiFormat(payload.fromDate) : undefined,
+          bonuses: toBonusPayload(payload.bonuses),
         },
       })
       await listSalaries(employeeID)
@@ -75,6 +88,7 @@ export default function useSalaries() {
           ...payload,
           amount: payload.amount ? AmountToInteger(payload.amount) : undefined,
           fromDate: payload.fromDate ? DateToApiFormat(payload.fromDate) : undefined,
+          bonuses: toBonusPayload(payload.bonuses),
         },
       })
       await listSalaries(employeeID)
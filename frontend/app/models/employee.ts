@@ -11,6 +11,7 @@ import type {
 export interface EmployeeResponse {
   id: number
   name: string
+  birthDate: string | null
   hoursPerMonth: number | null
   salaryAmount: number | null
   cycle: SalaryCycleTypeToStringDefinition | null
@@ -37,7 +38,20 @@ export interface SalaryCostDetailResponse {
   CostID: number
 }
 
-export interface SalaryCostResponse {
+export interface SalaryCostAgeRate {
+  fromAge: number
+  amount: number
+}
+
+export interface SalaryCostLimits {
+  baseThreshold: number | null
+  baseCeiling: number | null
+  baseDeduction: number | null
+  baseMinimum: number | null
+  ageRates: SalaryCostAgeRate[] | null
+}
+
+export interface SalaryCostResponse extends SalaryCostLimits {
   id: number
   label: SalaryCostLabelResponse | null
   cycle: CostCycleTypeToStringDefinition
@@ -55,12 +69,50 @@ export interface SalaryCostResponse {
   calculatedCostDetails: SalaryCostDetailResponse[]
 }
 
+export interface PayrollTemplateItemResponse extends SalaryCostLimits {
+  id: number
+  statutoryKey: string | null
+  label: string
+  cycle: CostCycleTypeToStringDefinition
+  amountType: EmployeeCostTypeToStringDefinition
+  amount: number
+  distributionType: EmployeeCostDistributionTypeToStringDefinition
+  relativeOffset: number
+  sortOrder: number
+}
+
+export interface PayrollTemplateResponse {
+  id: number
+  name: string
+  year: number
+  items: PayrollTemplateItemResponse[]
+  createdAt: string
+}
+
+export type SalaryExtraPayoutType = 'december' | 'june_december' | 'monthly'
+
+export interface SalaryBonus {
+  name: string
+  date: string
+  amount: number
+}
+
+export interface SalaryExtraPaymentResponse {
+  type: 'extra_salary' | 'bonus'
+  name: string
+  date: string
+  amount: number
+}
+
 export interface SalaryResponse {
   id: number
   employeeID: number
   hoursPerMonth: number
   amount: number
   cycle: SalaryCycleTypeToStringDefinition
+  extraSalaries: number
+  extraSalaryPayout: SalaryExtraPayoutType
+  bonuses: SalaryBonus[]
   currency: CurrencyResponse
   vacationDaysPerYear: number
   fromDate: string
@@ -70,6 +122,7 @@ export interface SalaryResponse {
   nextExecutionDate: string | null
   employeeDeductions: number
   employerCosts: number
+  extraPayments: SalaryExtraPaymentResponse[]
   isDisabled: boolean
 }
 
@@ -96,16 +149,30 @@ export interface SalaryCostFormData {
   baseSalaryCostIDs?: number[]
 }
 
+export interface PayrollTemplateApplyFormData {
+  templateID: number
+  replace: boolean
+}
+
 export interface SalaryCostCopyFormData {
   ids: number[]
   sourceSalaryID?: number
 }
 
+export interface SalaryBonusFormData {
+  name: string
+  date: Date
+  amount: number
+}
+
 export interface SalaryPUTFormData {
   id: number
   hoursPerMonth: number
   amount: number
   cycle: SalaryCycleTypeToStringDefinition
+  extraSalaries?: number
+  extraSalaryPayout?: SalaryExtraPayoutType
+  bonuses?: SalaryBonusFormData[]
   currencyID: number
   vacationDaysPerYear: number
   fromDate: Date
@@ -117,6 +184,9 @@ export interface SalaryPATCHFormData {
   hoursPerMonth?: number
   amount?: number
   cycle: SalaryCycleTypeToStringDefinition
+  extraSalaries?: number
+  extraSalaryPayout?: SalaryExtraPayoutType
+  bonuses?: SalaryBonusFormData[]
   currencyID?: number
   vacationDaysPerYear?: number
   fromDate?: Date
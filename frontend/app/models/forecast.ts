@@ -23,3 +23,13 @@ export interface ForecastDetailResponse {
   expense: ForecastDetailRevenueExpenseResponse[]
   forecastID: number
 }
+
+export interface ForecastWarningResponse {
+  relatedID: number
+  relatedTable: string
+  name: string
+  kind: 'missing' | 'stale' | 'fallback'
+  currency: string
+  source: string | null
+  rateDate: string | null
+}
@@ -235,6 +235,13 @@ export default defineNuxtPlugin((nuxtApp) => {
         console.error('SSE: Ungültiges Event', error)
       }
     })
+    // Reconnects send Last-Event-ID and the backend replays what was missed.
+    // When it can't (too many events, other instance, restart) it asks for a
+    // resync: refetch everything once, without highlighting or toasts.
+    source.addEventListener('resync', () => {
+      const unique = new Set(Object.values(refreshers))
+      void Promise.allSettled([...unique].map(refresh => refresh()))
+    })
     // EventSource reconnects automatically; reconnects re-run the full auth
     // middleware on the backend. Nothing to do on error while authenticated.
     source.onerror = () => {